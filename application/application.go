@@ -1,30 +1,79 @@
 package application
 
 import (
+	"log"
+
 	"github.com/zakiyalmaya/hotel-management/application/auth"
+	"github.com/zakiyalmaya/hotel-management/application/availability"
 	"github.com/zakiyalmaya/hotel-management/application/booking"
+	"github.com/zakiyalmaya/hotel-management/application/folio"
 	"github.com/zakiyalmaya/hotel-management/application/guest"
+	"github.com/zakiyalmaya/hotel-management/application/housekeeping"
+	"github.com/zakiyalmaya/hotel-management/application/invitation"
+	"github.com/zakiyalmaya/hotel-management/application/invoice"
+	"github.com/zakiyalmaya/hotel-management/application/maintenance"
+	"github.com/zakiyalmaya/hotel-management/application/mfa"
+	"github.com/zakiyalmaya/hotel-management/application/payment"
+	"github.com/zakiyalmaya/hotel-management/application/pricing"
+	"github.com/zakiyalmaya/hotel-management/application/rateplan"
 	"github.com/zakiyalmaya/hotel-management/application/room"
 	"github.com/zakiyalmaya/hotel-management/application/user"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/gateway"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/keyset"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/mailer"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 )
 
 type Application struct {
-	Repos      *repository.Repositories
-	RoomSvc    room.RoomService
-	GuestSvc   guest.GuestService
-	BookingSvc booking.BookingService
-	UserSvc    user.UserService
-	AuthSvc    auth.AuthService
+	Repos           *repository.Repositories
+	KeySet          *keyset.KeySet
+	RoomSvc         room.RoomService
+	GuestSvc        guest.GuestService
+	BookingSvc      booking.BookingService
+	UserSvc         user.UserService
+	AuthSvc         auth.AuthService
+	AvailabilitySvc availability.AvailabilityService
+	HousekeepingSvc housekeeping.HousekeepingService
+	MaintenanceSvc  maintenance.MaintenanceService
+	PricingSvc      pricing.PricingService
+	RatePlanSvc     rateplan.RatePlanService
+	FolioSvc        folio.FolioService
+	PaymentSvc      payment.PaymentService
+	InvoiceSvc      invoice.InvoiceService
+	InvitationSvc   invitation.InvitationService
+	MfaSvc          mfa.MfaService
 }
 
-func NewApplication(repos *repository.Repositories) *Application {
+func NewApplication(repos *repository.Repositories, cfg *config.Config) *Application {
+	pricingSvc := pricing.NewPricingServiceImpl(repos)
+	folioSvc := folio.NewFolioServiceImpl(repos, cfg)
+	paymentGateway := gateway.NewPaymentGateway(cfg.PaymentGateway)
+	paymentSvc := payment.NewPaymentServiceImpl(repos, folioSvc, paymentGateway)
+	emailSender := mailer.NewMailer(cfg.Mailer)
+	mfaSvc := mfa.NewMfaServiceImpl(repos, cfg)
+	keySet, err := keyset.NewKeySet(cfg.JWT)
+	if err != nil {
+		log.Panicln("invalid jwt configuration: ", err.Error())
+	}
+
 	return &Application{
-		Repos:      repos,
-		RoomSvc:    room.NewRoomServiceImpl(repos),
-		GuestSvc:   guest.NewGuestServiceImpl(repos),
-		BookingSvc: booking.NewBookingServiceImpl(repos),
-		UserSvc:    user.NewUserServiceImpl(repos),
-		AuthSvc:    auth.NewAuthServiceImpl(repos),
+		Repos:           repos,
+		KeySet:          keySet,
+		RoomSvc:         room.NewRoomServiceImpl(repos, pricingSvc),
+		GuestSvc:        guest.NewGuestServiceImpl(repos, cfg),
+		BookingSvc:      booking.NewBookingServiceImpl(repos, cfg, pricingSvc, paymentSvc, paymentGateway),
+		UserSvc:         user.NewUserServiceImpl(repos, cfg, emailSender),
+		AuthSvc:         auth.NewAuthServiceImpl(repos, cfg, keySet, mfaSvc),
+		AvailabilitySvc: availability.NewAvailabilityServiceImpl(repos),
+		HousekeepingSvc: housekeeping.NewHousekeepingServiceImpl(repos),
+		MaintenanceSvc:  maintenance.NewMaintenanceServiceImpl(repos),
+		PricingSvc:      pricingSvc,
+		RatePlanSvc:     rateplan.NewRatePlanServiceImpl(repos),
+		FolioSvc:        folioSvc,
+		PaymentSvc:      paymentSvc,
+		InvoiceSvc:      invoice.NewInvoiceServiceImpl(repos, cfg, folioSvc, paymentSvc),
+		InvitationSvc:   invitation.NewInvitationServiceImpl(repos, cfg),
+		MfaSvc:          mfaSvc,
 	}
 }
@@ -5,6 +5,10 @@ import "github.com/zakiyalmaya/hotel-management/model"
 //go:generate mockery --name=AuthService --output=../mocks --outpkg=mocks
 type AuthService interface {
 	Login(request *model.AuthRequest) (*model.AuthResponse, error)
-	Logout(username string) error
-	RefreshAuthToken(username string) (*model.AuthResponse, error)
+	VerifyMfa(request *model.MfaLoginRequest) (*model.AuthResponse, error)
+	Logout(sessionID string) error
+	RefreshAuthToken(request *model.RefreshRequest) (*model.AuthResponse, error)
+	GetSessions(userID int, currentSessionID string) ([]*model.SessionResponse, error)
+	RevokeSession(userID int, sessionID string) error
+	JWKS() *model.JWKS
 }
@@ -1,116 +1,455 @@
 package auth
 
 import (
-	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"math"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/zakiyalmaya/hotel-management/application/mfa"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/keyset"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyPasswordHash is compared against when the username does not exist,
+// so an unknown username takes as long to reject as a wrong password.
+const dummyPasswordHash = "$2a$10$vS3TxOGvfUszlR1zUebH2e3ZFlOzzexkQI0RfEiIxWEbXvGC6C.Cu"
+
 type authSvcImpl struct {
-	repos *repository.Repositories
+	repos  *repository.Repositories
+	cfg    *config.Config
+	keySet *keyset.KeySet
+	mfaSvc mfa.MfaService
 }
 
-func NewAuthServiceImpl(repos *repository.Repositories) AuthService {
-	return &authSvcImpl{repos: repos}
+func NewAuthServiceImpl(repos *repository.Repositories, cfg *config.Config, keySet *keyset.KeySet, mfaSvc mfa.MfaService) AuthService {
+	return &authSvcImpl{
+		repos:  repos,
+		cfg:    cfg,
+		keySet: keySet,
+		mfaSvc: mfaSvc,
+	}
 }
 
+// Login starts a new session, a hotelier can be logged in on several devices at once.
+// An unknown username and a wrong password fail the same way so usernames can not be guessed.
+// Hoteliers with two-factor authentication, or whose role requires it, get an mfa token
+// instead of a session, and finish the login with a code through VerifyMfa.
+// Deactivated hoteliers are only told so after the right password.
 func (a *authSvcImpl) Login(request *model.AuthRequest) (*model.AuthResponse, error) {
+	userKey, ipKey := "user-"+request.Username, "ip-"+request.IP
+	for _, key := range []string{userKey, ipKey} {
+		blockedFor, err := a.repos.LoginAttemptRepo.BlockedFor(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get login attempts from Redis")
+		}
+
+		if blockedFor > 0 {
+			return nil, model.NewTooManyRequestsError(blockedFor, "too many failed login attempts, try again in %d seconds", int(math.Ceil(blockedFor.Seconds())))
+		}
+	}
+
 	response, err := a.repos.UserRepo.GetByUsername(request.Username)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("error getting hotelier by username")
+	}
+
+	passwordHash := dummyPasswordHash
+	if response != nil {
+		passwordHash = response.Password
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(request.Password)); err != nil || response == nil {
+		if err := a.recordFailure(userKey, a.cfg.Login.LockoutAttempts); err != nil {
+			return nil, err
+		}
+
+		if err := a.recordFailure(ipKey, a.cfg.Login.IPLockoutAttempts); err != nil {
+			return nil, err
+		}
+
+		return nil, model.NewUnauthorizedError("invalid username or password")
+	}
+
+	// failures from the IP are kept, it may be guessing other usernames
+	if err := a.repos.LoginAttemptRepo.Reset(userKey); err != nil {
+		return nil, fmt.Errorf("failed to reset login attempts in Redis")
+	}
+
+	if response.DeactivatedAt != nil {
+		return nil, model.NewUnauthorizedError("your account has been deactivated")
+	}
+
+	required, err := a.mfaSvc.IsRequired(response.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error getting mfa policy")
+	}
+
+	if response.TOTPEnabled || required {
+		return a.challenge(response, request.Device)
+	}
+
+	return a.startSession(response, request.Device, request.IP)
+}
+
+// VerifyMfa finishes a login with the code of the mfa token. A hotelier who had to enrol
+// sends a code of the new secret, which turns two-factor authentication on and returns
+// the recovery codes. Too many wrong codes end the mfa token and the login starts over.
+// Wrong codes are also counted per hotelier across mfa tokens, with the backoff and lockout
+// of passwords, and only a right code clears them since the password is already known.
+func (a *authSvcImpl) VerifyMfa(request *model.MfaLoginRequest) (*model.AuthResponse, error) {
+	tokenHash := utils.HashToken(request.MfaToken)
+	challenge, err := a.repos.MfaChallengeRepo.GetByTokenHash(tokenHash)
+	if err != nil {
+		if err == redis.Nil {
+			return nil, model.NewUnauthorizedError("invalid or expired mfa token")
+		}
+
+		return nil, fmt.Errorf("failed to get mfa challenge from Redis")
+	}
+
+	user, err := a.repos.UserRepo.GetByID(challenge.UserID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, model.NewUnauthorizedError("invalid or expired mfa token")
 		}
 
-		return nil, fmt.Errorf("error getting hotelier by username")
+		return nil, fmt.Errorf("error getting hotelier by id")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(response.Password), []byte(request.Password)); err != nil {
-		return nil, fmt.Errorf("wrong password")
+	if user.DeactivatedAt != nil {
+		return nil, model.NewUnauthorizedError("your account has been deactivated")
 	}
 
-	duration := 15 * time.Minute
-	expirationTime := time.Now().Add(duration)
-	claims := &model.AuthClaims{
-		UserID:   response.ID,
-		Username: response.Username,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-		},
+	mfaKey := "mfa-" + user.Username
+	blockedFor, err := a.repos.LoginAttemptRepo.BlockedFor(mfaKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login attempts from Redis")
+	}
+
+	if blockedFor > 0 {
+		return nil, model.NewTooManyRequestsError(blockedFor, "too many invalid codes, try again in %d seconds", int(math.Ceil(blockedFor.Seconds())))
+	}
+
+	var recoveryCodes *model.RecoveryCodesResponse
+	if challenge.Enroll {
+		recoveryCodes, err = a.mfaSvc.Activate(user.ID, request.Code)
+	} else {
+		err = a.mfaSvc.Verify(user, request.Code, request.RecoveryCode)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte("hotel-management-secret-key"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create token")
+		if _, ok := err.(*model.ValidationError); ok {
+			if err := a.recordFailure(mfaKey, a.cfg.Login.LockoutAttempts); err != nil {
+				return nil, err
+			}
+
+			return nil, a.recordMfaFailure(tokenHash)
+		}
+
+		return nil, err
+	}
+
+	if err := a.repos.LoginAttemptRepo.Reset(mfaKey); err != nil {
+		return nil, fmt.Errorf("failed to reset login attempts in Redis")
+	}
+
+	if err := a.repos.MfaChallengeRepo.Delete(tokenHash); err != nil {
+		return nil, fmt.Errorf("failed to delete mfa challenge from Redis")
 	}
 
-	err = a.repos.RedCl.Set(context.Background(), "jwt-token-"+response.Username, tokenString, duration).Err()
+	response, err := a.startSession(user, challenge.Device, request.IP)
 	if err != nil {
-		log.Println("Failed to store token in Redis:", err.Error())
-		return nil, fmt.Errorf("failed to store token in Redis")
+		return nil, err
 	}
 
-	return &model.AuthResponse{
-		Username: response.Username,
-		Name:     response.Name,
-		Token:    tokenString,
-	}, nil
+	if recoveryCodes != nil {
+		response.RecoveryCodes = recoveryCodes.RecoveryCodes
+	}
+
+	return response, nil
 }
 
-func (a *authSvcImpl) Logout(username string) error {
-	if err := a.repos.RedCl.Del(context.Background(), "jwt-token-"+username).Err(); err != nil {
-		log.Println("Failed to delete token from Redis:", err.Error())
-		return fmt.Errorf("failed to delete token from Redis")
+func (a *authSvcImpl) Logout(sessionID string) error {
+	session, err := a.repos.SessionRepo.GetByID(sessionID)
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get session from Redis")
+	}
+
+	if err := a.repos.SessionRepo.Delete(session); err != nil {
+		return fmt.Errorf("failed to delete session from Redis")
 	}
 
 	return nil
 }
 
-func (a *authSvcImpl) RefreshAuthToken(username string) (*model.AuthResponse, error) {
-	oldToken, err := a.repos.RedCl.Get(context.Background(), "jwt-token-"+username).Result()
+// RefreshAuthToken swaps a refresh token for a new access and refresh token. Each refresh
+// token works once, one that comes back after it was swapped has been copied, so the
+// session is revoked and both the thief and the hotelier have to log in again.
+func (a *authSvcImpl) RefreshAuthToken(request *model.RefreshRequest) (*model.AuthResponse, error) {
+	refreshTokenHash := utils.HashToken(request.RefreshToken)
+	session, err := a.repos.SessionRepo.GetByRefreshTokenHash(refreshTokenHash)
+	if err != nil {
+		if err == redis.Nil {
+			return nil, model.NewUnauthorizedError("invalid or expired refresh token")
+		}
+
+		return nil, fmt.Errorf("failed to get session from Redis")
+	}
+
+	if session.RefreshTokenHash != refreshTokenHash {
+		return nil, a.revokeReused(session)
+	}
+
+	user, err := a.repos.UserRepo.GetByID(session.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewUnauthorizedError("invalid or expired refresh token")
+		}
+
+		return nil, fmt.Errorf("error getting hotelier by id")
+	}
+
+	if user.DeactivatedAt != nil {
+		return nil, model.NewUnauthorizedError("your account has been deactivated")
+	}
+
+	refreshToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token")
+	}
+
+	now := time.Now()
+	rotated := *session
+	rotated.RefreshTokenHash = utils.HashToken(refreshToken)
+	rotated.IP = request.IP
+	rotated.LastSeenAt = now
+	rotated.ExpiresAt = now.Add(a.refreshTokenExpiry())
+	if err := a.repos.SessionRepo.Rotate(&rotated, refreshTokenHash); err != nil {
+		// another refresh with the same token got there first
+		if _, ok := err.(*model.UnauthorizedError); ok {
+			return nil, a.revokeReused(session)
+		}
+
+		return nil, fmt.Errorf("failed to store session in Redis")
+	}
+
+	return a.issue(user, &rotated, refreshToken)
+}
+
+func (a *authSvcImpl) GetSessions(userID int, currentSessionID string) ([]*model.SessionResponse, error) {
+	sessions, err := a.repos.SessionRepo.GetByUserID(userID)
 	if err != nil {
-		log.Println("Failed to get token from Redis:", err.Error())
-		return nil, fmt.Errorf("failed to get token from Redis")
+		return nil, fmt.Errorf("failed to get sessions from Redis")
 	}
 
-	token, err := jwt.ParseWithClaims(oldToken, &model.AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte("hotel-management-secret-key"), nil
-	})
+	responses := make([]*model.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, &model.SessionResponse{
+			ID:         session.ID,
+			Device:     session.Device,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt.Format("02-01-2006 15:04:05"),
+			LastSeenAt: session.LastSeenAt.Format("02-01-2006 15:04:05"),
+			ExpiresAt:  session.ExpiresAt.Format("02-01-2006 15:04:05"),
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	return responses, nil
+}
+
+// RevokeSession logs one of the devices of a hotelier out. Sessions of others are reported
+// as not found so their ids can not be probed.
+func (a *authSvcImpl) RevokeSession(userID int, sessionID string) error {
+	session, err := a.repos.SessionRepo.GetByID(sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
+		if err == redis.Nil {
+			return model.NewNotFoundError("session not found")
+		}
+
+		return fmt.Errorf("failed to get session from Redis")
+	}
+
+	if session.UserID != userID {
+		return model.NewNotFoundError("session not found")
 	}
 
-	// Verify token is valid
-	if claims, ok := token.Claims.(*model.AuthClaims); ok && token.Valid {
-		// Extend expiration time by 15 minutes
-		duration := 15 * time.Minute
-		claims.ExpiresAt = time.Now().Add(duration).Unix()
+	if err := a.repos.SessionRepo.Delete(session); err != nil {
+		return fmt.Errorf("failed to delete session from Redis")
+	}
 
-		// Generate a new token with updated expiration
-		newToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-		tokenString, err := newToken.SignedString([]byte("hotel-management-secret-key"))
+	return nil
+}
+
+func (a *authSvcImpl) JWKS() *model.JWKS {
+	return a.keySet.JWKS()
+}
+
+func (a *authSvcImpl) startSession(user *model.UserEntity, device, ip string) (*model.AuthResponse, error) {
+	refreshToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token")
+	}
+
+	now := time.Now()
+	session := &model.SessionEntity{
+		ID:               uuid.NewString(),
+		UserID:           user.ID,
+		Device:           device,
+		IP:               ip,
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		CreatedAt:        now,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(a.refreshTokenExpiry()),
+	}
+
+	if err := a.repos.SessionRepo.Save(session); err != nil {
+		return nil, fmt.Errorf("failed to store session in Redis")
+	}
+
+	return a.issue(user, session, refreshToken)
+}
+
+// challenge hands out the mfa token of the second login step. A hotelier who has not
+// enrolled yet also gets a new secret to add to an authenticator app.
+func (a *authSvcImpl) challenge(user *model.UserEntity, device string) (*model.AuthResponse, error) {
+	response := &model.AuthResponse{
+		Username:    user.Username,
+		Name:        user.Name,
+		Role:        user.Role.Enum(),
+		MfaRequired: true,
+	}
+
+	if !user.TOTPEnabled {
+		enrollment, err := a.mfaSvc.Enroll(user.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign new token: %w", err)
+			return nil, err
+		}
+
+		response.MfaEnrollment = enrollment
+	}
+
+	mfaToken, err := utils.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mfa token")
+	}
+
+	if err := a.repos.MfaChallengeRepo.Create(&model.MfaChallengeEntity{
+		TokenHash: utils.HashToken(mfaToken),
+		UserID:    user.ID,
+		Device:    device,
+		Enroll:    !user.TOTPEnabled,
+		ExpiresAt: time.Now().Add(time.Duration(a.cfg.Mfa.ChallengeExpiryMinutes) * time.Minute),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store mfa challenge in Redis")
+	}
+
+	response.MfaToken = mfaToken
+	return response, nil
+}
+
+func (a *authSvcImpl) recordMfaFailure(tokenHash string) error {
+	failures, err := a.repos.MfaChallengeRepo.RecordFailure(tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to store mfa attempt in Redis")
+	}
+
+	if failures >= a.cfg.Mfa.ChallengeAttempts {
+		if err := a.repos.MfaChallengeRepo.Delete(tokenHash); err != nil {
+			return fmt.Errorf("failed to delete mfa challenge from Redis")
 		}
 
-		// Set new token in Redis
-		if err := a.repos.RedCl.Set(context.Background(), "jwt-token-"+username, tokenString, duration).Err(); err != nil {
-			log.Println("Failed to store token in Redis:", err.Error())
-			return nil, fmt.Errorf("failed to store token in Redis")
+		return model.NewUnauthorizedError("too many invalid codes, log in again")
+	}
+
+	return model.NewUnauthorizedError("invalid code")
+}
+
+// issue signs an access token for the session.
+func (a *authSvcImpl) issue(user *model.UserEntity, session *model.SessionEntity, refreshToken string) (*model.AuthResponse, error) {
+	claims := &model.AuthClaims{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		SessionID: session.ID,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Duration(a.cfg.Session.AccessTokenExpiryMinutes) * time.Minute).Unix(),
+		},
+	}
+
+	tokenString, err := a.keySet.Sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token")
+	}
+
+	return &model.AuthResponse{
+		Username:     user.Username,
+		Name:         user.Name,
+		Role:         user.Role.Enum(),
+		Token:        tokenString,
+		RefreshToken: refreshToken,
+		SessionID:    session.ID,
+	}, nil
+}
+
+func (a *authSvcImpl) revokeReused(session *model.SessionEntity) error {
+	log.Printf("refresh token reused, revoking session %s of user %d", session.ID, session.UserID)
+	if err := a.repos.SessionRepo.Delete(session); err != nil {
+		return fmt.Errorf("failed to delete session from Redis")
+	}
+
+	return model.NewUnauthorizedError("refresh token has already been used, the session is revoked")
+}
+
+// recordFailure counts a failed login, or mfa code, of the key and blocks the key for the backoff,
+// or for the lockout once lockoutAttempts failures are reached.
+func (a *authSvcImpl) recordFailure(key string, lockoutAttempts int) error {
+	failures, err := a.repos.LoginAttemptRepo.RecordFailure(key, time.Duration(a.cfg.Login.FailureWindowMinutes)*time.Minute)
+	if err != nil {
+		return fmt.Errorf("failed to store login attempt in Redis")
+	}
+
+	var block time.Duration
+	if failures >= lockoutAttempts {
+		log.Printf("locking %s out after %d failed login attempts", key, failures)
+		block = time.Duration(a.cfg.Login.LockoutMinutes) * time.Minute
+	} else if failures > a.cfg.Login.FreeAttempts {
+		// 1, 2, 4, ... times the backoff for every failure past the free ones
+		maxBackoff := time.Duration(a.cfg.Login.MaxBackoffSeconds) * time.Second
+		block = time.Duration(a.cfg.Login.BackoffSeconds) * time.Second
+		for i := a.cfg.Login.FreeAttempts + 1; i < failures && block < maxBackoff; i++ {
+			block *= 2
 		}
 
-		return &model.AuthResponse{
-			Username: claims.Username,
-			Token:    tokenString,
-		}, nil
+		if block > maxBackoff {
+			block = maxBackoff
+		}
 	}
 
-	return nil, fmt.Errorf("token is invalid or claims not valid")
+	if block == 0 {
+		return nil
+	}
+
+	if err := a.repos.LoginAttemptRepo.Block(key, block); err != nil {
+		return fmt.Errorf("failed to store login attempt in Redis")
+	}
+
+	return nil
+}
+
+func (a *authSvcImpl) refreshTokenExpiry() time.Duration {
+	return time.Duration(a.cfg.Session.RefreshTokenExpiryDays) * 24 * time.Hour
 }
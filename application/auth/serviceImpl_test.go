@@ -1,38 +1,80 @@
 package auth
 
 import (
-	"context"
 	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
-	"github.com/alicebob/miniredis/v2"
 	"github.com/go-playground/assert/v2"
 	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/mock"
+	appMocks "github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/keyset"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
 	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
 )
 
 var (
-	mockUser        *mocks.UserRepository
-	mockRedisClient *redis.Client
+	mockUser         *mocks.UserRepository
+	mockSession      *mocks.SessionRepository
+	mockLoginAttempt *mocks.LoginAttemptRepository
+	mockMfaChallenge *mocks.MfaChallengeRepository
+	mockMfa          *appMocks.MfaService
+	mockKeySet       = newKeySet()
+	mockConfig       = &config.Config{
+		Session: config.SessionConfig{
+			AccessTokenExpiryMinutes: 15,
+			RefreshTokenExpiryDays:   30,
+		},
+		Login: config.LoginConfig{
+			FreeAttempts:         3,
+			BackoffSeconds:       1,
+			MaxBackoffSeconds:    60,
+			LockoutAttempts:      10,
+			IPLockoutAttempts:    50,
+			LockoutMinutes:       30,
+			FailureWindowMinutes: 60,
+		},
+		Mfa: config.MfaConfig{
+			ChallengeExpiryMinutes: 5,
+			ChallengeAttempts:      5,
+		},
+	}
 )
 
-func Test_Login(t *testing.T) {
-	mockUser = new(mocks.UserRepository)
-	mockRedisServer, err := miniredis.Run()
+func newKeySet() *keyset.KeySet {
+	keySet, err := keyset.NewKeySet(config.JWTConfig{
+		SigningKeyID: "test",
+		Keys: []config.JWTKeyConfig{
+			{ID: "test", Algorithm: "HS256", Secret: "test-secret"},
+		},
+	})
 	if err != nil {
-		t.Fatalf(err.Error())
+		panic(err)
 	}
 
-	mockRedisClient = redis.NewClient(&redis.Options{
-		Addr: mockRedisServer.Addr(),
-	})
+	return keySet
+}
+
+func newService() AuthService {
+	return NewAuthServiceImpl(&repository.Repositories{UserRepo: mockUser, SessionRepo: mockSession, LoginAttemptRepo: mockLoginAttempt, MfaChallengeRepo: mockMfaChallenge}, mockConfig, mockKeySet, mockMfa)
+}
+
+func Test_Login(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockSession = new(mocks.SessionRepository)
+	mockLoginAttempt = new(mocks.LoginAttemptRepository)
+	mockMfa = new(appMocks.MfaService)
 
-	duration := 15 * time.Minute
+	notBlocked := func() {
+		mockLoginAttempt.On("BlockedFor", "user-username").Return(time.Duration(0), nil).Once()
+		mockLoginAttempt.On("BlockedFor", "ip-10.0.0.1").Return(time.Duration(0), nil).Once()
+	}
 
 	testCases := []struct {
 		name     string
@@ -45,49 +87,133 @@ func Test_Login(t *testing.T) {
 			request: &model.AuthRequest{
 				Username: "username",
 				Password: "password",
+				Device:   "Front desk PC",
+				IP:       "10.0.0.1",
 			},
 			mockCall: func() {
+				notBlocked()
 				mockUser.On("GetByUsername", "username").Return(&model.UserEntity{
+					ID:       1,
 					Username: "username",
 					Password: "$2a$10$ajTCV9x1V1.SDLkVIQMGX.2zASIoBojgofRV0s3Mnwg5cIfMNIsyy",
+					Role:     constant.RoleFrontDesk,
 				}, nil).Once()
-
-				mockRedisClient.Set(context.Background(), "jwt-token-username", mock.Anything, duration).Err()
+				mockLoginAttempt.On("Reset", "user-username").Return(nil).Once()
+				mockMfa.On("IsRequired", mock.Anything).Return(false, nil).Once()
+				mockSession.On("Save", mock.MatchedBy(func(session *model.SessionEntity) bool {
+					return session.UserID == 1 && session.Device == "Front desk PC" && session.IP == "10.0.0.1" &&
+						session.ExpiresAt.After(time.Now().Add(29*24*time.Hour))
+				})).Return(nil).Once()
 			},
 			err: nil,
 		},
 		{
-			name: "Given wrong password when login then return error response",
+			name: "Given error save session when login then return error response",
+			request: &model.AuthRequest{
+				Username: "username",
+				Password: "password",
+				IP:       "10.0.0.1",
+			},
+			mockCall: func() {
+				notBlocked()
+				mockUser.On("GetByUsername", "username").Return(&model.UserEntity{
+					ID:       1,
+					Username: "username",
+					Password: "$2a$10$ajTCV9x1V1.SDLkVIQMGX.2zASIoBojgofRV0s3Mnwg5cIfMNIsyy",
+				}, nil).Once()
+				mockLoginAttempt.On("Reset", "user-username").Return(nil).Once()
+				mockMfa.On("IsRequired", mock.Anything).Return(false, nil).Once()
+				mockSession.On("Save", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: errors.New("failed to store session in Redis"),
+		},
+		{
+			name: "Given deactivated hotelier when login then return unauthorized",
+			request: &model.AuthRequest{
+				Username: "username",
+				Password: "password",
+				IP:       "10.0.0.1",
+			},
+			mockCall: func() {
+				deactivatedAt := time.Now()
+				notBlocked()
+				mockUser.On("GetByUsername", "username").Return(&model.UserEntity{
+					ID:            1,
+					Username:      "username",
+					Password:      "$2a$10$ajTCV9x1V1.SDLkVIQMGX.2zASIoBojgofRV0s3Mnwg5cIfMNIsyy",
+					DeactivatedAt: &deactivatedAt,
+				}, nil).Once()
+				mockLoginAttempt.On("Reset", "user-username").Return(nil).Once()
+			},
+			err: model.NewUnauthorizedError("your account has been deactivated"),
+		},
+		{
+			name: "Given wrong password when login then count the failure and return credential error",
 			request: &model.AuthRequest{
 				Username: "username",
 				Password: "password",
+				IP:       "10.0.0.1",
 			},
 			mockCall: func() {
+				notBlocked()
 				mockUser.On("GetByUsername", "username").Return(&model.UserEntity{
 					Username: "username",
 					Password: "wrong-password",
 				}, nil).Once()
+				mockLoginAttempt.On("RecordFailure", "user-username", time.Hour).Return(1, nil).Once()
+				mockLoginAttempt.On("RecordFailure", "ip-10.0.0.1", time.Hour).Return(1, nil).Once()
 			},
-			err: errors.New("wrong password"),
+			err: model.NewUnauthorizedError("invalid username or password"),
 		},
 		{
-			name: "Given error not found when get user by username then return error response",
+			name: "Given unknown username when login then count the failure and return the same credential error",
 			request: &model.AuthRequest{
 				Username: "username",
 				Password: "password",
+				IP:       "10.0.0.1",
 			},
 			mockCall: func() {
+				notBlocked()
 				mockUser.On("GetByUsername", "username").Return(nil, sql.ErrNoRows).Once()
+				mockLoginAttempt.On("RecordFailure", "user-username", time.Hour).Return(1, nil).Once()
+				mockLoginAttempt.On("RecordFailure", "ip-10.0.0.1", time.Hour).Return(1, nil).Once()
+			},
+			err: model.NewUnauthorizedError("invalid username or password"),
+		},
+		{
+			name: "Given blocked username when login then return too many requests error",
+			request: &model.AuthRequest{
+				Username: "username",
+				Password: "password",
+				IP:       "10.0.0.1",
 			},
-			err: errors.New("user not found"),
+			mockCall: func() {
+				mockLoginAttempt.On("BlockedFor", "user-username").Return(1500*time.Millisecond, nil).Once()
+			},
+			err: model.NewTooManyRequestsError(1500*time.Millisecond, "too many failed login attempts, try again in 2 seconds"),
+		},
+		{
+			name: "Given blocked IP when login then return too many requests error",
+			request: &model.AuthRequest{
+				Username: "username",
+				Password: "password",
+				IP:       "10.0.0.1",
+			},
+			mockCall: func() {
+				mockLoginAttempt.On("BlockedFor", "user-username").Return(time.Duration(0), nil).Once()
+				mockLoginAttempt.On("BlockedFor", "ip-10.0.0.1").Return(30*time.Minute, nil).Once()
+			},
+			err: model.NewTooManyRequestsError(30*time.Minute, "too many failed login attempts, try again in 1800 seconds"),
 		},
 		{
 			name: "Given error when get user by username then return error response",
 			request: &model.AuthRequest{
 				Username: "username",
 				Password: "password",
+				IP:       "10.0.0.1",
 			},
 			mockCall: func() {
+				notBlocked()
 				mockUser.On("GetByUsername", "username").Return(nil, errors.New("error")).Once()
 			},
 			err: errors.New("error getting hotelier by username"),
@@ -98,22 +224,273 @@ func Test_Login(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewAuthServiceImpl(&repository.Repositories{UserRepo: mockUser, RedCl: mockRedisClient})
-			_, err := service.Login(tc.request.(*model.AuthRequest))
+			response, err := newService().Login(tc.request.(*model.AuthRequest))
 			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				claims := &model.AuthClaims{}
+				_, err := mockKeySet.Parse(response.Token, claims)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, response.SessionID, claims.SessionID)
+				assert.Equal(t, constant.RoleFrontDesk, claims.Role)
+				assert.NotEqual(t, "", response.RefreshToken)
+			}
 		})
 	}
 }
 
-func Test_Logout(t *testing.T) {
-	mockRedisServer, err := miniredis.Run()
-	if err != nil {
-		t.Fatalf(err.Error())
+func Test_LoginBackoff(t *testing.T) {
+	testCases := []struct {
+		name         string
+		userFailures int
+		ipFailures   int
+		userBlock    time.Duration
+		ipBlock      time.Duration
+	}{
+		{
+			name:         "Given free attempts left when login fails then do not block",
+			userFailures: 3,
+			ipFailures:   3,
+		},
+		{
+			name:         "Given first failure past the free attempts when login fails then block for the backoff",
+			userFailures: 4,
+			ipFailures:   4,
+			userBlock:    time.Second,
+			ipBlock:      time.Second,
+		},
+		{
+			name:         "Given more failures when login fails then double the backoff",
+			userFailures: 9,
+			ipFailures:   6,
+			userBlock:    32 * time.Second,
+			ipBlock:      4 * time.Second,
+		},
+		{
+			name:         "Given lockout attempts reached when login fails then lock the username out",
+			userFailures: 10,
+			ipFailures:   10,
+			userBlock:    30 * time.Minute,
+			ipBlock:      time.Minute,
+		},
+		{
+			name:         "Given IP lockout attempts reached when login fails then lock the IP out",
+			userFailures: 1,
+			ipFailures:   50,
+			ipBlock:      30 * time.Minute,
+		},
 	}
 
-	mockRedisClient = redis.NewClient(&redis.Options{
-		Addr: mockRedisServer.Addr(),
-	})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUser = new(mocks.UserRepository)
+			mockLoginAttempt = new(mocks.LoginAttemptRepository)
+			mockLoginAttempt.On("BlockedFor", mock.Anything).Return(time.Duration(0), nil)
+			mockUser.On("GetByUsername", "username").Return(nil, sql.ErrNoRows).Once()
+			mockLoginAttempt.On("RecordFailure", "user-username", time.Hour).Return(tc.userFailures, nil).Once()
+			mockLoginAttempt.On("RecordFailure", "ip-10.0.0.1", time.Hour).Return(tc.ipFailures, nil).Once()
+			if tc.userBlock > 0 {
+				mockLoginAttempt.On("Block", "user-username", tc.userBlock).Return(nil).Once()
+			}
+			if tc.ipBlock > 0 {
+				mockLoginAttempt.On("Block", "ip-10.0.0.1", tc.ipBlock).Return(nil).Once()
+			}
+
+			_, err := newService().Login(&model.AuthRequest{Username: "username", Password: "password", IP: "10.0.0.1"})
+			assert.Equal(t, model.NewUnauthorizedError("invalid username or password"), err)
+			mockLoginAttempt.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_LoginMfa(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockLoginAttempt = new(mocks.LoginAttemptRepository)
+	mockMfaChallenge = new(mocks.MfaChallengeRepository)
+	mockMfa = new(appMocks.MfaService)
+	request := &model.AuthRequest{Username: "username", Password: "password", Device: "Front desk PC", IP: "10.0.0.1"}
+	user := func(totpEnabled bool) *model.UserEntity {
+		return &model.UserEntity{
+			ID:          1,
+			Username:    "username",
+			Password:    "$2a$10$ajTCV9x1V1.SDLkVIQMGX.2zASIoBojgofRV0s3Mnwg5cIfMNIsyy",
+			Role:        constant.RoleAccountant,
+			TOTPEnabled: totpEnabled,
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		enrollment *model.MfaEnrollmentResponse
+	}{
+		{
+			name: "Given hotelier with two-factor authentication when login then return mfa token instead of a session",
+			mockCall: func() {
+				mockUser.On("GetByUsername", "username").Return(user(true), nil).Once()
+				mockMfa.On("IsRequired", constant.RoleAccountant).Return(false, nil).Once()
+				mockMfaChallenge.On("Create", mock.MatchedBy(func(challenge *model.MfaChallengeEntity) bool {
+					return challenge.UserID == 1 && !challenge.Enroll && challenge.Device == "Front desk PC"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "Given role requiring two-factor authentication when login without it then return mfa token and enrollment",
+			mockCall: func() {
+				mockUser.On("GetByUsername", "username").Return(user(false), nil).Once()
+				mockMfa.On("IsRequired", constant.RoleAccountant).Return(true, nil).Once()
+				mockMfa.On("Enroll", 1).Return(&model.MfaEnrollmentResponse{Secret: "secret", ProvisioningURI: "otpauth://totp/"}, nil).Once()
+				mockMfaChallenge.On("Create", mock.MatchedBy(func(challenge *model.MfaChallengeEntity) bool {
+					return challenge.UserID == 1 && challenge.Enroll
+				})).Return(nil).Once()
+			},
+			enrollment: &model.MfaEnrollmentResponse{Secret: "secret", ProvisioningURI: "otpauth://totp/"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLoginAttempt.On("BlockedFor", mock.Anything).Return(time.Duration(0), nil).Twice()
+			mockLoginAttempt.On("Reset", "user-username").Return(nil).Once()
+			tc.mockCall()
+
+			response, err := newService().Login(request)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, true, response.MfaRequired)
+			assert.NotEqual(t, "", response.MfaToken)
+			assert.Equal(t, "", response.Token)
+			assert.Equal(t, tc.enrollment, response.MfaEnrollment)
+		})
+	}
+}
+
+func Test_VerifyMfa(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockSession = new(mocks.SessionRepository)
+	mockLoginAttempt = new(mocks.LoginAttemptRepository)
+	mockMfaChallenge = new(mocks.MfaChallengeRepository)
+	mockMfa = new(appMocks.MfaService)
+
+	tokenHash := utils.HashToken("mfa-token")
+	user := &model.UserEntity{ID: 1, Username: "username", Role: constant.RoleAccountant, TOTPEnabled: true}
+	challenge := func(enroll bool) *model.MfaChallengeEntity {
+		return &model.MfaChallengeEntity{TokenHash: tokenHash, UserID: 1, Device: "Front desk PC", Enroll: enroll, ExpiresAt: time.Now().Add(5 * time.Minute)}
+	}
+
+	testCases := []struct {
+		name          string
+		request       *model.MfaLoginRequest
+		mockCall      func()
+		recoveryCodes []string
+		err           error
+	}{
+		{
+			name:    "Given valid code when verify mfa then start the session",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", Code: "123456", IP: "10.0.0.1"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(challenge(false), nil).Once()
+				mockUser.On("GetByID", 1).Return(user, nil).Once()
+				mockLoginAttempt.On("BlockedFor", "mfa-username").Return(time.Duration(0), nil).Once()
+				mockMfa.On("Verify", user, "123456", "").Return(nil).Once()
+				mockLoginAttempt.On("Reset", "mfa-username").Return(nil).Once()
+				mockMfaChallenge.On("Delete", tokenHash).Return(nil).Once()
+				mockSession.On("Save", mock.MatchedBy(func(session *model.SessionEntity) bool {
+					return session.UserID == 1 && session.Device == "Front desk PC" && session.IP == "10.0.0.1"
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given code of the new secret when verify enrolling mfa then activate and return recovery codes",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", Code: "123456", IP: "10.0.0.1"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(challenge(true), nil).Once()
+				mockUser.On("GetByID", 1).Return(user, nil).Once()
+				mockLoginAttempt.On("BlockedFor", "mfa-username").Return(time.Duration(0), nil).Once()
+				mockMfa.On("Activate", 1, "123456").Return(&model.RecoveryCodesResponse{RecoveryCodes: []string{"abcde-12345"}}, nil).Once()
+				mockLoginAttempt.On("Reset", "mfa-username").Return(nil).Once()
+				mockMfaChallenge.On("Delete", tokenHash).Return(nil).Once()
+				mockSession.On("Save", mock.Anything).Return(nil).Once()
+			},
+			recoveryCodes: []string{"abcde-12345"},
+			err:           nil,
+		},
+		{
+			name:    "Given wrong code when verify mfa then count the failure and return unauthorized",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", Code: "000000"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(challenge(false), nil).Once()
+				mockUser.On("GetByID", 1).Return(user, nil).Once()
+				mockLoginAttempt.On("BlockedFor", "mfa-username").Return(time.Duration(0), nil).Once()
+				mockMfa.On("Verify", user, "000000", "").Return(model.NewValidationError("invalid code")).Once()
+				mockLoginAttempt.On("RecordFailure", "mfa-username", time.Hour).Return(1, nil).Once()
+				mockMfaChallenge.On("RecordFailure", tokenHash).Return(1, nil).Once()
+			},
+			err: model.NewUnauthorizedError("invalid code"),
+		},
+		{
+			name:    "Given wrong codes past the free attempts when verify mfa then block the hotelier for the backoff",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", Code: "000000"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(challenge(false), nil).Once()
+				mockUser.On("GetByID", 1).Return(user, nil).Once()
+				mockLoginAttempt.On("BlockedFor", "mfa-username").Return(time.Duration(0), nil).Once()
+				mockMfa.On("Verify", user, "000000", "").Return(model.NewValidationError("invalid code")).Once()
+				mockLoginAttempt.On("RecordFailure", "mfa-username", time.Hour).Return(5, nil).Once()
+				mockLoginAttempt.On("Block", "mfa-username", 2*time.Second).Return(nil).Once()
+				mockMfaChallenge.On("RecordFailure", tokenHash).Return(1, nil).Once()
+			},
+			err: model.NewUnauthorizedError("invalid code"),
+		},
+		{
+			name:    "Given hotelier locked out of mfa when verify mfa then return too many requests without checking the code",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", Code: "123456"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(challenge(false), nil).Once()
+				mockUser.On("GetByID", 1).Return(user, nil).Once()
+				mockLoginAttempt.On("BlockedFor", "mfa-username").Return(30*time.Minute, nil).Once()
+			},
+			err: model.NewTooManyRequestsError(30*time.Minute, "too many invalid codes, try again in 1800 seconds"),
+		},
+		{
+			name:    "Given too many wrong codes when verify mfa then end the mfa token",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", RecoveryCode: "wrong"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(challenge(false), nil).Once()
+				mockUser.On("GetByID", 1).Return(user, nil).Once()
+				mockLoginAttempt.On("BlockedFor", "mfa-username").Return(time.Duration(0), nil).Once()
+				mockMfa.On("Verify", user, "", "wrong").Return(model.NewValidationError("invalid code")).Once()
+				mockLoginAttempt.On("RecordFailure", "mfa-username", time.Hour).Return(1, nil).Once()
+				mockMfaChallenge.On("RecordFailure", tokenHash).Return(5, nil).Once()
+				mockMfaChallenge.On("Delete", tokenHash).Return(nil).Once()
+			},
+			err: model.NewUnauthorizedError("too many invalid codes, log in again"),
+		},
+		{
+			name:    "Given unknown mfa token when verify mfa then return unauthorized",
+			request: &model.MfaLoginRequest{MfaToken: "mfa-token", Code: "123456"},
+			mockCall: func() {
+				mockMfaChallenge.On("GetByTokenHash", tokenHash).Return(nil, redis.Nil).Once()
+			},
+			err: model.NewUnauthorizedError("invalid or expired mfa token"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			response, err := newService().VerifyMfa(tc.request)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.NotEqual(t, "", response.Token)
+				assert.Equal(t, tc.recoveryCodes, response.RecoveryCodes)
+			}
+		})
+	}
+}
+
+func Test_Logout(t *testing.T) {
+	mockSession = new(mocks.SessionRepository)
 
 	testCases := []struct {
 		name     string
@@ -122,58 +499,158 @@ func Test_Logout(t *testing.T) {
 		err      error
 	}{
 		{
-			name:    "Given valid request when logout then return success response",
-			request: "username",
+			name:    "Given valid request when logout then delete the session",
+			request: "session-id",
 			mockCall: func() {
-				mockRedisClient.Del(context.Background(), "jwt-token-username").Err()
+				mockSession.On("GetByID", "session-id").Return(&model.SessionEntity{ID: "session-id", UserID: 1}, nil).Once()
+				mockSession.On("Delete", &model.SessionEntity{ID: "session-id", UserID: 1}).Return(nil).Once()
 			},
 			err: nil,
 		},
+		{
+			name:    "Given session already gone when logout then return success response",
+			request: "session-id",
+			mockCall: func() {
+				mockSession.On("GetByID", "session-id").Return(nil, redis.Nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error delete session when logout then return error response",
+			request: "session-id",
+			mockCall: func() {
+				mockSession.On("GetByID", "session-id").Return(&model.SessionEntity{ID: "session-id", UserID: 1}, nil).Once()
+				mockSession.On("Delete", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: errors.New("failed to delete session from Redis"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewAuthServiceImpl(&repository.Repositories{RedCl: mockRedisClient})
-			err := service.Logout(tc.request)
+			err := newService().Logout(tc.request)
 			assert.Equal(t, tc.err, err)
 		})
 	}
 }
 
 func Test_RefreshAuthToken(t *testing.T) {
-	mockRedisServer, err := miniredis.Run()
-	if err != nil {
-		t.Fatalf(err.Error())
+	mockUser = new(mocks.UserRepository)
+	mockSession = new(mocks.SessionRepository)
+
+	refreshTokenHash := utils.HashToken("refresh-token")
+	session := func() *model.SessionEntity {
+		return &model.SessionEntity{ID: "session-id", UserID: 1, Device: "Front desk PC", RefreshTokenHash: refreshTokenHash}
 	}
 
-	mockRedisClient = redis.NewClient(&redis.Options{
-		Addr: mockRedisServer.Addr(),
-	})
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given current refresh token when refresh auth token then rotate it",
+			mockCall: func() {
+				mockSession.On("GetByRefreshTokenHash", refreshTokenHash).Return(session(), nil).Once()
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1, Username: "username", Role: constant.RoleAccountant}, nil).Once()
+				mockSession.On("Rotate", mock.MatchedBy(func(rotated *model.SessionEntity) bool {
+					return rotated.ID == "session-id" && rotated.RefreshTokenHash != refreshTokenHash && rotated.IP == "10.0.0.2"
+				}), refreshTokenHash).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unknown refresh token when refresh auth token then return unauthorized error",
+			mockCall: func() {
+				mockSession.On("GetByRefreshTokenHash", refreshTokenHash).Return(nil, redis.Nil).Once()
+			},
+			err: model.NewUnauthorizedError("invalid or expired refresh token"),
+		},
+		{
+			name: "Given refresh token already rotated when refresh auth token then revoke the session",
+			mockCall: func() {
+				rotated := session()
+				rotated.RefreshTokenHash = "newer"
+				mockSession.On("GetByRefreshTokenHash", refreshTokenHash).Return(rotated, nil).Once()
+				mockSession.On("Delete", rotated).Return(nil).Once()
+			},
+			err: model.NewUnauthorizedError("refresh token has already been used, the session is revoked"),
+		},
+		{
+			name: "Given concurrent refresh won the race when refresh auth token then revoke the session",
+			mockCall: func() {
+				mockSession.On("GetByRefreshTokenHash", refreshTokenHash).Return(session(), nil).Once()
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1, Username: "username"}, nil).Once()
+				mockSession.On("Rotate", mock.Anything, refreshTokenHash).Return(model.NewUnauthorizedError("refresh token has already been used")).Once()
+				mockSession.On("Delete", session()).Return(nil).Once()
+			},
+			err: model.NewUnauthorizedError("refresh token has already been used, the session is revoked"),
+		},
+		{
+			name: "Given deleted user when refresh auth token then return unauthorized error",
+			mockCall: func() {
+				mockSession.On("GetByRefreshTokenHash", refreshTokenHash).Return(session(), nil).Once()
+				mockUser.On("GetByID", 1).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewUnauthorizedError("invalid or expired refresh token"),
+		},
+		{
+			name: "Given error get session when refresh auth token then return error response",
+			mockCall: func() {
+				mockSession.On("GetByRefreshTokenHash", refreshTokenHash).Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("failed to get session from Redis"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			response, err := newService().RefreshAuthToken(&model.RefreshRequest{RefreshToken: "refresh-token", IP: "10.0.0.2"})
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, "session-id", response.SessionID)
+				assert.Equal(t, "Accountant", response.Role)
+				assert.NotEqual(t, "refresh-token", response.RefreshToken)
+			}
+		})
+	}
+}
+
+func Test_GetSessions(t *testing.T) {
+	mockSession = new(mocks.SessionRepository)
+	seen := time.Date(2024, 12, 11, 9, 30, 0, 0, time.UTC)
 
 	testCases := []struct {
 		name     string
-		request  string
 		mockCall func()
-		err      bool
+		response []*model.SessionResponse
+		err      error
 	}{
 		{
-			name:    "Given error get token from redis when refresh auth token then return error response",
-			request: "username",
+			name: "Given sessions when get sessions then mark the current one",
 			mockCall: func() {
-				mockRedisClient.Get(context.Background(), "jwt-token-username").Err()
+				mockSession.On("GetByUserID", 1).Return([]*model.SessionEntity{
+					{ID: "a", Device: "Front desk PC", IP: "10.0.0.1", CreatedAt: seen, LastSeenAt: seen, ExpiresAt: seen},
+					{ID: "b", Device: "Tablet", IP: "10.0.0.2", CreatedAt: seen, LastSeenAt: seen, ExpiresAt: seen},
+				}, nil).Once()
+			},
+			response: []*model.SessionResponse{
+				{ID: "a", Device: "Front desk PC", IP: "10.0.0.1", CreatedAt: "11-12-2024 09:30:00", LastSeenAt: "11-12-2024 09:30:00", ExpiresAt: "11-12-2024 09:30:00", Current: false},
+				{ID: "b", Device: "Tablet", IP: "10.0.0.2", CreatedAt: "11-12-2024 09:30:00", LastSeenAt: "11-12-2024 09:30:00", ExpiresAt: "11-12-2024 09:30:00", Current: true},
 			},
-			err: true,
+			err: nil,
 		},
 		{
-			name:    "Given invalid token when parse token then return error response",
-			request: "username",
+			name: "Given error when get sessions then return error response",
 			mockCall: func() {
-				mockRedisClient.Get(context.Background(), "jwt-token-username").Result()
-				mockRedisClient.Set(context.Background(), "jwt-token-username", mock.Anything, 15*time.Minute).Err()
+				mockSession.On("GetByUserID", 1).Return(nil, errors.New("error")).Once()
 			},
-			err: true,
+			response: nil,
+			err:      errors.New("failed to get sessions from Redis"),
 		},
 	}
 
@@ -181,9 +658,51 @@ func Test_RefreshAuthToken(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewAuthServiceImpl(&repository.Repositories{RedCl: mockRedisClient})
-			_, err := service.RefreshAuthToken(tc.request)
-			assert.Equal(t, tc.err, err != nil)
+			response, err := newService().GetSessions(1, "b")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
+
+func Test_RevokeSession(t *testing.T) {
+	mockSession = new(mocks.SessionRepository)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given own session when revoke session then delete it",
+			mockCall: func() {
+				mockSession.On("GetByID", "a").Return(&model.SessionEntity{ID: "a", UserID: 1}, nil).Once()
+				mockSession.On("Delete", &model.SessionEntity{ID: "a", UserID: 1}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given session of another user when revoke session then return not found error",
+			mockCall: func() {
+				mockSession.On("GetByID", "a").Return(&model.SessionEntity{ID: "a", UserID: 2}, nil).Once()
+			},
+			err: model.NewNotFoundError("session not found"),
+		},
+		{
+			name: "Given unknown session when revoke session then return not found error",
+			mockCall: func() {
+				mockSession.On("GetByID", "a").Return(nil, redis.Nil).Once()
+			},
+			err: model.NewNotFoundError("session not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := newService().RevokeSession(1, "a")
+			assert.Equal(t, tc.err, err)
 		})
 	}
 }
@@ -0,0 +1,8 @@
+package availability
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=AvailabilityService --output=../mocks --outpkg=mocks
+type AvailabilityService interface {
+	IsAvailable(request *model.AvailabilityRequest) (bool, error)
+}
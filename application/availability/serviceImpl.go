@@ -0,0 +1,32 @@
+package availability
+
+import (
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type availabilitySvcImpl struct {
+	repos *repository.Repositories
+}
+
+func NewAvailabilityServiceImpl(repos *repository.Repositories) AvailabilityService {
+	return &availabilitySvcImpl{repos: repos}
+}
+
+func (a *availabilitySvcImpl) IsAvailable(request *model.AvailabilityRequest) (bool, error) {
+	room, err := a.repos.RoomRepo.GetByName(request.RoomName)
+	if err != nil {
+		return false, err
+	}
+
+	if !room.Status.IsBookable() {
+		return false, nil
+	}
+
+	count, err := a.repos.BookingRepo.CountOverlapping(request)
+	if err != nil {
+		return false, err
+	}
+
+	return count == 0, nil
+}
@@ -0,0 +1,97 @@
+package availability
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockBooking *mocks.BookingRepository
+	mockRoom    *mocks.RoomRepository
+)
+
+func Test_IsAvailable(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	request := &model.AvailabilityRequest{
+		RoomName: "room",
+		CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+		CheckOut: time.Date(2000, time.April, 03, 00, 00, 00, 00, time.UTC),
+	}
+
+	testCases := []struct {
+		name     string
+		request  *model.AvailabilityRequest
+		mockCall func()
+		response bool
+		err      error
+	}{
+		{
+			name:    "Given no overlapping reservation when check availability then return available",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusBooked}, nil).Once()
+				mockBooking.On("CountOverlapping", request).Return(0, nil).Once()
+			},
+			response: true,
+			err:      nil,
+		},
+		{
+			name:    "Given overlapping reservation when check availability then return not available",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusAvailable}, nil).Once()
+				mockBooking.On("CountOverlapping", request).Return(1, nil).Once()
+			},
+			response: false,
+			err:      nil,
+		},
+		{
+			name:    "Given room under maintenance when check availability then return not available",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusMaintenance}, nil).Once()
+			},
+			response: false,
+			err:      nil,
+		},
+		{
+			name:    "Given error get room data when check availability then return error",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(nil, errors.New("error")).Once()
+			},
+			response: false,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error count overlapping reservation when check availability then return error",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusAvailable}, nil).Once()
+				mockBooking.On("CountOverlapping", request).Return(0, errors.New("error")).Once()
+			},
+			response: false,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewAvailabilityServiceImpl(&repository.Repositories{RoomRepo: mockRoom, BookingRepo: mockBooking})
+			resp, err := service.IsAvailable(tc.request)
+			assert.Equal(t, tc.response, resp)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -6,6 +6,10 @@ import "github.com/zakiyalmaya/hotel-management/model"
 type BookingService interface {
 	Books(booking *model.BookingEntity) error
 	GetByRegisterNumber(registerNumber string) (*model.BookingResponse, error)
-	UpdatePayment(booking *model.BookingEntity) error
+	GetByGuestID(guestID int) ([]*model.BookingResponse, error)
 	Reschedule(booking *model.BookingEntity) error
-}
\ No newline at end of file
+	Cancel(registerNumber string) (*model.CancelBookingResponse, error)
+	CheckIn(registerNumber string, userID int) error
+	CheckOut(registerNumber string, userID int) error
+	NoShow(registerNumber string) error
+}
@@ -1,19 +1,31 @@
 package booking
 
 import (
-	"fmt"
+	"database/sql"
+	"log"
+	"math"
+	"time"
 
+	"github.com/zakiyalmaya/hotel-management/application/folio"
+	"github.com/zakiyalmaya/hotel-management/application/payment"
+	"github.com/zakiyalmaya/hotel-management/application/pricing"
+	"github.com/zakiyalmaya/hotel-management/config"
 	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/gateway"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
 type bookingSvcImpl struct {
-	repos *repository.Repositories
+	repos          *repository.Repositories
+	cfg            *config.Config
+	pricingSvc     pricing.PricingService
+	paymentSvc     payment.PaymentService
+	paymentGateway gateway.PaymentGateway
 }
 
-func NewBookingServiceImpl(repos *repository.Repositories) BookingService {
-	return &bookingSvcImpl{repos: repos}
+func NewBookingServiceImpl(repos *repository.Repositories, cfg *config.Config, pricingSvc pricing.PricingService, paymentSvc payment.PaymentService, paymentGateway gateway.PaymentGateway) BookingService {
+	return &bookingSvcImpl{repos: repos, cfg: cfg, pricingSvc: pricingSvc, paymentSvc: paymentSvc, paymentGateway: paymentGateway}
 }
 
 func (b *bookingSvcImpl) Books(booking *model.BookingEntity) error {
@@ -23,17 +35,39 @@ func (b *bookingSvcImpl) Books(booking *model.BookingEntity) error {
 		return err
 	}
 
-	if room.Status != constant.RoomStatusAvailable {
-		return fmt.Errorf("room not available")
+	if !room.Status.IsBookable() {
+		return model.NewConflictError("room not available")
 	}
 
-	// Calculate total cost based on days
-	duration := booking.CheckOut.Sub(booking.CheckIn).Hours() / 24
-	totalCost := duration * room.Price
-	booking.PaidAmount = totalCost
+	// Price every night of the stay from the rate plan
+	quote, err := b.pricingSvc.Quote(&model.PriceQuoteRequest{
+		RoomName: booking.RoomName,
+		CheckIn:  booking.CheckIn,
+		CheckOut: booking.CheckOut,
+	})
+	if err != nil {
+		return err
+	}
+	booking.RoomCharges = roomCharges(booking.RegisterNumber, booking.CheckIn, quote)
+	booking.PaidAmount = b.folioTotal(booking.RoomCharges)
+
+	// A card given when booking is held for the stay and charged at check out
+	if booking.PaymentMethod == constant.PaymentMethodCreditCard && booking.CardToken != "" {
+		transaction, err := b.paymentGateway.Authorize(booking.RegisterNumber, booking.PaidAmount, booking.CardToken)
+		if err != nil {
+			return err
+		}
+		booking.GatewayTransactionID = &transaction.ID
+		booking.AuthorizedAmount = transaction.Amount
+	}
 
+	// Overlapping reservations are rejected atomically by the repository
 	err = b.repos.BookingRepo.Books(booking)
 	if err != nil {
+		// release the hold of a booking that was not made, the booking error is the one to report
+		if booking.GatewayTransactionID != nil {
+			b.paymentGateway.Void(*booking.GatewayTransactionID)
+		}
 		return err
 	}
 
@@ -46,6 +80,32 @@ func (b *bookingSvcImpl) GetByRegisterNumber(registerNumber string) (*model.Book
 		return nil, err
 	}
 
+	return toBookingResponse(booking), nil
+}
+
+// GetByGuestID returns the stay history of a guest, the latest stay first.
+func (b *bookingSvcImpl) GetByGuestID(guestID int) ([]*model.BookingResponse, error) {
+	if _, err := b.repos.GuestRepo.GetByID(guestID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewNotFoundError("guest not found")
+		}
+		return nil, err
+	}
+
+	bookings, err := b.repos.BookingRepo.GetByGuestID(guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*model.BookingResponse, 0, len(bookings))
+	for _, booking := range bookings {
+		responses = append(responses, toBookingResponse(booking))
+	}
+
+	return responses, nil
+}
+
+func toBookingResponse(booking *model.BookingDetail) *model.BookingResponse {
 	return &model.BookingResponse{
 		RegisterNumber:    booking.RegisterNumber,
 		GuestID:           booking.GuestID,
@@ -60,30 +120,321 @@ func (b *bookingSvcImpl) GetByRegisterNumber(registerNumber string) (*model.Book
 		PaidAmount:        booking.PaidAmount,
 		PaymentMethod:     booking.PaymentMethod.Enum(),
 		PaymentStatus:     booking.PaymentStatus.Enum(),
+		Status:            booking.BookingEntity.Status.Enum(),
 		AdditionalRequest: booking.AdditionalRequest,
+		RefundAmount:      booking.RefundAmount,
+		CheckedInAt:       formatTimestamp(booking.CheckedInAt),
+		CheckedOutAt:      formatTimestamp(booking.CheckedOutAt),
 		CreatedAt:         booking.BookingEntity.CreatedAt.Format("02-01-2006 15:04:05"),
-	}, nil
+	}
 }
 
-func (b *bookingSvcImpl) UpdatePayment(booking *model.BookingEntity) error {
-	if err := b.repos.BookingRepo.UpdatePayment(booking); err != nil {
+func (b *bookingSvcImpl) Reschedule(booking *model.BookingEntity) error {
+	detail, err := b.repos.BookingRepo.GetByRegisterNumber(booking.RegisterNumber)
+	if err != nil {
 		return err
 	}
 
-	detail, err := b.repos.BookingRepo.GetByRegisterNumber(booking.RegisterNumber)
+	if err := transition(detail.BookingEntity.Status, constant.BookingStatusConfirmed); err != nil {
+		return err
+	}
+
+	// money already paid stays on the folio and counts against the new total
+	if detail.PaymentStatus != constant.PaymentStatusPending && detail.PaymentStatus != constant.PaymentStatusPartiallyPaid && detail.PaymentStatus != constant.PaymentStatusCompleted {
+		return model.NewConflictError("invalid payment status")
+	}
+
+	if !detail.RoomEntity.Status.IsBookable() {
+		return model.NewConflictError("invalid room status")
+	}
+
+	// Reprice the new dates from the rate plan
+	quote, err := b.pricingSvc.Quote(&model.PriceQuoteRequest{
+		RoomName: detail.RoomName,
+		CheckIn:  booking.CheckIn,
+		CheckOut: booking.CheckOut,
+	})
 	if err != nil {
 		return err
 	}
 
-	if booking.PaymentStatus == constant.PaymentStatusCompleted {
+	booking.RoomName = detail.RoomName
+	booking.FromStatus = detail.BookingEntity.Status
+	booking.RoomCharges = roomCharges(booking.RegisterNumber, booking.CheckIn, quote)
+	booking.PaidAmount = b.folioTotal(booking.RoomCharges)
+
+	// Update date time
+	if err := b.repos.BookingRepo.Reschedule(booking); err != nil {
+		return err
+	}
+
+	// The new dates change the folio total, so the payment status follows it
+	summary, err := b.paymentSvc.GetByRegisterNumber(booking.RegisterNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := b.repos.BookingRepo.UpdatePayment(&model.BookingEntity{
+		RegisterNumber: booking.RegisterNumber,
+		PaymentStatus:  folio.PaymentStatus(detail.BookingEntity.Status, summary.Paid, summary.Total),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *bookingSvcImpl) Cancel(registerNumber string) (*model.CancelBookingResponse, error) {
+	detail, err := b.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transition(detail.BookingEntity.Status, constant.BookingStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	daysBeforeCheckIn := int(detail.CheckIn.Sub(startOfDay(now)).Hours() / 24)
+	if daysBeforeCheckIn < 0 {
+		return nil, model.NewConflictError("booking can not be canceled after check in date")
+	}
+
+	penalty := b.cancellationPenalty(detail.PaidAmount, daysBeforeCheckIn)
+
+	payments, err := b.repos.PaymentRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	paid := 0.0
+	for _, payment := range payments {
+		paid += payment.Amount
+	}
+
+	// Only money already paid can be given back, the penalty is kept out of it first;
+	// an unpaid booking or a deposit smaller than the penalty is simply released
+	booking := &model.BookingEntity{
+		RegisterNumber: registerNumber,
+		Status:         constant.BookingStatusCancelled,
+		PaymentStatus:  constant.PaymentStatusCanceled,
+		CanceledAt:     &now,
+		FromStatus:     detail.BookingEntity.Status,
+	}
+	if refund := math.Round((paid-penalty)*100) / 100; refund > 0 {
+		booking.PaymentStatus = constant.PaymentStatusRefunded
+		booking.RefundAmount = refund
+	}
+
+	var refund *model.PaymentEntity
+	if booking.RefundAmount > 0 {
+		reference := "cancellation refund"
+		refund = &model.PaymentEntity{
+			RegisterNumber: registerNumber,
+			Amount:         -booking.RefundAmount,
+			PaymentMethod:  detail.PaymentMethod,
+			Reference:      &reference,
+			PaidAt:         now,
+		}
+	}
+
+	// A card held at booking is released unless the gateway has already charged it,
+	// then the refund goes back to the card
+	charged := detail.GatewayTransactionID != nil && chargedOnGateway(payments, *detail.GatewayTransactionID)
+	voids := detail.GatewayTransactionID != nil && !charged
+	refunds := charged && refund != nil
+
+	// The booking is claimed before the gateway is called, so a booking changed meanwhile never
+	// has its card touched. When the gateway is called the refund waits until it succeeded.
+	// Cancelled bookings no longer count towards availability, which frees the dates
+	claimed := refund
+	if voids || refunds {
+		claimed = nil
+	}
+
+	if err := b.repos.BookingRepo.Cancel(booking, claimed); err != nil {
+		return nil, err
+	}
+
+	if voids {
+		if _, err := b.paymentGateway.Void(*detail.GatewayTransactionID); err != nil {
+			return nil, b.revertCancel(detail, err)
+		}
+	}
+
+	if refunds {
+		transaction, err := b.paymentGateway.Refund(*detail.GatewayTransactionID, booking.RefundAmount)
+		if err != nil {
+			return nil, b.revertCancel(detail, err)
+		}
+		refund.PaymentMethod = constant.PaymentMethodCreditCard
+		refund.GatewayReference = &transaction.ID
+	}
+
+	if refund != nil && claimed == nil {
+		if err := b.repos.PaymentRepo.Create(refund); err != nil {
+			return nil, err
+		}
+	}
+
+	if detail.RoomEntity.Status == constant.RoomStatusBooked {
 		if err := b.repos.RoomRepo.Update(&model.RoomEntity{
-			Status: constant.RoomStatusBooked,
+			Status: constant.RoomStatusAvailable,
 			Name:   detail.RoomName,
 		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.CancelBookingResponse{
+		RegisterNumber: registerNumber,
+		PaidAmount:     detail.PaidAmount,
+		PenaltyAmount:  penalty,
+		RefundAmount:   booking.RefundAmount,
+		PaymentStatus:  booking.PaymentStatus.Enum(),
+	}, nil
+}
+
+// revertCancel puts a booking claimed for cancellation back the way it was when the gateway
+// failed, so the cancellation can be tried again. The gateway error is returned either way.
+func (b *bookingSvcImpl) revertCancel(detail *model.BookingDetail, gatewayErr error) error {
+	if err := b.repos.BookingRepo.RevertCancel(&model.BookingEntity{
+		RegisterNumber: detail.RegisterNumber,
+		Status:         detail.BookingEntity.Status,
+		PaymentStatus:  detail.PaymentStatus,
+		RefundAmount:   detail.RefundAmount,
+		FromStatus:     constant.BookingStatusCancelled,
+	}); err != nil {
+		log.Printf("failed to revert the cancellation of booking %s: %s", detail.RegisterNumber, err.Error())
+	}
+
+	return gatewayErr
+}
+
+// cancellationPenalty applies the configured cancellation policy to the booking amount.
+func (b *bookingSvcImpl) cancellationPenalty(amount float64, daysBeforeCheckIn int) float64 {
+	if daysBeforeCheckIn >= b.cfg.Cancellation.FreeDays {
+		return 0
+	}
+
+	penalty := amount * b.cfg.Cancellation.PenaltyPercentage / 100
+	return math.Round(penalty*100) / 100
+}
+
+func (b *bookingSvcImpl) CheckIn(registerNumber string, userID int) error {
+	detail, err := b.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := transition(detail.BookingEntity.Status, constant.BookingStatusCheckedIn); err != nil {
+		return err
+	}
+
+	// A deposit or a card held at booking guarantees the stay, the rest is settled at check out
+	if detail.PaymentStatus != constant.PaymentStatusCompleted && detail.PaymentStatus != constant.PaymentStatusPartiallyPaid && detail.GatewayTransactionID == nil {
+		return model.NewConflictError("booking is not paid")
+	}
+
+	now := time.Now()
+	today := startOfDay(now)
+	if today.Before(detail.CheckIn) || !today.Before(detail.CheckOut) {
+		return model.NewConflictError("check in is only allowed during the booking dates")
+	}
+
+	if detail.RoomEntity.Status != constant.RoomStatusAvailable && detail.RoomEntity.Status != constant.RoomStatusBooked {
+		return model.NewConflictError("room is not ready for check in")
+	}
+
+	if err := b.repos.BookingRepo.CheckIn(&model.BookingEntity{
+		RegisterNumber: registerNumber,
+		Status:         constant.BookingStatusCheckedIn,
+		CheckedInAt:    &now,
+		CheckedInBy:    &userID,
+		FromStatus:     detail.BookingEntity.Status,
+	}); err != nil {
+		return err
+	}
+
+	if err := b.repos.RoomRepo.Update(&model.RoomEntity{
+		Status: constant.RoomStatusOccupied,
+		Name:   detail.RoomName,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *bookingSvcImpl) CheckOut(registerNumber string, userID int) error {
+	detail, err := b.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := transition(detail.BookingEntity.Status, constant.BookingStatusCheckedOut); err != nil {
+		return err
+	}
+
+	if detail.GatewayTransactionID != nil {
+		if err := b.settleAuthorization(detail, userID); err != nil {
 			return err
 		}
+	}
+
+	now := time.Now()
+	if err := b.repos.BookingRepo.CheckOut(&model.BookingEntity{
+		RegisterNumber: registerNumber,
+		Status:         constant.BookingStatusCheckedOut,
+		CheckedOutAt:   &now,
+		CheckedOutBy:   &userID,
+		FromStatus:     detail.BookingEntity.Status,
+	}); err != nil {
+		return err
+	}
 
-	} else if booking.PaymentStatus == constant.PaymentStatusRefunded {
+	// The room needs housekeeping before the next guest can use it
+	if err := b.repos.RoomRepo.Update(&model.RoomEntity{
+		Status: constant.RoomStatusDirty,
+		Name:   detail.RoomName,
+	}); err != nil {
+		return err
+	}
+
+	if err := b.repos.HousekeepingRepo.Create(&model.HousekeepingTaskEntity{
+		RoomName:       detail.RoomName,
+		RegisterNumber: &registerNumber,
+		Status:         constant.HousekeepingTaskStatusPending,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *bookingSvcImpl) NoShow(registerNumber string) error {
+	detail, err := b.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := transition(detail.BookingEntity.Status, constant.BookingStatusNoShow); err != nil {
+		return err
+	}
+
+	if startOfDay(time.Now()).Before(detail.CheckIn) {
+		return model.NewConflictError("no show can only be recorded from the check in date")
+	}
+
+	if err := b.repos.BookingRepo.UpdateStatus(&model.BookingEntity{
+		RegisterNumber: registerNumber,
+		Status:         constant.BookingStatusNoShow,
+		FromStatus:     detail.BookingEntity.Status,
+	}); err != nil {
+		return err
+	}
+
+	if detail.RoomEntity.Status == constant.RoomStatusBooked {
 		if err := b.repos.RoomRepo.Update(&model.RoomEntity{
 			Status: constant.RoomStatusAvailable,
 			Name:   detail.RoomName,
@@ -95,34 +446,105 @@ func (b *bookingSvcImpl) UpdatePayment(booking *model.BookingEntity) error {
 	return nil
 }
 
-func (b *bookingSvcImpl) Reschedule(booking *model.BookingEntity) error {
-	detail, err := b.repos.BookingRepo.GetByRegisterNumber(booking.RegisterNumber)
+// folioTotal is what the charges come to on the folio once service charge and tax are added.
+func (b *bookingSvcImpl) folioTotal(charges []*model.FolioChargeEntity) float64 {
+	total := 0.0
+	for _, charge := range charges {
+		_, _, chargeTotal := folio.ChargeTotal(b.cfg.Folio, charge.Amount)
+		total += chargeTotal
+	}
+
+	return math.Round(total*100) / 100
+}
+
+// settleAuthorization charges the folio balance to the card held at booking, up to the
+// authorized amount, or releases the hold when the guest has already paid in full.
+// Whatever the hold does not cover stays on the folio to be paid at the desk.
+func (b *bookingSvcImpl) settleAuthorization(detail *model.BookingDetail, userID int) error {
+	summary, err := b.paymentSvc.GetByRegisterNumber(detail.RegisterNumber)
 	if err != nil {
 		return err
 	}
 
-	if detail.PaymentStatus != constant.PaymentStatusPending {
-		return fmt.Errorf("invalid payment status")
+	for _, payment := range summary.Payments {
+		if payment.GatewayReference != nil && *payment.GatewayReference == *detail.GatewayTransactionID {
+			return nil
+		}
 	}
 
-	if detail.RoomEntity.Status != constant.RoomStatusAvailable {
-		return fmt.Errorf("invalid room status")
+	if summary.Balance <= 0 {
+		_, err := b.paymentGateway.Void(*detail.GatewayTransactionID)
+		return err
 	}
 
-	// Calculate total cost based on days
-	room, err := b.repos.RoomRepo.GetByName(detail.RoomName)
+	transaction, err := b.paymentGateway.Capture(*detail.GatewayTransactionID, math.Min(summary.Balance, detail.AuthorizedAmount))
 	if err != nil {
 		return err
 	}
 
-	duration := booking.CheckOut.Sub(booking.CheckIn).Hours() / 24
-	totalCost := duration * room.Price
-	booking.PaidAmount = totalCost
+	return b.paymentSvc.Record(&model.PaymentEntity{
+		RegisterNumber:   detail.RegisterNumber,
+		Amount:           transaction.Amount,
+		PaymentMethod:    constant.PaymentMethodCreditCard,
+		PaidAt:           time.Now(),
+		ReceivedBy:       &userID,
+		GatewayReference: &transaction.ID,
+	})
+}
 
-	// Update date time
-	if err := b.repos.BookingRepo.Reschedule(booking); err != nil {
-		return err
+// chargedOnGateway reports whether a payment was captured from the authorization.
+func chargedOnGateway(payments []*model.PaymentEntity, transactionID string) bool {
+	for _, payment := range payments {
+		if payment.GatewayReference != nil && *payment.GatewayReference == transactionID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roomCharges turns the nightly breakdown of a quote into one folio charge per night of the stay.
+func roomCharges(registerNumber string, checkIn time.Time, quote *model.PriceQuoteResponse) []*model.FolioChargeEntity {
+	charges := make([]*model.FolioChargeEntity, len(quote.Breakdown))
+	for i, night := range quote.Breakdown {
+		description := "Room " + quote.RoomName
+		if night.Season != nil {
+			description += " - " + *night.Season
+		}
+
+		charges[i] = &model.FolioChargeEntity{
+			RegisterNumber: registerNumber,
+			ChargeType:     constant.FolioChargeTypeRoom,
+			Description:    description,
+			ChargeDate:     checkIn.AddDate(0, 0, i),
+			Quantity:       1,
+			UnitPrice:      night.Rate,
+			Amount:         night.Rate,
+		}
+	}
+
+	return charges
+}
+
+// transition rejects moves the booking lifecycle does not allow, e.g. rescheduling a checked out booking.
+func transition(current, next constant.BookingStatus) error {
+	if !current.CanTransitionTo(next) {
+		return model.NewConflictError("booking can not move from %s to %s", current.Enum(), next.Enum())
 	}
 
 	return nil
 }
+
+// startOfDay returns the calendar date of t at midnight UTC, the way booking dates are stored.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format("02-01-2006 15:04:05")
+	return &formatted
+}
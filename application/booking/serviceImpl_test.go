@@ -1,12 +1,17 @@
 package booking
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	appMocks "github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/config"
 	"github.com/zakiyalmaya/hotel-management/constant"
+	gatewayMocks "github.com/zakiyalmaya/hotel-management/infrastructure/gateway/mocks"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
 	"github.com/zakiyalmaya/hotel-management/model"
@@ -15,11 +20,28 @@ import (
 var (
 	mockBooking *mocks.BookingRepository
 	mockRoom    *mocks.RoomRepository
+	mockPayment *mocks.PaymentRepository
+	mockPricing *appMocks.PricingService
+	mockGateway *gatewayMocks.PaymentGateway
 )
 
 func Test_Books(t *testing.T) {
 	mockBooking = new(mocks.BookingRepository)
 	mockRoom = new(mocks.RoomRepository)
+	mockPricing = new(appMocks.PricingService)
+	mockGateway = new(gatewayMocks.PaymentGateway)
+
+	checkIn := time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC)
+	cfg := &config.Config{Folio: config.FolioConfig{ServiceChargePercentage: 10, TaxPercentage: 11}}
+	transactionID := "sim_auth_1"
+	roomCharges := []*model.FolioChargeEntity{
+		{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room room", ChargeDate: checkIn, Quantity: 1, UnitPrice: 1000, Amount: 1000},
+	}
+	quoteCall := func() {
+		mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusAvailable, Price: 1000}, nil).Once()
+		mockPricing.On("Quote", &model.PriceQuoteRequest{RoomName: "room", CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 1)}).
+			Return(&model.PriceQuoteResponse{RoomName: "room", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
+	}
 
 	testCases := []struct {
 		name     string
@@ -38,17 +60,99 @@ func Test_Books(t *testing.T) {
 			},
 			mockCall: func() {
 				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusAvailable, Price: 1000}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(&model.PriceQuoteResponse{RoomName: "room", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
 				mockBooking.On("Books", &model.BookingEntity{
 					RegisterNumber: "register_number",
 					GuestID:        1,
 					RoomName:       "room",
 					CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
 					CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
-					PaidAmount:     1000,
+					PaidAmount:     1221,
+					RoomCharges: []*model.FolioChargeEntity{
+						{
+							RegisterNumber: "register_number",
+							ChargeType:     constant.FolioChargeTypeRoom,
+							Description:    "Room room",
+							ChargeDate:     time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+							Quantity:       1,
+							UnitPrice:      1000,
+							Amount:         1000,
+						},
+					},
+				}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given card token when booking a room then hold the stay with service and tax on the card",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "room",
+				CheckIn:        checkIn,
+				CheckOut:       checkIn.AddDate(0, 0, 1),
+				PaymentMethod:  constant.PaymentMethodCreditCard,
+				CardToken:      "tok_visa",
+			},
+			mockCall: func() {
+				quoteCall()
+				mockGateway.On("Authorize", "register_number", 1221.0, "tok_visa").Return(&model.GatewayTransaction{ID: transactionID, OrderID: "register_number", Amount: 1221, Status: constant.GatewayStatusAuthorized}, nil).Once()
+				mockBooking.On("Books", &model.BookingEntity{
+					RegisterNumber:       "register_number",
+					GuestID:              1,
+					RoomName:             "room",
+					CheckIn:              checkIn,
+					CheckOut:             checkIn.AddDate(0, 0, 1),
+					PaidAmount:           1221,
+					PaymentMethod:        constant.PaymentMethodCreditCard,
+					GatewayTransactionID: &transactionID,
+					AuthorizedAmount:     1221,
+					RoomCharges:          roomCharges,
+					CardToken:            "tok_visa",
 				}).Return(nil).Once()
 			},
 			err: nil,
 		},
+		{
+			name: "Given declined card when booking a room then return error",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "room",
+				CheckIn:        checkIn,
+				CheckOut:       checkIn.AddDate(0, 0, 1),
+				PaymentMethod:  constant.PaymentMethodCreditCard,
+				CardToken:      "tok_declined",
+			},
+			mockCall: func() {
+				quoteCall()
+				mockGateway.On("Authorize", "register_number", 1221.0, "tok_declined").Return(nil, model.NewValidationError("card declined")).Once()
+			},
+			err: model.NewValidationError("card declined"),
+		},
+		{
+			name: "Given room taken after the card was held when booking a room then release the hold and return error",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "room",
+				CheckIn:        checkIn,
+				CheckOut:       checkIn.AddDate(0, 0, 1),
+				PaymentMethod:  constant.PaymentMethodCreditCard,
+				CardToken:      "tok_visa",
+			},
+			mockCall: func() {
+				quoteCall()
+				mockGateway.On("Authorize", "register_number", 1221.0, "tok_visa").Return(&model.GatewayTransaction{ID: transactionID, Amount: 1221}, nil).Once()
+				mockBooking.On("Books", mock.Anything).Return(model.NewConflictError("room not available")).Once()
+				mockGateway.On("Void", transactionID).Return(&model.GatewayTransaction{ID: transactionID, Status: constant.GatewayStatusVoided}, nil).Once()
+			},
+			err: model.NewConflictError("room not available"),
+		},
 		{
 			name: "Given error get room data when booking a room then return error",
 			booking: &model.BookingEntity{
@@ -74,13 +178,29 @@ func Test_Books(t *testing.T) {
 			},
 			mockCall: func() {
 				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusAvailable, Price: 1000}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(&model.PriceQuoteResponse{RoomName: "room", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
 				mockBooking.On("Books", &model.BookingEntity{
 					RegisterNumber: "register_number",
 					GuestID:        1,
 					RoomName:       "room",
 					CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
 					CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
-					PaidAmount:     1000,
+					PaidAmount:     1221,
+					RoomCharges: []*model.FolioChargeEntity{
+						{
+							RegisterNumber: "register_number",
+							ChargeType:     constant.FolioChargeTypeRoom,
+							Description:    "Room room",
+							ChargeDate:     time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+							Quantity:       1,
+							UnitPrice:      1000,
+							Amount:         1000,
+						},
+					},
 				}).Return(errors.New("error")).Once()
 			},
 			err: errors.New("error"),
@@ -97,7 +217,64 @@ func Test_Books(t *testing.T) {
 			mockCall: func() {
 				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusMaintenance, Price: 1000}, nil).Once()
 			},
-			err: errors.New("room not available"),
+			err: model.NewConflictError("room not available"),
+		},
+		{
+			name: "Given stay shorter than minimum when booking a room then return validation error",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "room",
+				CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+			},
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Type: "deluxe", Status: constant.RoomStatusAvailable, Price: 1000}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(nil, model.NewValidationError("minimum stay for deluxe is 2 nights")).Once()
+			},
+			err: model.NewValidationError("minimum stay for deluxe is 2 nights"),
+		},
+		{
+			name: "Given overlapping reservation when booking a booked room then return error",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "room",
+				CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+			},
+			mockCall: func() {
+				mockRoom.On("GetByName", "room").Return(&model.RoomEntity{Name: "room", Status: constant.RoomStatusBooked, Price: 1000}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(&model.PriceQuoteResponse{RoomName: "room", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
+				mockBooking.On("Books", &model.BookingEntity{
+					RegisterNumber: "register_number",
+					GuestID:        1,
+					RoomName:       "room",
+					CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+					PaidAmount:     1221,
+					RoomCharges: []*model.FolioChargeEntity{
+						{
+							RegisterNumber: "register_number",
+							ChargeType:     constant.FolioChargeTypeRoom,
+							Description:    "Room room",
+							ChargeDate:     time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+							Quantity:       1,
+							UnitPrice:      1000,
+							Amount:         1000,
+						},
+					},
+				}).Return(model.NewConflictError("room not available")).Once()
+			},
+			err: model.NewConflictError("room not available"),
 		},
 	}
 
@@ -105,7 +282,7 @@ func Test_Books(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewBookingServiceImpl(&repository.Repositories{RoomRepo: mockRoom, BookingRepo: mockBooking})
+			service := NewBookingServiceImpl(&repository.Repositories{RoomRepo: mockRoom, BookingRepo: mockBooking}, cfg, mockPricing, nil, mockGateway)
 			err := service.Books(tc.booking)
 			assert.Equal(t, tc.err, err)
 		})
@@ -135,6 +312,7 @@ func Test_GetByRegisterNumber(t *testing.T) {
 						CheckOut:       time,
 						PaymentMethod:  constant.PaymentMethodCash,
 						PaymentStatus:  constant.PaymentStatusCompleted,
+						Status:         constant.BookingStatusConfirmed,
 					},
 					RoomEntity: model.RoomEntity{
 						Status: constant.RoomStatusBooked,
@@ -151,6 +329,7 @@ func Test_GetByRegisterNumber(t *testing.T) {
 				CheckOut:       "01 April 2000",
 				CreatedAt:      "01-04-2000 00:00:00",
 				RoomStatus:     constant.RoomStatusBooked.Enum(),
+				Status:         constant.BookingStatusConfirmed.Enum(),
 			},
 		},
 		{
@@ -168,7 +347,7 @@ func Test_GetByRegisterNumber(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking})
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking}, &config.Config{}, nil, nil, nil)
 			resp, err := service.GetByRegisterNumber(tc.request)
 			assert.Equal(t, tc.response, resp)
 			assert.Equal(t, tc.err, err)
@@ -176,9 +355,11 @@ func Test_GetByRegisterNumber(t *testing.T) {
 	}
 }
 
-func Test_UpdatePayment(t *testing.T) {
+func Test_Reschedule(t *testing.T) {
 	mockBooking = new(mocks.BookingRepository)
-	mockRoom = new(mocks.RoomRepository)
+	mockPricing = new(appMocks.PricingService)
+	mockPaymentSvc := new(appMocks.PaymentService)
+	cfg := &config.Config{Folio: config.FolioConfig{ServiceChargePercentage: 10, TaxPercentage: 11}}
 
 	testCases := []struct {
 		name     string
@@ -187,164 +368,58 @@ func Test_UpdatePayment(t *testing.T) {
 		err      error
 	}{
 		{
-			name: "Given request payment status completed when update payment then return success",
-			request: &model.BookingEntity{
-				RegisterNumber: "register_number",
-				PaymentStatus:  constant.PaymentStatusCompleted,
-			},
-			mockCall: func() {
-				mockBooking.On("UpdatePayment", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusCompleted,
-				}).Return(nil).Once()
-
-				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
-					BookingEntity: model.BookingEntity{
-						RegisterNumber: "register_number",
-						PaymentStatus:  constant.PaymentStatusCompleted,
-					},
-					RoomEntity: model.RoomEntity{
-						Floor: 1,
-					},
-				}, nil).Once()
-
-				mockRoom.On("Update", &model.RoomEntity{Status: constant.RoomStatusBooked}).Return(nil).Once()
-			},
-			err: nil,
-		},
-		{
-			name: "Given request payment status refunded when update payment then return success",
+			name: "Given valid request when reschedule booking then return success",
 			request: &model.BookingEntity{
+				CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
 				RegisterNumber: "register_number",
-				PaymentStatus:  constant.PaymentStatusRefunded,
 			},
 			mockCall: func() {
-				mockBooking.On("UpdatePayment", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusRefunded,
-				}).Return(nil).Once()
-
 				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
 					BookingEntity: model.BookingEntity{
 						RegisterNumber: "register_number",
-						PaymentStatus:  constant.PaymentStatusRefunded,
+						Status:         constant.BookingStatusConfirmed,
+						PaymentStatus:  constant.PaymentStatusPending,
+						RoomName:       "room_name",
 					},
 					RoomEntity: model.RoomEntity{
-						Floor: 1,
+						Name:   "room_name",
+						Status: constant.RoomStatusAvailable,
 					},
 				}, nil).Once()
 
-				mockRoom.On("Update", &model.RoomEntity{Status: constant.RoomStatusAvailable}).Return(nil).Once()
-			},
-			err: nil,
-		},
-		{
-			name: "Given error when update payment status to completed then return error",
-			request: &model.BookingEntity{
-				RegisterNumber: "register_number",
-				PaymentStatus:  constant.PaymentStatusCompleted,
-			},
-			mockCall: func() {
-				mockBooking.On("UpdatePayment", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusCompleted,
-				}).Return(nil).Once()
-
-				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
-					BookingEntity: model.BookingEntity{
-						RegisterNumber: "register_number",
-						PaymentStatus:  constant.PaymentStatusCompleted,
-					},
-					RoomEntity: model.RoomEntity{
-						Floor: 1,
-					},
-				}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room_name",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(&model.PriceQuoteResponse{RoomName: "room_name", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
 
-				mockRoom.On("Update", &model.RoomEntity{Status: constant.RoomStatusBooked}).Return(errors.New("error")).Once()
-			},
-			err: errors.New("error"),
-		},
-		{
-			name: "Given error when update payment status to refunded then return errors",
-			request: &model.BookingEntity{
-				RegisterNumber: "register_number",
-				PaymentStatus:  constant.PaymentStatusRefunded,
-			},
-			mockCall: func() {
-				mockBooking.On("UpdatePayment", &model.BookingEntity{
+				mockBooking.On("Reschedule", &model.BookingEntity{
+					CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
 					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusRefunded,
-				}).Return(nil).Once()
-
-				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
-					BookingEntity: model.BookingEntity{
-						RegisterNumber: "register_number",
-						PaymentStatus:  constant.PaymentStatusRefunded,
-					},
-					RoomEntity: model.RoomEntity{
-						Floor: 1,
+					RoomName:       "room_name",
+					PaidAmount:     1221,
+					FromStatus:     constant.BookingStatusConfirmed,
+					RoomCharges: []*model.FolioChargeEntity{
+						{
+							RegisterNumber: "register_number",
+							ChargeType:     constant.FolioChargeTypeRoom,
+							Description:    "Room room_name",
+							ChargeDate:     time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+							Quantity:       1,
+							UnitPrice:      1000,
+							Amount:         1000,
+						},
 					},
-				}, nil).Once()
-
-				mockRoom.On("Update", &model.RoomEntity{Status: constant.RoomStatusAvailable}).Return(errors.New("error")).Once()
-			},
-			err: errors.New("error"),
-		},
-		{
-			name: "Given error when get reservation data by register number then return error",
-			request: &model.BookingEntity{
-				RegisterNumber: "register_number",
-				PaymentStatus:  constant.PaymentStatusRefunded,
-			},
-			mockCall: func() {
-				mockBooking.On("UpdatePayment", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusRefunded,
 				}).Return(nil).Once()
-
-				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
-			},
-			err: errors.New("error"),
-		},
-		{
-			name: "Given error when update payment then return error",
-			request: &model.BookingEntity{
-				RegisterNumber: "register_number",
-				PaymentStatus:  constant.PaymentStatusRefunded,
-			},
-			mockCall: func() {
-				mockBooking.On("UpdatePayment", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusRefunded,
-				}).Return(errors.New("error")).Once()
+				mockPaymentSvc.On("GetByRegisterNumber", "register_number").Return(&model.PaymentSummaryResponse{Total: 1221, Paid: 0}, nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPending}).Return(nil).Once()
 			},
-			err: errors.New("error"),
+			err: nil,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			tc.mockCall()
-
-			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking, RoomRepo: mockRoom})
-			err := service.UpdatePayment(tc.request)
-			assert.Equal(t, tc.err, err)
-		})
-	}
-}
-
-func Test_Reschedule(t *testing.T) {
-	mockBooking = new(mocks.BookingRepository)
-	mockRoom = new(mocks.RoomRepository)
-
-	testCases := []struct {
-		name     string
-		request  *model.BookingEntity
-		mockCall func()
-		err      error
-	}{
 		{
-			name: "Given valid request when reschedule booking then return success",
+			name: "Given partially paid booking moved to a cheaper stay when reschedule booking then recompute the payment status",
 			request: &model.BookingEntity{
 				CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
 				CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
@@ -354,7 +429,8 @@ func Test_Reschedule(t *testing.T) {
 				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
 					BookingEntity: model.BookingEntity{
 						RegisterNumber: "register_number",
-						PaymentStatus:  constant.PaymentStatusPending,
+						Status:         constant.BookingStatusConfirmed,
+						PaymentStatus:  constant.PaymentStatusPartiallyPaid,
 						RoomName:       "room_name",
 					},
 					RoomEntity: model.RoomEntity{
@@ -363,17 +439,17 @@ func Test_Reschedule(t *testing.T) {
 					},
 				}, nil).Once()
 
-				mockRoom.On("GetByName", "room_name").Return(&model.RoomEntity{
-					Name:  "room_name",
-					Price: 1000,
-				}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room_name",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(&model.PriceQuoteResponse{RoomName: "room_name", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
 
-				mockBooking.On("Reschedule", &model.BookingEntity{
-					CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
-					CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
-					RegisterNumber: "register_number",
-					PaidAmount:     1000,
-				}).Return(nil).Once()
+				mockBooking.On("Reschedule", mock.MatchedBy(func(booking *model.BookingEntity) bool {
+					return booking.RegisterNumber == "register_number" && booking.PaidAmount == 1221 && booking.FromStatus == constant.BookingStatusConfirmed
+				})).Return(nil).Once()
+				mockPaymentSvc.On("GetByRegisterNumber", "register_number").Return(&model.PaymentSummaryResponse{Total: 1221, Paid: 1500}, nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusCompleted}).Return(nil).Once()
 			},
 			err: nil,
 		},
@@ -388,6 +464,7 @@ func Test_Reschedule(t *testing.T) {
 				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
 					BookingEntity: model.BookingEntity{
 						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
 						PaymentStatus:  constant.PaymentStatusPending,
 						RoomName:       "room_name",
 					},
@@ -397,22 +474,36 @@ func Test_Reschedule(t *testing.T) {
 					},
 				}, nil).Once()
 
-				mockRoom.On("GetByName", "room_name").Return(&model.RoomEntity{
-					Name:  "room_name",
-					Price: 1000,
-				}, nil).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room_name",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(&model.PriceQuoteResponse{RoomName: "room_name", Nights: 1, Total: 1000, Breakdown: []*model.NightlyRateResponse{{Date: "01 April 2000", Rate: 1000}}}, nil).Once()
 
 				mockBooking.On("Reschedule", &model.BookingEntity{
 					CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
 					CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
 					RegisterNumber: "register_number",
-					PaidAmount:     1000,
+					RoomName:       "room_name",
+					PaidAmount:     1221,
+					FromStatus:     constant.BookingStatusConfirmed,
+					RoomCharges: []*model.FolioChargeEntity{
+						{
+							RegisterNumber: "register_number",
+							ChargeType:     constant.FolioChargeTypeRoom,
+							Description:    "Room room_name",
+							ChargeDate:     time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+							Quantity:       1,
+							UnitPrice:      1000,
+							Amount:         1000,
+						},
+					},
 				}).Return(errors.New("error")).Once()
 			},
 			err: errors.New("error"),
 		},
 		{
-			name: "Given error get room data when reschedule booking then return success",
+			name: "Given error get price quote when reschedule booking then return error",
 			request: &model.BookingEntity{
 				CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
 				CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
@@ -422,6 +513,7 @@ func Test_Reschedule(t *testing.T) {
 				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
 					BookingEntity: model.BookingEntity{
 						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
 						PaymentStatus:  constant.PaymentStatusPending,
 						RoomName:       "room_name",
 					},
@@ -431,7 +523,11 @@ func Test_Reschedule(t *testing.T) {
 					},
 				}, nil).Once()
 
-				mockRoom.On("GetByName", "room_name").Return(nil, errors.New("error")).Once()
+				mockPricing.On("Quote", &model.PriceQuoteRequest{
+					RoomName: "room_name",
+					CheckIn:  time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(nil, errors.New("error")).Once()
 			},
 			err: errors.New("error"),
 		},
@@ -446,16 +542,17 @@ func Test_Reschedule(t *testing.T) {
 				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
 					BookingEntity: model.BookingEntity{
 						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
 						PaymentStatus:  constant.PaymentStatusPending,
 						RoomName:       "room_name",
 					},
 					RoomEntity: model.RoomEntity{
 						Name:   "room_name",
-						Status: constant.RoomStatusBooked,
+						Status: constant.RoomStatusMaintenance,
 					},
 				}, nil).Once()
 			},
-			err: errors.New("invalid room status"),
+			err: model.NewConflictError("invalid room status"),
 		},
 		{
 			name: "Given invalid payment status when reschedule booking then return success",
@@ -468,6 +565,7 @@ func Test_Reschedule(t *testing.T) {
 				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
 					BookingEntity: model.BookingEntity{
 						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
 						PaymentStatus:  constant.PaymentStatusCanceled,
 						RoomName:       "room_name",
 					},
@@ -477,7 +575,25 @@ func Test_Reschedule(t *testing.T) {
 					},
 				}, nil).Once()
 			},
-			err: errors.New("invalid payment status"),
+			err: model.NewConflictError("invalid payment status"),
+		},
+		{
+			name: "Given checked out booking when reschedule booking then return conflict error",
+			request: &model.BookingEntity{
+				CheckIn:        time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.April, 02, 00, 00, 00, 00, time.UTC),
+				RegisterNumber: "register_number",
+			},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedOut,
+						PaymentStatus:  constant.PaymentStatusCompleted,
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("booking can not move from Checked Out to Confirmed"),
 		},
 		{
 			name: "Given error get reservation data when reschedule booking then return success",
@@ -497,9 +613,918 @@ func Test_Reschedule(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewBookingServiceImpl(&repository.Repositories{RoomRepo: mockRoom, BookingRepo: mockBooking})
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking}, cfg, mockPricing, mockPaymentSvc, nil)
 			err := service.Reschedule(tc.request)
 			assert.Equal(t, tc.err, err)
 		})
 	}
 }
+
+func Test_Cancel(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockRoom = new(mocks.RoomRepository)
+	mockPayment = new(mocks.PaymentRepository)
+	mockGateway = new(gatewayMocks.PaymentGateway)
+
+	now := time.Now()
+	transactionID := "sim_auth_1"
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Cancellation: config.CancellationConfig{FreeDays: 3, PenaltyPercentage: 50}}
+
+	matchCancel := func(status constant.PaymentStatus, refund float64) interface{} {
+		return mock.MatchedBy(func(booking *model.BookingEntity) bool {
+			return booking.RegisterNumber == "register_number" && booking.PaymentStatus == status &&
+				booking.RefundAmount == refund && booking.CanceledAt != nil && booking.FromStatus == constant.BookingStatusConfirmed
+		})
+	}
+
+	matchRefund := func(amount float64) interface{} {
+		return mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+			return payment.RegisterNumber == "register_number" && payment.Amount == amount &&
+				payment.PaymentMethod == constant.PaymentMethodCreditCard && *payment.Reference == "cancellation refund"
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		request  string
+		mockCall func()
+		response *model.CancelBookingResponse
+		err      error
+	}{
+		{
+			name:    "Given paid booking canceled before free cancellation deadline when cancel booking then return full refund",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today.AddDate(0, 0, 10),
+						PaidAmount:     1000,
+						PaymentStatus:  constant.PaymentStatusCompleted,
+						PaymentMethod:  constant.PaymentMethodCreditCard,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusBooked},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 1000}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 1000), matchRefund(-1000)).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusAvailable}).Return(nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  0,
+				RefundAmount:   1000,
+				PaymentStatus:  constant.PaymentStatusRefunded.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given paid booking canceled after free cancellation deadline when cancel booking then return partial refund",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today.AddDate(0, 0, 1),
+						PaidAmount:     1000,
+						PaymentStatus:  constant.PaymentStatusCompleted,
+						PaymentMethod:  constant.PaymentMethodCreditCard,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 1000}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 500), matchRefund(-500)).Return(nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  500,
+				RefundAmount:   500,
+				PaymentStatus:  constant.PaymentStatusRefunded.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given unpaid booking when cancel booking then return no refund",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today.AddDate(0, 0, 1),
+						PaidAmount:     1000,
+						PaymentStatus:  constant.PaymentStatusPending,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusCanceled, 0), (*model.PaymentEntity)(nil)).Return(nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  500,
+				RefundAmount:   0,
+				PaymentStatus:  constant.PaymentStatusCanceled.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given deposit smaller than the penalty when cancel booking then return no refund",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today.AddDate(0, 0, 1),
+						PaidAmount:     1000,
+						PaymentStatus:  constant.PaymentStatusPartiallyPaid,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 300}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusCanceled, 0), (*model.PaymentEntity)(nil)).Return(nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  500,
+				RefundAmount:   0,
+				PaymentStatus:  constant.PaymentStatusCanceled.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given card held at booking when cancel booking then release the hold",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						RoomName:             "room_name",
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaidAmount:           1000,
+						PaymentStatus:        constant.PaymentStatusPending,
+						PaymentMethod:        constant.PaymentMethodCreditCard,
+						GatewayTransactionID: &transactionID,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusCanceled, 0), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Void", transactionID).Return(&model.GatewayTransaction{ID: transactionID, Status: constant.GatewayStatusVoided}, nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  0,
+				RefundAmount:   0,
+				PaymentStatus:  constant.PaymentStatusCanceled.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given card held and a cash deposit when cancel booking then release the hold before recording the refund",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						RoomName:             "room_name",
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaidAmount:           1000,
+						PaymentStatus:        constant.PaymentStatusPartiallyPaid,
+						PaymentMethod:        constant.PaymentMethodCash,
+						GatewayTransactionID: &transactionID,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 300}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 300), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Void", transactionID).Return(&model.GatewayTransaction{ID: transactionID, Status: constant.GatewayStatusVoided}, nil).Once()
+				mockPayment.On("Create", mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+					return payment.Amount == -300 && payment.PaymentMethod == constant.PaymentMethodCash && payment.GatewayReference == nil
+				})).Return(nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  0,
+				RefundAmount:   300,
+				PaymentStatus:  constant.PaymentStatusRefunded.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given card already charged by the gateway when cancel booking then refund to the card",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						RoomName:             "room_name",
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaidAmount:           1000,
+						PaymentStatus:        constant.PaymentStatusCompleted,
+						PaymentMethod:        constant.PaymentMethodCreditCard,
+						GatewayTransactionID: &transactionID,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 1000, GatewayReference: &transactionID}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 1000), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Refund", transactionID, 1000.0).Return(&model.GatewayTransaction{ID: "sim_refund_1", Amount: 1000, Status: constant.GatewayStatusRefunded}, nil).Once()
+				mockPayment.On("Create", mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+					return payment.Amount == -1000 && *payment.GatewayReference == "sim_refund_1"
+				})).Return(nil).Once()
+			},
+			response: &model.CancelBookingResponse{
+				RegisterNumber: "register_number",
+				PaidAmount:     1000,
+				PenaltyAmount:  0,
+				RefundAmount:   1000,
+				PaymentStatus:  constant.PaymentStatusRefunded.Enum(),
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error release the card hold when cancel booking then revert the cancellation and return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaymentStatus:        constant.PaymentStatusPending,
+						GatewayTransactionID: &transactionID,
+					},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusCanceled, 0), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Void", transactionID).Return(nil, errors.New("error")).Once()
+				mockBooking.On("RevertCancel", &model.BookingEntity{
+					RegisterNumber: "register_number",
+					Status:         constant.BookingStatusConfirmed,
+					PaymentStatus:  constant.PaymentStatusPending,
+					FromStatus:     constant.BookingStatusCancelled,
+				}).Return(nil).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given already canceled booking when cancel booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCancelled,
+						PaymentStatus:  constant.PaymentStatusCanceled,
+					},
+				}, nil).Once()
+			},
+			response: nil,
+			err:      model.NewConflictError("booking can not move from Cancelled to Cancelled"),
+		},
+		{
+			name:    "Given check in date has passed when cancel booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today.AddDate(0, 0, -1),
+						PaymentStatus:  constant.PaymentStatusCompleted,
+					},
+				}, nil).Once()
+			},
+			response: nil,
+			err:      model.NewConflictError("booking can not be canceled after check in date"),
+		},
+		{
+			name:    "Given error when cancel booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today.AddDate(0, 0, 10),
+						PaymentStatus:  constant.PaymentStatusPending,
+					},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{}, nil).Once()
+				mockBooking.On("Cancel", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error refund to the card when cancel booking then revert the cancellation and return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaidAmount:           1000,
+						PaymentStatus:        constant.PaymentStatusCompleted,
+						GatewayTransactionID: &transactionID,
+					},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 1000, GatewayReference: &transactionID}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 1000), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Refund", transactionID, 1000.0).Return(nil, errors.New("error")).Once()
+				mockBooking.On("RevertCancel", &model.BookingEntity{
+					RegisterNumber: "register_number",
+					Status:         constant.BookingStatusConfirmed,
+					PaymentStatus:  constant.PaymentStatusCompleted,
+					FromStatus:     constant.BookingStatusCancelled,
+				}).Return(nil).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error revert the cancellation when cancel booking then return the gateway error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaidAmount:           1000,
+						PaymentStatus:        constant.PaymentStatusCompleted,
+						GatewayTransactionID: &transactionID,
+					},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 1000, GatewayReference: &transactionID}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 1000), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Refund", transactionID, 1000.0).Return(nil, errors.New("error")).Once()
+				mockBooking.On("RevertCancel", &model.BookingEntity{
+					RegisterNumber: "register_number",
+					Status:         constant.BookingStatusConfirmed,
+					PaymentStatus:  constant.PaymentStatusCompleted,
+					FromStatus:     constant.BookingStatusCancelled,
+				}).Return(errors.New("revert error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error record the card refund when cancel booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						CheckIn:              today.AddDate(0, 0, 10),
+						PaidAmount:           1000,
+						PaymentStatus:        constant.PaymentStatusCompleted,
+						GatewayTransactionID: &transactionID,
+					},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{Amount: 1000, GatewayReference: &transactionID}}, nil).Once()
+				mockBooking.On("Cancel", matchCancel(constant.PaymentStatusRefunded, 1000), (*model.PaymentEntity)(nil)).Return(nil).Once()
+				mockGateway.On("Refund", transactionID, 1000.0).Return(&model.GatewayTransaction{ID: "sim_refund_1", Amount: 1000, Status: constant.GatewayStatusRefunded}, nil).Once()
+				mockPayment.On("Create", mock.Anything).Return(errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error get payments when cancel booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today.AddDate(0, 0, 10),
+						PaymentStatus:  constant.PaymentStatusPending,
+					},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error get reservation data when cancel booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking, RoomRepo: mockRoom, PaymentRepo: mockPayment}, cfg, nil, nil, mockGateway)
+			resp, err := service.Cancel(tc.request)
+			assert.Equal(t, tc.response, resp)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_CheckIn(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	today := startOfDay(time.Now())
+	checkedInAt := today.Add(-time.Hour)
+	transactionID := "sim_auth_1"
+	matchCheckIn := mock.MatchedBy(func(booking *model.BookingEntity) bool {
+		return booking.RegisterNumber == "register_number" && booking.CheckedInAt != nil && *booking.CheckedInBy == 1 &&
+			booking.FromStatus == constant.BookingStatusConfirmed
+	})
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given paid booking on check in date when check in then return success",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today,
+						CheckOut:       today.AddDate(0, 0, 2),
+						PaymentStatus:  constant.PaymentStatusCompleted,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusBooked},
+				}, nil).Once()
+				mockBooking.On("CheckIn", matchCheckIn).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusOccupied}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unpaid booking held on a card when check in then return success",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber:       "register_number",
+						Status:               constant.BookingStatusConfirmed,
+						RoomName:             "room_name",
+						CheckIn:              today,
+						CheckOut:             today.AddDate(0, 0, 2),
+						PaymentStatus:        constant.PaymentStatusPending,
+						GatewayTransactionID: &transactionID,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockBooking.On("CheckIn", matchCheckIn).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusOccupied}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given booking with a deposit paid when check in then return success",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today,
+						CheckOut:       today.AddDate(0, 0, 2),
+						PaymentStatus:  constant.PaymentStatusPartiallyPaid,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockBooking.On("CheckIn", matchCheckIn).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusOccupied}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unpaid booking when check in then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today,
+						CheckOut:       today.AddDate(0, 0, 2),
+						PaymentStatus:  constant.PaymentStatusPending,
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("booking is not paid"),
+		},
+		{
+			name: "Given booking for a future date when check in then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today.AddDate(0, 0, 1),
+						CheckOut:       today.AddDate(0, 0, 2),
+						PaymentStatus:  constant.PaymentStatusCompleted,
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("check in is only allowed during the booking dates"),
+		},
+		{
+			name: "Given booking already checked in when check in then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedIn,
+						CheckedInAt:    &checkedInAt,
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("booking can not move from Checked In to Checked In"),
+		},
+		{
+			name: "Given dirty room when check in then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today,
+						CheckOut:       today.AddDate(0, 0, 2),
+						PaymentStatus:  constant.PaymentStatusCompleted,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusDirty},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("room is not ready for check in"),
+		},
+		{
+			name: "Given error when check in then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today,
+						CheckOut:       today.AddDate(0, 0, 2),
+						PaymentStatus:  constant.PaymentStatusCompleted,
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusAvailable},
+				}, nil).Once()
+				mockBooking.On("CheckIn", matchCheckIn).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name: "Given error get reservation data when check in then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking, RoomRepo: mockRoom}, &config.Config{}, nil, nil, nil)
+			err := service.CheckIn("register_number", 1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_CheckOut(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockRoom = new(mocks.RoomRepository)
+	mockHousekeeping := new(mocks.HousekeepingRepository)
+	mockPaymentSvc := new(appMocks.PaymentService)
+	mockGateway = new(gatewayMocks.PaymentGateway)
+
+	checkedInAt := time.Date(2000, time.April, 01, 14, 00, 00, 00, time.UTC)
+	checkedOutAt := time.Date(2000, time.April, 02, 12, 00, 00, 00, time.UTC)
+	registerNumber := "register_number"
+	transactionID := "sim_auth_1"
+	heldOnCard := &model.BookingDetail{
+		BookingEntity: model.BookingEntity{
+			RegisterNumber:       "register_number",
+			Status:               constant.BookingStatusCheckedIn,
+			RoomName:             "room_name",
+			CheckedInAt:          &checkedInAt,
+			GatewayTransactionID: &transactionID,
+			AuthorizedAmount:     1221,
+		},
+	}
+	matchCheckOut := mock.MatchedBy(func(booking *model.BookingEntity) bool {
+		return booking.RegisterNumber == "register_number" && booking.CheckedOutAt != nil && *booking.CheckedOutBy == 1 &&
+			booking.FromStatus == constant.BookingStatusCheckedIn
+	})
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given checked in booking when check out then return success",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedIn,
+						RoomName:       "room_name",
+						CheckedInAt:    &checkedInAt,
+					},
+				}, nil).Once()
+				mockBooking.On("CheckOut", matchCheckOut).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusDirty}).Return(nil).Once()
+				mockHousekeeping.On("Create", &model.HousekeepingTaskEntity{
+					RoomName:       "room_name",
+					RegisterNumber: &registerNumber,
+					Status:         constant.HousekeepingTaskStatusPending,
+				}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given booking held on a card when check out then charge the balance up to the hold",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(heldOnCard, nil).Once()
+				mockPaymentSvc.On("GetByRegisterNumber", "register_number").Return(&model.PaymentSummaryResponse{Total: 1500, Balance: 1500, Payments: []*model.PaymentResponse{}}, nil).Once()
+				mockGateway.On("Capture", transactionID, 1221.0).Return(&model.GatewayTransaction{ID: transactionID, Amount: 1221, Status: constant.GatewayStatusCaptured}, nil).Once()
+				mockPaymentSvc.On("Record", mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+					return payment.Amount == 1221 && payment.PaymentMethod == constant.PaymentMethodCreditCard &&
+						*payment.GatewayReference == transactionID && *payment.ReceivedBy == 1
+				})).Return(nil).Once()
+				mockBooking.On("CheckOut", matchCheckOut).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusDirty}).Return(nil).Once()
+				mockHousekeeping.On("Create", mock.Anything).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given booking held on a card and paid at the desk when check out then release the hold",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(heldOnCard, nil).Once()
+				mockPaymentSvc.On("GetByRegisterNumber", "register_number").Return(&model.PaymentSummaryResponse{Total: 1500, Paid: 1500, Payments: []*model.PaymentResponse{{Amount: 1500}}}, nil).Once()
+				mockGateway.On("Void", transactionID).Return(&model.GatewayTransaction{ID: transactionID, Status: constant.GatewayStatusVoided}, nil).Once()
+				mockBooking.On("CheckOut", matchCheckOut).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusDirty}).Return(nil).Once()
+				mockHousekeeping.On("Create", mock.Anything).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given error charge the card when check out then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(heldOnCard, nil).Once()
+				mockPaymentSvc.On("GetByRegisterNumber", "register_number").Return(&model.PaymentSummaryResponse{Total: 1000, Balance: 1000, Payments: []*model.PaymentResponse{}}, nil).Once()
+				mockGateway.On("Capture", transactionID, 1000.0).Return(nil, model.NewConflictError("can not capture a Voided transaction")).Once()
+			},
+			err: model.NewConflictError("can not capture a Voided transaction"),
+		},
+		{
+			name: "Given booking not checked in when check out then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusConfirmed},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("booking can not move from Confirmed to Checked Out"),
+		},
+		{
+			name: "Given booking already checked out when check out then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedOut,
+						CheckedInAt:    &checkedInAt,
+						CheckedOutAt:   &checkedOutAt,
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("booking can not move from Checked Out to Checked Out"),
+		},
+		{
+			name: "Given error update room when check out then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedIn,
+						RoomName:       "room_name",
+						CheckedInAt:    &checkedInAt,
+					},
+				}, nil).Once()
+				mockBooking.On("CheckOut", matchCheckOut).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusDirty}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name: "Given error create housekeeping task when check out then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedIn,
+						RoomName:       "room_name",
+						CheckedInAt:    &checkedInAt,
+					},
+				}, nil).Once()
+				mockBooking.On("CheckOut", matchCheckOut).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusDirty}).Return(nil).Once()
+				mockHousekeeping.On("Create", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking, RoomRepo: mockRoom, HousekeepingRepo: mockHousekeeping}, &config.Config{}, nil, mockPaymentSvc, mockGateway)
+			err := service.CheckOut("register_number", 1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_NoShow(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	today := startOfDay(time.Now())
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given confirmed booking past check in date when record no show then return success",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						RoomName:       "room_name",
+						CheckIn:        today.AddDate(0, 0, -1),
+					},
+					RoomEntity: model.RoomEntity{Status: constant.RoomStatusBooked},
+				}, nil).Once()
+				mockBooking.On("UpdateStatus", &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusNoShow, FromStatus: constant.BookingStatusConfirmed}).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "room_name", Status: constant.RoomStatusAvailable}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given booking before check in date when record no show then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today.AddDate(0, 0, 1),
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("no show can only be recorded from the check in date"),
+		},
+		{
+			name: "Given checked in booking when record no show then return conflict error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusCheckedIn,
+					},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("booking can not move from Checked In to No Show"),
+		},
+		{
+			name: "Given error when record no show then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						Status:         constant.BookingStatusConfirmed,
+						CheckIn:        today,
+					},
+				}, nil).Once()
+				mockBooking.On("UpdateStatus", &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusNoShow, FromStatus: constant.BookingStatusConfirmed}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking, RoomRepo: mockRoom}, &config.Config{}, nil, nil, nil)
+			err := service.NoShow("register_number")
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetByGuestID(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockGuest := new(mocks.GuestRepository)
+	checkIn := time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+		response []*model.BookingResponse
+	}{
+		{
+			name: "Given guest with bookings when get bookings by guest then return the stay history",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1}, nil).Once()
+				mockBooking.On("GetByGuestID", 1).Return([]*model.BookingDetail{
+					{
+						BookingEntity: model.BookingEntity{
+							RegisterNumber: "register_number",
+							GuestID:        1,
+							CheckIn:        checkIn,
+							CheckOut:       checkIn.AddDate(0, 0, 2),
+							PaymentMethod:  constant.PaymentMethodCash,
+							PaymentStatus:  constant.PaymentStatusCompleted,
+							Status:         constant.BookingStatusCheckedOut,
+							CreatedAt:      &checkIn,
+						},
+						GuestEntity: model.GuestEntity{FirstName: "John", LastName: "Doe"},
+						RoomEntity:  model.RoomEntity{Status: constant.RoomStatusAvailable},
+					},
+				}, nil).Once()
+			},
+			err: nil,
+			response: []*model.BookingResponse{
+				{
+					RegisterNumber: "register_number",
+					GuestID:        1,
+					GuestName:      "John Doe",
+					RoomStatus:     constant.RoomStatusAvailable.Enum(),
+					CheckIn:        "01 April 2000",
+					CheckOut:       "03 April 2000",
+					PaymentMethod:  constant.PaymentMethodCash.Enum(),
+					PaymentStatus:  constant.PaymentStatusCompleted.Enum(),
+					Status:         constant.BookingStatusCheckedOut.Enum(),
+					CreatedAt:      "01-04-2000 00:00:00",
+				},
+			},
+		},
+		{
+			name: "Given unknown guest when get bookings by guest then return not found",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(nil, sql.ErrNoRows).Once()
+			},
+			err:      model.NewNotFoundError("guest not found"),
+			response: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewBookingServiceImpl(&repository.Repositories{BookingRepo: mockBooking, GuestRepo: mockGuest}, nil, nil, nil, nil)
+			resp, err := service.GetByGuestID(1)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+		})
+	}
+}
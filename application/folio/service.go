@@ -0,0 +1,9 @@
+package folio
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=FolioService --output=../mocks --outpkg=mocks
+type FolioService interface {
+	GetByRegisterNumber(registerNumber string) (*model.FolioResponse, error)
+	PostCharge(charge *model.FolioChargeEntity) error
+}
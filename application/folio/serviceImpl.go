@@ -0,0 +1,140 @@
+package folio
+
+import (
+	"math"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type folioSvcImpl struct {
+	repos *repository.Repositories
+	cfg   *config.Config
+}
+
+func NewFolioServiceImpl(repos *repository.Repositories, cfg *config.Config) FolioService {
+	return &folioSvcImpl{repos: repos, cfg: cfg}
+}
+
+// GetByRegisterNumber lists the charges of a booking with the service charge and tax of each one
+// and the balance running from the first charge to the last, less what has been paid so far.
+func (f *folioSvcImpl) GetByRegisterNumber(registerNumber string) (*model.FolioResponse, error) {
+	detail, err := f.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	charges, err := f.repos.FolioRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	folio := &model.FolioResponse{
+		RegisterNumber: detail.RegisterNumber,
+		GuestName:      detail.FirstName + " " + detail.LastName,
+		RoomName:       detail.RoomName,
+		CheckIn:        detail.CheckIn.Format("02 January 2006"),
+		CheckOut:       detail.CheckOut.Format("02 January 2006"),
+		Status:         detail.BookingEntity.Status.Enum(),
+		Charges:        make([]*model.FolioChargeResponse, len(charges)),
+	}
+
+	for i, charge := range charges {
+		serviceCharge, tax, total := ChargeTotal(f.cfg.Folio, charge.Amount)
+
+		folio.Subtotal = round(folio.Subtotal + charge.Amount)
+		folio.ServiceCharge = round(folio.ServiceCharge + serviceCharge)
+		folio.Tax = round(folio.Tax + tax)
+		folio.Total = round(folio.Total + total)
+
+		folio.Charges[i] = &model.FolioChargeResponse{
+			ID:            charge.ID,
+			ChargeType:    charge.ChargeType.Enum(),
+			Description:   charge.Description,
+			ChargeDate:    charge.ChargeDate.Format("02 January 2006"),
+			Quantity:      charge.Quantity,
+			UnitPrice:     charge.UnitPrice,
+			Amount:        charge.Amount,
+			ServiceCharge: serviceCharge,
+			Tax:           tax,
+			Total:         total,
+			Balance:       folio.Total,
+			PostedBy:      charge.PostedBy,
+		}
+	}
+
+	payments, err := f.repos.PaymentRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// refunds are negative so they give back what the guest had paid
+	for _, payment := range payments {
+		folio.Paid = round(folio.Paid + payment.Amount)
+	}
+
+	folio.Balance = round(folio.Total - folio.Paid)
+	return folio, nil
+}
+
+// PostCharge adds an extra to the folio of a booking that is still open, i.e. confirmed or in house.
+// The folio total grows with it, so the payment status is worked out again.
+func (f *folioSvcImpl) PostCharge(charge *model.FolioChargeEntity) error {
+	detail, err := f.repos.BookingRepo.GetByRegisterNumber(charge.RegisterNumber)
+	if err != nil {
+		return err
+	}
+
+	if detail.BookingEntity.Status != constant.BookingStatusConfirmed && detail.BookingEntity.Status != constant.BookingStatusCheckedIn {
+		return model.NewConflictError("can not post charges to a %s booking", detail.BookingEntity.Status.Enum())
+	}
+
+	charge.Amount = round(float64(charge.Quantity) * charge.UnitPrice)
+	if err := f.repos.FolioRepo.CreateCharge(charge); err != nil {
+		return err
+	}
+
+	bookingFolio, err := f.GetByRegisterNumber(charge.RegisterNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := f.repos.BookingRepo.UpdatePayment(&model.BookingEntity{
+		RegisterNumber: charge.RegisterNumber,
+		PaymentStatus:  PaymentStatus(detail.BookingEntity.Status, bookingFolio.Paid, bookingFolio.Total),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PaymentStatus compares what has been paid with the folio total. A cancelled booking
+// only takes refunds, so whatever reaches it leaves the booking refunded.
+func PaymentStatus(status constant.BookingStatus, paid, total float64) constant.PaymentStatus {
+	switch {
+	case status == constant.BookingStatusCancelled:
+		return constant.PaymentStatusRefunded
+	case total > 0 && paid >= total:
+		return constant.PaymentStatusCompleted
+	case paid > 0:
+		return constant.PaymentStatusPartiallyPaid
+	default:
+		return constant.PaymentStatusPending
+	}
+}
+
+// ChargeTotal adds the service charge and the tax to the amount of a charge, each rounded
+// the way the folio prints it, so amounts worked out elsewhere match the folio to the cent.
+func ChargeTotal(cfg config.FolioConfig, amount float64) (serviceCharge, tax, total float64) {
+	serviceCharge = round(amount * cfg.ServiceChargePercentage / 100)
+	tax = round((amount + serviceCharge) * cfg.TaxPercentage / 100)
+	return serviceCharge, tax, round(amount + serviceCharge + tax)
+}
+
+// round keeps money at two decimals so totals add up the way they are printed.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
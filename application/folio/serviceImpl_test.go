@@ -0,0 +1,205 @@
+package folio
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockBooking *mocks.BookingRepository
+	mockFolio   *mocks.FolioRepository
+	mockPayment *mocks.PaymentRepository
+)
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockFolio = new(mocks.FolioRepository)
+	mockPayment = new(mocks.PaymentRepository)
+
+	cfg := &config.Config{Folio: config.FolioConfig{ServiceChargePercentage: 10, TaxPercentage: 11}}
+	checkIn := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	postedBy := 2
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		response *model.FolioResponse
+		err      error
+	}{
+		{
+			name: "Given room night, extra and payments when get folio then return charges with running balance",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{
+						RegisterNumber: "register_number",
+						RoomName:       "101",
+						CheckIn:        checkIn,
+						CheckOut:       checkIn.AddDate(0, 0, 1),
+						Status:         constant.BookingStatusCheckedIn,
+					},
+					GuestEntity: model.GuestEntity{FirstName: "John", LastName: "Doe"},
+				}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return([]*model.FolioChargeEntity{
+					{ID: 1, ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: checkIn, Quantity: 1, UnitPrice: 500000, Amount: 500000},
+					{ID: 2, ChargeType: constant.FolioChargeTypeLaundry, Description: "Shirt", ChargeDate: checkIn, Quantity: 1, UnitPrice: 30000, Amount: 30000, PostedBy: &postedBy},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{
+					{ID: 1, Amount: 300000},
+					{ID: 2, Amount: -100000},
+				}, nil).Once()
+			},
+			response: &model.FolioResponse{
+				RegisterNumber: "register_number",
+				GuestName:      "John Doe",
+				RoomName:       "101",
+				CheckIn:        "01 March 2000",
+				CheckOut:       "02 March 2000",
+				Status:         constant.BookingStatusCheckedIn.Enum(),
+				Charges: []*model.FolioChargeResponse{
+					{ID: 1, ChargeType: "Room", Description: "Room 101", ChargeDate: "01 March 2000", Quantity: 1, UnitPrice: 500000, Amount: 500000, ServiceCharge: 50000, Tax: 60500, Total: 610500, Balance: 610500},
+					{ID: 2, ChargeType: "Laundry", Description: "Shirt", ChargeDate: "01 March 2000", Quantity: 1, UnitPrice: 30000, Amount: 30000, ServiceCharge: 3000, Tax: 3630, Total: 36630, Balance: 647130, PostedBy: &postedBy},
+				},
+				Subtotal:      530000,
+				ServiceCharge: 53000,
+				Tax:           64130,
+				Total:         647130,
+				Paid:          200000,
+				Balance:       447130,
+			},
+			err: nil,
+		},
+		{
+			name: "Given error get payments when get folio then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return([]*model.FolioChargeEntity{}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name: "Given error get folio charges when get folio then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name: "Given error get reservation data when get folio then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewFolioServiceImpl(&repository.Repositories{BookingRepo: mockBooking, FolioRepo: mockFolio, PaymentRepo: mockPayment}, cfg)
+			response, err := service.GetByRegisterNumber("register_number")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
+
+func Test_PostCharge(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockFolio = new(mocks.FolioRepository)
+	mockPayment = new(mocks.PaymentRepository)
+
+	chargeDate := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	inHouse := &model.BookingDetail{
+		BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedIn, PaymentStatus: constant.PaymentStatusCompleted},
+	}
+
+	testCases := []struct {
+		name     string
+		charge   *model.FolioChargeEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given in house booking when post charge then compute the amount and save it",
+			charge: &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeMinibar, Description: "Soft drink", ChargeDate: chargeDate, Quantity: 3, UnitPrice: 12500.5},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(inHouse, nil).Twice()
+				mockFolio.On("CreateCharge", &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeMinibar, Description: "Soft drink", ChargeDate: chargeDate, Quantity: 3, UnitPrice: 12500.5, Amount: 37501.5}).Return(nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return([]*model.FolioChargeEntity{
+					{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, ChargeDate: chargeDate, Quantity: 1, UnitPrice: 500000, Amount: 500000},
+					{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeMinibar, ChargeDate: chargeDate, Quantity: 3, UnitPrice: 12500.5, Amount: 37501.5},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{RegisterNumber: "register_number", Amount: 500000}}, nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPartiallyPaid}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error update payment status when post charge then return error",
+			charge: &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, ChargeDate: chargeDate, Quantity: 1, UnitPrice: 10000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(inHouse, nil).Twice()
+				mockFolio.On("CreateCharge", &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, ChargeDate: chargeDate, Quantity: 1, UnitPrice: 10000, Amount: 10000}).Return(nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return([]*model.FolioChargeEntity{
+					{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, ChargeDate: chargeDate, Quantity: 1, UnitPrice: 10000, Amount: 10000},
+				}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{}, nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPending}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:   "Given checked out booking when post charge then return conflict error",
+			charge: &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeMinibar, Quantity: 1, UnitPrice: 10000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedOut},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("can not post charges to a Checked Out booking"),
+		},
+		{
+			name:   "Given error create charge when post charge then return error",
+			charge: &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, Quantity: 1, UnitPrice: 10000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusConfirmed},
+				}, nil).Once()
+				mockFolio.On("CreateCharge", &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, Quantity: 1, UnitPrice: 10000, Amount: 10000}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:   "Given error get reservation data when post charge then return error",
+			charge: &model.FolioChargeEntity{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, Quantity: 1, UnitPrice: 10000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewFolioServiceImpl(&repository.Repositories{BookingRepo: mockBooking, FolioRepo: mockFolio, PaymentRepo: mockPayment}, &config.Config{})
+			err := service.PostCharge(tc.charge)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package guest
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// weights of the details two guests share, they add up to 1
+const (
+	nameWeight        = 0.4
+	dateOfBirthWeight = 0.3
+	emailWeight       = 0.15
+	phoneNumberWeight = 0.15
+
+	// minNameSimilarity is how close two names have to be to count as the same name,
+	// it lets a typo or a missing middle name through
+	minNameSimilarity = 0.8
+)
+
+// findDuplicates scores the pairs of guests sharing a date of birth, an email or a phone
+// number and returns those reaching minScore. Guests sharing only a name are not compared,
+// a name alone never reaches the score of a duplicate.
+func findDuplicates(guests []*model.GuestEntity, minScore float64) []*model.GuestDuplicateEntity {
+	buckets := map[string][]*model.GuestEntity{}
+	for _, guest := range guests {
+		dateOfBirth := "date_of_birth:" + guest.DateOfBirth.Format("2006-01-02")
+		buckets[dateOfBirth] = append(buckets[dateOfBirth], guest)
+		if email := normalizeEmail(guest.Email); email != "" {
+			buckets["email:"+email] = append(buckets["email:"+email], guest)
+		}
+		if phoneNumber := normalizePhoneNumber(guest.PhoneNumber); phoneNumber != "" {
+			buckets["phone_number:"+phoneNumber] = append(buckets["phone_number:"+phoneNumber], guest)
+		}
+	}
+
+	scored := map[[2]int]bool{}
+	duplicates := make([]*model.GuestDuplicateEntity, 0)
+	for _, bucket := range buckets {
+		for i := 0; i < len(bucket); i++ {
+			for j := i + 1; j < len(bucket); j++ {
+				guest, other := bucket[i], bucket[j]
+				if other.ID < guest.ID {
+					guest, other = other, guest
+				}
+
+				pair := [2]int{guest.ID, other.ID}
+				if scored[pair] {
+					continue
+				}
+				scored[pair] = true
+
+				score, reasons := scoreDuplicate(guest, other)
+				if score < minScore {
+					continue
+				}
+
+				duplicates = append(duplicates, &model.GuestDuplicateEntity{
+					GuestID:          guest.ID,
+					DuplicateGuestID: other.ID,
+					Score:            score,
+					Reasons:          strings.Join(reasons, ","),
+					Status:           constant.GuestDuplicateStatusPending,
+				})
+			}
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].GuestID != duplicates[j].GuestID {
+			return duplicates[i].GuestID < duplicates[j].GuestID
+		}
+		return duplicates[i].DuplicateGuestID < duplicates[j].DuplicateGuestID
+	})
+
+	return duplicates
+}
+
+func scoreDuplicate(guest, other *model.GuestEntity) (float64, []string) {
+	var score float64
+	reasons := make([]string, 0)
+
+	if similarity := nameSimilarity(guest, other); similarity >= minNameSimilarity {
+		score += nameWeight * similarity
+		reasons = append(reasons, "name")
+	}
+
+	if guest.DateOfBirth.Format("2006-01-02") == other.DateOfBirth.Format("2006-01-02") {
+		score += dateOfBirthWeight
+		reasons = append(reasons, "date_of_birth")
+	}
+
+	if email := normalizeEmail(guest.Email); email != "" && email == normalizeEmail(other.Email) {
+		score += emailWeight
+		reasons = append(reasons, "email")
+	}
+
+	if phoneNumber := normalizePhoneNumber(guest.PhoneNumber); phoneNumber != "" && phoneNumber == normalizePhoneNumber(other.PhoneNumber) {
+		score += phoneNumberWeight
+		reasons = append(reasons, "phone_number")
+	}
+
+	return math.Round(score*100) / 100, reasons
+}
+
+// nameSimilarity compares the full names word by word in alphabetical order, so a first
+// and last name swapped between two registrations still match. 1 is the same name.
+func nameSimilarity(guest, other *model.GuestEntity) float64 {
+	name, otherName := normalizeName(guest), normalizeName(other)
+	longest := len([]rune(name))
+	if otherLength := len([]rune(otherName)); otherLength > longest {
+		longest = otherLength
+	}
+
+	if longest == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(name, otherName))/float64(longest)
+}
+
+func normalizeName(guest *model.GuestEntity) string {
+	words := strings.Fields(strings.ToLower(guest.FirstName + " " + guest.LastName))
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizePhoneNumber keeps the last 9 digits, enough to tell numbers apart while
+// ignoring whether the country code or the leading zero was written.
+func normalizePhoneNumber(phoneNumber string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+
+	if len(digits) > 9 {
+		return digits[len(digits)-9:]
+	}
+
+	return digits
+}
+
+// levenshtein counts the single character edits turning a into b.
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current := make([]int, len(target)+1)
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(target)]
+}
@@ -0,0 +1,115 @@
+package guest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// encodeExport writes the export as a single json file, or as a zip archive holding
+// the guest profile and the bookings in a file each.
+func encodeExport(export *model.GuestExportResponse, format constant.GuestExportFormat, exportedAt time.Time) ([]byte, error) {
+	if format == constant.GuestExportFormatJSON {
+		return json.MarshalIndent(export, "", "  ")
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{name: "guest.json", data: export.Guest},
+		{name: "bookings.json", data: export.Bookings},
+	}
+
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: exportedAt})
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := writer.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func toGuestExportProfile(guest *model.GuestEntity) *model.GuestExportProfile {
+	profile := &model.GuestExportProfile{
+		ID:          guest.ID,
+		FirstName:   guest.FirstName,
+		LastName:    guest.LastName,
+		Identity:    guest.Identity,
+		DateOfBirth: guest.DateOfBirth.Format("02 January 2006"),
+		PhoneNumber: guest.PhoneNumber,
+		Email:       guest.Email,
+		CreatedAt:   guest.CreatedAt.Format("02-01-2006 15:04:05"),
+		UpdatedAt:   guest.UpdatedAt.Format("02-01-2006 15:04:05"),
+	}
+
+	if guest.AnonymizedAt != nil {
+		anonymizedAt := guest.AnonymizedAt.Format("02-01-2006 15:04:05")
+		profile.AnonymizedAt = &anonymizedAt
+	}
+
+	return profile
+}
+
+func toGuestExportBooking(booking *model.BookingDetail, payments []*model.PaymentEntity, charges []*model.FolioChargeEntity) *model.GuestExportBooking {
+	export := &model.GuestExportBooking{
+		RegisterNumber:    booking.RegisterNumber,
+		RoomName:          booking.RoomName,
+		CheckIn:           booking.CheckIn.Format("02 January 2006"),
+		CheckOut:          booking.CheckOut.Format("02 January 2006"),
+		PaidAmount:        booking.PaidAmount,
+		PaymentMethod:     booking.PaymentMethod.Enum(),
+		PaymentStatus:     booking.PaymentStatus.Enum(),
+		Status:            booking.BookingEntity.Status.Enum(),
+		AdditionalRequest: booking.AdditionalRequest,
+		RefundAmount:      booking.RefundAmount,
+		CreatedAt:         booking.BookingEntity.CreatedAt.Format("02-01-2006 15:04:05"),
+		Payments:          make([]*model.PaymentResponse, len(payments)),
+		Charges:           make([]*model.GuestExportCharge, len(charges)),
+	}
+
+	for i, payment := range payments {
+		export.Payments[i] = &model.PaymentResponse{
+			ID:               payment.ID,
+			Amount:           payment.Amount,
+			PaymentMethod:    payment.PaymentMethod.Enum(),
+			Reference:        payment.Reference,
+			PaidAt:           payment.PaidAt.Format("02-01-2006 15:04:05"),
+			ReceivedBy:       payment.ReceivedBy,
+			GatewayReference: payment.GatewayReference,
+		}
+	}
+
+	for i, charge := range charges {
+		export.Charges[i] = &model.GuestExportCharge{
+			ChargeType:  charge.ChargeType.Enum(),
+			Description: charge.Description,
+			ChargeDate:  charge.ChargeDate.Format("02 January 2006"),
+			Quantity:    charge.Quantity,
+			UnitPrice:   charge.UnitPrice,
+			Amount:      charge.Amount,
+		}
+	}
+
+	return export
+}
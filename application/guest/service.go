@@ -1,9 +1,23 @@
 package guest
 
-import "github.com/zakiyalmaya/hotel-management/model"
+import (
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
 
 //go:generate mockery --name=GuestService --output=../mocks --outpkg=mocks
 type GuestService interface {
 	Create(guest *model.GuestEntity) error
 	GetByID(id int) (*model.GuestResponse, error)
-}
\ No newline at end of file
+	Search(request *model.SearchGuestRequest, pagination *model.PaginationRequest) (*model.PaginatedResponse, error)
+	Update(id int, request *model.UpdateGuestRequest) error
+	GetSummary(id int) (*model.GuestSummaryResponse, error)
+	DetectDuplicates() (int, error)
+	GetDuplicates(pagination *model.PaginationRequest) (*model.PaginatedResponse, error)
+	DismissDuplicate(id int, userID int) error
+	MergeDuplicate(id int, request *model.MergeGuestRequest, userID int) (*model.GuestMergeResponse, error)
+	Export(id int, format constant.GuestExportFormat) (*model.GuestExportDocument, error)
+	Anonymize(id int, userID int) error
+	AnonymizeInactive() (*model.AnonymizeInactiveResponse, error)
+	ReEncrypt() (int, error)
+}
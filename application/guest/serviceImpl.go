@@ -1,16 +1,27 @@
 package guest
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
 type guestSvcImpl struct {
 	repos *repository.Repositories
+	cfg   *config.Config
 }
 
-func NewGuestServiceImpl(repos *repository.Repositories) GuestService {
-	return &guestSvcImpl{repos: repos}
+func NewGuestServiceImpl(repos *repository.Repositories, cfg *config.Config) GuestService {
+	return &guestSvcImpl{repos: repos, cfg: cfg}
 }
 
 func (g *guestSvcImpl) Create(guest *model.GuestEntity) error {
@@ -28,6 +39,372 @@ func (g *guestSvcImpl) GetByID(id int) (*model.GuestResponse, error) {
 		return nil, err
 	}
 
+	return toGuestResponse(guest), nil
+}
+
+func (g *guestSvcImpl) Search(request *model.SearchGuestRequest, pagination *model.PaginationRequest) (*model.PaginatedResponse, error) {
+	guests, total, err := g.repos.GuestRepo.Search(request, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*model.GuestResponse, 0, len(guests))
+	for _, guest := range guests {
+		responses = append(responses, toGuestResponse(guest))
+	}
+
+	return model.NewPaginatedResponse(responses, pagination, total), nil
+}
+
+// Update changes the name and contact details of a guest, fields left out are kept.
+func (g *guestSvcImpl) Update(id int, request *model.UpdateGuestRequest) error {
+	guest, err := g.repos.GuestRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("guest not found")
+		}
+		return err
+	}
+
+	if guest.AnonymizedAt != nil {
+		return model.NewConflictError("guest has been anonymized")
+	}
+
+	if request.FirstName != nil {
+		guest.FirstName = *request.FirstName
+	}
+
+	if request.LastName != nil {
+		guest.LastName = *request.LastName
+	}
+
+	if request.PhoneNumber != nil {
+		guest.PhoneNumber = *request.PhoneNumber
+	}
+
+	if request.Email != nil {
+		guest.Email = *request.Email
+	}
+
+	return g.repos.GuestRepo.Update(guest)
+}
+
+func (g *guestSvcImpl) GetSummary(id int) (*model.GuestSummaryResponse, error) {
+	guest, err := g.repos.GuestRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewNotFoundError("guest not found")
+		}
+		return nil, err
+	}
+
+	bookings, err := g.repos.BookingRepo.GetByGuestID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	totalSpent, err := g.repos.PaymentRepo.SumByGuestID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.GuestSummaryResponse{
+		GuestID:    guest.ID,
+		Name:       guest.FirstName + " " + guest.LastName,
+		TotalSpent: math.Round(totalSpent*100) / 100,
+	}
+
+	// bookings come latest first, so the first stay found is the last one
+	for _, booking := range bookings {
+		switch booking.BookingEntity.Status {
+		case constant.BookingStatusCheckedIn, constant.BookingStatusCheckedOut:
+			summary.TotalStays++
+			summary.TotalNights += int(booking.CheckOut.Sub(booking.CheckIn).Hours() / 24)
+			if summary.LastStay == nil {
+				lastStay := booking.CheckIn.Format("02 January 2006")
+				summary.LastStay = &lastStay
+			}
+		case constant.BookingStatusCancelled:
+			summary.Cancellations++
+		case constant.BookingStatusNoShow:
+			summary.NoShows++
+		}
+	}
+
+	return summary, nil
+}
+
+// DetectDuplicates scores every guest against the others and saves the likely duplicates
+// for review. It returns how many pairs were found.
+func (g *guestSvcImpl) DetectDuplicates() (int, error) {
+	guests, err := g.repos.GuestRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	duplicates := findDuplicates(guests, g.cfg.GuestDuplicate.MinScore)
+	for _, duplicate := range duplicates {
+		if err := g.repos.GuestDuplicateRepo.Upsert(duplicate); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(duplicates), nil
+}
+
+func (g *guestSvcImpl) GetDuplicates(pagination *model.PaginationRequest) (*model.PaginatedResponse, error) {
+	duplicates, total, err := g.repos.GuestDuplicateRepo.GetPending(pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*model.GuestDuplicateResponse, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		guest, err := g.repos.GuestRepo.GetByID(duplicate.GuestID)
+		if err != nil {
+			return nil, err
+		}
+
+		other, err := g.repos.GuestRepo.GetByID(duplicate.DuplicateGuestID)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, &model.GuestDuplicateResponse{
+			ID:             duplicate.ID,
+			Score:          duplicate.Score,
+			Reasons:        strings.Split(duplicate.Reasons, ","),
+			Guest:          toGuestResponse(guest),
+			DuplicateGuest: toGuestResponse(other),
+			CreatedAt:      duplicate.CreatedAt.Format("02-01-2006 15:04:05"),
+		})
+	}
+
+	return model.NewPaginatedResponse(responses, pagination, total), nil
+}
+
+func (g *guestSvcImpl) DismissDuplicate(id int, userID int) error {
+	duplicate, err := g.getPendingDuplicate(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	duplicate.ReviewedBy = &userID
+	duplicate.ReviewedAt = &now
+	return g.repos.GuestDuplicateRepo.Dismiss(duplicate)
+}
+
+// MergeDuplicate keeps the surviving guest of the pair and moves the bookings of the other
+// one to it. The other guest is removed, a copy of it stays in the audit record of the merge.
+func (g *guestSvcImpl) MergeDuplicate(id int, request *model.MergeGuestRequest, userID int) (*model.GuestMergeResponse, error) {
+	duplicate, err := g.getPendingDuplicate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedGuestID := duplicate.DuplicateGuestID
+	switch request.SurvivingGuestID {
+	case duplicate.GuestID:
+	case duplicate.DuplicateGuestID:
+		mergedGuestID = duplicate.GuestID
+	default:
+		return nil, model.NewValidationError("surviving guest must be one of the suggested pair")
+	}
+
+	mergedGuest, err := g.repos.GuestRepo.GetByID(mergedGuestID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := json.Marshal(mergedGuest)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	duplicate.ReviewedBy = &userID
+	duplicate.ReviewedAt = &now
+	merge := &model.GuestMergeEntity{
+		DuplicateID:      duplicate.ID,
+		SurvivingGuestID: request.SurvivingGuestID,
+		MergedGuestID:    mergedGuestID,
+		MergedGuest:      string(snapshot),
+		MergedBy:         userID,
+	}
+
+	if err := g.repos.GuestDuplicateRepo.Merge(duplicate, merge); err != nil {
+		return nil, err
+	}
+
+	return &model.GuestMergeResponse{
+		SurvivingGuestID: merge.SurvivingGuestID,
+		MergedGuestID:    merge.MergedGuestID,
+		BookingsMoved:    merge.BookingsMoved,
+	}, nil
+}
+
+// Export gathers the profile of a guest and their bookings with the payments and charges
+// of each, for the guest asking what the hotel keeps about them.
+func (g *guestSvcImpl) Export(id int, format constant.GuestExportFormat) (*model.GuestExportDocument, error) {
+	if !format.Validation() {
+		return nil, model.NewValidationError("invalid export format")
+	}
+
+	guest, err := g.repos.GuestRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewNotFoundError("guest not found")
+		}
+		return nil, err
+	}
+
+	bookings, err := g.repos.BookingRepo.GetByGuestID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	export := &model.GuestExportResponse{
+		ExportedAt: now.Format("02-01-2006 15:04:05"),
+		Guest:      toGuestExportProfile(guest),
+		Bookings:   make([]*model.GuestExportBooking, len(bookings)),
+	}
+
+	for i, booking := range bookings {
+		payments, err := g.repos.PaymentRepo.GetByRegisterNumber(booking.RegisterNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		charges, err := g.repos.FolioRepo.GetByRegisterNumber(booking.RegisterNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		export.Bookings[i] = toGuestExportBooking(booking, payments, charges)
+	}
+
+	content, err := encodeExport(export, format, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GuestExportDocument{GuestID: guest.ID, Format: format, Content: content}, nil
+}
+
+// Anonymize erases the personal data of a guest who asks for it. Their bookings, payments
+// and invoices stay for the books, so a guest still expected or staying cannot be erased.
+func (g *guestSvcImpl) Anonymize(id int, userID int) error {
+	guest, err := g.repos.GuestRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("guest not found")
+		}
+		return err
+	}
+
+	if guest.AnonymizedAt != nil {
+		return model.NewConflictError("guest has already been anonymized")
+	}
+
+	bookings, err := g.repos.BookingRepo.GetByGuestID(id)
+	if err != nil {
+		return err
+	}
+
+	for _, booking := range bookings {
+		switch booking.BookingEntity.Status {
+		case constant.BookingStatusConfirmed, constant.BookingStatusCheckedIn:
+			return model.NewConflictError("guest has a booking that is not finished yet")
+		}
+	}
+
+	return g.anonymize(guest, &userID)
+}
+
+// AnonymizeInactive anonymizes the guests without a stay in the retention period of
+// the configuration. A guest that fails is logged and skipped so the run goes on with
+// the others, the response reports how many were anonymized and which failed.
+func (g *guestSvcImpl) AnonymizeInactive() (*model.AnonymizeInactiveResponse, error) {
+	response := &model.AnonymizeInactiveResponse{Failed: make([]int, 0)}
+	years := g.cfg.GuestRetention.AnonymizeAfterYears
+	if years <= 0 {
+		return response, nil
+	}
+
+	ids, err := g.repos.GuestRepo.GetIDsWithoutStaySince(time.Now().AddDate(-years, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	response.Processed = len(ids)
+	for _, id := range ids {
+		if err := g.anonymizeInactive(id); err != nil {
+			log.Printf("failed to anonymize guest %d: %s", id, err.Error())
+			response.Failed = append(response.Failed, id)
+			continue
+		}
+
+		response.Anonymized++
+	}
+
+	return response, nil
+}
+
+func (g *guestSvcImpl) anonymizeInactive(id int) error {
+	guest, err := g.repos.GuestRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return g.anonymize(guest, nil)
+}
+
+// ReEncrypt encrypts the guests and merged guests stored in plaintext or with an older key
+// with the active key, so the older key can be removed. It returns how many were rewritten.
+func (g *guestSvcImpl) ReEncrypt() (int, error) {
+	guests, err := g.repos.GuestRepo.ReEncrypt()
+	if err != nil {
+		return guests, err
+	}
+
+	merges, err := g.repos.GuestDuplicateRepo.ReEncrypt()
+	return guests + merges, err
+}
+
+// anonymize replaces the personal data of the guest, the identity number stays unique.
+// anonymizedBy is nil when the retention job does it.
+func (g *guestSvcImpl) anonymize(guest *model.GuestEntity, anonymizedBy *int) error {
+	now := time.Now()
+	guest.FirstName = "Anonymized"
+	guest.LastName = "Guest"
+	guest.Identity = fmt.Sprintf("anonymized-%d", guest.ID)
+	guest.DateOfBirth = time.Time{}
+	guest.PhoneNumber = ""
+	guest.Email = ""
+	guest.AnonymizedAt = &now
+	guest.AnonymizedBy = anonymizedBy
+	return g.repos.GuestRepo.Anonymize(guest)
+}
+
+func (g *guestSvcImpl) getPendingDuplicate(id int) (*model.GuestDuplicateEntity, error) {
+	duplicate, err := g.repos.GuestDuplicateRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewNotFoundError("duplicate suggestion not found")
+		}
+		return nil, err
+	}
+
+	if duplicate.Status != constant.GuestDuplicateStatusPending {
+		return nil, model.NewConflictError("duplicate suggestion has already been reviewed")
+	}
+
+	return duplicate, nil
+}
+
+func toGuestResponse(guest *model.GuestEntity) *model.GuestResponse {
 	return &model.GuestResponse{
 		ID:          guest.ID,
 		Name:        guest.FirstName + " " + guest.LastName,
@@ -35,5 +412,5 @@ func (g *guestSvcImpl) GetByID(id int) (*model.GuestResponse, error) {
 		DateOfBirth: guest.DateOfBirth.Format("02 January 2006"),
 		PhoneNumber: guest.PhoneNumber,
 		Email:       guest.Email,
-	}, nil
+	}
 }
@@ -1,18 +1,27 @@
 package guest
 
 import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
 var (
-	mockGuest *mocks.GuestRepository
+	mockGuest  *mocks.GuestRepository
+	mockConfig = &config.Config{GuestDuplicate: config.GuestDuplicateConfig{MinScore: 0.6}}
 )
 
 func Test_Create(t *testing.T) {
@@ -74,7 +83,7 @@ func Test_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest})
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest}, mockConfig)
 			err := service.Create(tc.guest)
 			assert.Equal(t, tc.err, err)
 		})
@@ -130,10 +139,631 @@ func Test_GetByID(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest})
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest}, mockConfig)
 			resp, err := service.GetByID(tc.id)
 			assert.Equal(t, tc.err, err)
 			assert.Equal(t, tc.response, resp)
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+
+	name := "jo"
+	pagination := &model.PaginationRequest{Page: 1, Limit: 1}
+	mockGuest.On("Search", &model.SearchGuestRequest{Name: &name}, pagination).Return([]*model.GuestEntity{
+		{
+			ID:          1,
+			FirstName:   "John",
+			LastName:    "Doe",
+			Identity:    "123",
+			DateOfBirth: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			PhoneNumber: "123",
+			Email:       "example@mail.com",
+		},
+	}, 2, nil).Once()
+
+	service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest}, mockConfig)
+	resp, err := service.Search(&model.SearchGuestRequest{Name: &name}, pagination)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &model.PaginatedResponse{
+		Items: []*model.GuestResponse{
+			{
+				ID:          1,
+				Name:        "John Doe",
+				Identity:    "123",
+				DateOfBirth: "01 January 2000",
+				PhoneNumber: "123",
+				Email:       "example@mail.com",
+			},
+		},
+		Page:       1,
+		Limit:      1,
+		Total:      2,
+		TotalPages: 2,
+	}, resp)
+}
+
+func Test_Update(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+
+	phoneNumber, email := "08123456789", "john.doe@mail.com"
+
+	testCases := []struct {
+		name     string
+		id       int
+		request  *model.UpdateGuestRequest
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given new contact details when update guest then keep the other fields",
+			id:      1,
+			request: &model.UpdateGuestRequest{PhoneNumber: &phoneNumber, Email: &email},
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe", Identity: "123", PhoneNumber: "123", Email: "example@mail.com"}, nil).Once()
+				mockGuest.On("Update", &model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe", Identity: "123", PhoneNumber: "08123456789", Email: "john.doe@mail.com"}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given unknown guest when update guest then return not found",
+			id:      2,
+			request: &model.UpdateGuestRequest{Email: &email},
+			mockCall: func() {
+				mockGuest.On("GetByID", 2).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("guest not found"),
+		},
+		{
+			name:    "Given anonymized guest when update guest then return conflict",
+			id:      3,
+			request: &model.UpdateGuestRequest{Email: &email},
+			mockCall: func() {
+				anonymizedAt := time.Now()
+				mockGuest.On("GetByID", 3).Return(&model.GuestEntity{ID: 3, AnonymizedAt: &anonymizedAt}, nil).Once()
+			},
+			err: model.NewConflictError("guest has been anonymized"),
+		},
+		{
+			name:    "Given error from db when update guest then return error",
+			id:      1,
+			request: &model.UpdateGuestRequest{Email: &email},
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1}, nil).Once()
+				mockGuest.On("Update", &model.GuestEntity{ID: 1, Email: "john.doe@mail.com"}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest}, mockConfig)
+			err := service.Update(tc.id, tc.request)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetSummary(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockBooking := new(mocks.BookingRepository)
+	mockPayment := new(mocks.PaymentRepository)
+
+	checkIn := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	lastStay := "10 May 2024"
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		response *model.GuestSummaryResponse
+		err      error
+	}{
+		{
+			name: "Given guest with bookings when get summary then count stays, nights, cancellations and no shows",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe"}, nil).Once()
+				mockBooking.On("GetByGuestID", 1).Return([]*model.BookingDetail{
+					{BookingEntity: model.BookingEntity{CheckIn: checkIn.AddDate(0, 1, 0), CheckOut: checkIn.AddDate(0, 1, 1), Status: constant.BookingStatusConfirmed}},
+					{BookingEntity: model.BookingEntity{CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 3), Status: constant.BookingStatusCheckedOut}},
+					{BookingEntity: model.BookingEntity{CheckIn: checkIn.AddDate(0, -1, 0), CheckOut: checkIn.AddDate(0, -1, 2), Status: constant.BookingStatusNoShow}},
+					{BookingEntity: model.BookingEntity{CheckIn: checkIn.AddDate(0, -2, 0), CheckOut: checkIn.AddDate(0, -2, 2), Status: constant.BookingStatusCheckedOut}},
+					{BookingEntity: model.BookingEntity{CheckIn: checkIn.AddDate(0, -3, 0), CheckOut: checkIn.AddDate(0, -3, 1), Status: constant.BookingStatusCancelled}},
+				}, nil).Once()
+				mockPayment.On("SumByGuestID", 1).Return(2500000.004, nil).Once()
+			},
+			response: &model.GuestSummaryResponse{
+				GuestID:       1,
+				Name:          "John Doe",
+				TotalStays:    2,
+				TotalNights:   5,
+				TotalSpent:    2500000,
+				LastStay:      &lastStay,
+				Cancellations: 1,
+				NoShows:       1,
+			},
+			err: nil,
+		},
+		{
+			name: "Given guest without stays when get summary then return no last stay",
+			mockCall: func() {
+				mockGuest.On("GetByID", 2).Return(&model.GuestEntity{ID: 2, FirstName: "Jane", LastName: "Doe"}, nil).Once()
+				mockBooking.On("GetByGuestID", 2).Return([]*model.BookingDetail{}, nil).Once()
+				mockPayment.On("SumByGuestID", 2).Return(float64(0), nil).Once()
+			},
+			response: &model.GuestSummaryResponse{GuestID: 2, Name: "Jane Doe"},
+			err:      nil,
+		},
+		{
+			name: "Given unknown guest when get summary then return not found",
+			mockCall: func() {
+				mockGuest.On("GetByID", 3).Return(nil, sql.ErrNoRows).Once()
+			},
+			response: nil,
+			err:      model.NewNotFoundError("guest not found"),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, BookingRepo: mockBooking, PaymentRepo: mockPayment}, mockConfig)
+			resp, err := service.GetSummary(i + 1)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+		})
+	}
+}
+
+func Test_DetectDuplicates(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockDuplicate := new(mocks.GuestDuplicateRepository)
+
+	dateOfBirth := time.Date(1990, time.March, 1, 0, 0, 0, 0, time.UTC)
+	mockGuest.On("GetAll").Return([]*model.GuestEntity{
+		{ID: 1, FirstName: "John", LastName: "Doe", Identity: "P123", DateOfBirth: dateOfBirth, PhoneNumber: "+62 812-3456-7890", Email: "john@mail.com"},
+		// the same person registered with the national id, first and last name swapped
+		{ID: 2, FirstName: "Doe", LastName: "John", Identity: "3171", DateOfBirth: dateOfBirth, PhoneNumber: "0812 3456 7890", Email: "John@Mail.com "},
+		// a typo in the name and another phone number
+		{ID: 3, FirstName: "Jon", LastName: "Doe", Identity: "P999", DateOfBirth: dateOfBirth, PhoneNumber: "0899", Email: "jon@mail.com"},
+		// family sharing an email and a phone number
+		{ID: 4, FirstName: "Jane", LastName: "Doe", Identity: "P456", DateOfBirth: dateOfBirth.AddDate(2, 0, 0), PhoneNumber: "081234567890", Email: "john@mail.com"},
+		// same birthday, nothing else in common
+		{ID: 5, FirstName: "Mary", LastName: "Major", Identity: "P789", DateOfBirth: dateOfBirth, PhoneNumber: "0877", Email: "mary@mail.com"},
+	}, nil).Once()
+	mockDuplicate.On("Upsert", &model.GuestDuplicateEntity{GuestID: 1, DuplicateGuestID: 2, Score: 1, Reasons: "name,date_of_birth,email,phone_number", Status: constant.GuestDuplicateStatusPending}).Return(nil).Once()
+	mockDuplicate.On("Upsert", &model.GuestDuplicateEntity{GuestID: 1, DuplicateGuestID: 3, Score: 0.65, Reasons: "name,date_of_birth", Status: constant.GuestDuplicateStatusPending}).Return(nil).Once()
+	mockDuplicate.On("Upsert", &model.GuestDuplicateEntity{GuestID: 2, DuplicateGuestID: 3, Score: 0.65, Reasons: "name,date_of_birth", Status: constant.GuestDuplicateStatusPending}).Return(nil).Once()
+
+	service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, GuestDuplicateRepo: mockDuplicate}, mockConfig)
+	found, err := service.DetectDuplicates()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, found)
+	mockDuplicate.AssertExpectations(t)
+}
+
+func Test_GetDuplicates(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockDuplicate := new(mocks.GuestDuplicateRepository)
+
+	createdAt := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	dateOfBirth := time.Date(1990, time.March, 1, 0, 0, 0, 0, time.UTC)
+	pagination := &model.PaginationRequest{Page: 1, Limit: 20}
+	mockDuplicate.On("GetPending", pagination).Return([]*model.GuestDuplicateEntity{
+		{ID: 7, GuestID: 1, DuplicateGuestID: 2, Score: 0.7, Reasons: "name,date_of_birth", Status: constant.GuestDuplicateStatusPending, CreatedAt: &createdAt},
+	}, 1, nil).Once()
+	mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe", Identity: "P123", DateOfBirth: dateOfBirth}, nil).Once()
+	mockGuest.On("GetByID", 2).Return(&model.GuestEntity{ID: 2, FirstName: "John", LastName: "Doe", Identity: "3171", DateOfBirth: dateOfBirth}, nil).Once()
+
+	service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, GuestDuplicateRepo: mockDuplicate}, mockConfig)
+	resp, err := service.GetDuplicates(pagination)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &model.PaginatedResponse{
+		Items: []*model.GuestDuplicateResponse{
+			{
+				ID:             7,
+				Score:          0.7,
+				Reasons:        []string{"name", "date_of_birth"},
+				Guest:          &model.GuestResponse{ID: 1, Name: "John Doe", Identity: "P123", DateOfBirth: "01 March 1990"},
+				DuplicateGuest: &model.GuestResponse{ID: 2, Name: "John Doe", Identity: "3171", DateOfBirth: "01 March 1990"},
+				CreatedAt:      "01-05-2024 09:00:00",
+			},
+		},
+		Page:       1,
+		Limit:      20,
+		Total:      1,
+		TotalPages: 1,
+	}, resp)
+}
+
+func Test_DismissDuplicate(t *testing.T) {
+	mockDuplicate := new(mocks.GuestDuplicateRepository)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending suggestion when dismiss duplicate then record the reviewer",
+			mockCall: func() {
+				mockDuplicate.On("GetByID", 7).Return(&model.GuestDuplicateEntity{ID: 7, Status: constant.GuestDuplicateStatusPending}, nil).Once()
+				mockDuplicate.On("Dismiss", mock.MatchedBy(func(duplicate *model.GuestDuplicateEntity) bool {
+					return duplicate.ID == 7 && *duplicate.ReviewedBy == 1 && duplicate.ReviewedAt != nil
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given merged suggestion when dismiss duplicate then return conflict",
+			mockCall: func() {
+				mockDuplicate.On("GetByID", 7).Return(&model.GuestDuplicateEntity{ID: 7, Status: constant.GuestDuplicateStatusMerged}, nil).Once()
+			},
+			err: model.NewConflictError("duplicate suggestion has already been reviewed"),
+		},
+		{
+			name: "Given unknown suggestion when dismiss duplicate then return not found",
+			mockCall: func() {
+				mockDuplicate.On("GetByID", 7).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("duplicate suggestion not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestDuplicateRepo: mockDuplicate}, mockConfig)
+			err := service.DismissDuplicate(7, 1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_MergeDuplicate(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockDuplicate := new(mocks.GuestDuplicateRepository)
+
+	pending := func() *model.GuestDuplicateEntity {
+		return &model.GuestDuplicateEntity{ID: 7, GuestID: 1, DuplicateGuestID: 2, Status: constant.GuestDuplicateStatusPending}
+	}
+
+	testCases := []struct {
+		name     string
+		request  *model.MergeGuestRequest
+		mockCall func()
+		response *model.GuestMergeResponse
+		err      error
+	}{
+		{
+			name:    "Given newer guest survives when merge duplicate then merge the older guest into it",
+			request: &model.MergeGuestRequest{SurvivingGuestID: 2},
+			mockCall: func() {
+				mockDuplicate.On("GetByID", 7).Return(pending(), nil).Once()
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1, FirstName: "John"}, nil).Once()
+				mockDuplicate.On("Merge", mock.MatchedBy(func(duplicate *model.GuestDuplicateEntity) bool {
+					return *duplicate.ReviewedBy == 1 && duplicate.ReviewedAt != nil
+				}), mock.MatchedBy(func(merge *model.GuestMergeEntity) bool {
+					return merge.DuplicateID == 7 && merge.SurvivingGuestID == 2 && merge.MergedGuestID == 1 && merge.MergedBy == 1 &&
+						strings.Contains(merge.MergedGuest, `"FirstName":"John"`)
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.GuestMergeEntity).BookingsMoved = 3
+				}).Return(nil).Once()
+			},
+			response: &model.GuestMergeResponse{SurvivingGuestID: 2, MergedGuestID: 1, BookingsMoved: 3},
+			err:      nil,
+		},
+		{
+			name:    "Given guest outside the pair when merge duplicate then return validation error",
+			request: &model.MergeGuestRequest{SurvivingGuestID: 5},
+			mockCall: func() {
+				mockDuplicate.On("GetByID", 7).Return(pending(), nil).Once()
+			},
+			response: nil,
+			err:      model.NewValidationError("surviving guest must be one of the suggested pair"),
+		},
+		{
+			name:    "Given dismissed suggestion when merge duplicate then return conflict",
+			request: &model.MergeGuestRequest{SurvivingGuestID: 1},
+			mockCall: func() {
+				mockDuplicate.On("GetByID", 7).Return(&model.GuestDuplicateEntity{ID: 7, GuestID: 1, DuplicateGuestID: 2, Status: constant.GuestDuplicateStatusDismissed}, nil).Once()
+			},
+			response: nil,
+			err:      model.NewConflictError("duplicate suggestion has already been reviewed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, GuestDuplicateRepo: mockDuplicate}, mockConfig)
+			resp, err := service.MergeDuplicate(7, tc.request, 1)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+		})
+	}
+}
+
+func Test_Export(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockBooking := new(mocks.BookingRepository)
+	mockPayment := new(mocks.PaymentRepository)
+	mockFolio := new(mocks.FolioRepository)
+
+	createdAt := time.Date(2024, time.May, 1, 9, 30, 0, 0, time.UTC)
+	checkIn := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	guest := &model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe", Identity: "123", DateOfBirth: time.Date(1990, time.January, 2, 0, 0, 0, 0, time.UTC), PhoneNumber: "08123456789", Email: "john.doe@mail.com", CreatedAt: &createdAt, UpdatedAt: &createdAt}
+	booking := &model.BookingDetail{BookingEntity: model.BookingEntity{RegisterNumber: "REG-1", RoomName: "101", CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 2), PaidAmount: 1000000, PaymentMethod: constant.PaymentMethodCash, PaymentStatus: constant.PaymentStatusCompleted, Status: constant.BookingStatusCheckedOut, CreatedAt: &createdAt}}
+	payment := &model.PaymentEntity{ID: 1, RegisterNumber: "REG-1", Amount: 1000000, PaymentMethod: constant.PaymentMethodCash, PaidAt: checkIn}
+	charge := &model.FolioChargeEntity{ID: 1, RegisterNumber: "REG-1", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: checkIn, Quantity: 1, UnitPrice: 500000, Amount: 500000}
+
+	testCases := []struct {
+		name     string
+		format   constant.GuestExportFormat
+		mockCall func()
+		files    []string
+		err      error
+	}{
+		{
+			name:   "Given guest with a booking when export as json then return the guest, bookings, payments and charges",
+			format: constant.GuestExportFormatJSON,
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(guest, nil).Once()
+				mockBooking.On("GetByGuestID", 1).Return([]*model.BookingDetail{booking}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "REG-1").Return([]*model.PaymentEntity{payment}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "REG-1").Return([]*model.FolioChargeEntity{charge}, nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:   "Given guest with a booking when export as zip then return the guest and the bookings in a file each",
+			format: constant.GuestExportFormatZIP,
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(guest, nil).Once()
+				mockBooking.On("GetByGuestID", 1).Return([]*model.BookingDetail{booking}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "REG-1").Return([]*model.PaymentEntity{payment}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "REG-1").Return([]*model.FolioChargeEntity{charge}, nil).Once()
+			},
+			files: []string{"guest.json", "bookings.json"},
+			err:   nil,
+		},
+		{
+			name:     "Given unknown format when export then return validation error",
+			format:   constant.GuestExportFormat("xml"),
+			mockCall: func() {},
+			err:      model.NewValidationError("invalid export format"),
+		},
+		{
+			name:   "Given unknown guest when export then return not found",
+			format: constant.GuestExportFormatJSON,
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("guest not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, BookingRepo: mockBooking, PaymentRepo: mockPayment, FolioRepo: mockFolio}, mockConfig)
+			document, err := service.Export(1, tc.format)
+			assert.Equal(t, tc.err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, 1, document.GuestID)
+			assert.Equal(t, tc.format, document.Format)
+			if tc.format == constant.GuestExportFormatJSON {
+				export := &model.GuestExportResponse{}
+				assert.NoError(t, json.Unmarshal(document.Content, export))
+				assert.Equal(t, "123", export.Guest.Identity)
+				assert.Equal(t, "02 January 1990", export.Guest.DateOfBirth)
+				assert.Equal(t, "REG-1", export.Bookings[0].RegisterNumber)
+				assert.Equal(t, 1000000.0, export.Bookings[0].Payments[0].Amount)
+				assert.Equal(t, "Room 101", export.Bookings[0].Charges[0].Description)
+				return
+			}
+
+			archive, err := zip.NewReader(bytes.NewReader(document.Content), int64(len(document.Content)))
+			assert.NoError(t, err)
+			names := make([]string, 0, len(archive.File))
+			for _, file := range archive.File {
+				names = append(names, file.Name)
+			}
+			assert.Equal(t, tc.files, names)
+		})
+	}
+}
+
+func Test_Anonymize(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockBooking := new(mocks.BookingRepository)
+
+	anonymizedAt := time.Now()
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given guest with finished bookings when anonymize then scrub the personal data",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe", Identity: "123", DateOfBirth: time.Date(1990, time.January, 2, 0, 0, 0, 0, time.UTC), PhoneNumber: "08123456789", Email: "john.doe@mail.com"}, nil).Once()
+				mockBooking.On("GetByGuestID", 1).Return([]*model.BookingDetail{
+					{BookingEntity: model.BookingEntity{Status: constant.BookingStatusCheckedOut}},
+					{BookingEntity: model.BookingEntity{Status: constant.BookingStatusCancelled}},
+				}, nil).Once()
+				mockGuest.On("Anonymize", mock.MatchedBy(func(guest *model.GuestEntity) bool {
+					return guest.ID == 1 && guest.FirstName == "Anonymized" && guest.LastName == "Guest" && guest.Identity == "anonymized-1" &&
+						guest.DateOfBirth.IsZero() && guest.PhoneNumber == "" && guest.Email == "" &&
+						guest.AnonymizedAt != nil && *guest.AnonymizedBy == 7
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unknown guest when anonymize then return not found",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("guest not found"),
+		},
+		{
+			name: "Given anonymized guest when anonymize then return conflict",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1, AnonymizedAt: &anonymizedAt}, nil).Once()
+			},
+			err: model.NewConflictError("guest has already been anonymized"),
+		},
+		{
+			name: "Given guest staying when anonymize then return conflict",
+			mockCall: func() {
+				mockGuest.On("GetByID", 1).Return(&model.GuestEntity{ID: 1}, nil).Once()
+				mockBooking.On("GetByGuestID", 1).Return([]*model.BookingDetail{
+					{BookingEntity: model.BookingEntity{Status: constant.BookingStatusCheckedIn}},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("guest has a booking that is not finished yet"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, BookingRepo: mockBooking}, mockConfig)
+			err := service.Anonymize(1, 7)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_AnonymizeInactive(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+
+	testCases := []struct {
+		name     string
+		years    int
+		mockCall func()
+		result   *model.AnonymizeInactiveResponse
+		err      error
+	}{
+		{
+			name:  "Given guests without a stay in the retention period when anonymize inactive then anonymize them",
+			years: 5,
+			mockCall: func() {
+				mockGuest.On("GetIDsWithoutStaySince", mock.MatchedBy(func(since time.Time) bool {
+					return since.Year() == time.Now().Year()-5
+				})).Return([]int{2, 3}, nil).Once()
+				mockGuest.On("GetByID", 2).Return(&model.GuestEntity{ID: 2}, nil).Once()
+				mockGuest.On("GetByID", 3).Return(&model.GuestEntity{ID: 3}, nil).Once()
+				mockGuest.On("Anonymize", mock.MatchedBy(func(guest *model.GuestEntity) bool {
+					return guest.Identity == "anonymized-2" && guest.AnonymizedBy == nil
+				})).Return(nil).Once()
+				mockGuest.On("Anonymize", mock.MatchedBy(func(guest *model.GuestEntity) bool {
+					return guest.Identity == "anonymized-3" && guest.AnonymizedBy == nil
+				})).Return(nil).Once()
+			},
+			result: &model.AnonymizeInactiveResponse{Processed: 2, Anonymized: 2, Failed: []int{}},
+			err:    nil,
+		},
+		{
+			name:  "Given guests failing to anonymize when anonymize inactive then go on with the others and report them",
+			years: 5,
+			mockCall: func() {
+				mockGuest.On("GetIDsWithoutStaySince", mock.Anything).Return([]int{2, 3, 4}, nil).Once()
+				mockGuest.On("GetByID", 2).Return(nil, errors.New("error")).Once()
+				mockGuest.On("GetByID", 3).Return(&model.GuestEntity{ID: 3}, nil).Once()
+				mockGuest.On("GetByID", 4).Return(&model.GuestEntity{ID: 4}, nil).Once()
+				mockGuest.On("Anonymize", mock.MatchedBy(func(guest *model.GuestEntity) bool {
+					return guest.Identity == "anonymized-3"
+				})).Return(errors.New("error")).Once()
+				mockGuest.On("Anonymize", mock.MatchedBy(func(guest *model.GuestEntity) bool {
+					return guest.Identity == "anonymized-4"
+				})).Return(nil).Once()
+			},
+			result: &model.AnonymizeInactiveResponse{Processed: 3, Anonymized: 1, Failed: []int{2, 3}},
+			err:    nil,
+		},
+		{
+			name:     "Given no retention period when anonymize inactive then keep every guest",
+			years:    0,
+			mockCall: func() {},
+			result:   &model.AnonymizeInactiveResponse{Failed: []int{}},
+			err:      nil,
+		},
+		{
+			name:  "Given error from db when anonymize inactive then return error",
+			years: 5,
+			mockCall: func() {
+				mockGuest.On("GetIDsWithoutStaySince", mock.Anything).Return(nil, errors.New("error")).Once()
+			},
+			result: nil,
+			err:    errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			cfg := &config.Config{GuestRetention: config.GuestRetentionConfig{AnonymizeAfterYears: tc.years}}
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest}, cfg)
+			result, err := service.AnonymizeInactive()
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_ReEncrypt(t *testing.T) {
+	mockGuest = new(mocks.GuestRepository)
+	mockGuestDuplicate := new(mocks.GuestDuplicateRepository)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   int
+		err      error
+	}{
+		{
+			name: "Given guests and merged guests to re-encrypt when re-encrypt then return how many were rewritten",
+			mockCall: func() {
+				mockGuest.On("ReEncrypt").Return(3, nil).Once()
+				mockGuestDuplicate.On("ReEncrypt").Return(1, nil).Once()
+			},
+			result: 4,
+			err:    nil,
+		},
+		{
+			name: "Given error from db when re-encrypt guests then stop and return error",
+			mockCall: func() {
+				mockGuest.On("ReEncrypt").Return(2, errors.New("error")).Once()
+			},
+			result: 2,
+			err:    errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewGuestServiceImpl(&repository.Repositories{GuestRepo: mockGuest, GuestDuplicateRepo: mockGuestDuplicate}, mockConfig)
+			result, err := service.ReEncrypt()
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package housekeeping
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=HousekeepingService --output=../mocks --outpkg=mocks
+type HousekeepingService interface {
+	GetAll(request *model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskResponse, error)
+	GetByID(id int) (*model.HousekeepingTaskResponse, error)
+	Claim(id int, userID int) error
+	Complete(id int, userID int, request *model.CompleteHousekeepingTaskRequest) error
+	Inspect(id int, userID int, request *model.InspectHousekeepingTaskRequest) error
+}
@@ -0,0 +1,194 @@
+package housekeeping
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type housekeepingSvcImpl struct {
+	repos *repository.Repositories
+}
+
+func NewHousekeepingServiceImpl(repos *repository.Repositories) HousekeepingService {
+	return &housekeepingSvcImpl{repos: repos}
+}
+
+func (h *housekeepingSvcImpl) GetAll(request *model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskResponse, error) {
+	tasks, err := h.repos.HousekeepingRepo.GetAll(request)
+	if err != nil {
+		return nil, err
+	}
+
+	taskResponses := make([]*model.HousekeepingTaskResponse, len(tasks))
+	for i, task := range tasks {
+		taskResponses[i] = newHousekeepingTaskResponse(task)
+	}
+
+	return taskResponses, nil
+}
+
+func (h *housekeepingSvcImpl) GetByID(id int) (*model.HousekeepingTaskResponse, error) {
+	task, err := h.repos.HousekeepingRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHousekeepingTaskResponse(task), nil
+}
+
+func (h *housekeepingSvcImpl) Claim(id int, userID int) error {
+	task, err := h.repos.HousekeepingRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := transition(task.Status, constant.HousekeepingTaskStatusInProgress); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := h.repos.HousekeepingRepo.Claim(&model.HousekeepingTaskEntity{
+		ID:         id,
+		Status:     constant.HousekeepingTaskStatusInProgress,
+		AssignedTo: &userID,
+		ClaimedAt:  &now,
+	}); err != nil {
+		return err
+	}
+
+	if err := h.repos.RoomRepo.Update(&model.RoomEntity{
+		Status: constant.RoomStatusCleaning,
+		Name:   task.RoomName,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (h *housekeepingSvcImpl) Complete(id int, userID int, request *model.CompleteHousekeepingTaskRequest) error {
+	task, err := h.repos.HousekeepingRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := transition(task.Status, constant.HousekeepingTaskStatusCompleted); err != nil {
+		return err
+	}
+
+	if task.AssignedTo == nil || *task.AssignedTo != userID {
+		return model.NewConflictError("housekeeping task is claimed by another housekeeper")
+	}
+
+	now := time.Now()
+	if err := h.repos.HousekeepingRepo.Complete(&model.HousekeepingTaskEntity{
+		ID:          id,
+		Status:      constant.HousekeepingTaskStatusCompleted,
+		CompletedAt: &now,
+		Notes:       request.Notes,
+	}); err != nil {
+		return err
+	}
+
+	// The room stays out of service until a supervisor has inspected it
+	if err := h.repos.RoomRepo.Update(&model.RoomEntity{
+		Status: constant.RoomStatusInspected,
+		Name:   task.RoomName,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (h *housekeepingSvcImpl) Inspect(id int, userID int, request *model.InspectHousekeepingTaskRequest) error {
+	task, err := h.repos.HousekeepingRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	status := constant.HousekeepingTaskStatusInspected
+	roomStatus := constant.RoomStatusAvailable
+	if !*request.Passed {
+		status = constant.HousekeepingTaskStatusRejected
+		roomStatus = constant.RoomStatusDirty
+	}
+
+	if err := transition(task.Status, status); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := h.repos.HousekeepingRepo.Inspect(&model.HousekeepingTaskEntity{
+		ID:          id,
+		Status:      status,
+		InspectedBy: &userID,
+		InspectedAt: &now,
+		Notes:       request.Notes,
+	}); err != nil {
+		return err
+	}
+
+	// A failed inspection goes back to the queue as a new task so the history of each attempt is kept
+	if status == constant.HousekeepingTaskStatusRejected {
+		if err := h.repos.HousekeepingRepo.Create(&model.HousekeepingTaskEntity{
+			RoomName:       task.RoomName,
+			RegisterNumber: task.RegisterNumber,
+			Status:         constant.HousekeepingTaskStatusPending,
+			Notes:          request.Notes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := h.repos.RoomRepo.Update(&model.RoomEntity{
+		Status: roomStatus,
+		Name:   task.RoomName,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// transition rejects moves the task workflow does not allow, e.g. completing a task nobody claimed.
+func transition(current, next constant.HousekeepingTaskStatus) error {
+	if !current.CanTransitionTo(next) {
+		return model.NewConflictError("housekeeping task can not move from %s to %s", current.Enum(), next.Enum())
+	}
+
+	return nil
+}
+
+func newHousekeepingTaskResponse(task *model.HousekeepingTaskEntity) *model.HousekeepingTaskResponse {
+	response := &model.HousekeepingTaskResponse{
+		ID:             task.ID,
+		RoomName:       task.RoomName,
+		RegisterNumber: task.RegisterNumber,
+		Status:         task.Status.Enum(),
+		AssignedTo:     task.AssignedTo,
+		ClaimedAt:      formatTimestamp(task.ClaimedAt),
+		CompletedAt:    formatTimestamp(task.CompletedAt),
+		InspectedBy:    task.InspectedBy,
+		InspectedAt:    formatTimestamp(task.InspectedAt),
+		Notes:          task.Notes,
+	}
+
+	if task.CreatedAt != nil {
+		response.CreatedAt = task.CreatedAt.Format("02-01-2006 15:04:05")
+	}
+
+	return response
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format("02-01-2006 15:04:05")
+	return &formatted
+}
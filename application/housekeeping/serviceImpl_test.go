@@ -0,0 +1,255 @@
+package housekeeping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockHousekeeping *mocks.HousekeepingRepository
+	mockRoom         *mocks.RoomRepository
+)
+
+func Test_GetAll(t *testing.T) {
+	mockHousekeeping = new(mocks.HousekeepingRepository)
+
+	status := "1"
+	createdAt := time.Date(2000, time.April, 01, 12, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		request  *model.GetAllHousekeepingTaskRequest
+		mockCall func()
+		response []*model.HousekeepingTaskResponse
+		err      error
+	}{
+		{
+			name:    "Given valid request when get all housekeeping tasks then return success",
+			request: &model.GetAllHousekeepingTaskRequest{Status: &status},
+			mockCall: func() {
+				mockHousekeeping.On("GetAll", &model.GetAllHousekeepingTaskRequest{Status: &status}).Return([]*model.HousekeepingTaskEntity{
+					{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusPending, CreatedAt: &createdAt},
+				}, nil).Once()
+			},
+			response: []*model.HousekeepingTaskResponse{
+				{ID: 1, RoomName: "101", Status: "Pending", CreatedAt: "01-04-2000 12:00:00"},
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error when get all housekeeping tasks then return error",
+			request: &model.GetAllHousekeepingTaskRequest{},
+			mockCall: func() {
+				mockHousekeeping.On("GetAll", &model.GetAllHousekeepingTaskRequest{}).Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewHousekeepingServiceImpl(&repository.Repositories{HousekeepingRepo: mockHousekeeping})
+			response, err := service.GetAll(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
+
+func Test_Claim(t *testing.T) {
+	mockHousekeeping = new(mocks.HousekeepingRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	matchClaim := mock.MatchedBy(func(task *model.HousekeepingTaskEntity) bool {
+		return task.ID == 1 && task.Status == constant.HousekeepingTaskStatusInProgress && *task.AssignedTo == 1 && task.ClaimedAt != nil
+	})
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending task when claim then return success",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusPending}, nil).Once()
+				mockHousekeeping.On("Claim", matchClaim).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusCleaning}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given task in progress when claim then return conflict error",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusInProgress}, nil).Once()
+			},
+			err: model.NewConflictError("housekeeping task can not move from In Progress to In Progress"),
+		},
+		{
+			name: "Given error claim when claim then return error",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusPending}, nil).Once()
+				mockHousekeeping.On("Claim", matchClaim).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewHousekeepingServiceImpl(&repository.Repositories{HousekeepingRepo: mockHousekeeping, RoomRepo: mockRoom})
+			err := service.Claim(1, 1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	mockHousekeeping = new(mocks.HousekeepingRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	assignee := 1
+	other := 2
+	notes := "minibar restocked"
+	matchComplete := mock.MatchedBy(func(task *model.HousekeepingTaskEntity) bool {
+		return task.ID == 1 && task.Status == constant.HousekeepingTaskStatusCompleted && task.CompletedAt != nil && task.Notes == &notes
+	})
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given claimed task when complete then return success",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusInProgress, AssignedTo: &assignee}, nil).Once()
+				mockHousekeeping.On("Complete", matchComplete).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusInspected}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given pending task when complete then return conflict error",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusPending}, nil).Once()
+			},
+			err: model.NewConflictError("housekeeping task can not move from Pending to Completed"),
+		},
+		{
+			name: "Given task claimed by another housekeeper when complete then return conflict error",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusInProgress, AssignedTo: &other}, nil).Once()
+			},
+			err: model.NewConflictError("housekeeping task is claimed by another housekeeper"),
+		},
+		{
+			name: "Given error update room when complete then return error",
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusInProgress, AssignedTo: &assignee}, nil).Once()
+				mockHousekeeping.On("Complete", matchComplete).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusInspected}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewHousekeepingServiceImpl(&repository.Repositories{HousekeepingRepo: mockHousekeeping, RoomRepo: mockRoom})
+			err := service.Complete(1, 1, &model.CompleteHousekeepingTaskRequest{Notes: &notes})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Inspect(t *testing.T) {
+	mockHousekeeping = new(mocks.HousekeepingRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	passed := true
+	failed := false
+	registerNumber := "register_number"
+	notes := "bathroom not cleaned"
+	completed := &model.HousekeepingTaskEntity{ID: 1, RoomName: "101", RegisterNumber: &registerNumber, Status: constant.HousekeepingTaskStatusCompleted}
+	matchInspect := func(status constant.HousekeepingTaskStatus) interface{} {
+		return mock.MatchedBy(func(task *model.HousekeepingTaskEntity) bool {
+			return task.ID == 1 && task.Status == status && *task.InspectedBy == 2 && task.InspectedAt != nil
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		request  *model.InspectHousekeepingTaskRequest
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given passed inspection when inspect then release the room",
+			request: &model.InspectHousekeepingTaskRequest{Passed: &passed},
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(completed, nil).Once()
+				mockHousekeeping.On("Inspect", matchInspect(constant.HousekeepingTaskStatusInspected)).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusAvailable}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given failed inspection when inspect then reopen a cleaning task",
+			request: &model.InspectHousekeepingTaskRequest{Passed: &failed, Notes: &notes},
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(completed, nil).Once()
+				mockHousekeeping.On("Inspect", matchInspect(constant.HousekeepingTaskStatusRejected)).Return(nil).Once()
+				mockHousekeeping.On("Create", &model.HousekeepingTaskEntity{
+					RoomName:       "101",
+					RegisterNumber: &registerNumber,
+					Status:         constant.HousekeepingTaskStatusPending,
+					Notes:          &notes,
+				}).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusDirty}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given task not completed when inspect then return conflict error",
+			request: &model.InspectHousekeepingTaskRequest{Passed: &passed},
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(&model.HousekeepingTaskEntity{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusInProgress}, nil).Once()
+			},
+			err: model.NewConflictError("housekeeping task can not move from In Progress to Inspected"),
+		},
+		{
+			name:    "Given error get task when inspect then return error",
+			request: &model.InspectHousekeepingTaskRequest{Passed: &passed},
+			mockCall: func() {
+				mockHousekeeping.On("GetByID", 1).Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewHousekeepingServiceImpl(&repository.Repositories{HousekeepingRepo: mockHousekeeping, RoomRepo: mockRoom})
+			err := service.Inspect(1, 2, tc.request)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package invitation
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=InvitationService --output=../mocks --outpkg=mocks
+type InvitationService interface {
+	Create(request *model.CreateInvitationRequest, createdBy int) (*model.InvitationResponse, error)
+	GetPending() ([]*model.InvitationResponse, error)
+	Revoke(id int, revokedBy int) error
+}
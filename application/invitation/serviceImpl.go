@@ -0,0 +1,101 @@
+package invitation
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type invitationSvcImpl struct {
+	repos *repository.Repositories
+	cfg   *config.Config
+}
+
+func NewInvitationServiceImpl(repos *repository.Repositories, cfg *config.Config) InvitationService {
+	return &invitationSvcImpl{repos: repos, cfg: cfg}
+}
+
+// Create invites someone to register with a role. The token is only returned here,
+// the admin hands it to the invitee who sends it back when registering.
+func (i *invitationSvcImpl) Create(request *model.CreateInvitationRequest, createdBy int) (*model.InvitationResponse, error) {
+	token, err := utils.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating invitation token")
+	}
+
+	expiresInHours := request.ExpiresInHours
+	if expiresInHours == 0 {
+		expiresInHours = i.cfg.Invitation.ExpiryHours
+	}
+
+	invitation := &model.InvitationEntity{
+		TokenHash: utils.HashToken(token),
+		Email:     strings.ToLower(request.Email),
+		Role:      constant.Role(request.Role),
+		ExpiresAt: time.Now().Add(time.Duration(expiresInHours) * time.Hour),
+		CreatedBy: createdBy,
+	}
+	if err := i.repos.InvitationRepo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	response := toResponse(invitation)
+	response.Token = token
+	return response, nil
+}
+
+// GetPending lists the invitations that can still be used to register.
+func (i *invitationSvcImpl) GetPending() ([]*model.InvitationResponse, error) {
+	invitations, err := i.repos.InvitationRepo.GetPending()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*model.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		if invitation.ExpiresAt.Before(now) {
+			continue
+		}
+		responses = append(responses, toResponse(invitation))
+	}
+
+	return responses, nil
+}
+
+func (i *invitationSvcImpl) Revoke(id int, revokedBy int) error {
+	invitation, err := i.repos.InvitationRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("invitation not found")
+		}
+
+		return err
+	}
+
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+		return model.NewConflictError("invitation is no longer pending")
+	}
+
+	now := time.Now()
+	invitation.RevokedAt = &now
+	invitation.RevokedBy = &revokedBy
+	return i.repos.InvitationRepo.Revoke(invitation)
+}
+
+func toResponse(invitation *model.InvitationEntity) *model.InvitationResponse {
+	return &model.InvitationResponse{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      invitation.Role.Enum(),
+		ExpiresAt: invitation.ExpiresAt.Format("02-01-2006 15:04:05"),
+		CreatedBy: invitation.CreatedBy,
+	}
+}
@@ -0,0 +1,183 @@
+package invitation
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+var (
+	mockInvitation *mocks.InvitationRepository
+)
+
+func Test_Create(t *testing.T) {
+	mockInvitation = new(mocks.InvitationRepository)
+
+	cfg := &config.Config{Invitation: config.InvitationConfig{ExpiryHours: 72}}
+
+	testCases := []struct {
+		name     string
+		request  *model.CreateInvitationRequest
+		mockCall func()
+		expiry   time.Duration
+		err      error
+	}{
+		{
+			name:    "Given no expiry when create invitation then expire after the configured hours",
+			request: &model.CreateInvitationRequest{Email: "Jane@Mail.com", Role: 2},
+			mockCall: func() {
+				mockInvitation.On("Create", mock.MatchedBy(func(invitation *model.InvitationEntity) bool {
+					return invitation.Email == "jane@mail.com" && invitation.Role == constant.RoleFrontDesk && invitation.CreatedBy == 1
+				})).Run(func(args mock.Arguments) {
+					args.Get(0).(*model.InvitationEntity).ID = 4
+				}).Return(nil).Once()
+			},
+			expiry: 72 * time.Hour,
+			err:    nil,
+		},
+		{
+			name:    "Given expiry when create invitation then expire after the given hours",
+			request: &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 4, ExpiresInHours: 2},
+			mockCall: func() {
+				mockInvitation.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(0).(*model.InvitationEntity).ID = 4
+				}).Return(nil).Once()
+			},
+			expiry: 2 * time.Hour,
+			err:    nil,
+		},
+		{
+			name:    "Given error when create invitation then return error",
+			request: &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 2},
+			mockCall: func() {
+				mockInvitation.On("Create", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewInvitationServiceImpl(&repository.Repositories{InvitationRepo: mockInvitation}, cfg)
+			response, err := service.Create(tc.request, 1)
+			assert.Equal(t, tc.err, err)
+			if tc.err != nil {
+				assert.Nil(t, response)
+				return
+			}
+
+			assert.Equal(t, 4, response.ID)
+			assert.NotEmpty(t, response.Token)
+
+			// only the hash of the handed out token is stored
+			created := mockInvitation.Calls[len(mockInvitation.Calls)-1].Arguments.Get(0).(*model.InvitationEntity)
+			assert.Equal(t, utils.HashToken(response.Token), created.TokenHash)
+			assert.WithinDuration(t, time.Now().Add(tc.expiry), created.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func Test_GetPending(t *testing.T) {
+	mockInvitation = new(mocks.InvitationRepository)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		response []*model.InvitationResponse
+		err      error
+	}{
+		{
+			name: "Given pending and expired invitations when get pending then leave out the expired ones",
+			mockCall: func() {
+				mockInvitation.On("GetPending").Return([]*model.InvitationEntity{
+					{ID: 1, Email: "old@mail.com", Role: constant.RoleReadOnly, ExpiresAt: time.Now().Add(-time.Hour), CreatedBy: 1},
+					{ID: 2, Email: "jane@mail.com", Role: constant.RoleFrontDesk, ExpiresAt: expiresAt, CreatedBy: 1},
+				}, nil).Once()
+			},
+			response: []*model.InvitationResponse{
+				{ID: 2, Email: "jane@mail.com", Role: "Front Desk", ExpiresAt: expiresAt.Format("02-01-2006 15:04:05"), CreatedBy: 1},
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when get pending then return error",
+			mockCall: func() {
+				mockInvitation.On("GetPending").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewInvitationServiceImpl(&repository.Repositories{InvitationRepo: mockInvitation}, &config.Config{})
+			response, err := service.GetPending()
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
+
+func Test_Revoke(t *testing.T) {
+	mockInvitation = new(mocks.InvitationRepository)
+
+	acceptedAt := time.Now()
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending invitation when revoke then record who revoked it",
+			mockCall: func() {
+				mockInvitation.On("GetByID", 3).Return(&model.InvitationEntity{ID: 3}, nil).Once()
+				mockInvitation.On("Revoke", mock.MatchedBy(func(invitation *model.InvitationEntity) bool {
+					return invitation.ID == 3 && invitation.RevokedAt != nil && *invitation.RevokedBy == 1
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given accepted invitation when revoke then return conflict error",
+			mockCall: func() {
+				mockInvitation.On("GetByID", 3).Return(&model.InvitationEntity{ID: 3, AcceptedAt: &acceptedAt}, nil).Once()
+			},
+			err: model.NewConflictError("invitation is no longer pending"),
+		},
+		{
+			name: "Given unknown invitation when revoke then return not found error",
+			mockCall: func() {
+				mockInvitation.On("GetByID", 3).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("invitation not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewInvitationServiceImpl(&repository.Repositories{InvitationRepo: mockInvitation}, &config.Config{})
+			err := service.Revoke(3, 1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{"amount": formatAmount}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.InvoiceNumber}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 13px; color: #222; margin: 40px; }
+h1 { font-size: 22px; margin: 0; }
+table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+th, td { padding: 6px; border-bottom: 1px solid #ddd; text-align: left; }
+th { background: #f2f2f2; }
+.number { text-align: right; }
+.header { display: flex; justify-content: space-between; }
+.totals td { border: none; }
+</style>
+</head>
+<body>
+<div class="header">
+<div>
+<h1>{{.HotelName}}</h1>
+<div>{{.HotelAddress}}</div>
+<div>{{.HotelPhone}} | {{.HotelEmail}}</div>
+</div>
+<div class="number">
+<h1>INVOICE</h1>
+<div>No. {{.InvoiceNumber}}</div>
+<div>Issued {{.IssuedAt}}</div>
+</div>
+</div>
+<table>
+<tr><th>Guest</th><td>{{.GuestName}}</td><th>Register Number</th><td>{{.RegisterNumber}}</td></tr>
+<tr><th>Room</th><td>{{.RoomName}} ({{.RoomType}})</td><th>Nights</th><td>{{.Nights}}</td></tr>
+<tr><th>Check In</th><td>{{.CheckIn}}</td><th>Check Out</th><td>{{.CheckOut}}</td></tr>
+</table>
+<table>
+<tr><th>Date</th><th>Description</th><th class="number">Qty</th><th class="number">Unit Price</th><th class="number">Amount</th><th class="number">Service</th><th class="number">Tax</th><th class="number">Total</th></tr>
+{{range .Charges}}<tr><td>{{.ChargeDate}}</td><td>{{.Description}}</td><td class="number">{{.Quantity}}</td><td class="number">{{amount .UnitPrice}}</td><td class="number">{{amount .Amount}}</td><td class="number">{{amount .ServiceCharge}}</td><td class="number">{{amount .Tax}}</td><td class="number">{{amount .Total}}</td></tr>
+{{end}}</table>
+<table class="totals">
+<tr><td class="number">Subtotal</td><td class="number">{{amount .Subtotal}}</td></tr>
+<tr><td class="number">Service Charge ({{.ServiceChargePercentage}}%)</td><td class="number">{{amount .ServiceCharge}}</td></tr>
+<tr><td class="number">Tax ({{.TaxPercentage}}%)</td><td class="number">{{amount .Tax}}</td></tr>
+<tr><th class="number">Total</th><th class="number">{{amount .Total}}</th></tr>
+</table>
+<table>
+<tr><th>Paid At</th><th>Method</th><th>Reference</th><th class="number">Amount</th></tr>
+{{range .Payments}}<tr><td>{{.PaidAt}}</td><td>{{.PaymentMethod}}</td><td>{{if .Reference}}{{.Reference}}{{end}}</td><td class="number">{{amount .Amount}}</td></tr>
+{{end}}</table>
+<table class="totals">
+<tr><td class="number">Paid</td><td class="number">{{amount .Paid}}</td></tr>
+<tr><th class="number">Balance</th><th class="number">{{amount .Balance}}</th></tr>
+</table>
+</body>
+</html>
+`))
+
+func renderHTML(invoice *model.InvoiceResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := invoiceTemplate.Execute(&buf, invoice); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderPDF(invoice *model.InvoiceResponse) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.AddPage()
+
+	// the core fonts are not unicode, guest names are translated to their code page
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(110, 8, tr(invoice.HotelName), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 8, "INVOICE", "", 1, "R", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(110, 5, tr(invoice.HotelAddress), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 5, "No. "+invoice.InvoiceNumber, "", 1, "R", false, 0, "")
+	pdf.CellFormat(110, 5, tr(invoice.HotelPhone+" | "+invoice.HotelEmail), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 5, "Issued "+invoice.IssuedAt, "", 1, "R", false, 0, "")
+	pdf.Ln(6)
+
+	details := [][2]string{
+		{"Guest", invoice.GuestName},
+		{"Register Number", invoice.RegisterNumber},
+		{"Room", invoice.RoomName + " (" + invoice.RoomType + ")"},
+		{"Stay", invoice.CheckIn + " - " + invoice.CheckOut + ", " + strconv.Itoa(invoice.Nights) + " night(s)"},
+	}
+	for _, detail := range details {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(35, 6, detail[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(145, 6, tr(detail[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	chargeWidths := []float64{24, 50, 10, 22, 22, 18, 16, 18}
+	pdfRow(pdf, chargeWidths, []string{"Date", "Description", "Qty", "Unit Price", "Amount", "Service", "Tax", "Total"}, 2, true)
+	for _, charge := range invoice.Charges {
+		pdfRow(pdf, chargeWidths, []string{
+			charge.ChargeDate,
+			tr(charge.Description),
+			strconv.Itoa(charge.Quantity),
+			formatAmount(charge.UnitPrice),
+			formatAmount(charge.Amount),
+			formatAmount(charge.ServiceCharge),
+			formatAmount(charge.Tax),
+			formatAmount(charge.Total),
+		}, 2, false)
+	}
+	pdf.Ln(2)
+
+	pdfTotal(pdf, "Subtotal", invoice.Subtotal, false)
+	pdfTotal(pdf, fmt.Sprintf("Service Charge (%g%%)", invoice.ServiceChargePercentage), invoice.ServiceCharge, false)
+	pdfTotal(pdf, fmt.Sprintf("Tax (%g%%)", invoice.TaxPercentage), invoice.Tax, false)
+	pdfTotal(pdf, "Total", invoice.Total, true)
+	pdf.Ln(4)
+
+	paymentWidths := []float64{40, 35, 70, 35}
+	pdfRow(pdf, paymentWidths, []string{"Paid At", "Method", "Reference", "Amount"}, 3, true)
+	for _, payment := range invoice.Payments {
+		reference := ""
+		if payment.Reference != nil {
+			reference = *payment.Reference
+		}
+		pdfRow(pdf, paymentWidths, []string{payment.PaidAt, payment.PaymentMethod, tr(reference), formatAmount(payment.Amount)}, 3, false)
+	}
+	pdf.Ln(2)
+
+	pdfTotal(pdf, "Paid", invoice.Paid, false)
+	pdfTotal(pdf, "Balance", invoice.Balance, true)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// pdfRow writes one table row, the columns from firstNumber on are right aligned numbers.
+func pdfRow(pdf *fpdf.Fpdf, widths []float64, cells []string, firstNumber int, header bool) {
+	if header {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(242, 242, 242)
+	} else {
+		pdf.SetFont("Helvetica", "", 9)
+	}
+
+	for i, cell := range cells {
+		align := "L"
+		if i >= firstNumber {
+			align = "R"
+		}
+		pdf.CellFormat(widths[i], 6, cell, "B", 0, align, header, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func pdfTotal(pdf *fpdf.Fpdf, label string, amount float64, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+
+	pdf.SetFont("Helvetica", style, 10)
+	pdf.CellFormat(145, 6, label, "", 0, "R", false, 0, "")
+	pdf.CellFormat(35, 6, formatAmount(amount), "", 1, "R", false, 0, "")
+}
+
+// formatAmount writes the amount with thousands separators and two decimals, e.g. 1,234,567.50
+func formatAmount(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	cents := int64(math.Round(amount * 100))
+	whole := strconv.FormatInt(cents/100, 10)
+
+	var groups []string
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+
+	return fmt.Sprintf("%s%s.%02d", sign, strings.Join(groups, ","), cents%100)
+}
@@ -0,0 +1,11 @@
+package invoice
+
+import (
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=InvoiceService --output=../mocks --outpkg=mocks
+type InvoiceService interface {
+	Generate(registerNumber string, format constant.InvoiceFormat, issuedBy int) (*model.InvoiceDocument, error)
+}
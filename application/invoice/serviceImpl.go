@@ -0,0 +1,104 @@
+package invoice
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/application/folio"
+	"github.com/zakiyalmaya/hotel-management/application/payment"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type invoiceSvcImpl struct {
+	repos      *repository.Repositories
+	cfg        *config.Config
+	folioSvc   folio.FolioService
+	paymentSvc payment.PaymentService
+}
+
+func NewInvoiceServiceImpl(repos *repository.Repositories, cfg *config.Config, folioSvc folio.FolioService, paymentSvc payment.PaymentService) InvoiceService {
+	return &invoiceSvcImpl{repos: repos, cfg: cfg, folioSvc: folioSvc, paymentSvc: paymentSvc}
+}
+
+// Generate renders the invoice of a booking from its folio and payments. The invoice number
+// is issued the first time the booking is invoiced, printing it again shows the same number
+// with the charges and payments as they are now. Only a stay that has started is invoiced,
+// so bookings that never arrive do not use up numbers.
+func (i *invoiceSvcImpl) Generate(registerNumber string, format constant.InvoiceFormat, issuedBy int) (*model.InvoiceDocument, error) {
+	if !format.Validation() {
+		return nil, model.NewValidationError("invalid invoice format")
+	}
+
+	detail, err := i.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if detail.BookingEntity.Status != constant.BookingStatusCheckedIn && detail.BookingEntity.Status != constant.BookingStatusCheckedOut {
+		return nil, model.NewConflictError("can not invoice a %s booking", detail.BookingEntity.Status.Enum())
+	}
+
+	bookingFolio, err := i.folioSvc.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := i.paymentSvc.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &model.InvoiceEntity{
+		RegisterNumber: registerNumber,
+		IssuedBy:       &issuedBy,
+		IssuedAt:       time.Now(),
+	}
+	if err := i.repos.InvoiceRepo.Issue(invoice); err != nil {
+		return nil, err
+	}
+
+	resp := &model.InvoiceResponse{
+		InvoiceNumber:           invoice.InvoiceNumber,
+		IssuedAt:                invoice.IssuedAt.Format("02 January 2006"),
+		HotelName:               i.cfg.Hotel.Name,
+		HotelAddress:            i.cfg.Hotel.Address,
+		HotelPhone:              i.cfg.Hotel.Phone,
+		HotelEmail:              i.cfg.Hotel.Email,
+		RegisterNumber:          bookingFolio.RegisterNumber,
+		GuestName:               bookingFolio.GuestName,
+		RoomName:                bookingFolio.RoomName,
+		RoomType:                detail.RoomEntity.Type,
+		CheckIn:                 bookingFolio.CheckIn,
+		CheckOut:                bookingFolio.CheckOut,
+		Nights:                  int(detail.CheckOut.Sub(detail.CheckIn).Hours() / 24),
+		Charges:                 bookingFolio.Charges,
+		Subtotal:                bookingFolio.Subtotal,
+		ServiceChargePercentage: i.cfg.Folio.ServiceChargePercentage,
+		ServiceCharge:           bookingFolio.ServiceCharge,
+		TaxPercentage:           i.cfg.Folio.TaxPercentage,
+		Tax:                     bookingFolio.Tax,
+		Total:                   bookingFolio.Total,
+		Payments:                payments.Payments,
+		Paid:                    bookingFolio.Paid,
+		Balance:                 bookingFolio.Balance,
+	}
+
+	var content []byte
+	switch format {
+	case constant.InvoiceFormatHTML:
+		content, err = renderHTML(resp)
+	default:
+		content, err = renderPDF(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.InvoiceDocument{
+		InvoiceNumber: invoice.InvoiceNumber,
+		Format:        format,
+		Content:       content,
+	}, nil
+}
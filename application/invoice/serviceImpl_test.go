@@ -0,0 +1,197 @@
+package invoice
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	appMocks "github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockBooking *mocks.BookingRepository
+	mockInvoice *mocks.InvoiceRepository
+	mockFolio   *appMocks.FolioService
+	mockPayment *appMocks.PaymentService
+)
+
+func Test_Generate(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockInvoice = new(mocks.InvoiceRepository)
+	mockFolio = new(appMocks.FolioService)
+	mockPayment = new(appMocks.PaymentService)
+
+	cfg := &config.Config{
+		Folio: config.FolioConfig{ServiceChargePercentage: 10, TaxPercentage: 11},
+		Hotel: config.HotelConfig{Name: "Hotel Management", Address: "Jl. Merdeka No. 1, Jakarta", Phone: "+62 21 1234 5678", Email: "frontdesk@hotel-management.com"},
+	}
+	checkIn := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	reference := "receipt-001"
+
+	detail := &model.BookingDetail{
+		BookingEntity: model.BookingEntity{RegisterNumber: "register_number", RoomName: "101", CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 2), Status: constant.BookingStatusCheckedOut},
+		GuestEntity:   model.GuestEntity{FirstName: "John", LastName: "Doe"},
+		RoomEntity:    model.RoomEntity{Type: "Deluxe"},
+	}
+	bookingFolio := &model.FolioResponse{
+		RegisterNumber: "register_number",
+		GuestName:      "John Doe",
+		RoomName:       "101",
+		CheckIn:        "01 March 2000",
+		CheckOut:       "03 March 2000",
+		Charges: []*model.FolioChargeResponse{
+			{ID: 1, ChargeType: "Room", Description: "Room 101", ChargeDate: "01 March 2000", Quantity: 1, UnitPrice: 500000, Amount: 500000, ServiceCharge: 50000, Tax: 60500, Total: 610500, Balance: 610500},
+			{ID: 2, ChargeType: "Room", Description: "Room 101", ChargeDate: "02 March 2000", Quantity: 1, UnitPrice: 500000, Amount: 500000, ServiceCharge: 50000, Tax: 60500, Total: 610500, Balance: 1221000},
+		},
+		Subtotal:      1000000,
+		ServiceCharge: 100000,
+		Tax:           121000,
+		Total:         1221000,
+		Paid:          1000000,
+		Balance:       221000,
+	}
+	payments := &model.PaymentSummaryResponse{
+		RegisterNumber: "register_number",
+		Payments: []*model.PaymentResponse{
+			{ID: 1, Amount: 1000000, PaymentMethod: "Cash", Reference: &reference, PaidAt: "01-03-2000 10:00:00"},
+		},
+	}
+	issue := func(invoice *model.InvoiceEntity) bool {
+		return invoice.RegisterNumber == "register_number" && *invoice.IssuedBy == 1
+	}
+	setNumber := func(args mock.Arguments) {
+		args.Get(0).(*model.InvoiceEntity).InvoiceNumber = "INV-000001"
+	}
+
+	testCases := []struct {
+		name     string
+		format   constant.InvoiceFormat
+		mockCall func()
+		contains [][]byte
+		err      error
+	}{
+		{
+			name:   "Given booking when generate pdf invoice then return pdf document",
+			format: constant.InvoiceFormatPDF,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(detail, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(bookingFolio, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(payments, nil).Once()
+				mockInvoice.On("Issue", mock.MatchedBy(issue)).Run(setNumber).Return(nil).Once()
+			},
+			contains: [][]byte{[]byte("%PDF-")},
+			err:      nil,
+		},
+		{
+			name:   "Given booking when generate html invoice then return hotel, guest, charges, taxes, payments and balance",
+			format: constant.InvoiceFormatHTML,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(detail, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(bookingFolio, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(payments, nil).Once()
+				mockInvoice.On("Issue", mock.MatchedBy(issue)).Run(setNumber).Return(nil).Once()
+			},
+			contains: [][]byte{
+				[]byte("Hotel Management"),
+				[]byte("No. INV-000001"),
+				[]byte("John Doe"),
+				[]byte("101 (Deluxe)"),
+				[]byte("<td>2</td>"),
+				[]byte("Tax (11%)"),
+				[]byte("1,221,000.00"),
+				[]byte("receipt-001"),
+				[]byte("221,000.00"),
+			},
+			err: nil,
+		},
+		{
+			name:   "Given confirmed booking when generate invoice then return conflict error without issuing a number",
+			format: constant.InvoiceFormatPDF,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusConfirmed},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("can not invoice a Confirmed booking"),
+		},
+		{
+			name:     "Given unknown format when generate invoice then return validation error",
+			format:   constant.InvoiceFormat("docx"),
+			mockCall: func() {},
+			err:      model.NewValidationError("invalid invoice format"),
+		},
+		{
+			name:   "Given error issue invoice number when generate invoice then return error",
+			format: constant.InvoiceFormatPDF,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(detail, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(bookingFolio, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(payments, nil).Once()
+				mockInvoice.On("Issue", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:   "Given error get payments when generate invoice then return error",
+			format: constant.InvoiceFormatPDF,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(detail, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(bookingFolio, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:   "Given error get folio when generate invoice then return error",
+			format: constant.InvoiceFormatHTML,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(detail, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:   "Given error get reservation data when generate invoice then return error",
+			format: constant.InvoiceFormatHTML,
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewInvoiceServiceImpl(&repository.Repositories{BookingRepo: mockBooking, InvoiceRepo: mockInvoice}, cfg, mockFolio, mockPayment)
+			document, err := service.Generate("register_number", tc.format, 1)
+			assert.Equal(t, tc.err, err)
+			if tc.err != nil {
+				assert.Nil(t, document)
+				return
+			}
+
+			assert.Equal(t, "INV-000001", document.InvoiceNumber)
+			assert.Equal(t, tc.format, document.Format)
+			for _, content := range tc.contains {
+				assert.True(t, bytes.Contains(document.Content, content), "missing %s", content)
+			}
+		})
+	}
+}
+
+func Test_formatAmount(t *testing.T) {
+	assert.Equal(t, "0.00", formatAmount(0))
+	assert.Equal(t, "999.50", formatAmount(999.5))
+	assert.Equal(t, "1,221,000.00", formatAmount(1221000))
+	assert.Equal(t, "-100,000.25", formatAmount(-100000.25))
+}
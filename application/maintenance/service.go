@@ -0,0 +1,12 @@
+package maintenance
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=MaintenanceService --output=../mocks --outpkg=mocks
+type MaintenanceService interface {
+	Create(order *model.MaintenanceOrderEntity) (*model.MaintenanceOrderResponse, error)
+	GetByID(id int) (*model.MaintenanceOrderResponse, error)
+	GetAll(request *model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderResponse, error)
+	Update(order *model.MaintenanceOrderEntity) error
+	Close(id int, userID int, request *model.CloseMaintenanceOrderRequest) error
+}
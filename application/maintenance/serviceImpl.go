@@ -0,0 +1,171 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type maintenanceSvcImpl struct {
+	repos *repository.Repositories
+}
+
+func NewMaintenanceServiceImpl(repos *repository.Repositories) MaintenanceService {
+	return &maintenanceSvcImpl{repos: repos}
+}
+
+func (m *maintenanceSvcImpl) Create(order *model.MaintenanceOrderEntity) (*model.MaintenanceOrderResponse, error) {
+	room, err := m.repos.RoomRepo.GetByName(order.RoomName)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = constant.MaintenanceStatusOpen
+	if err := m.repos.MaintenanceRepo.Create(order); err != nil {
+		return nil, err
+	}
+
+	// Work starting today takes the room out of service right away; an occupied room keeps
+	// its guest and future orders only block their dates until they start
+	if !order.StartDate.After(startOfDay(time.Now())) && room.Status != constant.RoomStatusOccupied {
+		if err := m.repos.RoomRepo.Update(&model.RoomEntity{
+			Status: constant.RoomStatusMaintenance,
+			Name:   order.RoomName,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return newMaintenanceOrderResponse(order), nil
+}
+
+func (m *maintenanceSvcImpl) GetByID(id int) (*model.MaintenanceOrderResponse, error) {
+	order, err := m.repos.MaintenanceRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMaintenanceOrderResponse(order), nil
+}
+
+func (m *maintenanceSvcImpl) GetAll(request *model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderResponse, error) {
+	orders, err := m.repos.MaintenanceRepo.GetAll(request)
+	if err != nil {
+		return nil, err
+	}
+
+	orderResponses := make([]*model.MaintenanceOrderResponse, len(orders))
+	for i, order := range orders {
+		orderResponses[i] = newMaintenanceOrderResponse(order)
+	}
+
+	return orderResponses, nil
+}
+
+func (m *maintenanceSvcImpl) Update(order *model.MaintenanceOrderEntity) error {
+	detail, err := m.repos.MaintenanceRepo.GetByID(order.ID)
+	if err != nil {
+		return err
+	}
+
+	if detail.Status != constant.MaintenanceStatusOpen {
+		return model.NewConflictError("maintenance order is already %s", detail.Status.Enum())
+	}
+
+	if !order.ExpectedCompletion.IsZero() && !order.ExpectedCompletion.After(detail.StartDate) {
+		return model.NewValidationError("expected completion date must be greater than start date")
+	}
+
+	if err := m.repos.MaintenanceRepo.Update(order); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *maintenanceSvcImpl) Close(id int, userID int, request *model.CloseMaintenanceOrderRequest) error {
+	order, err := m.repos.MaintenanceRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != constant.MaintenanceStatusOpen {
+		return model.NewConflictError("maintenance order is already %s", order.Status.Enum())
+	}
+
+	now := time.Now()
+	if err := m.repos.MaintenanceRepo.Close(&model.MaintenanceOrderEntity{
+		ID:         id,
+		Status:     constant.MaintenanceStatusClosed,
+		Resolution: &request.Resolution,
+		ClosedBy:   &userID,
+		ClosedAt:   &now,
+	}); err != nil {
+		return err
+	}
+
+	// Another order may still keep the room out of service
+	active, err := m.repos.MaintenanceRepo.CountActive(order.RoomName, startOfDay(now))
+	if err != nil {
+		return err
+	}
+
+	if active > 0 {
+		return nil
+	}
+
+	room, err := m.repos.RoomRepo.GetByName(order.RoomName)
+	if err != nil {
+		return err
+	}
+
+	if room.Status == constant.RoomStatusMaintenance {
+		if err := m.repos.RoomRepo.Update(&model.RoomEntity{
+			Status: constant.RoomStatusAvailable,
+			Name:   order.RoomName,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newMaintenanceOrderResponse(order *model.MaintenanceOrderEntity) *model.MaintenanceOrderResponse {
+	response := &model.MaintenanceOrderResponse{
+		ID:                 order.ID,
+		RoomName:           order.RoomName,
+		Issue:              order.Issue,
+		Priority:           order.Priority.Enum(),
+		Status:             order.Status.Enum(),
+		ReportedBy:         order.ReportedBy,
+		AssignedTo:         order.AssignedTo,
+		StartDate:          order.StartDate.Format("02 January 2006"),
+		ExpectedCompletion: order.ExpectedCompletion.Format("02 January 2006"),
+		Resolution:         order.Resolution,
+		ClosedBy:           order.ClosedBy,
+		ClosedAt:           formatTimestamp(order.ClosedAt),
+	}
+
+	if order.CreatedAt != nil {
+		response.CreatedAt = order.CreatedAt.Format("02-01-2006 15:04:05")
+	}
+
+	return response
+}
+
+// startOfDay returns the calendar date of t at midnight UTC, the way order dates are stored.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format("02-01-2006 15:04:05")
+	return &formatted
+}
@@ -0,0 +1,216 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockMaintenance *mocks.MaintenanceRepository
+	mockRoom        *mocks.RoomRepository
+)
+
+func Test_Create(t *testing.T) {
+	mockMaintenance = new(mocks.MaintenanceRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	today := startOfDay(time.Now())
+
+	testCases := []struct {
+		name     string
+		order    *model.MaintenanceOrderEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given order starting today when create maintenance order then take the room out of service",
+			order: &model.MaintenanceOrderEntity{
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           constant.MaintenancePriorityHigh,
+				StartDate:          today,
+				ExpectedCompletion: today.AddDate(0, 0, 2),
+			},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Status: constant.RoomStatusAvailable}, nil).Once()
+				mockMaintenance.On("Create", mock.Anything).Return(nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusMaintenance}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given order scheduled ahead when create maintenance order then keep the room status",
+			order: &model.MaintenanceOrderEntity{
+				RoomName:           "101",
+				Issue:              "repaint walls",
+				Priority:           constant.MaintenancePriorityLow,
+				StartDate:          today.AddDate(0, 0, 10),
+				ExpectedCompletion: today.AddDate(0, 0, 12),
+			},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Status: constant.RoomStatusAvailable}, nil).Once()
+				mockMaintenance.On("Create", mock.Anything).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given occupied room when create maintenance order then keep the guest in the room",
+			order: &model.MaintenanceOrderEntity{
+				RoomName:           "101",
+				Issue:              "broken lamp",
+				Priority:           constant.MaintenancePriorityMedium,
+				StartDate:          today,
+				ExpectedCompletion: today.AddDate(0, 0, 1),
+			},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Status: constant.RoomStatusOccupied}, nil).Once()
+				mockMaintenance.On("Create", mock.Anything).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unknown room when create maintenance order then return error",
+			order: &model.MaintenanceOrderEntity{
+				RoomName: "999",
+			},
+			mockCall: func() {
+				mockRoom.On("GetByName", "999").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewMaintenanceServiceImpl(&repository.Repositories{MaintenanceRepo: mockMaintenance, RoomRepo: mockRoom})
+			_, err := service.Create(tc.order)
+			assert.Equal(t, tc.err, err)
+			mockRoom.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	mockMaintenance = new(mocks.MaintenanceRepository)
+
+	startDate := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	assignee := 2
+
+	testCases := []struct {
+		name     string
+		order    *model.MaintenanceOrderEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:  "Given open order when update maintenance order then return success",
+			order: &model.MaintenanceOrderEntity{ID: 1, AssignedTo: &assignee},
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(&model.MaintenanceOrderEntity{ID: 1, Status: constant.MaintenanceStatusOpen, StartDate: startDate}, nil).Once()
+				mockMaintenance.On("Update", &model.MaintenanceOrderEntity{ID: 1, AssignedTo: &assignee}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:  "Given closed order when update maintenance order then return conflict error",
+			order: &model.MaintenanceOrderEntity{ID: 1, AssignedTo: &assignee},
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(&model.MaintenanceOrderEntity{ID: 1, Status: constant.MaintenanceStatusClosed, StartDate: startDate}, nil).Once()
+			},
+			err: model.NewConflictError("maintenance order is already Closed"),
+		},
+		{
+			name:  "Given expected completion before start date when update maintenance order then return error",
+			order: &model.MaintenanceOrderEntity{ID: 1, ExpectedCompletion: startDate},
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(&model.MaintenanceOrderEntity{ID: 1, Status: constant.MaintenanceStatusOpen, StartDate: startDate}, nil).Once()
+			},
+			err: model.NewValidationError("expected completion date must be greater than start date"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewMaintenanceServiceImpl(&repository.Repositories{MaintenanceRepo: mockMaintenance})
+			err := service.Update(tc.order)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Close(t *testing.T) {
+	mockMaintenance = new(mocks.MaintenanceRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	today := startOfDay(time.Now())
+	request := &model.CloseMaintenanceOrderRequest{Resolution: "replaced the valve"}
+	open := &model.MaintenanceOrderEntity{ID: 1, RoomName: "101", Status: constant.MaintenanceStatusOpen}
+	matchClose := mock.MatchedBy(func(order *model.MaintenanceOrderEntity) bool {
+		return order.ID == 1 && order.Status == constant.MaintenanceStatusClosed && *order.Resolution == "replaced the valve" && *order.ClosedBy == 1 && order.ClosedAt != nil
+	})
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given last open order when close maintenance order then release the room",
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(open, nil).Once()
+				mockMaintenance.On("Close", matchClose).Return(nil).Once()
+				mockMaintenance.On("CountActive", "101", today).Return(0, nil).Once()
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Status: constant.RoomStatusMaintenance}, nil).Once()
+				mockRoom.On("Update", &model.RoomEntity{Name: "101", Status: constant.RoomStatusAvailable}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given another active order when close maintenance order then keep the room out of service",
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(open, nil).Once()
+				mockMaintenance.On("Close", matchClose).Return(nil).Once()
+				mockMaintenance.On("CountActive", "101", today).Return(1, nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given closed order when close maintenance order then return conflict error",
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(&model.MaintenanceOrderEntity{ID: 1, RoomName: "101", Status: constant.MaintenanceStatusClosed}, nil).Once()
+			},
+			err: model.NewConflictError("maintenance order is already Closed"),
+		},
+		{
+			name: "Given error close when close maintenance order then return error",
+			mockCall: func() {
+				mockMaintenance.On("GetByID", 1).Return(open, nil).Once()
+				mockMaintenance.On("Close", matchClose).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewMaintenanceServiceImpl(&repository.Repositories{MaintenanceRepo: mockMaintenance, RoomRepo: mockRoom})
+			err := service.Close(1, 1, request)
+			assert.Equal(t, tc.err, err)
+			mockRoom.AssertExpectations(t)
+		})
+	}
+}
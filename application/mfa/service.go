@@ -0,0 +1,19 @@
+package mfa
+
+import (
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=MfaService --output=../mocks --outpkg=mocks
+type MfaService interface {
+	Enroll(userID int) (*model.MfaEnrollmentResponse, error)
+	Activate(userID int, code string) (*model.RecoveryCodesResponse, error)
+	Verify(user *model.UserEntity, code, recoveryCode string) error
+	Disable(userID int, code string) error
+	RegenerateRecoveryCodes(userID int, code string) (*model.RecoveryCodesResponse, error)
+	Reset(userID int) error
+	IsRequired(role constant.Role) (bool, error)
+	GetPolicies() ([]*model.MfaPolicyResponse, error)
+	UpdatePolicy(role constant.Role, required bool, updatedBy int) error
+}
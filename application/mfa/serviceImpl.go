@@ -0,0 +1,280 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+// recoveryCodeCount is how many one time recovery codes a hotelier gets, each
+// code logs in once when the authenticator app is not at hand.
+const recoveryCodeCount = 10
+
+type mfaSvcImpl struct {
+	repos *repository.Repositories
+	cfg   *config.Config
+}
+
+func NewMfaServiceImpl(repos *repository.Repositories, cfg *config.Config) MfaService {
+	return &mfaSvcImpl{
+		repos: repos,
+		cfg:   cfg,
+	}
+}
+
+// Enroll gives the hotelier a new secret to add to an authenticator app. The secret
+// is pending and two-factor authentication stays off until a code of it is activated.
+func (m *mfaSvcImpl) Enroll(userID int) (*model.MfaEnrollmentResponse, error) {
+	user, err := m.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, model.NewConflictError("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create totp secret")
+	}
+
+	user.TOTPSecret = &secret
+	user.TOTPLastStep = 0
+	if err := m.repos.UserRepo.UpdateTOTP(user, nil); err != nil {
+		return nil, err
+	}
+
+	return &model.MfaEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(m.cfg.Hotel.Name, user.Username, secret),
+	}, nil
+}
+
+// Activate turns two-factor authentication on once the hotelier sends a code of the
+// pending secret, which proves the app was set up. The recovery codes are only shown here.
+func (m *mfaSvcImpl) Activate(userID int, code string) (*model.RecoveryCodesResponse, error) {
+	user, err := m.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, model.NewConflictError("two-factor authentication is already enabled")
+	}
+
+	if user.TOTPSecret == nil {
+		return nil, model.NewConflictError("enroll an authenticator app first")
+	}
+
+	step, ok := utils.ValidateTOTP(*user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, model.NewValidationError("invalid code")
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	return m.saveRecoveryCodes(user)
+}
+
+// Verify checks the second factor of a hotelier, either a code of the authenticator app
+// or an unused recovery code. Codes are used up so a seen code can not be replayed.
+func (m *mfaSvcImpl) Verify(user *model.UserEntity, code, recoveryCode string) error {
+	if !user.TOTPEnabled || user.TOTPSecret == nil {
+		return model.NewValidationError("invalid code")
+	}
+
+	if code == "" {
+		used, err := m.repos.UserRepo.UseRecoveryCode(user.ID, utils.HashToken(normalizeRecoveryCode(recoveryCode)))
+		if err != nil {
+			return err
+		}
+
+		if !used {
+			return model.NewValidationError("invalid code")
+		}
+
+		return nil
+	}
+
+	step, ok := utils.ValidateTOTP(*user.TOTPSecret, code, time.Now())
+	if !ok {
+		return model.NewValidationError("invalid code")
+	}
+
+	used, err := m.repos.UserRepo.UseTOTPStep(user.ID, step)
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		return model.NewValidationError("invalid code")
+	}
+
+	return nil
+}
+
+// Disable turns two-factor authentication off, unless the role of the hotelier requires it.
+func (m *mfaSvcImpl) Disable(userID int, code string) error {
+	user, err := m.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return model.NewConflictError("two-factor authentication is not enabled")
+	}
+
+	required, err := m.IsRequired(user.Role)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		return model.NewConflictError("two-factor authentication is required for your role")
+	}
+
+	if err := m.Verify(user, code, ""); err != nil {
+		return err
+	}
+
+	return m.clear(user)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes, the old ones stop working.
+func (m *mfaSvcImpl) RegenerateRecoveryCodes(userID int, code string) (*model.RecoveryCodesResponse, error) {
+	user, err := m.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, model.NewConflictError("two-factor authentication is not enabled")
+	}
+
+	if err := m.Verify(user, code, ""); err != nil {
+		return nil, err
+	}
+
+	// read the user again so the step Verify used up is not rolled back
+	updated, err := m.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.saveRecoveryCodes(updated)
+}
+
+// Reset turns two-factor authentication off for a hotelier who lost both the app and the
+// recovery codes. When the role requires it they enrol again on their next login.
+func (m *mfaSvcImpl) Reset(userID int) error {
+	user, err := m.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	return m.clear(user)
+}
+
+// IsRequired tells whether hoteliers with the role must use two-factor authentication,
+// roles without a policy do not.
+func (m *mfaSvcImpl) IsRequired(role constant.Role) (bool, error) {
+	policy, err := m.repos.MfaPolicyRepo.GetByRole(role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return policy.Required, nil
+}
+
+// GetPolicies lists every role and whether it requires two-factor authentication.
+func (m *mfaSvcImpl) GetPolicies() ([]*model.MfaPolicyResponse, error) {
+	policies, err := m.repos.MfaPolicyRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[constant.Role]bool, len(policies))
+	for _, policy := range policies {
+		required[policy.Role] = policy.Required
+	}
+
+	responses := make([]*model.MfaPolicyResponse, 0)
+	for role := constant.RoleAdmin; role.Validation(); role++ {
+		responses = append(responses, &model.MfaPolicyResponse{
+			Role:     role.Enum(),
+			Required: required[role],
+		})
+	}
+
+	return responses, nil
+}
+
+func (m *mfaSvcImpl) UpdatePolicy(role constant.Role, required bool, updatedBy int) error {
+	return m.repos.MfaPolicyRepo.Upsert(&model.MfaPolicyEntity{
+		Role:      role,
+		Required:  required,
+		UpdatedBy: &updatedBy,
+	})
+}
+
+func (m *mfaSvcImpl) getUser(userID int) (*model.UserEntity, error) {
+	user, err := m.repos.UserRepo.GetByID(userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewNotFoundError("user not found")
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (m *mfaSvcImpl) clear(user *model.UserEntity) error {
+	user.TOTPSecret = nil
+	user.TOTPEnabled = false
+	user.TOTPLastStep = 0
+	return m.repos.UserRepo.UpdateTOTP(user, nil)
+}
+
+// saveRecoveryCodes stores the user with a new set of recovery codes. Only their
+// hashes are kept, the codes themselves are returned to be shown once.
+func (m *mfaSvcImpl) saveRecoveryCodes(user *model.UserEntity) (*model.RecoveryCodesResponse, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to create recovery code")
+		}
+
+		code := hex.EncodeToString(b)
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, utils.HashToken(code))
+	}
+
+	if err := m.repos.UserRepo.UpdateTOTP(user, hashes); err != nil {
+		return nil, err
+	}
+
+	return &model.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// normalizeRecoveryCode accepts a recovery code typed with or without the dash and in any case.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,299 @@
+package mfa
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var (
+	mockUser      *mocks.UserRepository
+	mockMfaPolicy *mocks.MfaPolicyRepository
+	mockConfig    = &config.Config{Hotel: config.HotelConfig{Name: "Hotel Management"}}
+)
+
+func newService() MfaService {
+	return NewMfaServiceImpl(&repository.Repositories{UserRepo: mockUser, MfaPolicyRepo: mockMfaPolicy}, mockConfig)
+}
+
+func enrolled() *model.UserEntity {
+	totpSecret := secret
+	return &model.UserEntity{ID: 1, Username: "janedoe", Role: constant.RoleFrontDesk, TOTPSecret: &totpSecret, TOTPEnabled: true}
+}
+
+func currentCode() string {
+	code, _ := utils.TOTPCode(secret, time.Now())
+	return code
+}
+
+func Test_Enroll(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given hotelier without two-factor authentication when enroll then store a pending secret",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1, Username: "janedoe"}, nil).Once()
+				mockUser.On("UpdateTOTP", mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.TOTPSecret != nil && !user.TOTPEnabled
+				}), []string(nil)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given hotelier with two-factor authentication when enroll then return conflict",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(enrolled(), nil).Once()
+			},
+			err: model.NewConflictError("two-factor authentication is already enabled"),
+		},
+		{
+			name: "Given unknown hotelier when enroll then return not found",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("user not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, err := newService().Enroll(1)
+			assert.Equal(t, tc.err, err)
+			if err == nil {
+				assert.Equal(t, "otpauth://totp/Hotel%20Management:janedoe?algorithm=SHA1&digits=6&issuer=Hotel+Management&period=30&secret="+result.Secret, result.ProvisioningURI)
+			}
+		})
+	}
+}
+
+func Test_Activate(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	pending := func() *model.UserEntity {
+		user := enrolled()
+		user.TOTPEnabled = false
+		return user
+	}
+
+	testCases := []struct {
+		name     string
+		code     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given code of the pending secret when activate then enable and store recovery codes",
+			code: currentCode(),
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(pending(), nil).Once()
+				mockUser.On("UpdateTOTP", mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.TOTPEnabled && user.TOTPLastStep > 0
+				}), mock.MatchedBy(func(hashes []string) bool {
+					return len(hashes) == 10
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given wrong code when activate then return validation error",
+			code: "000000",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(pending(), nil).Once()
+			},
+			err: model.NewValidationError("invalid code"),
+		},
+		{
+			name: "Given no pending secret when activate then return conflict",
+			code: currentCode(),
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1}, nil).Once()
+			},
+			err: model.NewConflictError("enroll an authenticator app first"),
+		},
+		{
+			name: "Given enabled two-factor authentication when activate then return conflict",
+			code: currentCode(),
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(enrolled(), nil).Once()
+			},
+			err: model.NewConflictError("two-factor authentication is already enabled"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, err := newService().Activate(1, tc.code)
+			assert.Equal(t, tc.err, err)
+			if err == nil {
+				assert.Equal(t, 10, len(result.RecoveryCodes))
+			}
+		})
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+
+	testCases := []struct {
+		name         string
+		code         string
+		recoveryCode string
+		mockCall     func()
+		err          error
+	}{
+		{
+			name: "Given current code when verify then use up its step",
+			code: currentCode(),
+			mockCall: func() {
+				mockUser.On("UseTOTPStep", 1, mock.Anything).Return(true, nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given replayed code when verify then return validation error",
+			code: currentCode(),
+			mockCall: func() {
+				mockUser.On("UseTOTPStep", 1, mock.Anything).Return(false, nil).Once()
+			},
+			err: model.NewValidationError("invalid code"),
+		},
+		{
+			name:     "Given wrong code when verify then return validation error",
+			code:     "000000",
+			mockCall: func() {},
+			err:      model.NewValidationError("invalid code"),
+		},
+		{
+			name:         "Given unused recovery code when verify then use it up",
+			recoveryCode: "ABCDE-12345",
+			mockCall: func() {
+				mockUser.On("UseRecoveryCode", 1, utils.HashToken("abcde12345")).Return(true, nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:         "Given used recovery code when verify then return validation error",
+			recoveryCode: "abcde-12345",
+			mockCall: func() {
+				mockUser.On("UseRecoveryCode", 1, utils.HashToken("abcde12345")).Return(false, nil).Once()
+			},
+			err: model.NewValidationError("invalid code"),
+		},
+		{
+			name:         "Given error when verify then return error response",
+			recoveryCode: "abcde-12345",
+			mockCall: func() {
+				mockUser.On("UseRecoveryCode", 1, mock.Anything).Return(false, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := newService().Verify(enrolled(), tc.code, tc.recoveryCode)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Disable(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockMfaPolicy = new(mocks.MfaPolicyRepository)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given role without policy when disable then clear the secret",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(enrolled(), nil).Once()
+				mockMfaPolicy.On("GetByRole", constant.RoleFrontDesk).Return(nil, sql.ErrNoRows).Once()
+				mockUser.On("UseTOTPStep", 1, mock.Anything).Return(true, nil).Once()
+				mockUser.On("UpdateTOTP", mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.TOTPSecret == nil && !user.TOTPEnabled
+				}), []string(nil)).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given role requiring two-factor authentication when disable then return conflict",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(enrolled(), nil).Once()
+				mockMfaPolicy.On("GetByRole", constant.RoleFrontDesk).Return(&model.MfaPolicyEntity{Role: constant.RoleFrontDesk, Required: true}, nil).Once()
+			},
+			err: model.NewConflictError("two-factor authentication is required for your role"),
+		},
+		{
+			name: "Given disabled two-factor authentication when disable then return conflict",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1}, nil).Once()
+			},
+			err: model.NewConflictError("two-factor authentication is not enabled"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := newService().Disable(1, currentCode())
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_RegenerateRecoveryCodes(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+
+	mockUser.On("GetByID", 1).Return(enrolled(), nil).Twice()
+	mockUser.On("UseTOTPStep", 1, mock.Anything).Return(true, nil).Once()
+	mockUser.On("UpdateTOTP", mock.MatchedBy(func(user *model.UserEntity) bool {
+		return user.TOTPEnabled
+	}), mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == 10
+	})).Return(nil).Once()
+
+	result, err := newService().RegenerateRecoveryCodes(1, currentCode())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 10, len(result.RecoveryCodes))
+}
+
+func Test_GetPolicies(t *testing.T) {
+	mockMfaPolicy = new(mocks.MfaPolicyRepository)
+	mockMfaPolicy.On("GetAll").Return([]*model.MfaPolicyEntity{{Role: constant.RoleAdmin, Required: true}, {Role: constant.RoleAccountant, Required: true}}, nil).Once()
+
+	result, err := newService().GetPolicies()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []*model.MfaPolicyResponse{
+		{Role: "Admin", Required: true},
+		{Role: "Front Desk", Required: false},
+		{Role: "Housekeeping", Required: false},
+		{Role: "Accountant", Required: true},
+		{Role: "Read Only", Required: false},
+	}, result)
+}
@@ -12,6 +12,56 @@ type AuthService struct {
 	mock.Mock
 }
 
+// GetSessions provides a mock function with given fields: userID, currentSessionID
+func (_m *AuthService) GetSessions(userID int, currentSessionID string) ([]*model.SessionResponse, error) {
+	ret := _m.Called(userID, currentSessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []*model.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) ([]*model.SessionResponse, error)); ok {
+		return rf(userID, currentSessionID)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) []*model.SessionResponse); ok {
+		r0 = rf(userID, currentSessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SessionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userID, currentSessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with given fields:
+func (_m *AuthService) JWKS() *model.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 *model.JWKS
+	if rf, ok := ret.Get(0).(func() *model.JWKS); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.JWKS)
+		}
+	}
+
+	return r0
+}
+
 // Login provides a mock function with given fields: request
 func (_m *AuthService) Login(request *model.AuthRequest) (*model.AuthResponse, error) {
 	ret := _m.Called(request)
@@ -42,9 +92,9 @@ func (_m *AuthService) Login(request *model.AuthRequest) (*model.AuthResponse, e
 	return r0, r1
 }
 
-// Logout provides a mock function with given fields: username
-func (_m *AuthService) Logout(username string) error {
-	ret := _m.Called(username)
+// Logout provides a mock function with given fields: sessionID
+func (_m *AuthService) Logout(sessionID string) error {
+	ret := _m.Called(sessionID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Logout")
@@ -52,7 +102,7 @@ func (_m *AuthService) Logout(username string) error {
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(username)
+		r0 = rf(sessionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,9 +110,9 @@ func (_m *AuthService) Logout(username string) error {
 	return r0
 }
 
-// RefreshAuthToken provides a mock function with given fields: username
-func (_m *AuthService) RefreshAuthToken(username string) (*model.AuthResponse, error) {
-	ret := _m.Called(username)
+// RefreshAuthToken provides a mock function with given fields: request
+func (_m *AuthService) RefreshAuthToken(request *model.RefreshRequest) (*model.AuthResponse, error) {
+	ret := _m.Called(request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshAuthToken")
@@ -70,19 +120,67 @@ func (_m *AuthService) RefreshAuthToken(username string) (*model.AuthResponse, e
 
 	var r0 *model.AuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*model.AuthResponse, error)); ok {
-		return rf(username)
+	if rf, ok := ret.Get(0).(func(*model.RefreshRequest) (*model.AuthResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.RefreshRequest) *model.AuthResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.RefreshRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: userID, sessionID
+func (_m *AuthService) RevokeSession(userID int, sessionID string) error {
+	ret := _m.Called(userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyMfa provides a mock function with given fields: request
+func (_m *AuthService) VerifyMfa(request *model.MfaLoginRequest) (*model.AuthResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMfa")
+	}
+
+	var r0 *model.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.MfaLoginRequest) (*model.AuthResponse, error)); ok {
+		return rf(request)
 	}
-	if rf, ok := ret.Get(0).(func(string) *model.AuthResponse); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(*model.MfaLoginRequest) *model.AuthResponse); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.AuthResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(*model.MfaLoginRequest) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// AvailabilityService is an autogenerated mock type for the AvailabilityService type
+type AvailabilityService struct {
+	mock.Mock
+}
+
+// IsAvailable provides a mock function with given fields: request
+func (_m *AvailabilityService) IsAvailable(request *model.AvailabilityRequest) (bool, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsAvailable")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.AvailabilityRequest) (bool, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.AvailabilityRequest) bool); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.AvailabilityRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAvailabilityService creates a new instance of AvailabilityService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAvailabilityService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AvailabilityService {
+	mock := &AvailabilityService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
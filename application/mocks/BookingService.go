@@ -30,6 +30,102 @@ func (_m *BookingService) Books(_a0 *model.BookingEntity) error {
 	return r0
 }
 
+// Cancel provides a mock function with given fields: registerNumber
+func (_m *BookingService) Cancel(registerNumber string) (*model.CancelBookingResponse, error) {
+	ret := _m.Called(registerNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *model.CancelBookingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.CancelBookingResponse, error)); ok {
+		return rf(registerNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.CancelBookingResponse); ok {
+		r0 = rf(registerNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CancelBookingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(registerNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckIn provides a mock function with given fields: registerNumber, userID
+func (_m *BookingService) CheckIn(registerNumber string, userID int) error {
+	ret := _m.Called(registerNumber, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(registerNumber, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckOut provides a mock function with given fields: registerNumber, userID
+func (_m *BookingService) CheckOut(registerNumber string, userID int) error {
+	ret := _m.Called(registerNumber, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckOut")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int) error); ok {
+		r0 = rf(registerNumber, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByGuestID provides a mock function with given fields: guestID
+func (_m *BookingService) GetByGuestID(guestID int) ([]*model.BookingResponse, error) {
+	ret := _m.Called(guestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByGuestID")
+	}
+
+	var r0 []*model.BookingResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*model.BookingResponse, error)); ok {
+		return rf(guestID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*model.BookingResponse); ok {
+		r0 = rf(guestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BookingResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(guestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByRegisterNumber provides a mock function with given fields: registerNumber
 func (_m *BookingService) GetByRegisterNumber(registerNumber string) (*model.BookingResponse, error) {
 	ret := _m.Called(registerNumber)
@@ -60,17 +156,17 @@ func (_m *BookingService) GetByRegisterNumber(registerNumber string) (*model.Boo
 	return r0, r1
 }
 
-// Reschedule provides a mock function with given fields: _a0
-func (_m *BookingService) Reschedule(_a0 *model.BookingEntity) error {
-	ret := _m.Called(_a0)
+// NoShow provides a mock function with given fields: registerNumber
+func (_m *BookingService) NoShow(registerNumber string) error {
+	ret := _m.Called(registerNumber)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Reschedule")
+		panic("no return value specified for NoShow")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*model.BookingEntity) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(registerNumber)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -78,12 +174,12 @@ func (_m *BookingService) Reschedule(_a0 *model.BookingEntity) error {
 	return r0
 }
 
-// UpdatePayment provides a mock function with given fields: _a0
-func (_m *BookingService) UpdatePayment(_a0 *model.BookingEntity) error {
+// Reschedule provides a mock function with given fields: _a0
+func (_m *BookingService) Reschedule(_a0 *model.BookingEntity) error {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePayment")
+		panic("no return value specified for Reschedule")
 	}
 
 	var r0 error
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// FolioService is an autogenerated mock type for the FolioService type
+type FolioService struct {
+	mock.Mock
+}
+
+// GetByRegisterNumber provides a mock function with given fields: registerNumber
+func (_m *FolioService) GetByRegisterNumber(registerNumber string) (*model.FolioResponse, error) {
+	ret := _m.Called(registerNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRegisterNumber")
+	}
+
+	var r0 *model.FolioResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.FolioResponse, error)); ok {
+		return rf(registerNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.FolioResponse); ok {
+		r0 = rf(registerNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FolioResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(registerNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostCharge provides a mock function with given fields: charge
+func (_m *FolioService) PostCharge(charge *model.FolioChargeEntity) error {
+	ret := _m.Called(charge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostCharge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.FolioChargeEntity) error); ok {
+		r0 = rf(charge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFolioService creates a new instance of FolioService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFolioService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FolioService {
+	mock := &FolioService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	constant "github.com/zakiyalmaya/hotel-management/constant"
+
 	mock "github.com/stretchr/testify/mock"
+
 	model "github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -12,6 +15,54 @@ type GuestService struct {
 	mock.Mock
 }
 
+// Anonymize provides a mock function with given fields: id, userID
+func (_m *GuestService) Anonymize(id int, userID int) error {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AnonymizeInactive provides a mock function with given fields:
+func (_m *GuestService) AnonymizeInactive() (*model.AnonymizeInactiveResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymizeInactive")
+	}
+
+	var r0 *model.AnonymizeInactiveResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*model.AnonymizeInactiveResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *model.AnonymizeInactiveResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AnonymizeInactiveResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: _a0
 func (_m *GuestService) Create(_a0 *model.GuestEntity) error {
 	ret := _m.Called(_a0)
@@ -30,6 +81,82 @@ func (_m *GuestService) Create(_a0 *model.GuestEntity) error {
 	return r0
 }
 
+// DetectDuplicates provides a mock function with given fields:
+func (_m *GuestService) DetectDuplicates() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetectDuplicates")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DismissDuplicate provides a mock function with given fields: id, userID
+func (_m *GuestService) DismissDuplicate(id int, userID int) error {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DismissDuplicate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: id, format
+func (_m *GuestService) Export(id int, format constant.GuestExportFormat) (*model.GuestExportDocument, error) {
+	ret := _m.Called(id, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 *model.GuestExportDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, constant.GuestExportFormat) (*model.GuestExportDocument, error)); ok {
+		return rf(id, format)
+	}
+	if rf, ok := ret.Get(0).(func(int, constant.GuestExportFormat) *model.GuestExportDocument); ok {
+		r0 = rf(id, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestExportDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, constant.GuestExportFormat) error); ok {
+		r1 = rf(id, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *GuestService) GetByID(id int) (*model.GuestResponse, error) {
 	ret := _m.Called(id)
@@ -60,6 +187,172 @@ func (_m *GuestService) GetByID(id int) (*model.GuestResponse, error) {
 	return r0, r1
 }
 
+// GetDuplicates provides a mock function with given fields: pagination
+func (_m *GuestService) GetDuplicates(pagination *model.PaginationRequest) (*model.PaginatedResponse, error) {
+	ret := _m.Called(pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicates")
+	}
+
+	var r0 *model.PaginatedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) (*model.PaginatedResponse, error)); ok {
+		return rf(pagination)
+	}
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) *model.PaginatedResponse); ok {
+		r0 = rf(pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PaginatedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.PaginationRequest) error); ok {
+		r1 = rf(pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSummary provides a mock function with given fields: id
+func (_m *GuestService) GetSummary(id int) (*model.GuestSummaryResponse, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSummary")
+	}
+
+	var r0 *model.GuestSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.GuestSummaryResponse, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.GuestSummaryResponse); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeDuplicate provides a mock function with given fields: id, request, userID
+func (_m *GuestService) MergeDuplicate(id int, request *model.MergeGuestRequest, userID int) (*model.GuestMergeResponse, error) {
+	ret := _m.Called(id, request, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeDuplicate")
+	}
+
+	var r0 *model.GuestMergeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, *model.MergeGuestRequest, int) (*model.GuestMergeResponse, error)); ok {
+		return rf(id, request, userID)
+	}
+	if rf, ok := ret.Get(0).(func(int, *model.MergeGuestRequest, int) *model.GuestMergeResponse); ok {
+		r0 = rf(id, request, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestMergeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, *model.MergeGuestRequest, int) error); ok {
+		r1 = rf(id, request, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReEncrypt provides a mock function with given fields:
+func (_m *GuestService) ReEncrypt() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReEncrypt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: request, pagination
+func (_m *GuestService) Search(request *model.SearchGuestRequest, pagination *model.PaginationRequest) (*model.PaginatedResponse, error) {
+	ret := _m.Called(request, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *model.PaginatedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SearchGuestRequest, *model.PaginationRequest) (*model.PaginatedResponse, error)); ok {
+		return rf(request, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SearchGuestRequest, *model.PaginationRequest) *model.PaginatedResponse); ok {
+		r0 = rf(request, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PaginatedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SearchGuestRequest, *model.PaginationRequest) error); ok {
+		r1 = rf(request, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: id, request
+func (_m *GuestService) Update(id int, request *model.UpdateGuestRequest) error {
+	ret := _m.Called(id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, *model.UpdateGuestRequest) error); ok {
+		r0 = rf(id, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewGuestService creates a new instance of GuestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGuestService(t interface {
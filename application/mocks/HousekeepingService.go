@@ -0,0 +1,141 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// HousekeepingService is an autogenerated mock type for the HousekeepingService type
+type HousekeepingService struct {
+	mock.Mock
+}
+
+// Claim provides a mock function with given fields: id, userID
+func (_m *HousekeepingService) Claim(id int, userID int) error {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Complete provides a mock function with given fields: id, userID, request
+func (_m *HousekeepingService) Complete(id int, userID int, request *model.CompleteHousekeepingTaskRequest) error {
+	ret := _m.Called(id, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, *model.CompleteHousekeepingTaskRequest) error); ok {
+		r0 = rf(id, userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: request
+func (_m *HousekeepingService) GetAll(request *model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.HousekeepingTaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.GetAllHousekeepingTaskRequest) []*model.HousekeepingTaskResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HousekeepingTaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.GetAllHousekeepingTaskRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *HousekeepingService) GetByID(id int) (*model.HousekeepingTaskResponse, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.HousekeepingTaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.HousekeepingTaskResponse, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.HousekeepingTaskResponse); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HousekeepingTaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Inspect provides a mock function with given fields: id, userID, request
+func (_m *HousekeepingService) Inspect(id int, userID int, request *model.InspectHousekeepingTaskRequest) error {
+	ret := _m.Called(id, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inspect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, *model.InspectHousekeepingTaskRequest) error); ok {
+		r0 = rf(id, userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHousekeepingService creates a new instance of HousekeepingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHousekeepingService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HousekeepingService {
+	mock := &HousekeepingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// InvitationService is an autogenerated mock type for the InvitationService type
+type InvitationService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: request, createdBy
+func (_m *InvitationService) Create(request *model.CreateInvitationRequest, createdBy int) (*model.InvitationResponse, error) {
+	ret := _m.Called(request, createdBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.InvitationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.CreateInvitationRequest, int) (*model.InvitationResponse, error)); ok {
+		return rf(request, createdBy)
+	}
+	if rf, ok := ret.Get(0).(func(*model.CreateInvitationRequest, int) *model.InvitationResponse); ok {
+		r0 = rf(request, createdBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.InvitationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.CreateInvitationRequest, int) error); ok {
+		r1 = rf(request, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPending provides a mock function with given fields:
+func (_m *InvitationService) GetPending() ([]*model.InvitationResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 []*model.InvitationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.InvitationResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.InvitationResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.InvitationResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: id, revokedBy
+func (_m *InvitationService) Revoke(id int, revokedBy int) error {
+	ret := _m.Called(id, revokedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(id, revokedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewInvitationService creates a new instance of InvitationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvitationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvitationService {
+	mock := &InvitationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
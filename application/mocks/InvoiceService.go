@@ -0,0 +1,60 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	constant "github.com/zakiyalmaya/hotel-management/constant"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// InvoiceService is an autogenerated mock type for the InvoiceService type
+type InvoiceService struct {
+	mock.Mock
+}
+
+// Generate provides a mock function with given fields: registerNumber, format, issuedBy
+func (_m *InvoiceService) Generate(registerNumber string, format constant.InvoiceFormat, issuedBy int) (*model.InvoiceDocument, error) {
+	ret := _m.Called(registerNumber, format, issuedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Generate")
+	}
+
+	var r0 *model.InvoiceDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, constant.InvoiceFormat, int) (*model.InvoiceDocument, error)); ok {
+		return rf(registerNumber, format, issuedBy)
+	}
+	if rf, ok := ret.Get(0).(func(string, constant.InvoiceFormat, int) *model.InvoiceDocument); ok {
+		r0 = rf(registerNumber, format, issuedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.InvoiceDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, constant.InvoiceFormat, int) error); ok {
+		r1 = rf(registerNumber, format, issuedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewInvoiceService creates a new instance of InvoiceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvoiceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvoiceService {
+	mock := &InvoiceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// MaintenanceService is an autogenerated mock type for the MaintenanceService type
+type MaintenanceService struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: id, userID, request
+func (_m *MaintenanceService) Close(id int, userID int, request *model.CloseMaintenanceOrderRequest) error {
+	ret := _m.Called(id, userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int, *model.CloseMaintenanceOrderRequest) error); ok {
+		r0 = rf(id, userID, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: order
+func (_m *MaintenanceService) Create(order *model.MaintenanceOrderEntity) (*model.MaintenanceOrderResponse, error) {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.MaintenanceOrderResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.MaintenanceOrderEntity) (*model.MaintenanceOrderResponse, error)); ok {
+		return rf(order)
+	}
+	if rf, ok := ret.Get(0).(func(*model.MaintenanceOrderEntity) *model.MaintenanceOrderResponse); ok {
+		r0 = rf(order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MaintenanceOrderResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.MaintenanceOrderEntity) error); ok {
+		r1 = rf(order)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: request
+func (_m *MaintenanceService) GetAll(request *model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.MaintenanceOrderResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.GetAllMaintenanceOrderRequest) []*model.MaintenanceOrderResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MaintenanceOrderResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.GetAllMaintenanceOrderRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MaintenanceService) GetByID(id int) (*model.MaintenanceOrderResponse, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.MaintenanceOrderResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.MaintenanceOrderResponse, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.MaintenanceOrderResponse); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MaintenanceOrderResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: order
+func (_m *MaintenanceService) Update(order *model.MaintenanceOrderEntity) error {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.MaintenanceOrderEntity) error); ok {
+		r0 = rf(order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMaintenanceService creates a new instance of MaintenanceService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMaintenanceService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MaintenanceService {
+	mock := &MaintenanceService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,250 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	constant "github.com/zakiyalmaya/hotel-management/constant"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// MfaService is an autogenerated mock type for the MfaService type
+type MfaService struct {
+	mock.Mock
+}
+
+// Activate provides a mock function with given fields: userID, code
+func (_m *MfaService) Activate(userID int, code string) (*model.RecoveryCodesResponse, error) {
+	ret := _m.Called(userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activate")
+	}
+
+	var r0 *model.RecoveryCodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (*model.RecoveryCodesResponse, error)); ok {
+		return rf(userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) *model.RecoveryCodesResponse); ok {
+		r0 = rf(userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RecoveryCodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disable provides a mock function with given fields: userID, code
+func (_m *MfaService) Disable(userID int, code string) error {
+	ret := _m.Called(userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enroll provides a mock function with given fields: userID
+func (_m *MfaService) Enroll(userID int) (*model.MfaEnrollmentResponse, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enroll")
+	}
+
+	var r0 *model.MfaEnrollmentResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.MfaEnrollmentResponse, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.MfaEnrollmentResponse); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MfaEnrollmentResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPolicies provides a mock function with given fields:
+func (_m *MfaService) GetPolicies() ([]*model.MfaPolicyResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPolicies")
+	}
+
+	var r0 []*model.MfaPolicyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.MfaPolicyResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.MfaPolicyResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MfaPolicyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsRequired provides a mock function with given fields: role
+func (_m *MfaService) IsRequired(role constant.Role) (bool, error) {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRequired")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(constant.Role) (bool, error)); ok {
+		return rf(role)
+	}
+	if rf, ok := ret.Get(0).(func(constant.Role) bool); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(constant.Role) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: userID, code
+func (_m *MfaService) RegenerateRecoveryCodes(userID int, code string) (*model.RecoveryCodesResponse, error) {
+	ret := _m.Called(userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegenerateRecoveryCodes")
+	}
+
+	var r0 *model.RecoveryCodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (*model.RecoveryCodesResponse, error)); ok {
+		return rf(userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) *model.RecoveryCodesResponse); ok {
+		r0 = rf(userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RecoveryCodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reset provides a mock function with given fields: userID
+func (_m *MfaService) Reset(userID int) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePolicy provides a mock function with given fields: role, required, updatedBy
+func (_m *MfaService) UpdatePolicy(role constant.Role, required bool, updatedBy int) error {
+	ret := _m.Called(role, required, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(constant.Role, bool, int) error); ok {
+		r0 = rf(role, required, updatedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Verify provides a mock function with given fields: user, code, recoveryCode
+func (_m *MfaService) Verify(user *model.UserEntity, code string, recoveryCode string) error {
+	ret := _m.Called(user, code, recoveryCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.UserEntity, string, string) error); ok {
+		r0 = rf(user, code, recoveryCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMfaService creates a new instance of MfaService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMfaService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MfaService {
+	mock := &MfaService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,93 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// PaymentService is an autogenerated mock type for the PaymentService type
+type PaymentService struct {
+	mock.Mock
+}
+
+// GetByRegisterNumber provides a mock function with given fields: registerNumber
+func (_m *PaymentService) GetByRegisterNumber(registerNumber string) (*model.PaymentSummaryResponse, error) {
+	ret := _m.Called(registerNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRegisterNumber")
+	}
+
+	var r0 *model.PaymentSummaryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.PaymentSummaryResponse, error)); ok {
+		return rf(registerNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.PaymentSummaryResponse); ok {
+		r0 = rf(registerNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PaymentSummaryResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(registerNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleWebhook provides a mock function with given fields: payload, signature
+func (_m *PaymentService) HandleWebhook(payload []byte, signature string) error {
+	ret := _m.Called(payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, string) error); ok {
+		r0 = rf(payload, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Record provides a mock function with given fields: _a0
+func (_m *PaymentService) Record(_a0 *model.PaymentEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PaymentEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentService creates a new instance of PaymentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentService {
+	mock := &PaymentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
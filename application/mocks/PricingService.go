@@ -0,0 +1,57 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// PricingService is an autogenerated mock type for the PricingService type
+type PricingService struct {
+	mock.Mock
+}
+
+// Quote provides a mock function with given fields: request
+func (_m *PricingService) Quote(request *model.PriceQuoteRequest) (*model.PriceQuoteResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quote")
+	}
+
+	var r0 *model.PriceQuoteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.PriceQuoteRequest) (*model.PriceQuoteResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.PriceQuoteRequest) *model.PriceQuoteResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PriceQuoteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.PriceQuoteRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPricingService creates a new instance of PricingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPricingService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PricingService {
+	mock := &PricingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
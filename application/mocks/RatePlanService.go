@@ -0,0 +1,159 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// RatePlanService is an autogenerated mock type for the RatePlanService type
+type RatePlanService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: plan
+func (_m *RatePlanService) Create(plan *model.RatePlanEntity) error {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.RatePlanEntity) error); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSeasonalRate provides a mock function with given fields: season
+func (_m *RatePlanService) CreateSeasonalRate(season *model.SeasonalRateEntity) error {
+	ret := _m.Called(season)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeasonalRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.SeasonalRateEntity) error); ok {
+		r0 = rf(season)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSeasonalRate provides a mock function with given fields: id
+func (_m *RatePlanService) DeleteSeasonalRate(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSeasonalRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *RatePlanService) GetAll() ([]*model.RatePlanResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.RatePlanResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.RatePlanResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.RatePlanResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RatePlanResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSeasonalRates provides a mock function with given fields: roomType
+func (_m *RatePlanService) GetSeasonalRates(roomType *string) ([]*model.SeasonalRateResponse, error) {
+	ret := _m.Called(roomType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeasonalRates")
+	}
+
+	var r0 []*model.SeasonalRateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*string) ([]*model.SeasonalRateResponse, error)); ok {
+		return rf(roomType)
+	}
+	if rf, ok := ret.Get(0).(func(*string) []*model.SeasonalRateResponse); ok {
+		r0 = rf(roomType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SeasonalRateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*string) error); ok {
+		r1 = rf(roomType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: id, request
+func (_m *RatePlanService) Update(id int, request *model.UpdateRatePlanRequest) error {
+	ret := _m.Called(id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, *model.UpdateRatePlanRequest) error); ok {
+		r0 = rf(id, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRatePlanService creates a new instance of RatePlanService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRatePlanService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RatePlanService {
+	mock := &RatePlanService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
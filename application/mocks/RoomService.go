@@ -90,6 +90,36 @@ func (_m *RoomService) GetByName(name string) (*model.RoomResponse, error) {
 	return r0, r1
 }
 
+// Search provides a mock function with given fields: request
+func (_m *RoomService) Search(request *model.SearchRoomRequest) ([]*model.RoomResponse, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*model.RoomResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SearchRoomRequest) ([]*model.RoomResponse, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SearchRoomRequest) []*model.RoomResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RoomResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SearchRoomRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: request
 func (_m *RoomService) Update(request *model.UpdateRoomRequest) error {
 	ret := _m.Called(request)
@@ -12,6 +12,24 @@ type UserService struct {
 	mock.Mock
 }
 
+// AssignRole provides a mock function with given fields: _a0, assignedBy
+func (_m *UserService) AssignRole(_a0 *model.UserEntity, assignedBy int) error {
+	ret := _m.Called(_a0, assignedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.UserEntity, int) error); ok {
+		r0 = rf(_a0, assignedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ChangePassword provides a mock function with given fields: request
 func (_m *UserService) ChangePassword(request *model.ChangePasswordRequest) error {
 	ret := _m.Called(request)
@@ -48,6 +66,174 @@ func (_m *UserService) Create(request *model.CreateUserRequest) error {
 	return r0
 }
 
+// CreateAdmin provides a mock function with given fields: request
+func (_m *UserService) CreateAdmin(request *model.CreateAdminRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdmin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.CreateAdminRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Deactivate provides a mock function with given fields: id, deactivatedBy
+func (_m *UserService) Deactivate(id int, deactivatedBy int) error {
+	ret := _m.Called(id, deactivatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deactivate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(id, deactivatedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgotPassword provides a mock function with given fields: request
+func (_m *UserService) ForgotPassword(request *model.ForgotPasswordRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.ForgotPasswordRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: pagination
+func (_m *UserService) GetAll(pagination *model.PaginationRequest) (*model.PaginatedResponse, error) {
+	ret := _m.Called(pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 *model.PaginatedResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) (*model.PaginatedResponse, error)); ok {
+		return rf(pagination)
+	}
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) *model.PaginatedResponse); ok {
+		r0 = rf(pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PaginatedResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.PaginationRequest) error); ok {
+		r1 = rf(pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *UserService) GetByID(id int) (*model.UserResponse, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.UserResponse, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.UserResponse); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: request
+func (_m *UserService) ResetPassword(request *model.ResetPasswordRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.ResetPasswordRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: id
+func (_m *UserService) Unlock(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: id, request, updatedBy
+func (_m *UserService) Update(id int, request *model.UpdateUserRequest, updatedBy int) error {
+	ret := _m.Called(id, request, updatedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, *model.UpdateUserRequest, int) error); ok {
+		r0 = rf(id, request, updatedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserService(t interface {
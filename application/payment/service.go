@@ -0,0 +1,10 @@
+package payment
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=PaymentService --output=../mocks --outpkg=mocks
+type PaymentService interface {
+	Record(payment *model.PaymentEntity) error
+	GetByRegisterNumber(registerNumber string) (*model.PaymentSummaryResponse, error)
+	HandleWebhook(payload []byte, signature string) error
+}
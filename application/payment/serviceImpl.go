@@ -0,0 +1,154 @@
+package payment
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/application/folio"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/gateway"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type paymentSvcImpl struct {
+	repos          *repository.Repositories
+	folioSvc       folio.FolioService
+	paymentGateway gateway.PaymentGateway
+}
+
+func NewPaymentServiceImpl(repos *repository.Repositories, folioSvc folio.FolioService, paymentGateway gateway.PaymentGateway) PaymentService {
+	return &paymentSvcImpl{repos: repos, folioSvc: folioSvc, paymentGateway: paymentGateway}
+}
+
+// Record saves a payment, or a refund when the amount is negative, and derives the
+// payment status of the booking from the new amount paid against the folio total.
+func (p *paymentSvcImpl) Record(payment *model.PaymentEntity) error {
+	detail, err := p.repos.BookingRepo.GetByRegisterNumber(payment.RegisterNumber)
+	if err != nil {
+		return err
+	}
+
+	if payment.Amount > 0 && detail.BookingEntity.Status == constant.BookingStatusCancelled {
+		return model.NewConflictError("can not take payments for a %s booking", detail.BookingEntity.Status.Enum())
+	}
+
+	bookingFolio, err := p.folioSvc.GetByRegisterNumber(payment.RegisterNumber)
+	if err != nil {
+		return err
+	}
+
+	if payment.Amount < 0 && -payment.Amount > bookingFolio.Paid {
+		return model.NewValidationError("refund amount exceeds the amount paid")
+	}
+
+	// a card refund taken at the desk goes back through the gateway that charged the card
+	if payment.Amount < 0 && payment.GatewayReference == nil && payment.PaymentMethod == constant.PaymentMethodCreditCard && detail.GatewayTransactionID != nil {
+		transaction, err := p.paymentGateway.Refund(*detail.GatewayTransactionID, -payment.Amount)
+		if err != nil {
+			return err
+		}
+		payment.GatewayReference = &transaction.ID
+	}
+
+	if err := p.repos.PaymentRepo.Create(payment); err != nil {
+		return err
+	}
+
+	// the other report of the same gateway transaction got here first
+	if payment.GatewayReference != nil && payment.ID == 0 {
+		return nil
+	}
+
+	paid := math.Round((bookingFolio.Paid+payment.Amount)*100) / 100
+	if err := p.repos.BookingRepo.UpdatePayment(&model.BookingEntity{
+		RegisterNumber: payment.RegisterNumber,
+		PaymentStatus:  folio.PaymentStatus(detail.BookingEntity.Status, paid, bookingFolio.Total),
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *paymentSvcImpl) GetByRegisterNumber(registerNumber string) (*model.PaymentSummaryResponse, error) {
+	detail, err := p.repos.BookingRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	bookingFolio, err := p.folioSvc.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := p.repos.PaymentRepo.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentResponses := make([]*model.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		paymentResponses[i] = &model.PaymentResponse{
+			ID:               payment.ID,
+			Amount:           payment.Amount,
+			PaymentMethod:    payment.PaymentMethod.Enum(),
+			Reference:        payment.Reference,
+			PaidAt:           payment.PaidAt.Format("02-01-2006 15:04:05"),
+			ReceivedBy:       payment.ReceivedBy,
+			GatewayReference: payment.GatewayReference,
+		}
+	}
+
+	return &model.PaymentSummaryResponse{
+		RegisterNumber: registerNumber,
+		Total:          bookingFolio.Total,
+		Paid:           bookingFolio.Paid,
+		Balance:        bookingFolio.Balance,
+		PaymentStatus:  detail.PaymentStatus.Enum(),
+		Payments:       paymentResponses,
+	}, nil
+}
+
+// HandleWebhook records the money moved by a gateway callback. Callbacks are delivered at
+// least once, a transaction that is already recorded is acknowledged without change.
+func (p *paymentSvcImpl) HandleWebhook(payload []byte, signature string) error {
+	if !p.paymentGateway.VerifySignature(payload, signature) {
+		return model.NewUnauthorizedError("invalid signature")
+	}
+
+	event := &model.GatewayEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return model.NewValidationError("invalid event: %s", err.Error())
+	}
+
+	amount := event.Amount
+	switch event.Type {
+	case constant.GatewayEventCaptured:
+	case constant.GatewayEventRefunded:
+		amount = -amount
+	default:
+		// authorizations and voids only hold or release money, nothing is paid
+		return nil
+	}
+
+	payments, err := p.repos.PaymentRepo.GetByRegisterNumber(event.OrderID)
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if payment.GatewayReference != nil && *payment.GatewayReference == event.Reference {
+			return nil
+		}
+	}
+
+	return p.Record(&model.PaymentEntity{
+		RegisterNumber:   event.OrderID,
+		Amount:           amount,
+		PaymentMethod:    constant.PaymentMethodCreditCard,
+		PaidAt:           time.Now(),
+		GatewayReference: &event.Reference,
+	})
+}
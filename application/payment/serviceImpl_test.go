@@ -0,0 +1,360 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	appMocks "github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	gatewayMocks "github.com/zakiyalmaya/hotel-management/infrastructure/gateway/mocks"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockBooking *mocks.BookingRepository
+	mockPayment *mocks.PaymentRepository
+	mockFolio   *appMocks.FolioService
+	mockGateway *gatewayMocks.PaymentGateway
+)
+
+func Test_Record(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockPayment = new(mocks.PaymentRepository)
+	mockFolio = new(appMocks.FolioService)
+	mockGateway = new(gatewayMocks.PaymentGateway)
+
+	paidAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+	transactionID := "sim_auth_1"
+	refundID := "sim_refund_1"
+	heldOnCard := &model.BookingDetail{BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedOut, GatewayTransactionID: &transactionID}}
+	confirmed := &model.BookingDetail{BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusConfirmed}}
+
+	testCases := []struct {
+		name     string
+		payment  *model.PaymentEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given deposit below the folio total when record payment then mark booking partially paid",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt}).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPartiallyPaid}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given payment settling the balance when record payment then mark booking completed",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 310500, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500, Paid: 300000}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: 310500, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt}).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusCompleted}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given refund of the whole amount paid when record refund then mark booking pending",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -300000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500, Paid: 300000}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: -300000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt}).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPending}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given refund on cancelled booking when record refund then mark booking refunded",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -50000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCancelled},
+				}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500, Paid: 100000}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: -50000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt}).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusRefunded}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given card refund on a booking charged by the gateway when record refund then refund it to the card",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -200, PaymentMethod: constant.PaymentMethodCreditCard, PaidAt: paidAt},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(heldOnCard, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 1000, Paid: 1000}, nil).Once()
+				mockGateway.On("Refund", transactionID, 200.0).Return(&model.GatewayTransaction{ID: refundID, Amount: 200, Status: constant.GatewayStatusRefunded}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: -200, PaymentMethod: constant.PaymentMethodCreditCard, PaidAt: paidAt, GatewayReference: &refundID}).
+					Run(func(args mock.Arguments) { args.Get(0).(*model.PaymentEntity).ID = 7 }).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPartiallyPaid}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given gateway transaction recorded by its other report when record payment then leave the status as it is",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 1000, PaymentMethod: constant.PaymentMethodCreditCard, PaidAt: paidAt, GatewayReference: &transactionID},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(heldOnCard, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 1000}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: 1000, PaymentMethod: constant.PaymentMethodCreditCard, PaidAt: paidAt, GatewayReference: &transactionID}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given gateway refuses the refund when record refund then return error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -200, PaymentMethod: constant.PaymentMethodCreditCard},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(heldOnCard, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 1000, Paid: 1000}, nil).Once()
+				mockGateway.On("Refund", transactionID, 200.0).Return(nil, model.NewConflictError("can not refund a Voided transaction")).Once()
+			},
+			err: model.NewConflictError("can not refund a Voided transaction"),
+		},
+		{
+			name:    "Given cancelled booking when record payment then return conflict error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCancelled},
+				}, nil).Once()
+			},
+			err: model.NewConflictError("can not take payments for a Cancelled booking"),
+		},
+		{
+			name:    "Given refund larger than the amount paid when record refund then return validation error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -400000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500, Paid: 300000}, nil).Once()
+			},
+			err: model.NewValidationError("refund amount exceeds the amount paid"),
+		},
+		{
+			name:    "Given error update payment status when record payment then return error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000}).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPartiallyPaid}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:    "Given error create payment when record payment then return error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500}, nil).Once()
+				mockPayment.On("Create", &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:    "Given error get folio when record payment then return error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(confirmed, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:    "Given error get reservation data when record payment then return error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: 300000},
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewPaymentServiceImpl(&repository.Repositories{BookingRepo: mockBooking, PaymentRepo: mockPayment}, mockFolio, mockGateway)
+			err := service.Record(tc.payment)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockPayment = new(mocks.PaymentRepository)
+	mockFolio = new(appMocks.FolioService)
+	mockGateway = new(gatewayMocks.PaymentGateway)
+
+	paidAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+	reference := "receipt-001"
+	receivedBy := 1
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		response *model.PaymentSummaryResponse
+		err      error
+	}{
+		{
+			name: "Given payments and refund when get payments then return summary against the folio",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusPartiallyPaid},
+				}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 610500, Paid: 200000, Balance: 410500}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{
+					{ID: 1, Amount: 300000, PaymentMethod: constant.PaymentMethodCash, Reference: &reference, PaidAt: paidAt, ReceivedBy: &receivedBy},
+					{ID: 2, Amount: -100000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt.Add(time.Hour), ReceivedBy: &receivedBy},
+				}, nil).Once()
+			},
+			response: &model.PaymentSummaryResponse{
+				RegisterNumber: "register_number",
+				Total:          610500,
+				Paid:           200000,
+				Balance:        410500,
+				PaymentStatus:  "Partially Paid",
+				Payments: []*model.PaymentResponse{
+					{ID: 1, Amount: 300000, PaymentMethod: "Cash", Reference: &reference, PaidAt: "01-03-2000 10:00:00", ReceivedBy: &receivedBy},
+					{ID: 2, Amount: -100000, PaymentMethod: "Cash", PaidAt: "01-03-2000 11:00:00", ReceivedBy: &receivedBy},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "Given error get payments when get payments then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{}, nil).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name: "Given error get folio when get payments then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name: "Given error get reservation data when get payments then return error",
+			mockCall: func() {
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewPaymentServiceImpl(&repository.Repositories{BookingRepo: mockBooking, PaymentRepo: mockPayment}, mockFolio, mockGateway)
+			response, err := service.GetByRegisterNumber("register_number")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
+
+func Test_HandleWebhook(t *testing.T) {
+	mockBooking = new(mocks.BookingRepository)
+	mockPayment = new(mocks.PaymentRepository)
+	mockFolio = new(appMocks.FolioService)
+	mockGateway = new(gatewayMocks.PaymentGateway)
+
+	transactionID := "sim_auth_1"
+	captured := []byte(`{"id":"sim_evt_1","type":"payment.captured","order_id":"register_number","transaction_id":"sim_auth_1","reference":"sim_auth_1","amount":1000}`)
+	authorized := []byte(`{"id":"sim_evt_2","type":"payment.authorized","order_id":"register_number","transaction_id":"sim_auth_1","reference":"sim_auth_1","amount":1000}`)
+
+	testCases := []struct {
+		name     string
+		payload  []byte
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given captured event when handle webhook then record the payment",
+			payload: captured,
+			mockCall: func() {
+				mockGateway.On("VerifySignature", captured, "signature").Return(true).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{}, nil).Once()
+				mockBooking.On("GetByRegisterNumber", "register_number").Return(&model.BookingDetail{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedIn, GatewayTransactionID: &transactionID},
+				}, nil).Once()
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{Total: 1000}, nil).Once()
+				mockPayment.On("Create", mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+					return payment.Amount == 1000 && payment.PaymentMethod == constant.PaymentMethodCreditCard && *payment.GatewayReference == transactionID
+				})).Run(func(args mock.Arguments) { args.Get(0).(*model.PaymentEntity).ID = 1 }).Return(nil).Once()
+				mockBooking.On("UpdatePayment", &model.BookingEntity{RegisterNumber: "register_number", PaymentStatus: constant.PaymentStatusCompleted}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given captured event already recorded when handle webhook then acknowledge it",
+			payload: captured,
+			mockCall: func() {
+				mockGateway.On("VerifySignature", captured, "signature").Return(true).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return([]*model.PaymentEntity{{ID: 1, Amount: 1000, GatewayReference: &transactionID}}, nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given authorized event when handle webhook then acknowledge it",
+			payload: authorized,
+			mockCall: func() {
+				mockGateway.On("VerifySignature", authorized, "signature").Return(true).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given invalid signature when handle webhook then return unauthorized error",
+			payload: captured,
+			mockCall: func() {
+				mockGateway.On("VerifySignature", captured, "signature").Return(false).Once()
+			},
+			err: model.NewUnauthorizedError("invalid signature"),
+		},
+		{
+			name:    "Given malformed event when handle webhook then return validation error",
+			payload: []byte(`{`),
+			mockCall: func() {
+				mockGateway.On("VerifySignature", []byte(`{`), "signature").Return(true).Once()
+			},
+			err: model.NewValidationError("invalid event: unexpected end of JSON input"),
+		},
+		{
+			name:    "Given error get payments when handle webhook then return error",
+			payload: captured,
+			mockCall: func() {
+				mockGateway.On("VerifySignature", captured, "signature").Return(true).Once()
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewPaymentServiceImpl(&repository.Repositories{BookingRepo: mockBooking, PaymentRepo: mockPayment}, mockFolio, mockGateway)
+			err := service.HandleWebhook(tc.payload, "signature")
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
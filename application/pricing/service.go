@@ -0,0 +1,8 @@
+package pricing
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=PricingService --output=../mocks --outpkg=mocks
+type PricingService interface {
+	Quote(request *model.PriceQuoteRequest) (*model.PriceQuoteResponse, error)
+}
@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type pricingSvcImpl struct {
+	repos *repository.Repositories
+}
+
+func NewPricingServiceImpl(repos *repository.Repositories) PricingService {
+	return &pricingSvcImpl{repos: repos}
+}
+
+// Quote prices every night of the stay. The rate plan of the room type gives the base rate,
+// a seasonal rate replaces it on the nights it covers, and Friday and Saturday nights add the weekend surcharge.
+// Room types without a rate plan fall back to the flat room price.
+func (p *pricingSvcImpl) Quote(request *model.PriceQuoteRequest) (*model.PriceQuoteResponse, error) {
+	nights := int(request.CheckOut.Sub(request.CheckIn).Hours() / 24)
+	if nights < 1 {
+		return nil, model.NewValidationError("check out date must be greater than check in date")
+	}
+
+	room, err := p.repos.RoomRepo.GetByName(request.RoomName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := p.repos.RatePlanRepo.GetByRoomType(room.Type)
+	if errors.Is(err, sql.ErrNoRows) {
+		plan = &model.RatePlanEntity{RoomType: room.Type, BaseRate: room.Price, MinStay: 1}
+	} else if err != nil {
+		return nil, err
+	}
+
+	seasons, err := p.repos.RatePlanRepo.GetOverlappingSeasonalRates(room.Type, request.CheckIn, request.CheckOut)
+	if err != nil {
+		return nil, err
+	}
+
+	minStay := plan.MinStay
+	total := 0.0
+	breakdown := make([]*model.NightlyRateResponse, 0, nights)
+	for night := request.CheckIn; night.Before(request.CheckOut); night = night.AddDate(0, 0, 1) {
+		nightly := &model.NightlyRateResponse{
+			Date: night.Format("02 January 2006"),
+			Rate: plan.BaseRate,
+		}
+
+		if season := seasonOf(seasons, night); season != nil {
+			nightly.Rate = season.Rate
+			nightly.Season = &season.Name
+			if season.MinStay > minStay {
+				minStay = season.MinStay
+			}
+		}
+
+		if night.Weekday() == time.Friday || night.Weekday() == time.Saturday {
+			nightly.Weekend = true
+			nightly.Rate += nightly.Rate * plan.WeekendSurcharge / 100
+		}
+
+		nightly.Rate = math.Round(nightly.Rate*100) / 100
+		total += nightly.Rate
+		breakdown = append(breakdown, nightly)
+	}
+
+	if nights < minStay {
+		return nil, model.NewValidationError("minimum stay for %s is %d nights", room.Type, minStay)
+	}
+
+	return &model.PriceQuoteResponse{
+		RoomName:  room.Name,
+		RoomType:  room.Type,
+		CheckIn:   request.CheckIn.Format("02 January 2006"),
+		CheckOut:  request.CheckOut.Format("02 January 2006"),
+		Nights:    nights,
+		Total:     math.Round(total*100) / 100,
+		Breakdown: breakdown,
+	}, nil
+}
+
+// seasonOf returns the season covering the night, seasons are ordered so the first match wins.
+func seasonOf(seasons []*model.SeasonalRateEntity, night time.Time) *model.SeasonalRateEntity {
+	for _, season := range seasons {
+		if !night.Before(season.StartDate) && !night.After(season.EndDate) {
+			return season
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package pricing
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockRatePlan *mocks.RatePlanRepository
+	mockRoom     *mocks.RoomRepository
+)
+
+func Test_Quote(t *testing.T) {
+	mockRatePlan = new(mocks.RatePlanRepository)
+	mockRoom = new(mocks.RoomRepository)
+
+	// Thursday 02 March 2000 to Sunday 05 March 2000 covers a Thursday, Friday and Saturday night
+	checkIn := time.Date(2000, time.March, 02, 00, 00, 00, 00, time.UTC)
+	checkOut := time.Date(2000, time.March, 05, 00, 00, 00, 00, time.UTC)
+	season := "spring"
+
+	testCases := []struct {
+		name     string
+		request  *model.PriceQuoteRequest
+		mockCall func()
+		response *model.PriceQuoteResponse
+		err      error
+	}{
+		{
+			name:    "Given rate plan with season and weekend surcharge when quote then price every night",
+			request: &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkOut},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Type: "deluxe", Price: 1000}, nil).Once()
+				mockRatePlan.On("GetByRoomType", "deluxe").Return(&model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, WeekendSurcharge: 20, MinStay: 1}, nil).Once()
+				mockRatePlan.On("GetOverlappingSeasonalRates", "deluxe", checkIn, checkOut).Return([]*model.SeasonalRateEntity{
+					{Name: "spring", StartDate: time.Date(2000, time.March, 04, 00, 00, 00, 00, time.UTC), EndDate: time.Date(2000, time.March, 10, 00, 00, 00, 00, time.UTC), Rate: 600, MinStay: 2},
+				}, nil).Once()
+			},
+			response: &model.PriceQuoteResponse{
+				RoomName: "101",
+				RoomType: "deluxe",
+				CheckIn:  "02 March 2000",
+				CheckOut: "05 March 2000",
+				Nights:   3,
+				Total:    1820,
+				Breakdown: []*model.NightlyRateResponse{
+					{Date: "02 March 2000", Rate: 500},
+					{Date: "03 March 2000", Rate: 600, Weekend: true},
+					{Date: "04 March 2000", Rate: 720, Season: &season, Weekend: true},
+				},
+			},
+			err: nil,
+		},
+		{
+			name:    "Given room type without rate plan when quote then fall back to room price",
+			request: &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 1)},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Type: "standard", Price: 1000}, nil).Once()
+				mockRatePlan.On("GetByRoomType", "standard").Return(nil, sql.ErrNoRows).Once()
+				mockRatePlan.On("GetOverlappingSeasonalRates", "standard", checkIn, checkIn.AddDate(0, 0, 1)).Return([]*model.SeasonalRateEntity{}, nil).Once()
+			},
+			response: &model.PriceQuoteResponse{
+				RoomName:  "101",
+				RoomType:  "standard",
+				CheckIn:   "02 March 2000",
+				CheckOut:  "03 March 2000",
+				Nights:    1,
+				Total:     1000,
+				Breakdown: []*model.NightlyRateResponse{{Date: "02 March 2000", Rate: 1000}},
+			},
+			err: nil,
+		},
+		{
+			name:    "Given stay shorter than season minimum when quote then return validation error",
+			request: &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkOut},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Type: "deluxe", Price: 1000}, nil).Once()
+				mockRatePlan.On("GetByRoomType", "deluxe").Return(&model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, MinStay: 1}, nil).Once()
+				mockRatePlan.On("GetOverlappingSeasonalRates", "deluxe", checkIn, checkOut).Return([]*model.SeasonalRateEntity{
+					{Name: "spring", StartDate: checkIn, EndDate: checkOut, Rate: 600, MinStay: 4},
+				}, nil).Once()
+			},
+			response: nil,
+			err:      model.NewValidationError("minimum stay for deluxe is 4 nights"),
+		},
+		{
+			name:     "Given check out equal to check in when quote then return validation error",
+			request:  &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkIn},
+			mockCall: func() {},
+			response: nil,
+			err:      model.NewValidationError("check out date must be greater than check in date"),
+		},
+		{
+			name:    "Given error get room data when quote then return error",
+			request: &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkOut},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+		{
+			name:    "Given error get rate plan when quote then return error",
+			request: &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkOut},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Type: "deluxe", Price: 1000}, nil).Once()
+				mockRatePlan.On("GetByRoomType", "deluxe").Return(nil, sql.ErrConnDone).Once()
+			},
+			response: nil,
+			err:      sql.ErrConnDone,
+		},
+		{
+			name:    "Given error get seasonal rates when quote then return error",
+			request: &model.PriceQuoteRequest{RoomName: "101", CheckIn: checkIn, CheckOut: checkOut},
+			mockCall: func() {
+				mockRoom.On("GetByName", "101").Return(&model.RoomEntity{Name: "101", Type: "deluxe", Price: 1000}, nil).Once()
+				mockRatePlan.On("GetByRoomType", "deluxe").Return(&model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, MinStay: 1}, nil).Once()
+				mockRatePlan.On("GetOverlappingSeasonalRates", "deluxe", checkIn, checkOut).Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewPricingServiceImpl(&repository.Repositories{RoomRepo: mockRoom, RatePlanRepo: mockRatePlan})
+			response, err := service.Quote(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
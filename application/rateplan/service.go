@@ -0,0 +1,13 @@
+package rateplan
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=RatePlanService --output=../mocks --outpkg=mocks
+type RatePlanService interface {
+	Create(plan *model.RatePlanEntity) error
+	GetAll() ([]*model.RatePlanResponse, error)
+	Update(id int, request *model.UpdateRatePlanRequest) error
+	CreateSeasonalRate(season *model.SeasonalRateEntity) error
+	GetSeasonalRates(roomType *string) ([]*model.SeasonalRateResponse, error)
+	DeleteSeasonalRate(id int) error
+}
@@ -0,0 +1,115 @@
+package rateplan
+
+import (
+	"database/sql"
+
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type ratePlanSvcImpl struct {
+	repos *repository.Repositories
+}
+
+func NewRatePlanServiceImpl(repos *repository.Repositories) RatePlanService {
+	return &ratePlanSvcImpl{repos: repos}
+}
+
+func (r *ratePlanSvcImpl) Create(plan *model.RatePlanEntity) error {
+	err := r.repos.RatePlanRepo.Create(plan)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ratePlanSvcImpl) GetAll() ([]*model.RatePlanResponse, error) {
+	plans, err := r.repos.RatePlanRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	planResponses := make([]*model.RatePlanResponse, len(plans))
+	for i, plan := range plans {
+		planResponses[i] = &model.RatePlanResponse{
+			ID:               plan.ID,
+			RoomType:         plan.RoomType,
+			BaseRate:         plan.BaseRate,
+			WeekendSurcharge: plan.WeekendSurcharge,
+			MinStay:          plan.MinStay,
+		}
+	}
+
+	return planResponses, nil
+}
+
+func (r *ratePlanSvcImpl) Update(id int, request *model.UpdateRatePlanRequest) error {
+	plan, err := r.repos.RatePlanRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("rate plan not found")
+		}
+
+		return err
+	}
+
+	if request.BaseRate != nil {
+		plan.BaseRate = *request.BaseRate
+	}
+
+	if request.WeekendSurcharge != nil {
+		plan.WeekendSurcharge = *request.WeekendSurcharge
+	}
+
+	if request.MinStay != nil {
+		plan.MinStay = *request.MinStay
+	}
+
+	err = r.repos.RatePlanRepo.Update(plan)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ratePlanSvcImpl) CreateSeasonalRate(season *model.SeasonalRateEntity) error {
+	err := r.repos.RatePlanRepo.CreateSeasonalRate(season)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ratePlanSvcImpl) GetSeasonalRates(roomType *string) ([]*model.SeasonalRateResponse, error) {
+	seasons, err := r.repos.RatePlanRepo.GetSeasonalRates(roomType)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonResponses := make([]*model.SeasonalRateResponse, len(seasons))
+	for i, season := range seasons {
+		seasonResponses[i] = &model.SeasonalRateResponse{
+			ID:        season.ID,
+			RoomType:  season.RoomType,
+			Name:      season.Name,
+			StartDate: season.StartDate.Format("02 January 2006"),
+			EndDate:   season.EndDate.Format("02 January 2006"),
+			Rate:      season.Rate,
+			MinStay:   season.MinStay,
+		}
+	}
+
+	return seasonResponses, nil
+}
+
+func (r *ratePlanSvcImpl) DeleteSeasonalRate(id int) error {
+	err := r.repos.RatePlanRepo.DeleteSeasonalRate(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
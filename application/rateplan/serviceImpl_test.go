@@ -0,0 +1,160 @@
+package rateplan
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var mockRatePlan *mocks.RatePlanRepository
+
+func Test_Create(t *testing.T) {
+	mockRatePlan = new(mocks.RatePlanRepository)
+
+	testCases := []struct {
+		name     string
+		plan     *model.RatePlanEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when create rate plan then return success",
+			plan: &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, MinStay: 1},
+			mockCall: func() {
+				mockRatePlan.On("Create", &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, MinStay: 1}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when create rate plan then return error",
+			plan: &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, MinStay: 1},
+			mockCall: func() {
+				mockRatePlan.On("Create", &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, MinStay: 1}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewRatePlanServiceImpl(&repository.Repositories{RatePlanRepo: mockRatePlan})
+			err := service.Create(tc.plan)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	mockRatePlan = new(mocks.RatePlanRepository)
+
+	baseRate := 650.0
+	minStay := 2
+
+	testCases := []struct {
+		name     string
+		request  *model.UpdateRatePlanRequest
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given partial request when update rate plan then keep the untouched fields",
+			request: &model.UpdateRatePlanRequest{BaseRate: &baseRate, MinStay: &minStay},
+			mockCall: func() {
+				mockRatePlan.On("GetByID", 1).Return(&model.RatePlanEntity{ID: 1, RoomType: "deluxe", BaseRate: 500, WeekendSurcharge: 20, MinStay: 1}, nil).Once()
+				mockRatePlan.On("Update", &model.RatePlanEntity{ID: 1, RoomType: "deluxe", BaseRate: 650, WeekendSurcharge: 20, MinStay: 2}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given unknown rate plan when update rate plan then return not found",
+			request: &model.UpdateRatePlanRequest{BaseRate: &baseRate},
+			mockCall: func() {
+				mockRatePlan.On("GetByID", 1).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("rate plan not found"),
+		},
+		{
+			name:    "Given error when update rate plan then return error",
+			request: &model.UpdateRatePlanRequest{BaseRate: &baseRate},
+			mockCall: func() {
+				mockRatePlan.On("GetByID", 1).Return(&model.RatePlanEntity{ID: 1, RoomType: "deluxe", BaseRate: 500, MinStay: 1}, nil).Once()
+				mockRatePlan.On("Update", &model.RatePlanEntity{ID: 1, RoomType: "deluxe", BaseRate: 650, MinStay: 1}).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewRatePlanServiceImpl(&repository.Repositories{RatePlanRepo: mockRatePlan})
+			err := service.Update(1, tc.request)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetSeasonalRates(t *testing.T) {
+	mockRatePlan = new(mocks.RatePlanRepository)
+
+	roomType := "deluxe"
+
+	testCases := []struct {
+		name     string
+		request  *string
+		mockCall func()
+		response []*model.SeasonalRateResponse
+		err      error
+	}{
+		{
+			name:    "Given room type when get seasonal rates then return formatted seasons",
+			request: &roomType,
+			mockCall: func() {
+				mockRatePlan.On("GetSeasonalRates", &roomType).Return([]*model.SeasonalRateEntity{
+					{
+						ID:        1,
+						RoomType:  "deluxe",
+						Name:      "holiday",
+						StartDate: time.Date(2000, time.December, 20, 00, 00, 00, 00, time.UTC),
+						EndDate:   time.Date(2000, time.December, 31, 00, 00, 00, 00, time.UTC),
+						Rate:      800,
+						MinStay:   3,
+					},
+				}, nil).Once()
+			},
+			response: []*model.SeasonalRateResponse{
+				{ID: 1, RoomType: "deluxe", Name: "holiday", StartDate: "20 December 2000", EndDate: "31 December 2000", Rate: 800, MinStay: 3},
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error when get seasonal rates then return error",
+			request: nil,
+			mockCall: func() {
+				mockRatePlan.On("GetSeasonalRates", (*string)(nil)).Return(nil, errors.New("error")).Once()
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewRatePlanServiceImpl(&repository.Repositories{RatePlanRepo: mockRatePlan})
+			response, err := service.GetSeasonalRates(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, response)
+		})
+	}
+}
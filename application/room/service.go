@@ -8,4 +8,5 @@ type RoomService interface {
 	GetByName(name string) (*model.RoomResponse, error)
 	GetAll(request *model.GetAllRoomRequest) ([]*model.RoomResponse, error)
 	Update(request *model.UpdateRoomRequest) error
-}
\ No newline at end of file
+	Search(request *model.SearchRoomRequest) ([]*model.RoomResponse, error)
+}
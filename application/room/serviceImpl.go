@@ -1,17 +1,22 @@
 package room
 
 import (
+	"math"
+	"sort"
+
+	"github.com/zakiyalmaya/hotel-management/application/pricing"
 	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
 type roomSvcImpl struct {
-	repos *repository.Repositories
+	repos      *repository.Repositories
+	pricingSvc pricing.PricingService
 }
 
-func NewRoomServiceImpl(repos *repository.Repositories) RoomService {
-	return &roomSvcImpl{repos: repos}
+func NewRoomServiceImpl(repos *repository.Repositories, pricingSvc pricing.PricingService) RoomService {
+	return &roomSvcImpl{repos: repos, pricingSvc: pricingSvc}
 }
 
 func (r *roomSvcImpl) Create(room *model.RoomEntity) error {
@@ -29,15 +34,7 @@ func (r *roomSvcImpl) GetByName(name string) (*model.RoomResponse, error) {
 		return nil, err
 	}
 
-	return &model.RoomResponse{
-		ID:          room.ID,
-		Name:        room.Name,
-		Floor:       room.Floor,
-		Type:        room.Type,
-		Price:       room.Price,
-		Status:      room.Status.Enum(),
-		Description: room.Description,
-	}, nil
+	return newRoomResponse(room), nil
 }
 
 func (r *roomSvcImpl) GetAll(request *model.GetAllRoomRequest) ([]*model.RoomResponse, error) {
@@ -48,21 +45,13 @@ func (r *roomSvcImpl) GetAll(request *model.GetAllRoomRequest) ([]*model.RoomRes
 
 	roomResponses := make([]*model.RoomResponse, len(rooms))
 	for i, room := range rooms {
-		roomResponses[i] = &model.RoomResponse{
-			ID:          room.ID,
-			Name:        room.Name,
-			Floor:       room.Floor,
-			Type:        room.Type,
-			Price:       room.Price,
-			Status:      room.Status.Enum(),
-			Description: room.Description,
-		}
+		roomResponses[i] = newRoomResponse(room)
 	}
 
 	return roomResponses, nil
 }
 
-func (r *roomSvcImpl) Update(request *model.UpdateRoomRequest) error {	
+func (r *roomSvcImpl) Update(request *model.UpdateRoomRequest) error {
 	var roomEntity model.RoomEntity
 	roomEntity.Name = request.Name
 
@@ -78,6 +67,10 @@ func (r *roomSvcImpl) Update(request *model.UpdateRoomRequest) error {
 		roomEntity.Status = constant.RoomStatus(*request.Status)
 	}
 
+	if request.Capacity != nil {
+		roomEntity.Capacity = *request.Capacity
+	}
+
 	if request.Description != nil {
 		roomEntity.Description = request.Description
 	}
@@ -85,7 +78,64 @@ func (r *roomSvcImpl) Update(request *model.UpdateRoomRequest) error {
 	err := r.repos.RoomRepo.Update(&roomEntity)
 	if err != nil {
 		return err
-	}	
+	}
 
 	return nil
 }
+
+// Search prices the stay in every free room the way a booking would, so the price range
+// applies to the quoted nightly rate and the cheapest stay comes first. Rooms whose rate
+// plan needs a longer stay are left out.
+func (r *roomSvcImpl) Search(request *model.SearchRoomRequest) ([]*model.RoomResponse, error) {
+	rooms, err := r.repos.RoomRepo.Search(request)
+	if err != nil {
+		return nil, err
+	}
+
+	roomResponses := make([]*model.RoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		quote, err := r.pricingSvc.Quote(&model.PriceQuoteRequest{
+			RoomName: room.Name,
+			CheckIn:  request.CheckIn,
+			CheckOut: request.CheckOut,
+		})
+		if _, ok := err.(*model.ValidationError); ok {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		nightlyRate := math.Round(quote.Total/float64(quote.Nights)*100) / 100
+		if request.MinPrice != nil && nightlyRate < *request.MinPrice {
+			continue
+		}
+
+		if request.MaxPrice != nil && nightlyRate > *request.MaxPrice {
+			continue
+		}
+
+		roomResponse := newRoomResponse(room)
+		roomResponse.StayPrice = &quote.Total
+		roomResponses = append(roomResponses, roomResponse)
+	}
+
+	// rooms come ordered by name, so rooms with the same price keep that order
+	sort.SliceStable(roomResponses, func(i, j int) bool {
+		return *roomResponses[i].StayPrice < *roomResponses[j].StayPrice
+	})
+
+	return roomResponses, nil
+}
+
+func newRoomResponse(room *model.RoomEntity) *model.RoomResponse {
+	return &model.RoomResponse{
+		ID:          room.ID,
+		Name:        room.Name,
+		Floor:       room.Floor,
+		Type:        room.Type,
+		Price:       room.Price,
+		Status:      room.Status.Enum(),
+		Capacity:    room.Capacity,
+		Description: room.Description,
+	}
+}
@@ -3,8 +3,10 @@ package room
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	appMocks "github.com/zakiyalmaya/hotel-management/application/mocks"
 	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
@@ -12,7 +14,8 @@ import (
 )
 
 var (
-	mockRoom *mocks.RoomRepository
+	mockRoom    *mocks.RoomRepository
+	mockPricing *appMocks.PricingService
 )
 
 func Test_Create(t *testing.T) {
@@ -70,7 +73,7 @@ func Test_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom})
+			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom}, nil)
 			err := service.Create(tc.room)
 			assert.Equal(t, tc.err, err)
 		})
@@ -125,7 +128,7 @@ func Test_GetByName(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom})
+			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom}, nil)
 			resp, err := service.GetByName(tc.request)
 			assert.Equal(t, tc.response, resp)
 			assert.Equal(t, tc.err, err)
@@ -190,7 +193,7 @@ func Test_GetAll(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom})
+			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom}, nil)
 			resp, err := service.GetAll(tc.request)
 			assert.Equal(t, tc.response, resp)
 			assert.Equal(t, tc.err, err)
@@ -260,9 +263,117 @@ func Test_Update(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom})
+			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom}, nil)
 			err := service.Update(tc.request)
 			assert.Equal(t, tc.err, err)
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	mockRoom = new(mocks.RoomRepository)
+	mockPricing = new(appMocks.PricingService)
+
+	checkIn := time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC)
+	checkOut := time.Date(2000, time.April, 03, 00, 00, 00, 00, time.UTC)
+	request := &model.SearchRoomRequest{CheckIn: checkIn, CheckOut: checkOut, Guests: 2}
+	minPrice, maxPrice := 150.0, 250.0
+	priceRange := &model.SearchRoomRequest{CheckIn: checkIn, CheckOut: checkOut, Guests: 2, MinPrice: &minPrice, MaxPrice: &maxPrice}
+	stayPrice := func(total float64) *float64 { return &total }
+
+	quote := func(roomName string, total float64, err error) {
+		var response *model.PriceQuoteResponse
+		if err == nil {
+			response = &model.PriceQuoteResponse{RoomName: roomName, Nights: 2, Total: total}
+		}
+		mockPricing.On("Quote", &model.PriceQuoteRequest{RoomName: roomName, CheckIn: checkIn, CheckOut: checkOut}).Return(response, err).Once()
+	}
+
+	testCases := []struct {
+		name     string
+		request  *model.SearchRoomRequest
+		mockCall func()
+		err      error
+		response []*model.RoomResponse
+	}{
+		{
+			name:    "Given valid request when search available rooms then return rooms sorted by the quoted stay price",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("Search", request).Return([]*model.RoomEntity{
+					{ID: 1, Name: "101", Price: 100, Status: constant.RoomStatusAvailable, Capacity: 2},
+					{ID: 2, Name: "102", Price: 200, Status: constant.RoomStatusBooked, Capacity: 4},
+				}, nil).Once()
+				quote("101", 500, nil)
+				quote("102", 400, nil)
+			},
+			err: nil,
+			response: []*model.RoomResponse{
+				{ID: 2, Name: "102", Price: 200, Status: constant.RoomStatusBooked.Enum(), Capacity: 4, StayPrice: stayPrice(400)},
+				{ID: 1, Name: "101", Price: 100, Status: constant.RoomStatusAvailable.Enum(), Capacity: 2, StayPrice: stayPrice(500)},
+			},
+		},
+		{
+			name:    "Given price range when search available rooms then filter by the quoted nightly rate",
+			request: priceRange,
+			mockCall: func() {
+				mockRoom.On("Search", priceRange).Return([]*model.RoomEntity{
+					{ID: 1, Name: "101", Price: 100, Status: constant.RoomStatusAvailable, Capacity: 2},
+					{ID: 2, Name: "102", Price: 200, Status: constant.RoomStatusAvailable, Capacity: 2},
+					{ID: 3, Name: "103", Price: 200, Status: constant.RoomStatusAvailable, Capacity: 2},
+				}, nil).Once()
+				quote("101", 400, nil)
+				quote("102", 600, nil)
+				quote("103", 200, nil)
+			},
+			err: nil,
+			response: []*model.RoomResponse{
+				{ID: 1, Name: "101", Price: 100, Status: constant.RoomStatusAvailable.Enum(), Capacity: 2, StayPrice: stayPrice(400)},
+			},
+		},
+		{
+			name:    "Given stay shorter than the minimum stay when search available rooms then leave the room out",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("Search", request).Return([]*model.RoomEntity{
+					{ID: 1, Name: "101", Price: 100, Status: constant.RoomStatusAvailable, Capacity: 2},
+				}, nil).Once()
+				quote("101", 0, model.NewValidationError("minimum stay for deluxe is 3 nights"))
+			},
+			err:      nil,
+			response: []*model.RoomResponse{},
+		},
+		{
+			name:    "Given error when quote room then return error",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("Search", request).Return([]*model.RoomEntity{
+					{ID: 1, Name: "101", Price: 100, Status: constant.RoomStatusAvailable, Capacity: 2},
+				}, nil).Once()
+				quote("101", 0, errors.New("error"))
+			},
+			err:      errors.New("error"),
+			response: nil,
+		},
+		{
+			name:    "Given error when search available rooms then return error",
+			request: request,
+			mockCall: func() {
+				mockRoom.On("Search", request).Return(nil, errors.New("error")).Once()
+			},
+			err:      errors.New("error"),
+			response: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewRoomServiceImpl(&repository.Repositories{RoomRepo: mockRoom}, mockPricing)
+			resp, err := service.Search(tc.request)
+			assert.Equal(t, tc.response, resp)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -5,5 +5,14 @@ import "github.com/zakiyalmaya/hotel-management/model"
 //go:generate mockery --name=UserService --output=../mocks --outpkg=mocks
 type UserService interface {
 	Create(request *model.CreateUserRequest) error
+	CreateAdmin(request *model.CreateAdminRequest) error
 	ChangePassword(request *model.ChangePasswordRequest) error
+	ForgotPassword(request *model.ForgotPasswordRequest) error
+	ResetPassword(request *model.ResetPasswordRequest) error
+	AssignRole(user *model.UserEntity, assignedBy int) error
+	Unlock(id int) error
+	GetAll(pagination *model.PaginationRequest) (*model.PaginatedResponse, error)
+	GetByID(id int) (*model.UserResponse, error)
+	Update(id int, request *model.UpdateUserRequest, updatedBy int) error
+	Deactivate(id int, deactivatedBy int) error
 }
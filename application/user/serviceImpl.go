@@ -3,31 +3,68 @@ package user
 import (
 	"database/sql"
 	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/mailer"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type userSvcImpl struct {
-	repos *repository.Repositories
+	repos  *repository.Repositories
+	cfg    *config.Config
+	mailer mailer.Mailer
 }
 
-func NewUserServiceImpl(repos *repository.Repositories) UserService {
-	return &userSvcImpl{repos: repos}
+func NewUserServiceImpl(repos *repository.Repositories, cfg *config.Config, mailer mailer.Mailer) UserService {
+	return &userSvcImpl{
+		repos:  repos,
+		cfg:    cfg,
+		mailer: mailer,
+	}
 }
 
+// Create registers a hotelier with the role of their invitation. The invitation must be
+// pending, not expired, and made out to the email the hotelier registers with.
 func (u *userSvcImpl) Create(request *model.CreateUserRequest) error {
+	invitation, err := u.repos.InvitationRepo.GetByTokenHash(utils.HashToken(request.InvitationToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewValidationError("invalid invitation token")
+		}
+
+		return err
+	}
+
+	now := time.Now()
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil || invitation.ExpiresAt.Before(now) {
+		return model.NewValidationError("invitation is no longer valid")
+	}
+
+	if !strings.EqualFold(invitation.Email, request.Email) {
+		return model.NewValidationError("email does not match the invitation")
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return fmt.Errorf("error hashing password")
 	}
 
-	err = u.repos.UserRepo.Create(&model.UserEntity{
+	invitation.AcceptedAt = &now
+	err = u.repos.InvitationRepo.Accept(invitation, &model.UserEntity{
 		Name:     request.Name,
 		Username: request.Username,
 		Password: string(hashedPassword),
 		Email:    request.Email,
+		Role:     invitation.Role,
 	})
 	if err != nil {
 		return err
@@ -36,6 +73,32 @@ func (u *userSvcImpl) Create(request *model.CreateUserRequest) error {
 	return nil
 }
 
+// CreateAdmin bootstraps the first admin of a new installation, once there is an admin
+// everyone else is invited.
+func (u *userSvcImpl) CreateAdmin(request *model.CreateAdminRequest) error {
+	count, err := u.repos.UserRepo.CountByRole(constant.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return model.NewConflictError("an admin already exists, invite new users instead")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error hashing password")
+	}
+
+	return u.repos.UserRepo.Create(&model.UserEntity{
+		Name:     request.Name,
+		Username: request.Username,
+		Password: string(hashedPassword),
+		Email:    request.Email,
+		Role:     constant.RoleAdmin,
+	})
+}
+
 func (u *userSvcImpl) ChangePassword(request *model.ChangePasswordRequest) error {
 	user, err := u.repos.UserRepo.GetByUsername(request.Username)
 	if err != nil {
@@ -68,3 +131,332 @@ func (u *userSvcImpl) ChangePassword(request *model.ChangePasswordRequest) error
 
 	return nil
 }
+
+// ForgotPassword emails a link to reset the password to the hotelier with the username,
+// or to every hotelier registered with the email. Nothing tells whether an account was
+// found, so the endpoint can not be used to find out who works at the hotel, and a link
+// that could not be sent is only logged. Requests are throttled per account and per IP.
+func (u *userSvcImpl) ForgotPassword(request *model.ForgotPasswordRequest) error {
+	account := request.Username
+	if account == "" {
+		account = strings.ToLower(request.Email)
+	}
+
+	if err := u.throttlePasswordReset("reset-"+account, "reset-ip-"+request.IP); err != nil {
+		return err
+	}
+
+	users := make([]*model.UserEntity, 0)
+	if request.Username != "" {
+		user, err := u.repos.UserRepo.GetByUsername(request.Username)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if user != nil {
+			users = append(users, user)
+		}
+	} else {
+		var err error
+		if users, err = u.repos.UserRepo.GetByEmail(request.Email); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range users {
+		if user.DeactivatedAt != nil {
+			continue
+		}
+
+		if err := u.sendPasswordReset(user); err != nil {
+			log.Printf("failed to send password reset to hotelier %d: %s", user.ID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// throttlePasswordReset refuses a password reset while the account or the IP is blocked, and otherwise
+// counts the request for both, blocking either for the window once it reaches its limit.
+func (u *userSvcImpl) throttlePasswordReset(accountKey, ipKey string) error {
+	limits := map[string]int{accountKey: u.cfg.PasswordReset.MaxRequests, ipKey: u.cfg.PasswordReset.IPMaxRequests}
+	for _, key := range []string{accountKey, ipKey} {
+		blockedFor, err := u.repos.LoginAttemptRepo.BlockedFor(key)
+		if err != nil {
+			return fmt.Errorf("failed to get password reset requests from Redis")
+		}
+
+		if blockedFor > 0 {
+			return model.NewTooManyRequestsError(blockedFor, "too many password reset requests, try again in %d seconds", int(math.Ceil(blockedFor.Seconds())))
+		}
+	}
+
+	window := time.Duration(u.cfg.PasswordReset.WindowMinutes) * time.Minute
+	for _, key := range []string{accountKey, ipKey} {
+		requests, err := u.repos.LoginAttemptRepo.RecordFailure(key, window)
+		if err != nil {
+			return fmt.Errorf("failed to store password reset request in Redis")
+		}
+
+		if requests >= limits[key] {
+			if err := u.repos.LoginAttemptRepo.Block(key, window); err != nil {
+				return fmt.Errorf("failed to store password reset request in Redis")
+			}
+		}
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with the token of a reset email. The token works once,
+// and the sessions and the login lockout of the hotelier end with the old password.
+func (u *userSvcImpl) ResetPassword(request *model.ResetPasswordRequest) error {
+	reset, err := u.repos.PasswordResetRepo.GetByTokenHash(utils.HashToken(request.Token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewValidationError("invalid or expired reset token")
+		}
+
+		return err
+	}
+
+	now := time.Now()
+	if reset.UsedAt != nil || now.After(reset.ExpiresAt) {
+		return model.NewValidationError("invalid or expired reset token")
+	}
+
+	user, err := u.repos.UserRepo.GetByID(reset.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewValidationError("invalid or expired reset token")
+		}
+
+		return err
+	}
+
+	if user.DeactivatedAt != nil {
+		return model.NewValidationError("invalid or expired reset token")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error hashing password")
+	}
+
+	user.Password = string(hashedPassword)
+	reset.UsedAt = &now
+	if err := u.repos.PasswordResetRepo.Use(reset, user); err != nil {
+		return err
+	}
+
+	if err := u.repos.SessionRepo.DeleteByUserID(user.ID); err != nil {
+		return fmt.Errorf("failed to delete sessions from Redis")
+	}
+
+	if err := u.repos.LoginAttemptRepo.Reset("user-" + user.Username); err != nil {
+		return fmt.Errorf("failed to reset login attempts in Redis")
+	}
+
+	return nil
+}
+
+// AssignRole changes the role of a hotelier. The sessions of the hotelier are ended
+// so the role in their tokens can not outlive the change.
+func (u *userSvcImpl) AssignRole(user *model.UserEntity, assignedBy int) error {
+	if user.ID == assignedBy {
+		return model.NewConflictError("can not change your own role")
+	}
+
+	existing, err := u.repos.UserRepo.GetByID(user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("user not found")
+		}
+
+		return err
+	}
+
+	existing.Role = user.Role
+	if err := u.repos.UserRepo.UpdateRole(existing); err != nil {
+		return err
+	}
+
+	if err := u.repos.SessionRepo.DeleteByUserID(existing.ID); err != nil {
+		return fmt.Errorf("failed to delete sessions from Redis")
+	}
+
+	return nil
+}
+
+// Unlock lifts the lockout and backoff of a hotelier after too many failed logins or mfa codes.
+func (u *userSvcImpl) Unlock(id int) error {
+	user, err := u.repos.UserRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("user not found")
+		}
+
+		return err
+	}
+
+	if err := u.repos.LoginAttemptRepo.Reset("user-" + user.Username); err != nil {
+		return fmt.Errorf("failed to reset login attempts in Redis")
+	}
+
+	if err := u.repos.LoginAttemptRepo.Reset("mfa-" + user.Username); err != nil {
+		return fmt.Errorf("failed to reset login attempts in Redis")
+	}
+
+	return nil
+}
+
+func (u *userSvcImpl) GetAll(pagination *model.PaginationRequest) (*model.PaginatedResponse, error) {
+	users, total, err := u.repos.UserRepo.GetAll(pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*model.UserResponse, 0, len(users))
+	for _, user := range users {
+		responses = append(responses, toUserResponse(user))
+	}
+
+	return model.NewPaginatedResponse(responses, pagination, total), nil
+}
+
+func (u *userSvcImpl) GetByID(id int) (*model.UserResponse, error) {
+	user, err := u.repos.UserRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.NewNotFoundError("user not found")
+		}
+
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+// Update changes the name, email and role of a hotelier. A new role logs the hotelier
+// out everywhere, as AssignRole does, and admins can not change their own role.
+func (u *userSvcImpl) Update(id int, request *model.UpdateUserRequest, updatedBy int) error {
+	user, err := u.repos.UserRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("user not found")
+		}
+
+		return err
+	}
+
+	roleChanged := request.Role != nil && constant.Role(*request.Role) != user.Role
+	if roleChanged && id == updatedBy {
+		return model.NewConflictError("can not change your own role")
+	}
+
+	if request.Name != nil {
+		user.Name = *request.Name
+	}
+
+	if request.Email != nil {
+		user.Email = *request.Email
+	}
+
+	if request.Role != nil {
+		user.Role = constant.Role(*request.Role)
+	}
+
+	if err := u.repos.UserRepo.Update(user); err != nil {
+		return err
+	}
+
+	if roleChanged {
+		if err := u.repos.SessionRepo.DeleteByUserID(user.ID); err != nil {
+			return fmt.Errorf("failed to delete sessions from Redis")
+		}
+	}
+
+	return nil
+}
+
+// Deactivate stops a hotelier from logging in and ends their sessions at once. The account
+// is kept so bookings, payments and other records still show who made them.
+func (u *userSvcImpl) Deactivate(id int, deactivatedBy int) error {
+	if id == deactivatedBy {
+		return model.NewConflictError("can not deactivate your own account")
+	}
+
+	user, err := u.repos.UserRepo.GetByID(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.NewNotFoundError("user not found")
+		}
+
+		return err
+	}
+
+	now := time.Now()
+	user.DeactivatedAt = &now
+	user.DeactivatedBy = &deactivatedBy
+	if err := u.repos.UserRepo.Deactivate(user); err != nil {
+		return err
+	}
+
+	if err := u.repos.SessionRepo.DeleteByUserID(user.ID); err != nil {
+		return fmt.Errorf("failed to delete sessions from Redis")
+	}
+
+	return nil
+}
+
+func (u *userSvcImpl) sendPasswordReset(user *model.UserEntity) error {
+	token, err := utils.GenerateToken()
+	if err != nil {
+		return fmt.Errorf("failed to create reset token")
+	}
+
+	expiry := time.Duration(u.cfg.PasswordReset.ExpiryMinutes) * time.Minute
+	if err := u.repos.PasswordResetRepo.Create(&model.PasswordResetEntity{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(expiry),
+	}); err != nil {
+		return err
+	}
+
+	link := u.cfg.PasswordReset.URL + "?" + url.Values{"token": {token}}.Encode()
+	body := fmt.Sprintf("Hello %s,\n\n"+
+		"Someone asked to reset the password of your %s account %s. Open the link below within %d minutes to choose a new password:\n\n"+
+		"%s\n\n"+
+		"If it was not you, ignore this email and your password stays the same.\n",
+		user.Name, u.cfg.Hotel.Name, user.Username, u.cfg.PasswordReset.ExpiryMinutes, link)
+
+	if err := u.mailer.Send(&model.MailMessage{To: user.Email, Subject: "Reset your password", Body: body}); err != nil {
+		log.Println("failed to send password reset email: ", err.Error())
+		return fmt.Errorf("failed to send password reset email")
+	}
+
+	return nil
+}
+
+func toUserResponse(user *model.UserEntity) *model.UserResponse {
+	response := &model.UserResponse{
+		ID:         user.ID,
+		Name:       user.Name,
+		Username:   user.Username,
+		Email:      user.Email,
+		Role:       user.Role.Enum(),
+		MfaEnabled: user.TOTPEnabled,
+		Active:     user.DeactivatedAt == nil,
+	}
+
+	if user.DeactivatedAt != nil {
+		response.DeactivatedAt = user.DeactivatedAt.Format("02-01-2006 15:04:05")
+	}
+
+	if user.CreatedAt != nil {
+		response.CreatedAt = user.CreatedAt.Format("02-01-2006 15:04:05")
+	}
+
+	return response
+}
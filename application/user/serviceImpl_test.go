@@ -3,21 +3,47 @@ package user
 import (
 	"database/sql"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/assert/v2"
 	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	mailerMocks "github.com/zakiyalmaya/hotel-management/infrastructure/mailer/mocks"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mocks"
 	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
 )
 
 var (
-	mockUser *mocks.UserRepository
+	mockUser          *mocks.UserRepository
+	mockInvitation    *mocks.InvitationRepository
+	mockPasswordReset *mocks.PasswordResetRepository
+	mockMailer        *mailerMocks.Mailer
+	mockConfig        = &config.Config{
+		Hotel:         config.HotelConfig{Name: "Hotel Management"},
+		PasswordReset: config.PasswordResetConfig{ExpiryMinutes: 30, URL: "http://localhost:3000/reset-password", MaxRequests: 3, IPMaxRequests: 20, WindowMinutes: 60},
+	}
 )
 
 func Test_Create(t *testing.T) {
 	mockUser = new(mocks.UserRepository)
+	mockInvitation = new(mocks.InvitationRepository)
+
+	request := &model.CreateUserRequest{
+		Name:            "name",
+		Username:        "username",
+		Password:        "password",
+		Email:           "Jane@Mail.com",
+		InvitationToken: "token",
+	}
+	pending := func() *model.InvitationEntity {
+		return &model.InvitationEntity{ID: 1, Email: "jane@mail.com", Role: constant.RoleFrontDesk, ExpiresAt: time.Now().Add(time.Hour)}
+	}
+	acceptedAt := time.Now()
 
 	testCases := []struct {
 		name     string
@@ -26,28 +52,56 @@ func Test_Create(t *testing.T) {
 		err      error
 	}{
 		{
-			name: "Given valid request when create a new user then return success response",
-			request: &model.CreateUserRequest{
-				Name:     "name",
-				Username: "username",
-				Password: "password",
-				Email:    "email",
-			},
+			name:    "Given valid invitation when create a new user then register with the role of the invitation",
+			request: request,
 			mockCall: func() {
-				mockUser.On("Create", mock.Anything).Return(nil).Once()
+				mockInvitation.On("GetByTokenHash", utils.HashToken("token")).Return(pending(), nil).Once()
+				mockInvitation.On("Accept", mock.MatchedBy(func(invitation *model.InvitationEntity) bool {
+					return invitation.ID == 1 && invitation.AcceptedAt != nil
+				}), mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.Username == "username" && user.Role == constant.RoleFrontDesk && user.Password != "password"
+				})).Return(nil).Once()
 			},
 			err: nil,
 		},
 		{
-			name: "Given error when create a new user then return error response",
-			request: &model.CreateUserRequest{
-				Name:     "name",
-				Username: "username",
-				Password: "password",
-				Email:    "email",
+			name:    "Given unknown invitation token when create a new user then return validation error",
+			request: request,
+			mockCall: func() {
+				mockInvitation.On("GetByTokenHash", utils.HashToken("token")).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewValidationError("invalid invitation token"),
+		},
+		{
+			name:    "Given expired invitation when create a new user then return validation error",
+			request: request,
+			mockCall: func() {
+				mockInvitation.On("GetByTokenHash", utils.HashToken("token")).Return(&model.InvitationEntity{ID: 1, Email: "jane@mail.com", ExpiresAt: time.Now().Add(-time.Hour)}, nil).Once()
 			},
+			err: model.NewValidationError("invitation is no longer valid"),
+		},
+		{
+			name:    "Given accepted invitation when create a new user then return validation error",
+			request: request,
+			mockCall: func() {
+				mockInvitation.On("GetByTokenHash", utils.HashToken("token")).Return(&model.InvitationEntity{ID: 1, Email: "jane@mail.com", ExpiresAt: time.Now().Add(time.Hour), AcceptedAt: &acceptedAt}, nil).Once()
+			},
+			err: model.NewValidationError("invitation is no longer valid"),
+		},
+		{
+			name:    "Given invitation for another email when create a new user then return validation error",
+			request: request,
+			mockCall: func() {
+				mockInvitation.On("GetByTokenHash", utils.HashToken("token")).Return(&model.InvitationEntity{ID: 1, Email: "john@mail.com", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+			},
+			err: model.NewValidationError("email does not match the invitation"),
+		},
+		{
+			name:    "Given error when create a new user then return error response",
+			request: request,
 			mockCall: func() {
-				mockUser.On("Create", mock.Anything).Return(errors.New("error")).Once()
+				mockInvitation.On("GetByTokenHash", utils.HashToken("token")).Return(pending(), nil).Once()
+				mockInvitation.On("Accept", mock.Anything, mock.Anything).Return(errors.New("error")).Once()
 			},
 			err: errors.New("error"),
 		},
@@ -57,13 +111,60 @@ func Test_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser})
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, InvitationRepo: mockInvitation}, mockConfig, mockMailer)
 			err := service.Create(tc.request.(*model.CreateUserRequest))
 			assert.Equal(t, tc.err, err)
 		})
 	}
 }
 
+func Test_CreateAdmin(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+
+	request := &model.CreateAdminRequest{Name: "name", Username: "username", Password: "password", Email: "email"}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given no admin yet when create admin then create the user as admin",
+			mockCall: func() {
+				mockUser.On("CountByRole", constant.RoleAdmin).Return(0, nil).Once()
+				mockUser.On("Create", mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.Username == "username" && user.Role == constant.RoleAdmin
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given an admin already when create admin then return conflict error",
+			mockCall: func() {
+				mockUser.On("CountByRole", constant.RoleAdmin).Return(1, nil).Once()
+			},
+			err: model.NewConflictError("an admin already exists, invite new users instead"),
+		},
+		{
+			name: "Given error count admins when create admin then return error",
+			mockCall: func() {
+				mockUser.On("CountByRole", constant.RoleAdmin).Return(0, errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser}, mockConfig, mockMailer)
+			err := service.CreateAdmin(request)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
 func Test_ChangePassword(t *testing.T) {
 	mockUser = new(mocks.UserRepository)
 
@@ -167,9 +268,501 @@ func Test_ChangePassword(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser})
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser}, mockConfig, mockMailer)
 			err := service.ChangePassword(tc.request.(*model.ChangePasswordRequest))
 			assert.Equal(t, tc.err, err)
 		})
 	}
 }
+
+func Test_AssignRole(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockSession := new(mocks.SessionRepository)
+
+	testCases := []struct {
+		name       string
+		user       *model.UserEntity
+		assignedBy int
+		mockCall   func()
+		err        error
+	}{
+		{
+			name:       "Given valid request when assign role then save it and end the sessions of the user",
+			user:       &model.UserEntity{ID: 2, Role: constant.RoleAccountant},
+			assignedBy: 1,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "username", Role: constant.RoleReadOnly}, nil).Once()
+				mockUser.On("UpdateRole", &model.UserEntity{ID: 2, Username: "username", Role: constant.RoleAccountant}).Return(nil).Once()
+				mockSession.On("DeleteByUserID", 2).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:       "Given own account when assign role then return conflict error",
+			user:       &model.UserEntity{ID: 1, Role: constant.RoleReadOnly},
+			assignedBy: 1,
+			mockCall:   func() {},
+			err:        model.NewConflictError("can not change your own role"),
+		},
+		{
+			name:       "Given unknown user when assign role then return not found error",
+			user:       &model.UserEntity{ID: 2, Role: constant.RoleAccountant},
+			assignedBy: 1,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("user not found"),
+		},
+		{
+			name:       "Given error update role when assign role then return error",
+			user:       &model.UserEntity{ID: 2, Role: constant.RoleAccountant},
+			assignedBy: 1,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "username"}, nil).Once()
+				mockUser.On("UpdateRole", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: errors.New("error"),
+		},
+		{
+			name:       "Given error delete sessions when assign role then return error",
+			user:       &model.UserEntity{ID: 2, Role: constant.RoleAccountant},
+			assignedBy: 1,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "username"}, nil).Once()
+				mockUser.On("UpdateRole", mock.Anything).Return(nil).Once()
+				mockSession.On("DeleteByUserID", 2).Return(errors.New("error")).Once()
+			},
+			err: errors.New("failed to delete sessions from Redis"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, SessionRepo: mockSession}, mockConfig, mockMailer)
+			err := service.AssignRole(tc.user, tc.assignedBy)
+			assert.Equal(t, tc.err, err)
+			mockSession.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Unlock(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockLoginAttempt := new(mocks.LoginAttemptRepository)
+
+	testCases := []struct {
+		name     string
+		id       int
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given locked user when unlock then reset the login and mfa attempts of the username",
+			id:   2,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "username"}, nil).Once()
+				mockLoginAttempt.On("Reset", "user-username").Return(nil).Once()
+				mockLoginAttempt.On("Reset", "mfa-username").Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unknown user when unlock then return not found error",
+			id:   2,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("user not found"),
+		},
+		{
+			name: "Given error reset when unlock then return error",
+			id:   2,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "username"}, nil).Once()
+				mockLoginAttempt.On("Reset", "user-username").Return(errors.New("error")).Once()
+			},
+			err: errors.New("failed to reset login attempts in Redis"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, LoginAttemptRepo: mockLoginAttempt}, mockConfig, mockMailer)
+			err := service.Unlock(tc.id)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_ForgotPassword(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockPasswordReset = new(mocks.PasswordResetRepository)
+	mockMailer = new(mailerMocks.Mailer)
+	mockLoginAttempt := new(mocks.LoginAttemptRepository)
+
+	jane := &model.UserEntity{ID: 1, Name: "Jane Doe", Username: "janedoe", Email: "jane@mail.com"}
+	resetCreated := func(userID int) {
+		mockPasswordReset.On("Create", mock.MatchedBy(func(reset *model.PasswordResetEntity) bool {
+			return reset.UserID == userID && reset.TokenHash != "" && reset.ExpiresAt.After(time.Now().Add(29*time.Minute))
+		})).Return(nil).Once()
+	}
+	allowed := func(accountKey string) {
+		for _, key := range []string{accountKey, "reset-ip-127.0.0.1"} {
+			mockLoginAttempt.On("BlockedFor", key).Return(time.Duration(0), nil).Once()
+			mockLoginAttempt.On("RecordFailure", key, time.Hour).Return(1, nil).Once()
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		request  *model.ForgotPasswordRequest
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given known username when forgot password then email a reset link",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				allowed("reset-janedoe")
+				mockUser.On("GetByUsername", "janedoe").Return(jane, nil).Once()
+				resetCreated(1)
+				mockMailer.On("Send", mock.MatchedBy(func(message *model.MailMessage) bool {
+					return message.To == "jane@mail.com" && strings.Contains(message.Body, "http://localhost:3000/reset-password?token=")
+				})).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given unknown username when forgot password then succeed without sending",
+			request: &model.ForgotPasswordRequest{Username: "unknown", IP: "127.0.0.1"},
+			mockCall: func() {
+				allowed("reset-unknown")
+				mockUser.On("GetByUsername", "unknown").Return(nil, sql.ErrNoRows).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given email of two accounts when forgot password then email a reset link for each",
+			request: &model.ForgotPasswordRequest{Email: "Jane@mail.com", IP: "127.0.0.1"},
+			mockCall: func() {
+				allowed("reset-jane@mail.com")
+				mockUser.On("GetByEmail", "Jane@mail.com").Return([]*model.UserEntity{jane, {ID: 3, Username: "janedesk", Email: "jane@mail.com"}}, nil).Once()
+				resetCreated(1)
+				resetCreated(3)
+				mockMailer.On("Send", mock.Anything).Return(nil).Twice()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error send when forgot password then succeed the same way",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				allowed("reset-janedoe")
+				mockUser.On("GetByUsername", "janedoe").Return(jane, nil).Once()
+				resetCreated(1)
+				mockMailer.On("Send", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error create reset token when forgot password then succeed the same way",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				allowed("reset-janedoe")
+				mockUser.On("GetByUsername", "janedoe").Return(jane, nil).Once()
+				mockPasswordReset.On("Create", mock.Anything).Return(errors.New("error")).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given last request allowed for the account when forgot password then block the account for the window",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				mockLoginAttempt.On("BlockedFor", "reset-janedoe").Return(time.Duration(0), nil).Once()
+				mockLoginAttempt.On("BlockedFor", "reset-ip-127.0.0.1").Return(time.Duration(0), nil).Once()
+				mockLoginAttempt.On("RecordFailure", "reset-janedoe", time.Hour).Return(3, nil).Once()
+				mockLoginAttempt.On("Block", "reset-janedoe", time.Hour).Return(nil).Once()
+				mockLoginAttempt.On("RecordFailure", "reset-ip-127.0.0.1", time.Hour).Return(3, nil).Once()
+				mockUser.On("GetByUsername", "janedoe").Return(jane, nil).Once()
+				resetCreated(1)
+				mockMailer.On("Send", mock.Anything).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given blocked account when forgot password then return too many requests",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				mockLoginAttempt.On("BlockedFor", "reset-janedoe").Return(90*time.Second, nil).Once()
+			},
+			err: model.NewTooManyRequestsError(90*time.Second, "too many password reset requests, try again in %d seconds", 90),
+		},
+		{
+			name:    "Given blocked ip when forgot password then return too many requests",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				mockLoginAttempt.On("BlockedFor", "reset-janedoe").Return(time.Duration(0), nil).Once()
+				mockLoginAttempt.On("BlockedFor", "reset-ip-127.0.0.1").Return(90*time.Second, nil).Once()
+			},
+			err: model.NewTooManyRequestsError(90*time.Second, "too many password reset requests, try again in %d seconds", 90),
+		},
+		{
+			name:    "Given error redis when forgot password then return error",
+			request: &model.ForgotPasswordRequest{Username: "janedoe", IP: "127.0.0.1"},
+			mockCall: func() {
+				mockLoginAttempt.On("BlockedFor", "reset-janedoe").Return(time.Duration(0), nil).Once()
+				mockLoginAttempt.On("BlockedFor", "reset-ip-127.0.0.1").Return(time.Duration(0), nil).Once()
+				mockLoginAttempt.On("RecordFailure", "reset-janedoe", time.Hour).Return(0, errors.New("error")).Once()
+			},
+			err: errors.New("failed to store password reset request in Redis"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, PasswordResetRepo: mockPasswordReset, LoginAttemptRepo: mockLoginAttempt}, mockConfig, mockMailer)
+			err := service.ForgotPassword(tc.request)
+			assert.Equal(t, tc.err, err)
+			mockMailer.AssertExpectations(t)
+			mockLoginAttempt.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_ResetPassword(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockPasswordReset = new(mocks.PasswordResetRepository)
+	mockSession := new(mocks.SessionRepository)
+	mockLoginAttempt := new(mocks.LoginAttemptRepository)
+
+	request := &model.ResetPasswordRequest{Token: "token", NewPassword: "new-password"}
+	usedAt := time.Now()
+	pending := func() *model.PasswordResetEntity {
+		return &model.PasswordResetEntity{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid token when reset password then save the password and end the sessions",
+			mockCall: func() {
+				mockPasswordReset.On("GetByTokenHash", utils.HashToken("token")).Return(pending(), nil).Once()
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1, Username: "janedoe", Password: "old"}, nil).Once()
+				mockPasswordReset.On("Use", mock.MatchedBy(func(reset *model.PasswordResetEntity) bool {
+					return reset.ID == 5 && reset.UsedAt != nil
+				}), mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.ID == 1 && user.Password != "old" && user.Password != "new-password"
+				})).Return(nil).Once()
+				mockSession.On("DeleteByUserID", 1).Return(nil).Once()
+				mockLoginAttempt.On("Reset", "user-janedoe").Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name: "Given unknown token when reset password then return validation error",
+			mockCall: func() {
+				mockPasswordReset.On("GetByTokenHash", utils.HashToken("token")).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewValidationError("invalid or expired reset token"),
+		},
+		{
+			name: "Given expired token when reset password then return validation error",
+			mockCall: func() {
+				mockPasswordReset.On("GetByTokenHash", utils.HashToken("token")).Return(&model.PasswordResetEntity{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil).Once()
+			},
+			err: model.NewValidationError("invalid or expired reset token"),
+		},
+		{
+			name: "Given used token when reset password then return validation error",
+			mockCall: func() {
+				mockPasswordReset.On("GetByTokenHash", utils.HashToken("token")).Return(&model.PasswordResetEntity{ID: 5, UserID: 1, ExpiresAt: time.Now().Add(time.Minute), UsedAt: &usedAt}, nil).Once()
+			},
+			err: model.NewValidationError("invalid or expired reset token"),
+		},
+		{
+			name: "Given token used at the same time when reset password then return validation error",
+			mockCall: func() {
+				mockPasswordReset.On("GetByTokenHash", utils.HashToken("token")).Return(pending(), nil).Once()
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1, Username: "janedoe"}, nil).Once()
+				mockPasswordReset.On("Use", mock.Anything, mock.Anything).Return(model.NewValidationError("invalid or expired reset token")).Once()
+			},
+			err: model.NewValidationError("invalid or expired reset token"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, PasswordResetRepo: mockPasswordReset, SessionRepo: mockSession, LoginAttemptRepo: mockLoginAttempt}, mockConfig, mockMailer)
+			err := service.ResetPassword(request)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+
+	createdAt := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	deactivatedAt := time.Date(2024, time.June, 1, 9, 0, 0, 0, time.UTC)
+	pagination := &model.PaginationRequest{Page: 2, Limit: 2}
+	mockUser.On("GetAll", pagination).Return([]*model.UserEntity{
+		{ID: 3, Name: "Jane Desk", Username: "janedesk", Email: "jane@mail.com", Role: constant.RoleFrontDesk, TOTPEnabled: true, CreatedAt: &createdAt},
+		{ID: 4, Name: "John Doe", Username: "johndoe", Email: "john@mail.com", Role: constant.RoleReadOnly, DeactivatedAt: &deactivatedAt, CreatedAt: &createdAt},
+	}, 5, nil).Once()
+
+	service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser}, mockConfig, mockMailer)
+	result, err := service.GetAll(pagination)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &model.PaginatedResponse{
+		Items: []*model.UserResponse{
+			{ID: 3, Name: "Jane Desk", Username: "janedesk", Email: "jane@mail.com", Role: "Front Desk", MfaEnabled: true, Active: true, CreatedAt: "01-05-2024 09:00:00"},
+			{ID: 4, Name: "John Doe", Username: "johndoe", Email: "john@mail.com", Role: "Read Only", Active: false, DeactivatedAt: "01-06-2024 09:00:00", CreatedAt: "01-05-2024 09:00:00"},
+		},
+		Page:       2,
+		Limit:      2,
+		Total:      5,
+		TotalPages: 3,
+	}, result)
+}
+
+func Test_Update(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockSession := new(mocks.SessionRepository)
+
+	name, email := "Jane Doe", "jane.doe@mail.com"
+	accountant, frontDesk := int(constant.RoleAccountant), int(constant.RoleFrontDesk)
+	existing := func() *model.UserEntity {
+		return &model.UserEntity{ID: 2, Name: "Jane", Username: "janedoe", Email: "jane@mail.com", Role: constant.RoleFrontDesk}
+	}
+
+	testCases := []struct {
+		name     string
+		id       int
+		request  *model.UpdateUserRequest
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given new name and email when update then keep the role and the sessions",
+			id:      2,
+			request: &model.UpdateUserRequest{Name: &name, Email: &email, Role: &frontDesk},
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(existing(), nil).Once()
+				mockUser.On("Update", &model.UserEntity{ID: 2, Name: "Jane Doe", Username: "janedoe", Email: "jane.doe@mail.com", Role: constant.RoleFrontDesk}).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given new role when update then end the sessions of the hotelier",
+			id:      2,
+			request: &model.UpdateUserRequest{Role: &accountant},
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(existing(), nil).Once()
+				mockUser.On("Update", mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.Name == "Jane" && user.Role == constant.RoleAccountant
+				})).Return(nil).Once()
+				mockSession.On("DeleteByUserID", 2).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:    "Given own role when update then return conflict",
+			id:      1,
+			request: &model.UpdateUserRequest{Role: &accountant},
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(&model.UserEntity{ID: 1, Role: constant.RoleAdmin}, nil).Once()
+			},
+			err: model.NewConflictError("can not change your own role"),
+		},
+		{
+			name:    "Given unknown user when update then return not found",
+			id:      2,
+			request: &model.UpdateUserRequest{Name: &name},
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("user not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, SessionRepo: mockSession}, mockConfig, mockMailer)
+			err := service.Update(tc.id, tc.request, 1)
+			assert.Equal(t, tc.err, err)
+			mockSession.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Deactivate(t *testing.T) {
+	mockUser = new(mocks.UserRepository)
+	mockSession := new(mocks.SessionRepository)
+
+	testCases := []struct {
+		name     string
+		id       int
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given active user when deactivate then end the sessions of the hotelier",
+			id:   2,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "janedoe"}, nil).Once()
+				mockUser.On("Deactivate", mock.MatchedBy(func(user *model.UserEntity) bool {
+					return user.ID == 2 && user.DeactivatedAt != nil && *user.DeactivatedBy == 1
+				})).Return(nil).Once()
+				mockSession.On("DeleteByUserID", 2).Return(nil).Once()
+			},
+			err: nil,
+		},
+		{
+			name:     "Given own account when deactivate then return conflict",
+			id:       1,
+			mockCall: func() {},
+			err:      model.NewConflictError("can not deactivate your own account"),
+		},
+		{
+			name: "Given deactivated user when deactivate then return conflict",
+			id:   2,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(&model.UserEntity{ID: 2, Username: "janedoe"}, nil).Once()
+				mockUser.On("Deactivate", mock.Anything).Return(model.NewConflictError("user is already deactivated")).Once()
+			},
+			err: model.NewConflictError("user is already deactivated"),
+		},
+		{
+			name: "Given unknown user when deactivate then return not found",
+			id:   2,
+			mockCall: func() {
+				mockUser.On("GetByID", 2).Return(nil, sql.ErrNoRows).Once()
+			},
+			err: model.NewNotFoundError("user not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			service := NewUserServiceImpl(&repository.Repositories{UserRepo: mockUser, SessionRepo: mockSession}, mockConfig, mockMailer)
+			err := service.Deactivate(tc.id, 1)
+			assert.Equal(t, tc.err, err)
+			mockSession.AssertExpectations(t)
+		})
+	}
+}
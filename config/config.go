@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/zakiyalmaya/hotel-management/utils"
 )
 
 type Config struct {
@@ -13,6 +15,20 @@ type Config struct {
 		Host string `json:"host"`
 		Port string `json:"port"`
 	} `json:"redis"`
+	Cancellation    CancellationConfig    `json:"cancellation"`
+	Folio           FolioConfig           `json:"folio"`
+	PaymentGateway  PaymentGatewayConfig  `json:"payment_gateway"`
+	Hotel           HotelConfig           `json:"hotel"`
+	Invitation      InvitationConfig      `json:"invitation"`
+	JWT             JWTConfig             `json:"jwt"`
+	Session         SessionConfig         `json:"session"`
+	Login           LoginConfig           `json:"login"`
+	Mfa             MfaConfig             `json:"mfa"`
+	Mailer          MailerConfig          `json:"mailer"`
+	PasswordReset   PasswordResetConfig   `json:"password_reset"`
+	GuestDuplicate  GuestDuplicateConfig  `json:"guest_duplicate"`
+	GuestRetention  GuestRetentionConfig  `json:"guest_retention"`
+	GuestEncryption GuestEncryptionConfig `json:"guest_encryption"`
 }
 
 type ServerConfig struct {
@@ -23,9 +39,247 @@ type DatabaseConfig struct {
 	File string `json:"file"`
 }
 
+// CancellationConfig is the hotel cancellation policy: bookings canceled at least
+// FreeDays before check in are refunded in full, later ones pay PenaltyPercentage.
+type CancellationConfig struct {
+	FreeDays          int     `json:"free_days"`
+	PenaltyPercentage float64 `json:"penalty_percentage"`
+}
+
+// FolioConfig holds the rates added on top of every folio charge. The service charge
+// is a percentage of the charge and the tax is a percentage of the charge plus service.
+type FolioConfig struct {
+	ServiceChargePercentage float64 `json:"service_charge_percentage"`
+	TaxPercentage           float64 `json:"tax_percentage"`
+}
+
+// PaymentGatewayConfig selects the card payment provider. Callbacks posted to the webhook
+// are signed with WebhookSecret, and WebhookURL is where the simulator delivers them.
+type PaymentGatewayConfig struct {
+	Provider      string `json:"provider"`
+	WebhookSecret string `json:"webhook_secret"`
+	WebhookURL    string `json:"webhook_url"`
+}
+
+// HotelConfig is the hotel printed on the header of every invoice.
+type HotelConfig struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email"`
+}
+
+// InvitationConfig sets how long an invitation to register can be used when the admin does not say.
+type InvitationConfig struct {
+	ExpiryHours int `json:"expiry_hours"`
+}
+
+// JWTConfig holds the keys of the access tokens. New tokens are signed with the key named
+// by SigningKeyID and any key of the set verifies them, so a key is rotated out by signing
+// with a new one and keeping the old one until the last token it signed has expired.
+type JWTConfig struct {
+	SigningKeyID string         `json:"signing_key_id"`
+	Keys         []JWTKeyConfig `json:"keys"`
+}
+
+// JWTKeyConfig is a key of the set, ID is sent as the kid header of the tokens it signs.
+// HS256 keys use Secret, RS256 and EdDSA keys read PEM files: PrivateKeyFile to sign
+// or only PublicKeyFile for a key that verifies.
+type JWTKeyConfig struct {
+	ID             string `json:"id"`
+	Algorithm      string `json:"algorithm"`
+	Secret         string `json:"secret,omitempty"`
+	PrivateKeyFile string `json:"private_key_file,omitempty"`
+	PublicKeyFile  string `json:"public_key_file,omitempty"`
+}
+
+// SessionConfig sets how long a login lasts. Access tokens are short lived, the refresh
+// token is replaced on every refresh and the session ends when it is not refreshed in time.
+type SessionConfig struct {
+	AccessTokenExpiryMinutes int `json:"access_token_expiry_minutes"`
+	RefreshTokenExpiryDays   int `json:"refresh_token_expiry_days"`
+}
+
+// LoginConfig slows down password guessing. After FreeAttempts failed logins in a row each
+// failure blocks the username and the IP for BackoffSeconds, doubling up to MaxBackoffSeconds.
+// LockoutAttempts failures lock the username, and IPLockoutAttempts the IP, for LockoutMinutes.
+// Failures are forgotten FailureWindowMinutes after the last one.
+type LoginConfig struct {
+	FreeAttempts         int `json:"free_attempts"`
+	BackoffSeconds       int `json:"backoff_seconds"`
+	MaxBackoffSeconds    int `json:"max_backoff_seconds"`
+	LockoutAttempts      int `json:"lockout_attempts"`
+	IPLockoutAttempts    int `json:"ip_lockout_attempts"`
+	LockoutMinutes       int `json:"lockout_minutes"`
+	FailureWindowMinutes int `json:"failure_window_minutes"`
+}
+
+// MfaConfig sets the second login step of hoteliers with two-factor authentication: the
+// code has to be sent within ChallengeExpiryMinutes and ChallengeAttempts wrong codes end it.
+type MfaConfig struct {
+	ChallengeExpiryMinutes int `json:"challenge_expiry_minutes"`
+	ChallengeAttempts      int `json:"challenge_attempts"`
+}
+
+// MailerConfig selects how emails are sent: "smtp" delivers them through the SMTP server,
+// "file" writes them to Directory, or only logs them when it is empty, for local testing.
+type MailerConfig struct {
+	Provider  string     `json:"provider"`
+	From      string     `json:"from"`
+	Directory string     `json:"directory,omitempty"`
+	SMTP      SMTPConfig `json:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// PasswordResetConfig sets how long the emailed link to reset a password works.
+// URL is the page that takes the token, it is sent as its token query parameter.
+// An account can ask for MaxRequests links and an IP for IPMaxRequests within
+// WindowMinutes, further requests are refused until the window ends.
+type PasswordResetConfig struct {
+	ExpiryMinutes int    `json:"expiry_minutes"`
+	URL           string `json:"url"`
+	MaxRequests   int    `json:"max_requests"`
+	IPMaxRequests int    `json:"ip_max_requests"`
+	WindowMinutes int    `json:"window_minutes"`
+}
+
+// GuestDuplicateConfig sets the search for guests registered more than once. It runs every
+// ScanIntervalMinutes, or only on demand when zero, and suggests pairs scoring at least MinScore.
+// Matching names score 0.4, the date of birth 0.3, the email and the phone number 0.15 each.
+type GuestDuplicateConfig struct {
+	ScanIntervalMinutes int     `json:"scan_interval_minutes"`
+	MinScore            float64 `json:"min_score"`
+}
+
+// GuestRetentionConfig sets how long the personal data of guests is kept. Every
+// ScanIntervalMinutes guests without a stay in the last AnonymizeAfterYears years are
+// anonymized, their bookings and payments are kept. A zero AnonymizeAfterYears keeps guests.
+type GuestRetentionConfig struct {
+	ScanIntervalMinutes int `json:"scan_interval_minutes"`
+	AnonymizeAfterYears int `json:"anonymize_after_years"`
+}
+
+// GuestEncryptionConfig holds the keys encrypting the identity number, phone number and email
+// of guests. Values are encrypted with the key named by ActiveKeyID and any key of the set
+// decrypts them, so a key is rotated by adding a new one and making it active: guests are
+// re-encrypted with it when the server starts, after which the old key can be removed.
+// BlindIndexKey hashes the values to look them up and must not change once guests are stored.
+type GuestEncryptionConfig struct {
+	ActiveKeyID   string                     `json:"active_key_id"`
+	Keys          []GuestEncryptionKeyConfig `json:"keys"`
+	BlindIndexKey string                     `json:"blind_index_key"`
+}
+
+// GuestEncryptionKeyConfig is an AES-256 key of the set, Key is 32 bytes in hex.
+type GuestEncryptionKeyConfig struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// Init writes config.json with the default values when it does not exist yet,
+// an existing file is kept so the keys and settings in it survive a restart.
 func Init() {
+	if _, err := os.Stat("config.json"); err == nil {
+		return
+	}
+
+	config := defaultConfig()
+	if _, err := generateMissingKeys(&config); err != nil {
+		fmt.Println("Error generating keys:", err)
+		os.Exit(1)
+	}
+
+	if err := writeConfig("config.json", &config); err != nil {
+		fmt.Println("Error writing config file:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Config file generated successfully as config.json")
+}
+
+// generateMissingKeys gives the config the keys it has none of, every deployment gets
+// its own secret instead of one known to everyone. It reports whether a key was added.
+func generateMissingKeys(config *Config) (bool, error) {
+	generated := false
+	if len(config.JWT.Keys) == 0 {
+		secret, err := utils.GenerateToken()
+		if err != nil {
+			return false, fmt.Errorf("generating jwt secret: %w", err)
+		}
+
+		config.JWT = JWTConfig{
+			SigningKeyID: "default",
+			Keys: []JWTKeyConfig{
+				{
+					ID:        "default",
+					Algorithm: "HS256",
+					Secret:    secret,
+				},
+			},
+		}
+		generated = true
+	}
+
+	if len(config.GuestEncryption.Keys) == 0 {
+		encryptionKey, err := utils.GenerateToken()
+		if err != nil {
+			return false, fmt.Errorf("generating guest encryption key: %w", err)
+		}
+
+		config.GuestEncryption.ActiveKeyID = "default"
+		config.GuestEncryption.Keys = []GuestEncryptionKeyConfig{{ID: "default", Key: encryptionKey}}
+		generated = true
+	}
+
+	// the blind index of stored guests is made with this key, so it is only generated when
+	// missing and never replaced
+	if config.GuestEncryption.BlindIndexKey == "" {
+		blindIndexKey, err := utils.GenerateToken()
+		if err != nil {
+			return false, fmt.Errorf("generating guest blind index key: %w", err)
+		}
+
+		config.GuestEncryption.BlindIndexKey = blindIndexKey
+		generated = true
+	}
+
+	// the webhook only trusts callbacks signed with this secret, so it is never a known default
+	if config.PaymentGateway.WebhookSecret == "" {
+		webhookSecret, err := utils.GenerateToken()
+		if err != nil {
+			return false, fmt.Errorf("generating payment webhook secret: %w", err)
+		}
+
+		config.PaymentGateway.WebhookSecret = webhookSecret
+		generated = true
+	}
+
+	return generated, nil
+}
+
+func writeConfig(filename string, config *Config) error {
+	configFile, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// the file holds the keys, so only its owner can read it, also when it already existed
+	if err := os.WriteFile(filename, configFile, 0600); err != nil {
+		return err
+	}
+
+	return os.Chmod(filename, 0600)
+}
+
+func defaultConfig() Config {
 	// Initialize config with default values
-	config := Config{
+	return Config{
 		Server: ServerConfig{
 			Port: ":3000",
 		},
@@ -39,23 +293,69 @@ func Init() {
 			Host: "localhost",
 			Port: "6379",
 		},
+		Cancellation: CancellationConfig{
+			FreeDays:          3,
+			PenaltyPercentage: 50,
+		},
+		Folio: FolioConfig{
+			ServiceChargePercentage: 10,
+			TaxPercentage:           11,
+		},
+		PaymentGateway: PaymentGatewayConfig{
+			Provider:   "simulator",
+			WebhookURL: "http://localhost:3000/api/payment/webhook",
+		},
+		Hotel: HotelConfig{
+			Name:    "Hotel Management",
+			Address: "Jl. Merdeka No. 1, Jakarta",
+			Phone:   "+62 21 1234 5678",
+			Email:   "frontdesk@hotel-management.com",
+		},
+		Invitation: InvitationConfig{
+			ExpiryHours: 72,
+		},
+		Session: SessionConfig{
+			AccessTokenExpiryMinutes: 15,
+			RefreshTokenExpiryDays:   30,
+		},
+		Login: LoginConfig{
+			FreeAttempts:         3,
+			BackoffSeconds:       1,
+			MaxBackoffSeconds:    60,
+			LockoutAttempts:      10,
+			IPLockoutAttempts:    50,
+			LockoutMinutes:       30,
+			FailureWindowMinutes: 60,
+		},
+		Mfa: MfaConfig{
+			ChallengeExpiryMinutes: 5,
+			ChallengeAttempts:      5,
+		},
+		Mailer: MailerConfig{
+			Provider:  "file",
+			From:      "no-reply@hotel-management.com",
+			Directory: "./mail",
+			SMTP: SMTPConfig{
+				Host: "localhost",
+				Port: "587",
+			},
+		},
+		PasswordReset: PasswordResetConfig{
+			ExpiryMinutes: 30,
+			URL:           "http://localhost:3000/reset-password",
+			MaxRequests:   3,
+			IPMaxRequests: 20,
+			WindowMinutes: 60,
+		},
+		GuestDuplicate: GuestDuplicateConfig{
+			ScanIntervalMinutes: 1440,
+			MinScore:            0.6,
+		},
+		GuestRetention: GuestRetentionConfig{
+			ScanIntervalMinutes: 1440,
+			AnonymizeAfterYears: 0,
+		},
 	}
-
-	// Convert config struct to JSON
-	configFile, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		fmt.Println("Error marshalling config:", err)
-		os.Exit(1)
-	}
-
-	// Write JSON to file
-	err = os.WriteFile("config.json", configFile, 0644)
-	if err != nil {
-		fmt.Println("Error writing config file:", err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Config file generated successfully as config.json")
 }
 
 func LoadConfig(filename string) (*Config, error) {
@@ -65,12 +365,24 @@ func LoadConfig(filename string) (*Config, error) {
 	}
 	defer file.Close()
 
-	// Decode the JSON into the struct
-	var config Config
+	// Decode the JSON over the defaults so settings added later have a value
+	config := defaultConfig()
 	decoder := json.NewDecoder(file)
 	if err := decoder.Decode(&config); err != nil {
 		return nil, err
 	}
 
+	// configs written before a key was needed get one, kept in the file for the next start
+	generated, err := generateMissingKeys(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	if generated {
+		if err := writeConfig(filename, &config); err != nil {
+			return nil, err
+		}
+	}
+
 	return &config, nil
 }
@@ -0,0 +1,52 @@
+package constant
+
+type BookingStatus int
+
+const (
+	BookingStatusConfirmed  BookingStatus = iota + 1 // Reservation is held for the guest
+	BookingStatusCheckedIn                           // Guest has arrived and occupies the room
+	BookingStatusCheckedOut                          // Guest has left, the stay is finished
+	BookingStatusNoShow                              // Guest never arrived for the reservation
+	BookingStatusCancelled                           // Reservation was cancelled before arrival
+)
+
+var mapBookingStatus = map[BookingStatus]string{
+	BookingStatusConfirmed:  "Confirmed",
+	BookingStatusCheckedIn:  "Checked In",
+	BookingStatusCheckedOut: "Checked Out",
+	BookingStatusNoShow:     "No Show",
+	BookingStatusCancelled:  "Cancelled",
+}
+
+// bookingStatusTransitions lists the statuses a booking may move to from each status.
+// Confirmed to Confirmed is a reschedule; statuses without an entry are final.
+var bookingStatusTransitions = map[BookingStatus][]BookingStatus{
+	BookingStatusConfirmed: {BookingStatusConfirmed, BookingStatusCheckedIn, BookingStatusNoShow, BookingStatusCancelled},
+	BookingStatusCheckedIn: {BookingStatusCheckedOut},
+}
+
+func (bs BookingStatus) Enum() string {
+	if val, ok := mapBookingStatus[bs]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+func (bs BookingStatus) Validation() bool {
+	if _, ok := mapBookingStatus[bs]; !ok {
+		return false
+	}
+
+	return true
+}
+
+func (bs BookingStatus) CanTransitionTo(next BookingStatus) bool {
+	for _, status := range bookingStatusTransitions[bs] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
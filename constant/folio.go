@@ -0,0 +1,40 @@
+package constant
+
+type FolioChargeType int
+
+const (
+	FolioChargeTypeRoom    FolioChargeType = iota + 1 // One night of the stay, posted when the booking is made or rescheduled
+	FolioChargeTypeMinibar                            // Extras below are posted by staff during the stay
+	FolioChargeTypeLaundry
+	FolioChargeTypeRoomService
+	FolioChargeTypeOther
+)
+
+var mapFolioChargeType = map[FolioChargeType]string{
+	FolioChargeTypeRoom:        "Room",
+	FolioChargeTypeMinibar:     "Minibar",
+	FolioChargeTypeLaundry:     "Laundry",
+	FolioChargeTypeRoomService: "Room Service",
+	FolioChargeTypeOther:       "Other",
+}
+
+func (fc FolioChargeType) Enum() string {
+	if val, ok := mapFolioChargeType[fc]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+func (fc FolioChargeType) Validation() bool {
+	if _, ok := mapFolioChargeType[fc]; !ok {
+		return false
+	}
+
+	return true
+}
+
+// IsExtra reports whether staff may post the charge type by hand, room nights only come from pricing.
+func (fc FolioChargeType) IsExtra() bool {
+	return fc.Validation() && fc != FolioChargeTypeRoom
+}
@@ -0,0 +1,36 @@
+package constant
+
+type (
+	GatewayStatus    int
+	GatewayEventType string
+)
+
+const (
+	GatewayStatusAuthorized GatewayStatus = iota + 1 // The amount is held on the card
+	GatewayStatusCaptured                            // The held amount has been charged
+	GatewayStatusVoided                              // The hold has been released without charging
+	GatewayStatusRefunded                            // Charged money has been returned to the card
+)
+
+var mapGatewayStatus = map[GatewayStatus]string{
+	GatewayStatusAuthorized: "Authorized",
+	GatewayStatusCaptured:   "Captured",
+	GatewayStatusVoided:     "Voided",
+	GatewayStatusRefunded:   "Refunded",
+}
+
+func (gs GatewayStatus) Enum() string {
+	if val, ok := mapGatewayStatus[gs]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+// Events the payment gateway delivers to the webhook
+const (
+	GatewayEventAuthorized GatewayEventType = "payment.authorized"
+	GatewayEventCaptured   GatewayEventType = "payment.captured"
+	GatewayEventVoided     GatewayEventType = "payment.voided"
+	GatewayEventRefunded   GatewayEventType = "payment.refunded"
+)
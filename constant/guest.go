@@ -0,0 +1,45 @@
+package constant
+
+type GuestDuplicateStatus int
+
+const (
+	GuestDuplicateStatusPending   GuestDuplicateStatus = iota + 1 // Suggestion waits for staff to review it
+	GuestDuplicateStatusMerged                                    // Both guests were merged into one
+	GuestDuplicateStatusDismissed                                 // Staff decided the guests are different people
+)
+
+var mapGuestDuplicateStatus = map[GuestDuplicateStatus]string{
+	GuestDuplicateStatusPending:   "Pending",
+	GuestDuplicateStatusMerged:    "Merged",
+	GuestDuplicateStatusDismissed: "Dismissed",
+}
+
+func (gs GuestDuplicateStatus) Enum() string {
+	if val, ok := mapGuestDuplicateStatus[gs]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+type GuestExportFormat string
+
+// Files the data of a guest can be exported as
+const (
+	GuestExportFormatJSON GuestExportFormat = "json"
+	GuestExportFormatZIP  GuestExportFormat = "zip"
+)
+
+var mapGuestExportContentType = map[GuestExportFormat]string{
+	GuestExportFormatJSON: "application/json",
+	GuestExportFormatZIP:  "application/zip",
+}
+
+func (f GuestExportFormat) ContentType() string {
+	return mapGuestExportContentType[f]
+}
+
+func (f GuestExportFormat) Validation() bool {
+	_, ok := mapGuestExportContentType[f]
+	return ok
+}
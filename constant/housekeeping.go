@@ -0,0 +1,53 @@
+package constant
+
+type HousekeepingTaskStatus int
+
+const (
+	HousekeepingTaskStatusPending    HousekeepingTaskStatus = iota + 1 // Task waits for a housekeeper to claim it
+	HousekeepingTaskStatusInProgress                                   // A housekeeper has claimed the task and is cleaning
+	HousekeepingTaskStatusCompleted                                    // Cleaning is done and waits for inspection
+	HousekeepingTaskStatusInspected                                    // Inspection passed and the room was released
+	HousekeepingTaskStatusRejected                                     // Inspection failed and the room was sent back to cleaning
+)
+
+var mapHousekeepingTaskStatus = map[HousekeepingTaskStatus]string{
+	HousekeepingTaskStatusPending:    "Pending",
+	HousekeepingTaskStatusInProgress: "In Progress",
+	HousekeepingTaskStatusCompleted:  "Completed",
+	HousekeepingTaskStatusInspected:  "Inspected",
+	HousekeepingTaskStatusRejected:   "Rejected",
+}
+
+// housekeepingTaskStatusTransitions lists the statuses a task may move to from each status.
+// Statuses without an entry are final.
+var housekeepingTaskStatusTransitions = map[HousekeepingTaskStatus][]HousekeepingTaskStatus{
+	HousekeepingTaskStatusPending:    {HousekeepingTaskStatusInProgress},
+	HousekeepingTaskStatusInProgress: {HousekeepingTaskStatusCompleted},
+	HousekeepingTaskStatusCompleted:  {HousekeepingTaskStatusInspected, HousekeepingTaskStatusRejected},
+}
+
+func (hs HousekeepingTaskStatus) Enum() string {
+	if val, ok := mapHousekeepingTaskStatus[hs]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+func (hs HousekeepingTaskStatus) Validation() bool {
+	if _, ok := mapHousekeepingTaskStatus[hs]; !ok {
+		return false
+	}
+
+	return true
+}
+
+func (hs HousekeepingTaskStatus) CanTransitionTo(next HousekeepingTaskStatus) bool {
+	for _, status := range housekeepingTaskStatusTransitions[hs] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
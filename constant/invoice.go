@@ -0,0 +1,23 @@
+package constant
+
+type InvoiceFormat string
+
+// Documents an invoice can be rendered as
+const (
+	InvoiceFormatPDF  InvoiceFormat = "pdf"
+	InvoiceFormatHTML InvoiceFormat = "html"
+)
+
+var mapInvoiceContentType = map[InvoiceFormat]string{
+	InvoiceFormatPDF:  "application/pdf",
+	InvoiceFormatHTML: "text/html; charset=utf-8",
+}
+
+func (f InvoiceFormat) ContentType() string {
+	return mapInvoiceContentType[f]
+}
+
+func (f InvoiceFormat) Validation() bool {
+	_, ok := mapInvoiceContentType[f]
+	return ok
+}
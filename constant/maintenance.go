@@ -0,0 +1,62 @@
+package constant
+
+type (
+	MaintenancePriority int
+	MaintenanceStatus   int
+)
+
+const (
+	MaintenancePriorityLow MaintenancePriority = iota + 1
+	MaintenancePriorityMedium
+	MaintenancePriorityHigh
+	MaintenancePriorityUrgent
+)
+
+var mapMaintenancePriority = map[MaintenancePriority]string{
+	MaintenancePriorityLow:    "Low",
+	MaintenancePriorityMedium: "Medium",
+	MaintenancePriorityHigh:   "High",
+	MaintenancePriorityUrgent: "Urgent",
+}
+
+func (mp MaintenancePriority) Enum() string {
+	if val, ok := mapMaintenancePriority[mp]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+func (mp MaintenancePriority) Validation() bool {
+	if _, ok := mapMaintenancePriority[mp]; !ok {
+		return false
+	}
+
+	return true
+}
+
+const (
+	MaintenanceStatusOpen   MaintenanceStatus = iota + 1 // Work is pending or ongoing and the room is blocked for the order dates
+	MaintenanceStatusClosed                              // Work is finished and the room is released
+)
+
+var mapMaintenanceStatus = map[MaintenanceStatus]string{
+	MaintenanceStatusOpen:   "Open",
+	MaintenanceStatusClosed: "Closed",
+}
+
+func (ms MaintenanceStatus) Enum() string {
+	if val, ok := mapMaintenanceStatus[ms]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+func (ms MaintenanceStatus) Validation() bool {
+	if _, ok := mapMaintenanceStatus[ms]; !ok {
+		return false
+	}
+
+	return true
+}
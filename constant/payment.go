@@ -34,19 +34,21 @@ func (pm PaymentMethod) Validation() bool {
 }
 
 const (
-	PaymentStatusPending   PaymentStatus = iota + 1 // Payment has been initiated but not completed yet
-	PaymentStatusCompleted                          // Payment has been successfully processed
-	PaymentStatusFailed                             // Payment attempt failed due to an error
-	PaymentStatusCanceled                           // Payment has been canceled by the customer or the system
-	PaymentStatusRefunded                           // Payment has been refunded to the customer
+	PaymentStatusPending       PaymentStatus = iota + 1 // Payment has been initiated but not completed yet
+	PaymentStatusCompleted                              // Payment has been successfully processed
+	PaymentStatusFailed                                 // Payment attempt failed due to an error
+	PaymentStatusCanceled                               // Payment has been canceled by the customer or the system
+	PaymentStatusRefunded                               // Payment has been refunded to the customer
+	PaymentStatusPartiallyPaid                          // A deposit or part of the folio has been paid, the rest is still due
 )
 
 var mapPayemntStatus = map[PaymentStatus]string{
-	PaymentStatusPending:   "Pending",
-	PaymentStatusCompleted: "Completed",
-	PaymentStatusFailed:    "Failed",
-	PaymentStatusCanceled:  "Canceled",
-	PaymentStatusRefunded:  "Refunded",
+	PaymentStatusPending:       "Pending",
+	PaymentStatusCompleted:     "Completed",
+	PaymentStatusFailed:        "Failed",
+	PaymentStatusCanceled:      "Canceled",
+	PaymentStatusRefunded:      "Refunded",
+	PaymentStatusPartiallyPaid: "Partially Paid",
 }
 
 func (ps PaymentStatus) Enum() string {
@@ -63,4 +65,4 @@ func (ps PaymentStatus) Validation() bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
@@ -0,0 +1,35 @@
+package constant
+
+type Role int
+
+const (
+	RoleAdmin        Role = iota + 1 // Manages users, rooms and rates, and can do everything the other roles can
+	RoleFrontDesk                    // Handles guests, bookings, stays and takes payments
+	RoleHousekeeping                 // Works the cleaning tasks and reports room maintenance
+	RoleAccountant                   // Takes payments and refunds, posts charges and prints invoices
+	RoleReadOnly                     // Can only look
+)
+
+var mapRole = map[Role]string{
+	RoleAdmin:        "Admin",
+	RoleFrontDesk:    "Front Desk",
+	RoleHousekeeping: "Housekeeping",
+	RoleAccountant:   "Accountant",
+	RoleReadOnly:     "Read Only",
+}
+
+func (r Role) Enum() string {
+	if val, ok := mapRole[r]; ok {
+		return val
+	}
+
+	return "Unknown"
+}
+
+func (r Role) Validation() bool {
+	if _, ok := mapRole[r]; !ok {
+		return false
+	}
+
+	return true
+}
@@ -2,11 +2,18 @@ package constant
 
 type RoomStatus int
 
+// DefaultRoomCapacity is the number of guests a room sleeps when none is given.
+const DefaultRoomCapacity = 2
+
 const (
-	RoomStatusAvailable  RoomStatus = iota + 1
+	RoomStatusAvailable RoomStatus = iota + 1
 	RoomStatusBooked
 	RoomStatusMaintenance
 	RoomStatusUnavailable
+	RoomStatusOccupied
+	RoomStatusDirty     // Guest has checked out and the room waits for housekeeping
+	RoomStatusCleaning  // A housekeeper is cleaning the room
+	RoomStatusInspected // Cleaning is done and the room waits for the supervisor's inspection
 )
 
 var mapRoomStatus = map[RoomStatus]string{
@@ -14,6 +21,10 @@ var mapRoomStatus = map[RoomStatus]string{
 	RoomStatusBooked:      "Booked",
 	RoomStatusMaintenance: "Maintenance",
 	RoomStatusUnavailable: "Unavailable",
+	RoomStatusOccupied:    "Occupied",
+	RoomStatusDirty:       "Dirty",
+	RoomStatusCleaning:    "Cleaning",
+	RoomStatusInspected:   "Inspected",
 }
 
 func (r RoomStatus) Enum() string {
@@ -23,3 +34,9 @@ func (r RoomStatus) Enum() string {
 
 	return "Unknown"
 }
+
+// IsBookable reports whether a room in this status can take reservations.
+// Booked rooms are still bookable for dates that do not overlap an existing stay.
+func (r RoomStatus) IsBookable() bool {
+	return r != RoomStatusMaintenance && r != RoomStatusUnavailable
+}
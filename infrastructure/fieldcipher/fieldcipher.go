@@ -0,0 +1,127 @@
+package fieldcipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+)
+
+// prefix marks an encrypted value, it is followed by the id of the key and the sealed value.
+// Values without it were stored before encryption and are read as they are.
+const prefix = "enc:"
+
+// Cipher encrypts the personal data of guests at rest with AES-256-GCM. Values are encrypted
+// with the active key and name the key they were encrypted with, so any key of the set
+// decrypts them and a key is rotated out once nothing encrypted with it is left.
+type Cipher struct {
+	active   string
+	keys     map[string]cipher.AEAD
+	indexKey []byte
+}
+
+func NewCipher(cfg config.GuestEncryptionConfig) (*Cipher, error) {
+	c := &Cipher{active: cfg.ActiveKeyID, keys: map[string]cipher.AEAD{}}
+	for _, keyCfg := range cfg.Keys {
+		if keyCfg.ID == "" || strings.Contains(keyCfg.ID, ":") {
+			return nil, fmt.Errorf("encryption key id %q is empty or contains a colon", keyCfg.ID)
+		}
+
+		if _, ok := c.keys[keyCfg.ID]; ok {
+			return nil, fmt.Errorf("duplicate encryption key id %s", keyCfg.ID)
+		}
+
+		aead, err := newAEAD(keyCfg.Key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %s: %w", keyCfg.ID, err)
+		}
+		c.keys[keyCfg.ID] = aead
+	}
+
+	if _, ok := c.keys[cfg.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("active key %s is not in the key set", cfg.ActiveKeyID)
+	}
+
+	indexKey, err := hex.DecodeString(cfg.BlindIndexKey)
+	if err != nil || len(indexKey) < 32 {
+		return nil, fmt.Errorf("blind index key must be at least 32 bytes in hex")
+	}
+	c.indexKey = indexKey
+
+	return c, nil
+}
+
+func newAEAD(hexKey string) (cipher.AEAD, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes in hex")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt seals the value with the active key.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	aead := c.keys[c.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return prefix + c.active + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens the value with the key it names, values stored before encryption are returned as they are.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+
+	aead, ok := c.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("unknown encryption key %q", keyID)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// IsCurrent reports whether the value is encrypted with the active key, the others need re-encrypting.
+func (c *Cipher) IsCurrent(value string) bool {
+	return strings.HasPrefix(value, prefix+c.active+":")
+}
+
+// BlindIndex is a keyed hash of the value, ignoring case and surrounding spaces. Equal values
+// get the same index, so it finds and keeps unique what is encrypted without revealing it.
+func (c *Cipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
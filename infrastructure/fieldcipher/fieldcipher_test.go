@@ -0,0 +1,125 @@
+package fieldcipher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
+)
+
+const (
+	oldKey   = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	newKey   = "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+	indexKey = "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f"
+)
+
+func Test_NewCipher(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  config.GuestEncryptionConfig
+		err  bool
+	}{
+		{
+			name: "Given valid keys when new cipher then return the cipher",
+			cfg:  config.GuestEncryptionConfig{ActiveKeyID: "v1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: oldKey}}, BlindIndexKey: indexKey},
+			err:  false,
+		},
+		{
+			name: "Given active key outside the set when new cipher then return error",
+			cfg:  config.GuestEncryptionConfig{ActiveKeyID: "v2", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: oldKey}}, BlindIndexKey: indexKey},
+			err:  true,
+		},
+		{
+			name: "Given short key when new cipher then return error",
+			cfg:  config.GuestEncryptionConfig{ActiveKeyID: "v1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: "0001"}}, BlindIndexKey: indexKey},
+			err:  true,
+		},
+		{
+			name: "Given key id with a colon when new cipher then return error",
+			cfg:  config.GuestEncryptionConfig{ActiveKeyID: "v:1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v:1", Key: oldKey}}, BlindIndexKey: indexKey},
+			err:  true,
+		},
+		{
+			name: "Given no blind index key when new cipher then return error",
+			cfg:  config.GuestEncryptionConfig{ActiveKeyID: "v1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: oldKey}}},
+			err:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCipher(tc.cfg)
+			assert.Equal(t, tc.err, err != nil)
+		})
+	}
+}
+
+func Test_EncryptAndDecrypt(t *testing.T) {
+	c, err := NewCipher(config.GuestEncryptionConfig{ActiveKeyID: "v1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: oldKey}}, BlindIndexKey: indexKey})
+	assert.NoError(t, err)
+
+	encrypted, err := c.Encrypt("3171234567890001")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:"))
+	assert.NotContains(t, encrypted, "3171234567890001")
+
+	// a new nonce every time, equal values do not give away they are equal
+	again, err := c.Encrypt("3171234567890001")
+	assert.NoError(t, err)
+	assert.NotEqual(t, encrypted, again)
+
+	decrypted, err := c.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "3171234567890001", decrypted)
+
+	plaintext, err := c.Decrypt("john.doe@mail.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "john.doe@mail.com", plaintext)
+
+	_, err = c.Decrypt(encrypted[:len(encrypted)-4] + "AAAA")
+	assert.Error(t, err)
+}
+
+func Test_Rotation(t *testing.T) {
+	before, err := NewCipher(config.GuestEncryptionConfig{ActiveKeyID: "v1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: oldKey}}, BlindIndexKey: indexKey})
+	assert.NoError(t, err)
+
+	encrypted, err := before.Encrypt("08123456789")
+	assert.NoError(t, err)
+
+	// the new key encrypts, the old one is kept to decrypt until everything is re-encrypted
+	after, err := NewCipher(config.GuestEncryptionConfig{
+		ActiveKeyID:   "v2",
+		Keys:          []config.GuestEncryptionKeyConfig{{ID: "v2", Key: newKey}, {ID: "v1", Key: oldKey}},
+		BlindIndexKey: indexKey,
+	})
+	assert.NoError(t, err)
+	assert.False(t, after.IsCurrent(encrypted))
+	assert.False(t, after.IsCurrent("08123456789"))
+
+	decrypted, err := after.Decrypt(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, "08123456789", decrypted)
+
+	reEncrypted, err := after.Encrypt(decrypted)
+	assert.NoError(t, err)
+	assert.True(t, after.IsCurrent(reEncrypted))
+	assert.Equal(t, before.BlindIndex("08123456789"), after.BlindIndex("08123456789"))
+
+	// once the old key is removed what it encrypted can no longer be read
+	removed, err := NewCipher(config.GuestEncryptionConfig{ActiveKeyID: "v2", Keys: []config.GuestEncryptionKeyConfig{{ID: "v2", Key: newKey}}, BlindIndexKey: indexKey})
+	assert.NoError(t, err)
+
+	_, err = removed.Decrypt(encrypted)
+	assert.Error(t, err)
+}
+
+func Test_BlindIndex(t *testing.T) {
+	c, err := NewCipher(config.GuestEncryptionConfig{ActiveKeyID: "v1", Keys: []config.GuestEncryptionKeyConfig{{ID: "v1", Key: oldKey}}, BlindIndexKey: indexKey})
+	assert.NoError(t, err)
+
+	assert.Equal(t, c.BlindIndex("John.Doe@mail.com "), c.BlindIndex("john.doe@mail.com"))
+	assert.NotEqual(t, c.BlindIndex("john.doe@mail.com"), c.BlindIndex("jane.doe@mail.com"))
+	assert.Len(t, c.BlindIndex("john.doe@mail.com"), 64)
+}
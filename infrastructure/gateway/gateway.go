@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// SignatureHeader carries the signature of a webhook callback.
+const SignatureHeader = "X-Gateway-Signature"
+
+// PaymentGateway holds and charges guest cards. A booking is authorized when it is made,
+// captured or voided when it ends and captured money can be refunded.
+//
+//go:generate mockery --name=PaymentGateway --output=./mocks --outpkg=mocks
+type PaymentGateway interface {
+	Authorize(orderID string, amount float64, cardToken string) (*model.GatewayTransaction, error)
+	Capture(transactionID string, amount float64) (*model.GatewayTransaction, error)
+	Void(transactionID string) (*model.GatewayTransaction, error)
+	Refund(transactionID string, amount float64) (*model.GatewayTransaction, error)
+	VerifySignature(payload []byte, signature string) bool
+}
+
+func NewPaymentGateway(cfg config.PaymentGatewayConfig) PaymentGateway {
+	switch cfg.Provider {
+	case "simulator":
+		return NewSimulatorGateway(cfg)
+	default:
+		log.Panicln("unknown payment gateway provider: ", cfg.Provider)
+		return nil
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verify(secret string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
@@ -0,0 +1,165 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// PaymentGateway is an autogenerated mock type for the PaymentGateway type
+type PaymentGateway struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: orderID, amount, cardToken
+func (_m *PaymentGateway) Authorize(orderID string, amount float64, cardToken string) (*model.GatewayTransaction, error) {
+	ret := _m.Called(orderID, amount, cardToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 *model.GatewayTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, float64, string) (*model.GatewayTransaction, error)); ok {
+		return rf(orderID, amount, cardToken)
+	}
+	if rf, ok := ret.Get(0).(func(string, float64, string) *model.GatewayTransaction); ok {
+		r0 = rf(orderID, amount, cardToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GatewayTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, float64, string) error); ok {
+		r1 = rf(orderID, amount, cardToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Capture provides a mock function with given fields: transactionID, amount
+func (_m *PaymentGateway) Capture(transactionID string, amount float64) (*model.GatewayTransaction, error) {
+	ret := _m.Called(transactionID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Capture")
+	}
+
+	var r0 *model.GatewayTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, float64) (*model.GatewayTransaction, error)); ok {
+		return rf(transactionID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(string, float64) *model.GatewayTransaction); ok {
+		r0 = rf(transactionID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GatewayTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, float64) error); ok {
+		r1 = rf(transactionID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refund provides a mock function with given fields: transactionID, amount
+func (_m *PaymentGateway) Refund(transactionID string, amount float64) (*model.GatewayTransaction, error) {
+	ret := _m.Called(transactionID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 *model.GatewayTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, float64) (*model.GatewayTransaction, error)); ok {
+		return rf(transactionID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(string, float64) *model.GatewayTransaction); ok {
+		r0 = rf(transactionID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GatewayTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, float64) error); ok {
+		r1 = rf(transactionID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifySignature provides a mock function with given fields: payload, signature
+func (_m *PaymentGateway) VerifySignature(payload []byte, signature string) bool {
+	ret := _m.Called(payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignature")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte, string) bool); ok {
+		r0 = rf(payload, signature)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Void provides a mock function with given fields: transactionID
+func (_m *PaymentGateway) Void(transactionID string) (*model.GatewayTransaction, error) {
+	ret := _m.Called(transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Void")
+	}
+
+	var r0 *model.GatewayTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.GatewayTransaction, error)); ok {
+		return rf(transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.GatewayTransaction); ok {
+		r0 = rf(transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GatewayTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentGateway creates a new instance of PaymentGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentGateway(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentGateway {
+	mock := &PaymentGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
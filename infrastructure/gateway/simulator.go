@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// SimulatorDeclinedCard is the card token the simulator always declines.
+const SimulatorDeclinedCard = "tok_declined"
+
+type simulatorTransaction struct {
+	orderID    string
+	authorized float64
+	captured   float64
+	refunded   float64
+	status     constant.GatewayStatus
+}
+
+// simulatorGateway is an in-process gateway for running the card flow offline. Transactions
+// live in memory and every operation is also delivered, signed, to the configured webhook.
+type simulatorGateway struct {
+	cfg          config.PaymentGatewayConfig
+	client       *http.Client
+	mu           sync.Mutex
+	transactions map[string]*simulatorTransaction
+}
+
+func NewSimulatorGateway(cfg config.PaymentGatewayConfig) PaymentGateway {
+	return &simulatorGateway{
+		cfg:          cfg,
+		client:       &http.Client{Timeout: 5 * time.Second},
+		transactions: make(map[string]*simulatorTransaction),
+	}
+}
+
+func (s *simulatorGateway) Authorize(orderID string, amount float64, cardToken string) (*model.GatewayTransaction, error) {
+	if cardToken == SimulatorDeclinedCard {
+		return nil, model.NewValidationError("card declined")
+	}
+
+	if amount <= 0 {
+		return nil, model.NewValidationError("authorization amount must be greater than zero")
+	}
+
+	s.mu.Lock()
+	id := "sim_auth_" + uuid.New().String()
+	s.transactions[id] = &simulatorTransaction{orderID: orderID, authorized: amount, status: constant.GatewayStatusAuthorized}
+	s.mu.Unlock()
+
+	return s.notify(constant.GatewayEventAuthorized, id, &model.GatewayTransaction{ID: id, OrderID: orderID, Amount: amount, Status: constant.GatewayStatusAuthorized}), nil
+}
+
+func (s *simulatorGateway) Capture(transactionID string, amount float64) (*model.GatewayTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transaction, ok := s.transactions[transactionID]
+	if !ok {
+		return nil, model.NewValidationError("transaction %s not found", transactionID)
+	}
+
+	if transaction.status != constant.GatewayStatusAuthorized {
+		return nil, model.NewConflictError("can not capture a %s transaction", transaction.status.Enum())
+	}
+
+	if amount <= 0 || amount > transaction.authorized {
+		return nil, model.NewValidationError("capture amount must be greater than zero and at most the authorized amount")
+	}
+
+	transaction.captured = amount
+	transaction.status = constant.GatewayStatusCaptured
+
+	return s.notify(constant.GatewayEventCaptured, transactionID, &model.GatewayTransaction{ID: transactionID, OrderID: transaction.orderID, Amount: amount, Status: transaction.status}), nil
+}
+
+func (s *simulatorGateway) Void(transactionID string) (*model.GatewayTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transaction, ok := s.transactions[transactionID]
+	if !ok {
+		return nil, model.NewValidationError("transaction %s not found", transactionID)
+	}
+
+	if transaction.status != constant.GatewayStatusAuthorized {
+		return nil, model.NewConflictError("can not void a %s transaction", transaction.status.Enum())
+	}
+
+	transaction.status = constant.GatewayStatusVoided
+
+	return s.notify(constant.GatewayEventVoided, transactionID, &model.GatewayTransaction{ID: transactionID, OrderID: transaction.orderID, Amount: transaction.authorized, Status: transaction.status}), nil
+}
+
+func (s *simulatorGateway) Refund(transactionID string, amount float64) (*model.GatewayTransaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transaction, ok := s.transactions[transactionID]
+	if !ok {
+		return nil, model.NewValidationError("transaction %s not found", transactionID)
+	}
+
+	if transaction.status != constant.GatewayStatusCaptured {
+		return nil, model.NewConflictError("can not refund a %s transaction", transaction.status.Enum())
+	}
+
+	if amount <= 0 || amount > transaction.captured-transaction.refunded {
+		return nil, model.NewValidationError("refund amount must be greater than zero and at most the captured amount")
+	}
+
+	// the authorization stays captured until all of it has been given back
+	transaction.refunded += amount
+	if transaction.refunded >= transaction.captured {
+		transaction.status = constant.GatewayStatusRefunded
+	}
+
+	refundID := "sim_refund_" + uuid.New().String()
+	s.notify(constant.GatewayEventRefunded, transactionID, &model.GatewayTransaction{ID: refundID, OrderID: transaction.orderID, Amount: amount, Status: constant.GatewayStatusRefunded})
+
+	return &model.GatewayTransaction{ID: refundID, OrderID: transaction.orderID, Amount: amount, Status: constant.GatewayStatusRefunded}, nil
+}
+
+func (s *simulatorGateway) VerifySignature(payload []byte, signature string) bool {
+	return verify(s.cfg.WebhookSecret, payload, signature)
+}
+
+// notify delivers the event of a transaction to the webhook in the background, the way a real
+// gateway calls back after answering the request, and hands the transaction back to the caller.
+func (s *simulatorGateway) notify(eventType constant.GatewayEventType, transactionID string, transaction *model.GatewayTransaction) *model.GatewayTransaction {
+	if s.cfg.WebhookURL == "" {
+		return transaction
+	}
+
+	payload, err := json.Marshal(&model.GatewayEvent{
+		ID:            "sim_evt_" + uuid.New().String(),
+		Type:          eventType,
+		OrderID:       transaction.OrderID,
+		TransactionID: transactionID,
+		Reference:     transaction.ID,
+		Amount:        transaction.Amount,
+	})
+	if err != nil {
+		log.Println("errorGateway: ", err.Error())
+		return transaction
+	}
+
+	go func() {
+		req, err := http.NewRequest(http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(payload))
+		if err != nil {
+			log.Println("errorGateway: ", err.Error())
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(SignatureHeader, Sign(s.cfg.WebhookSecret, payload))
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			log.Println("errorGateway: ", err.Error())
+			return
+		}
+		resp.Body.Close()
+	}()
+
+	return transaction
+}
@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_SimulatorFlow(t *testing.T) {
+	simulator := NewSimulatorGateway(config.PaymentGatewayConfig{Provider: "simulator", WebhookSecret: "secret"})
+
+	authorization, err := simulator.Authorize("register_number", 1000, "tok_visa")
+	assert.Nil(t, err)
+	assert.Equal(t, constant.GatewayStatusAuthorized, authorization.Status)
+	assert.Equal(t, "register_number", authorization.OrderID)
+
+	_, err = simulator.Capture(authorization.ID, 1200)
+	assert.Equal(t, model.NewValidationError("capture amount must be greater than zero and at most the authorized amount"), err)
+
+	capture, err := simulator.Capture(authorization.ID, 800)
+	assert.Nil(t, err)
+	assert.Equal(t, &model.GatewayTransaction{ID: authorization.ID, OrderID: "register_number", Amount: 800, Status: constant.GatewayStatusCaptured}, capture)
+
+	_, err = simulator.Void(authorization.ID)
+	assert.Equal(t, model.NewConflictError("can not void a Captured transaction"), err)
+
+	refund, err := simulator.Refund(authorization.ID, 300)
+	assert.Nil(t, err)
+	assert.NotEqual(t, authorization.ID, refund.ID)
+	assert.Equal(t, 300.0, refund.Amount)
+
+	_, err = simulator.Refund(authorization.ID, 600)
+	assert.Equal(t, model.NewValidationError("refund amount must be greater than zero and at most the captured amount"), err)
+
+	_, err = simulator.Refund(authorization.ID, 500)
+	assert.Nil(t, err)
+
+	_, err = simulator.Refund(authorization.ID, 1)
+	assert.Equal(t, model.NewConflictError("can not refund a Refunded transaction"), err)
+}
+
+func Test_SimulatorAuthorize(t *testing.T) {
+	simulator := NewSimulatorGateway(config.PaymentGatewayConfig{Provider: "simulator"})
+
+	testCases := []struct {
+		name      string
+		amount    float64
+		cardToken string
+		err       error
+	}{
+		{
+			name:      "Given valid card when authorize then hold the amount",
+			amount:    1000,
+			cardToken: "tok_visa",
+			err:       nil,
+		},
+		{
+			name:      "Given declined card when authorize then return error",
+			amount:    1000,
+			cardToken: SimulatorDeclinedCard,
+			err:       model.NewValidationError("card declined"),
+		},
+		{
+			name:      "Given zero amount when authorize then return error",
+			amount:    0,
+			cardToken: "tok_visa",
+			err:       model.NewValidationError("authorization amount must be greater than zero"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := simulator.Authorize("register_number", tc.amount, tc.cardToken)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_SimulatorVoid(t *testing.T) {
+	simulator := NewSimulatorGateway(config.PaymentGatewayConfig{Provider: "simulator"})
+
+	authorization, _ := simulator.Authorize("register_number", 1000, "tok_visa")
+	voided, err := simulator.Void(authorization.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, constant.GatewayStatusVoided, voided.Status)
+
+	_, err = simulator.Capture(authorization.ID, 1000)
+	assert.Equal(t, model.NewConflictError("can not capture a Voided transaction"), err)
+
+	_, err = simulator.Void("unknown")
+	assert.Equal(t, model.NewValidationError("transaction unknown not found"), err)
+}
+
+func Test_SimulatorWebhook(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	simulator := NewSimulatorGateway(config.PaymentGatewayConfig{Provider: "simulator", WebhookSecret: "secret", WebhookURL: server.URL})
+	authorization, err := simulator.Authorize("register_number", 1000, "tok_visa")
+	assert.Nil(t, err)
+
+	select {
+	case req := <-received:
+		body := <-bodies
+		assert.True(t, simulator.VerifySignature(body, req.Header.Get(SignatureHeader)))
+		assert.False(t, simulator.VerifySignature(body, Sign("another secret", body)))
+
+		event := &model.GatewayEvent{}
+		assert.Nil(t, json.Unmarshal(body, event))
+		assert.Equal(t, constant.GatewayEventAuthorized, event.Type)
+		assert.Equal(t, "register_number", event.OrderID)
+		assert.Equal(t, authorization.ID, event.TransactionID)
+		assert.Equal(t, 1000.0, event.Amount)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+}
@@ -0,0 +1,215 @@
+package keyset
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type key struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// KeySet signs access tokens with one key and verifies them with any key of the set,
+// the kid header of a token names the key it was signed with.
+type KeySet struct {
+	signing *key
+	keys    map[string]*key
+}
+
+func NewKeySet(cfg config.JWTConfig) (*KeySet, error) {
+	keySet := &KeySet{keys: map[string]*key{}}
+	for _, keyCfg := range cfg.Keys {
+		if keyCfg.ID == "" {
+			return nil, fmt.Errorf("jwt key is missing an id")
+		}
+
+		if _, ok := keySet.keys[keyCfg.ID]; ok {
+			return nil, fmt.Errorf("duplicate jwt key id %s", keyCfg.ID)
+		}
+
+		k, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", keyCfg.ID, err)
+		}
+		keySet.keys[k.id] = k
+	}
+
+	signing, ok := keySet.keys[cfg.SigningKeyID]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not in the key set", cfg.SigningKeyID)
+	}
+
+	if signing.signKey == nil {
+		return nil, fmt.Errorf("signing key %s has no private key", cfg.SigningKeyID)
+	}
+	keySet.signing = signing
+
+	return keySet, nil
+}
+
+// Sign returns the token of the claims signed with the signing key.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.signing.method, claims)
+	token.Header["kid"] = k.signing.id
+
+	return token.SignedString(k.signing.signKey)
+}
+
+// Parse verifies the token with the key named by its kid header and decodes it into claims.
+func (k *KeySet) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		verifying, ok := k.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+
+		// the algorithm is read from the token, it has to match the key or an RS256
+		// public key could be used as an HMAC secret
+		if token.Method.Alg() != verifying.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+
+		return verifying.verifyKey, nil
+	})
+}
+
+// JWKS returns the public keys of the set. HMAC secrets are never published.
+func (k *KeySet) JWKS() *model.JWKS {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := &model.JWKS{Keys: []*model.JWK{}}
+	for _, id := range ids {
+		verifying := k.keys[id]
+		switch publicKey := verifying.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, &model.JWK{
+				Kty: "RSA",
+				Kid: id,
+				Alg: verifying.method.Alg(),
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, &model.JWK{
+				Kty: "OKP",
+				Kid: id,
+				Alg: verifying.method.Alg(),
+				Use: "sig",
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+
+	return jwks
+}
+
+// loadKey reads a key of the set. A key with only a public key can verify but not sign,
+// which is how a rotated out key is kept until the tokens it signed have expired.
+func loadKey(cfg config.JWTKeyConfig) (*key, error) {
+	k := &key{id: cfg.ID}
+	switch cfg.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("missing secret")
+		}
+
+		k.method = jwt.SigningMethodHS256
+		k.signKey = []byte(cfg.Secret)
+		k.verifyKey = []byte(cfg.Secret)
+	case jwt.SigningMethodRS256.Alg():
+		k.method = jwt.SigningMethodRS256
+		if cfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			k.signKey = privateKey
+			k.verifyKey = &privateKey.PublicKey
+		} else if cfg.PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			k.verifyKey = publicKey
+		} else {
+			return nil, fmt.Errorf("missing private_key_file or public_key_file")
+		}
+	case SigningMethodEdDSA.Alg():
+		k.method = SigningMethodEdDSA
+		if cfg.PrivateKeyFile != "" {
+			parsed, err := parsePEM(cfg.PrivateKeyFile, x509.ParsePKCS8PrivateKey)
+			if err != nil {
+				return nil, err
+			}
+
+			privateKey, ok := parsed.(ed25519.PrivateKey)
+			if !ok {
+				return nil, fmt.Errorf("private key is not an Ed25519 key")
+			}
+			k.signKey = privateKey
+			k.verifyKey = privateKey.Public()
+		} else if cfg.PublicKeyFile != "" {
+			parsed, err := parsePEM(cfg.PublicKeyFile, x509.ParsePKIXPublicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			publicKey, ok := parsed.(ed25519.PublicKey)
+			if !ok {
+				return nil, fmt.Errorf("public key is not an Ed25519 key")
+			}
+			k.verifyKey = publicKey
+		} else {
+			return nil, fmt.Errorf("missing private_key_file or public_key_file")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	return k, nil
+}
+
+func parsePEM(file string, parse func(der []byte) (interface{}, error)) (interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM file", file)
+	}
+
+	return parse(block.Bytes)
+}
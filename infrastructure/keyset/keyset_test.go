@@ -0,0 +1,299 @@
+package keyset
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return file
+}
+
+func rsaKeyFiles(t *testing.T) (string, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(privateKey)),
+		writePEM(t, "rsa.pub.pem", "PUBLIC KEY", publicDER)
+}
+
+func ed25519KeyFiles(t *testing.T) (string, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return writePEM(t, "ed25519.pem", "PRIVATE KEY", privateDER), writePEM(t, "ed25519.pub.pem", "PUBLIC KEY", publicDER)
+}
+
+func claims() *model.AuthClaims {
+	return &model.AuthClaims{
+		UserID:   1,
+		Username: "janedoe",
+		Role:     1,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Minute).Unix(),
+		},
+	}
+}
+
+func Test_SignAndParse(t *testing.T) {
+	rsaPrivate, _ := rsaKeyFiles(t)
+	edPrivate, _ := ed25519KeyFiles(t)
+
+	testCases := []struct {
+		name string
+		key  config.JWTKeyConfig
+	}{
+		{
+			name: "Given HS256 key when sign then parse the token back",
+			key:  config.JWTKeyConfig{ID: "hs", Algorithm: "HS256", Secret: "secret"},
+		},
+		{
+			name: "Given RS256 key when sign then parse the token back",
+			key:  config.JWTKeyConfig{ID: "rs", Algorithm: "RS256", PrivateKeyFile: rsaPrivate},
+		},
+		{
+			name: "Given EdDSA key when sign then parse the token back",
+			key:  config.JWTKeyConfig{ID: "ed", Algorithm: "EdDSA", PrivateKeyFile: edPrivate},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keySet, err := NewKeySet(config.JWTConfig{SigningKeyID: tc.key.ID, Keys: []config.JWTKeyConfig{tc.key}})
+			assert.NoError(t, err)
+
+			tokenString, err := keySet.Sign(claims())
+			assert.NoError(t, err)
+
+			parsed := &model.AuthClaims{}
+			token, err := keySet.Parse(tokenString, parsed)
+			assert.NoError(t, err)
+			assert.True(t, token.Valid)
+			assert.Equal(t, tc.key.ID, token.Header["kid"])
+			assert.Equal(t, tc.key.Algorithm, token.Header["alg"])
+			assert.Equal(t, "janedoe", parsed.Username)
+		})
+	}
+}
+
+func Test_Rotation(t *testing.T) {
+	rsaPrivate, rsaPublic := rsaKeyFiles(t)
+
+	before, err := NewKeySet(config.JWTConfig{
+		SigningKeyID: "2024-01",
+		Keys: []config.JWTKeyConfig{
+			{ID: "2024-01", Algorithm: "RS256", PrivateKeyFile: rsaPrivate},
+		},
+	})
+	assert.NoError(t, err)
+
+	oldToken, err := before.Sign(claims())
+	assert.NoError(t, err)
+
+	// the new key signs, the old one is kept with only its public key
+	after, err := NewKeySet(config.JWTConfig{
+		SigningKeyID: "2024-06",
+		Keys: []config.JWTKeyConfig{
+			{ID: "2024-06", Algorithm: "HS256", Secret: "new-secret"},
+			{ID: "2024-01", Algorithm: "RS256", PublicKeyFile: rsaPublic},
+		},
+	})
+	assert.NoError(t, err)
+
+	token, err := after.Parse(oldToken, &model.AuthClaims{})
+	assert.NoError(t, err)
+	assert.True(t, token.Valid)
+
+	newToken, err := after.Sign(claims())
+	assert.NoError(t, err)
+
+	token, err = after.Parse(newToken, &model.AuthClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-06", token.Header["kid"])
+
+	// once the old key is removed its tokens are rejected
+	_, err = before.Parse(newToken, &model.AuthClaims{})
+	assert.Error(t, err)
+}
+
+func Test_ParseRejected(t *testing.T) {
+	_, rsaPublic := rsaKeyFiles(t)
+	keySet, err := NewKeySet(config.JWTConfig{
+		SigningKeyID: "hs",
+		Keys: []config.JWTKeyConfig{
+			{ID: "hs", Algorithm: "HS256", Secret: "secret"},
+			{ID: "rs", Algorithm: "RS256", PublicKeyFile: rsaPublic},
+		},
+	})
+	assert.NoError(t, err)
+
+	publicPEM, _ := os.ReadFile(rsaPublic)
+
+	testCases := []struct {
+		name  string
+		token func() string
+	}{
+		{
+			name: "Given token without kid when parse then return error",
+			token: func() string {
+				token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims()).SignedString([]byte("secret"))
+				return token
+			},
+		},
+		{
+			name: "Given unknown kid when parse then return error",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+				token.Header["kid"] = "unknown"
+				signed, _ := token.SignedString([]byte("secret"))
+				return signed
+			},
+		},
+		{
+			name: "Given HS256 token signed with the RSA public key when parse then return error",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+				token.Header["kid"] = "rs"
+				signed, _ := token.SignedString(publicPEM)
+				return signed
+			},
+		},
+		{
+			name: "Given wrong secret when parse then return error",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+				token.Header["kid"] = "hs"
+				signed, _ := token.SignedString([]byte("other-secret"))
+				return signed
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := keySet.Parse(tc.token(), &model.AuthClaims{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_NewKeySet(t *testing.T) {
+	_, rsaPublic := rsaKeyFiles(t)
+
+	testCases := []struct {
+		name string
+		cfg  config.JWTConfig
+	}{
+		{
+			name: "Given unknown signing key id when new key set then return error",
+			cfg: config.JWTConfig{
+				SigningKeyID: "missing",
+				Keys:         []config.JWTKeyConfig{{ID: "hs", Algorithm: "HS256", Secret: "secret"}},
+			},
+		},
+		{
+			name: "Given signing key without private key when new key set then return error",
+			cfg: config.JWTConfig{
+				SigningKeyID: "rs",
+				Keys:         []config.JWTKeyConfig{{ID: "rs", Algorithm: "RS256", PublicKeyFile: rsaPublic}},
+			},
+		},
+		{
+			name: "Given duplicate key id when new key set then return error",
+			cfg: config.JWTConfig{
+				SigningKeyID: "hs",
+				Keys: []config.JWTKeyConfig{
+					{ID: "hs", Algorithm: "HS256", Secret: "secret"},
+					{ID: "hs", Algorithm: "HS256", Secret: "other-secret"},
+				},
+			},
+		},
+		{
+			name: "Given unsupported algorithm when new key set then return error",
+			cfg: config.JWTConfig{
+				SigningKeyID: "es",
+				Keys:         []config.JWTKeyConfig{{ID: "es", Algorithm: "ES256"}},
+			},
+		},
+		{
+			name: "Given HS256 key without secret when new key set then return error",
+			cfg: config.JWTConfig{
+				SigningKeyID: "hs",
+				Keys:         []config.JWTKeyConfig{{ID: "hs", Algorithm: "HS256"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewKeySet(tc.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_JWKS(t *testing.T) {
+	rsaPrivate, _ := rsaKeyFiles(t)
+	_, edPublic := ed25519KeyFiles(t)
+
+	keySet, err := NewKeySet(config.JWTConfig{
+		SigningKeyID: "rs",
+		Keys: []config.JWTKeyConfig{
+			{ID: "rs", Algorithm: "RS256", PrivateKeyFile: rsaPrivate},
+			{ID: "ed", Algorithm: "EdDSA", PublicKeyFile: edPublic},
+			{ID: "hs", Algorithm: "HS256", Secret: "secret"},
+		},
+	})
+	assert.NoError(t, err)
+
+	jwks := keySet.JWKS()
+	assert.Equal(t, 2, len(jwks.Keys))
+
+	assert.Equal(t, "ed", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.NotEmpty(t, jwks.Keys[0].X)
+
+	assert.Equal(t, "rs", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+	assert.NotEmpty(t, jwks.Keys[1].N)
+}
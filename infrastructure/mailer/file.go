@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// fileMailer is for running without an SMTP server. Every email is written to an .eml file
+// in the configured directory, which most mail clients open, or only logged when there is none.
+type fileMailer struct {
+	cfg config.MailerConfig
+}
+
+func NewFileMailer(cfg config.MailerConfig) Mailer {
+	return &fileMailer{cfg: cfg}
+}
+
+func (f *fileMailer) Send(message *model.MailMessage) error {
+	now := time.Now()
+	email := compose(f.cfg.From, message, now)
+	if f.cfg.Directory == "" {
+		log.Printf("email to %s:\n%s", message.To, email)
+		return nil
+	}
+
+	if err := os.MkdirAll(f.cfg.Directory, 0755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(f.cfg.Directory, now.Format("20060102-150405")+"-"+uuid.NewString()+".eml")
+	if err := os.WriteFile(path, email, 0600); err != nil {
+		return err
+	}
+
+	log.Printf("email to %s written to %s", message.To, path)
+	return nil
+}
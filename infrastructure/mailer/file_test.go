@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_FileMailer(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "mail")
+	mailer := NewMailer(config.MailerConfig{Provider: "file", From: "no-reply@hotel.com", Directory: directory})
+
+	err := mailer.Send(&model.MailMessage{To: "jane@mail.com", Subject: "Reset your password", Body: "Open the link"})
+	assert.Nil(t, err)
+
+	files, err := os.ReadDir(directory)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(files))
+	assert.True(t, strings.HasSuffix(files[0].Name(), ".eml"))
+
+	email, err := os.ReadFile(filepath.Join(directory, files[0].Name()))
+	assert.Nil(t, err)
+	assert.Contains(t, string(email), "From: no-reply@hotel.com\r\n")
+	assert.Contains(t, string(email), "To: jane@mail.com\r\n")
+	assert.Contains(t, string(email), "Subject: Reset your password\r\n")
+	assert.True(t, strings.HasSuffix(string(email), "\r\n\r\nOpen the link"))
+}
+
+func Test_FileMailerWithoutDirectory(t *testing.T) {
+	mailer := NewFileMailer(config.MailerConfig{From: "no-reply@hotel.com"})
+
+	err := mailer.Send(&model.MailMessage{To: "jane@mail.com", Subject: "Reset your password", Body: "Open the link"})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"mime"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// Mailer sends emails to hoteliers, e.g. the link to reset a forgotten password.
+//
+//go:generate mockery --name=Mailer --output=./mocks --outpkg=mocks
+type Mailer interface {
+	Send(message *model.MailMessage) error
+}
+
+func NewMailer(cfg config.MailerConfig) Mailer {
+	switch cfg.Provider {
+	case "smtp":
+		return NewSMTPMailer(cfg)
+	case "file":
+		return NewFileMailer(cfg)
+	default:
+		log.Panicln("unknown mailer provider: ", cfg.Provider)
+		return nil
+	}
+}
+
+// compose renders the message as a plain text RFC 5322 email.
+func compose(from string, message *model.MailMessage, at time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message.Body)
+	return b.Bytes()
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// smtpMailer delivers emails through an SMTP server. It logs in with PLAIN auth when a
+// username is set, which net/smtp only allows over TLS or to localhost.
+type smtpMailer struct {
+	cfg config.MailerConfig
+}
+
+func NewSMTPMailer(cfg config.MailerConfig) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+func (s *smtpMailer) Send(message *model.MailMessage) error {
+	var auth smtp.Auth
+	if s.cfg.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.SMTP.Username, s.cfg.SMTP.Password, s.cfg.SMTP.Host)
+	}
+
+	addr := net.JoinHostPort(s.cfg.SMTP.Host, s.cfg.SMTP.Port)
+	return smtp.SendMail(addr, auth, s.cfg.From, []string{message.To}, compose(s.cfg.From, message, time.Now()))
+}
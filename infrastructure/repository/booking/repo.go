@@ -6,6 +6,13 @@ import "github.com/zakiyalmaya/hotel-management/model"
 type BookingRepository interface {
 	Books(booking *model.BookingEntity) error
 	GetByRegisterNumber(registerNumber string) (*model.BookingDetail, error)
+	GetByGuestID(guestID int) ([]*model.BookingDetail, error)
 	UpdatePayment(booking *model.BookingEntity) error
 	Reschedule(booing *model.BookingEntity) error
+	CountOverlapping(request *model.AvailabilityRequest) (int, error)
+	Cancel(booking *model.BookingEntity, refund *model.PaymentEntity) error
+	RevertCancel(booking *model.BookingEntity) error
+	CheckIn(booking *model.BookingEntity) error
+	CheckOut(booking *model.BookingEntity) error
+	UpdateStatus(booking *model.BookingEntity) error
 }
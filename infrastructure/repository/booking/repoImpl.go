@@ -2,33 +2,121 @@ package booking
 
 import (
 	"log"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/folio"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/payment"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
+// overlapQuery counts active bookings and open maintenance orders of a room whose dates intersect [check_in, check_out).
+var overlapQuery = func() string {
+	bookings, maintenance := OverlapConditions("?")
+	return "SELECT (SELECT COUNT(*) FROM bookings b WHERE " + bookings + ") + (SELECT COUNT(*) FROM maintenance_orders m WHERE " + maintenance + ")"
+}()
+
+const selectBookingDetailQuery = `SELECT b.register_number, b.guest_id, g.first_name, g.last_name, g.identity_number, b.room_name, r.floor AS "room.floor", r.type AS "room.type", r.status AS "room.status", b.check_in, b.check_out, b.paid_amount, b.payment_method, b.payment_status, b.status, b.additional_request, b.refund_amount, b.checked_in_at, b.checked_in_by, b.checked_out_at, b.checked_out_by, b.gateway_transaction_id, b.authorized_amount, b.created_at FROM bookings b JOIN guests g ON b.guest_id = g.id JOIN rooms r ON r.name = b.room_name`
+
 type bookingRepoImpl struct {
-	db *sqlx.DB
+	db          *sqlx.DB
+	cipher      *fieldcipher.Cipher
+	folioRepo   folio.FolioRepository
+	paymentRepo payment.PaymentRepository
+}
+
+// NewBookingRepository decrypts the identity number of the guest of a booking with cipher,
+// posts the room nights of a booking to the folio through folioRepo and saves the refund
+// of a cancellation through paymentRepo.
+func NewBookingRepository(db *sqlx.DB, cipher *fieldcipher.Cipher, folioRepo folio.FolioRepository, paymentRepo payment.PaymentRepository) BookingRepository {
+	return &bookingRepoImpl{db: db, cipher: cipher, folioRepo: folioRepo, paymentRepo: paymentRepo}
+}
+
+// OverlapConditions returns the conditions matching the active bookings, aliased b, and the
+// open maintenance orders, aliased m, of a room whose dates intersect [check_in, check_out).
+// room is what the room name is compared with, a placeholder or a column of the outer query,
+// so every query deciding whether a room is free applies the same rules. A guest checking out
+// on the same day another checks in is not an overlap, and neither is a stay starting on the
+// expected completion date of a maintenance order.
+func OverlapConditions(room string) (bookings, maintenance string) {
+	bookings = "b.room_name = " + room + " AND b.check_in < ? AND b.check_out > ? AND b.register_number != ? AND b.status IN (?, ?) AND b.payment_status NOT IN (?, ?, ?)"
+	maintenance = "m.room_name = " + room + " AND m.start_date < ? AND m.expected_completion > ? AND m.status = ?"
+	return bookings, maintenance
+}
+
+// OverlapBookingArgs binds the bookings condition of OverlapConditions, after the room when it is
+// a placeholder. The booking of excludeRegisterNumber is left out, e.g. the one being rescheduled.
+func OverlapBookingArgs(checkIn, checkOut time.Time, excludeRegisterNumber string) []interface{} {
+	return []interface{}{
+		checkOut,
+		checkIn,
+		excludeRegisterNumber,
+		constant.BookingStatusConfirmed,
+		constant.BookingStatusCheckedIn,
+		constant.PaymentStatusFailed,
+		constant.PaymentStatusCanceled,
+		constant.PaymentStatusRefunded,
+	}
+}
+
+// OverlapMaintenanceArgs binds the maintenance condition of OverlapConditions, after the room
+// when it is a placeholder.
+func OverlapMaintenanceArgs(checkIn, checkOut time.Time) []interface{} {
+	return []interface{}{checkOut, checkIn, constant.MaintenanceStatusOpen}
 }
 
-func NewBookingRepository(db *sqlx.DB) BookingRepository {
-	return &bookingRepoImpl{db: db}
+func overlapArgs(request *model.AvailabilityRequest) []interface{} {
+	args := append([]interface{}{request.RoomName}, OverlapBookingArgs(request.CheckIn, request.CheckOut, request.ExcludeRegisterNumber)...)
+	args = append(args, request.RoomName)
+	return append(args, OverlapMaintenanceArgs(request.CheckIn, request.CheckOut)...)
 }
 
 func (b *bookingRepoImpl) Books(booking *model.BookingEntity) error {
-	query := "INSERT INTO bookings (register_number, guest_id, room_name, check_in, check_out, paid_amount, payment_method, payment_status, additional_request) VALUES (:register_number, :guest_id, :room_name, :check_in, :check_out, :paid_amount, :payment_method, :payment_status, :additional_request)"
-	_, err := b.db.NamedExec(query, booking)
+	tx, err := b.db.Beginx()
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
 		return err
 	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.Get(&count, overlapQuery, overlapArgs(&model.AvailabilityRequest{
+		RoomName:              booking.RoomName,
+		CheckIn:               booking.CheckIn,
+		CheckOut:              booking.CheckOut,
+		ExcludeRegisterNumber: booking.RegisterNumber,
+	})...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if count > 0 {
+		return model.NewConflictError("room not available")
+	}
+
+	query := "INSERT INTO bookings (register_number, guest_id, room_name, check_in, check_out, paid_amount, payment_method, payment_status, status, additional_request, gateway_transaction_id, authorized_amount) VALUES (:register_number, :guest_id, :room_name, :check_in, :check_out, :paid_amount, :payment_method, :payment_status, :status, :additional_request, :gateway_transaction_id, :authorized_amount)"
+	if _, err := tx.NamedExec(query, booking); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := b.folioRepo.CreateCharges(tx, booking.RoomCharges); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
 
 	return nil
 }
 
 func (b *bookingRepoImpl) GetByRegisterNumber(registerNumber string) (*model.BookingDetail, error) {
 	booking := &model.BookingDetail{}
-	query := "SELECT b.register_number, b.guest_id, g.first_name, g.last_name, g.identity_number, b.room_name, r.floor, r.type, r.status, b.check_in, b.check_out, b.paid_amount, b.payment_method, b.payment_status, b.additional_request, b.created_at FROM bookings b JOIN guests g ON b.guest_id = g.id JOIN rooms r ON r.name = b.room_name WHERE b.register_number = ?"
+	query := selectBookingDetailQuery + " WHERE b.register_number = ?"
 
 	err := b.db.Get(booking, query, registerNumber)
 	if err != nil {
@@ -36,9 +124,36 @@ func (b *bookingRepoImpl) GetByRegisterNumber(registerNumber string) (*model.Boo
 		return nil, err
 	}
 
+	if booking.Identity, err = b.cipher.Decrypt(booking.Identity); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
 	return booking, nil
 }
 
+// GetByGuestID returns every booking of a guest, the latest stay first.
+func (b *bookingRepoImpl) GetByGuestID(guestID int) ([]*model.BookingDetail, error) {
+	bookings := make([]*model.BookingDetail, 0)
+	query := selectBookingDetailQuery + " WHERE b.guest_id = ? ORDER BY b.check_in DESC, b.id DESC"
+
+	if err := b.db.Select(&bookings, query, guestID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	for _, booking := range bookings {
+		identity, err := b.cipher.Decrypt(booking.Identity)
+		if err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return nil, err
+		}
+		booking.Identity = identity
+	}
+
+	return bookings, nil
+}
+
 func (b *bookingRepoImpl) UpdatePayment(booking *model.BookingEntity) error {
 	query := "UPDATE bookings SET payment_status = :payment_status WHERE register_number = :register_number"
 	_, err := b.db.NamedExec(query, booking)
@@ -51,12 +166,129 @@ func (b *bookingRepoImpl) UpdatePayment(booking *model.BookingEntity) error {
 }
 
 func (b *bookingRepoImpl) Reschedule(booking *model.BookingEntity) error {
-	query := "UPDATE bookings SET check_in = :check_in, check_out = :check_out, paid_amount = :paid_amount WHERE register_number = :register_number"
-	_, err := b.db.NamedExec(query, booking)
+	tx, err := b.db.Beginx()
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
 		return err
 	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.Get(&count, overlapQuery, overlapArgs(&model.AvailabilityRequest{
+		RoomName:              booking.RoomName,
+		CheckIn:               booking.CheckIn,
+		CheckOut:              booking.CheckOut,
+		ExcludeRegisterNumber: booking.RegisterNumber,
+	})...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if count > 0 {
+		return model.NewConflictError("room not available")
+	}
+
+	query := "UPDATE bookings SET check_in = :check_in, check_out = :check_out, paid_amount = :paid_amount WHERE register_number = :register_number AND status = :from_status"
+	if err := changeStatus(tx, query, booking); err != nil {
+		return err
+	}
+
+	// the room nights follow the new dates, extras already posted stay on the folio
+	if _, err := tx.Exec("DELETE FROM folio_charges WHERE register_number = ? AND charge_type = ?", booking.RegisterNumber, constant.FolioChargeTypeRoom); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := b.folioRepo.CreateCharges(tx, booking.RoomCharges); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (b *bookingRepoImpl) CountOverlapping(request *model.AvailabilityRequest) (int, error) {
+	var count int
+	if err := b.db.Get(&count, overlapQuery, overlapArgs(request)...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Cancel saves the cancellation together with the refund given back, if any, so a refund
+// is never recorded for a booking that stays active.
+func (b *bookingRepoImpl) Cancel(booking *model.BookingEntity, refund *model.PaymentEntity) error {
+	tx, err := b.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE bookings SET status = :status, payment_status = :payment_status, refund_amount = :refund_amount, canceled_at = :canceled_at, updated_at = CURRENT_TIMESTAMP WHERE register_number = :register_number AND status = :from_status"
+	if err := changeStatus(tx, query, booking); err != nil {
+		return err
+	}
+
+	if refund != nil {
+		if err := b.paymentRepo.CreateTx(tx, refund); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// RevertCancel restores a booking claimed by Cancel when the gateway could not void or refund its card.
+func (b *bookingRepoImpl) RevertCancel(booking *model.BookingEntity) error {
+	query := "UPDATE bookings SET status = :status, payment_status = :payment_status, refund_amount = :refund_amount, canceled_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE register_number = :register_number AND status = :from_status"
+	return changeStatus(b.db, query, booking)
+}
+
+func (b *bookingRepoImpl) CheckIn(booking *model.BookingEntity) error {
+	query := "UPDATE bookings SET status = :status, checked_in_at = :checked_in_at, checked_in_by = :checked_in_by, updated_at = CURRENT_TIMESTAMP WHERE register_number = :register_number AND status = :from_status"
+	return changeStatus(b.db, query, booking)
+}
+
+func (b *bookingRepoImpl) CheckOut(booking *model.BookingEntity) error {
+	query := "UPDATE bookings SET status = :status, checked_out_at = :checked_out_at, checked_out_by = :checked_out_by, updated_at = CURRENT_TIMESTAMP WHERE register_number = :register_number AND status = :from_status"
+	return changeStatus(b.db, query, booking)
+}
+
+func (b *bookingRepoImpl) UpdateStatus(booking *model.BookingEntity) error {
+	query := "UPDATE bookings SET status = :status, updated_at = CURRENT_TIMESTAMP WHERE register_number = :register_number AND status = :from_status"
+	return changeStatus(b.db, query, booking)
+}
+
+// changeStatus only changes the booking when it is still in the status the change was decided on,
+// so two requests racing on the same booking can not both apply, e.g. a reschedule and a check in.
+func changeStatus(db sqlx.Ext, query string, booking *model.BookingEntity) error {
+	res, err := sqlx.NamedExec(db, query, booking)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("booking is no longer %s", booking.FromStatus.Enum())
+	}
+
+	return nil
+}
@@ -8,10 +8,20 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
 	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/folio"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/payment"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
+var testCipher, _ = fieldcipher.NewCipher(config.GuestEncryptionConfig{
+	ActiveKeyID:   "test",
+	Keys:          []config.GuestEncryptionKeyConfig{{ID: "test", Key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}},
+	BlindIndexKey: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+})
+
 func Test_Books(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -40,10 +50,37 @@ func Test_Books(t *testing.T) {
 				PaymentStatus:  constant.PaymentStatusPending,
 			},
 			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 				mock.ExpectExec("INSERT INTO bookings").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			err: nil,
 		},
+		{
+			name: "Given error writing room charges when reservation room then roll back the booking",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "101",
+				CheckIn:        time.Date(2000, time.December, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.December, 02, 00, 00, 00, 00, time.UTC),
+				PaidAmount:     1000000,
+				PaymentMethod:  constant.PaymentMethodCash,
+				PaymentStatus:  constant.PaymentStatusPending,
+				RoomCharges: []*model.FolioChargeEntity{
+					{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: time.Date(2000, time.December, 01, 00, 00, 00, 00, time.UTC), Quantity: 1, UnitPrice: 1000000, Amount: 1000000},
+				},
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("INSERT INTO bookings").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO folio_charges").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
+			},
+			err: sqlmock.ErrCancelled,
+		},
 		{
 			name: "Given error canceled when reservation room then return error response",
 			booking: &model.BookingEntity{
@@ -57,7 +94,48 @@ func Test_Books(t *testing.T) {
 				PaymentStatus:  constant.PaymentStatusPending,
 			},
 			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 				mock.ExpectExec("INSERT INTO bookings").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
+			},
+			err: sqlmock.ErrCancelled,
+		},
+		{
+			name: "Given overlapping reservation when reservation room then return error response",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "101",
+				CheckIn:        time.Date(2000, time.December, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.December, 02, 00, 00, 00, 00, time.UTC),
+				PaidAmount:     1000000,
+				PaymentMethod:  constant.PaymentMethodCash,
+				PaymentStatus:  constant.PaymentStatusPending,
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			err: model.NewConflictError("room not available"),
+		},
+		{
+			name: "Given error when check overlapping reservation then return error response",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				GuestID:        1,
+				RoomName:       "101",
+				CheckIn:        time.Date(2000, time.December, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.December, 02, 00, 00, 00, 00, time.UTC),
+				PaidAmount:     1000000,
+				PaymentMethod:  constant.PaymentMethodCash,
+				PaymentStatus:  constant.PaymentStatusPending,
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
 			},
 			err: sqlmock.ErrCancelled,
 		},
@@ -67,7 +145,7 @@ func Test_Books(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			repo := NewBookingRepository(sqlxDB)
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
 
 			err := repo.Books(tc.booking)
 			assert.Equal(t, tc.err, err)
@@ -84,6 +162,9 @@ func Test_GetByRegisterNumber(t *testing.T) {
 
 	sqlxDB := sqlx.NewDb(db, "sqlmock")
 
+	// the identity number of the guest is stored encrypted
+	encryptedIdentity, _ := testCipher.Encrypt("identity")
+
 	testCases := []struct {
 		name     string
 		request  string
@@ -96,8 +177,8 @@ func Test_GetByRegisterNumber(t *testing.T) {
 			request: "register_number",
 			mockCall: func() {
 				mock.ExpectQuery("SELECT").WithArgs("register_number").WillReturnRows(sqlmock.NewRows([]string{"register_number", "guest_id", "first_name", "last_name",
-					"identity_number", "room_name", "floor", "type", "status", "check_in", "check_out", "paid_amount", "payment_method", "payment_status", "additional_request", "created_at"}).
-					AddRow("register_number", "1", "John", "Doe", "identity", "room", "1", "", "1", time.Time{}, time.Time{}, "1000", "2", "1", nil, nil))
+					"identity_number", "room_name", "room.floor", "room.type", "room.status", "check_in", "check_out", "paid_amount", "payment_method", "payment_status", "additional_request", "created_at"}).
+					AddRow("register_number", "1", "John", "Doe", encryptedIdentity, "room", "1", "", "1", time.Time{}, time.Time{}, "1000", "2", "1", nil, nil))
 			},
 			response: &model.BookingDetail{
 				BookingEntity: model.BookingEntity{
@@ -135,7 +216,7 @@ func Test_GetByRegisterNumber(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			repo := NewBookingRepository(sqlxDB)
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
 
 			resp, err := repo.GetByRegisterNumber(tc.request)
 			assert.Equal(t, tc.err, err)
@@ -187,7 +268,7 @@ func Test_UpdatePayment(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			repo := NewBookingRepository(sqlxDB)
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
 
 			err := repo.UpdatePayment(tc.booking)
 			assert.Equal(t, tc.err, err)
@@ -217,9 +298,22 @@ func Test_Reschedule(t *testing.T) {
 				CheckIn:        time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
 				CheckOut:       time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC),
 				PaidAmount:     1000000,
+				FromStatus:     constant.BookingStatusConfirmed,
+				RoomCharges: []*model.FolioChargeEntity{
+					{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC), Quantity: 1, UnitPrice: 500000, Amount: 500000},
+					{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: time.Date(2000, time.March, 02, 00, 00, 00, 00, time.UTC), Quantity: 1, UnitPrice: 500000, Amount: 500000},
+				},
 			},
 			mockCall: func() {
-				mock.ExpectExec("UPDATE bookings").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("UPDATE bookings SET check_in = \\?, check_out = \\?, paid_amount = \\? WHERE register_number = \\? AND status = \\?").
+					WithArgs(time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC), time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC), float64(1000000), "register_number", constant.BookingStatusConfirmed).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("DELETE FROM folio_charges").WithArgs("register_number", constant.FolioChargeTypeRoom).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO folio_charges").WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT INTO folio_charges").WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectCommit()
 			},
 			err: nil,
 		},
@@ -232,20 +326,523 @@ func Test_Reschedule(t *testing.T) {
 				PaidAmount:     1000000,
 			},
 			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 				mock.ExpectExec("UPDATE bookings").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
 			},
 			err: sqlmock.ErrCancelled,
 		},
+		{
+			name: "Given overlapping reservation when reschedule reservation then return error response",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				CheckIn:        time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC),
+				PaidAmount:     1000000,
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectRollback()
+			},
+			err: model.NewConflictError("room not available"),
+		},
+		{
+			name: "Given booking changed status meanwhile when reschedule reservation then return conflict",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				CheckIn:        time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut:       time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC),
+				PaidAmount:     1000000,
+				FromStatus:     constant.BookingStatusConfirmed,
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("UPDATE bookings").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			err: model.NewConflictError("booking is no longer Confirmed"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			repo := NewBookingRepository(sqlxDB)
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
 
 			err := repo.Reschedule(tc.booking)
 			assert.Equal(t, tc.err, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_CountOverlapping(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		request  *model.AvailabilityRequest
+		mockCall func()
+		response int
+		err      error
+	}{
+		{
+			name: "Given valid request when count overlapping reservation then return success response",
+			request: &model.AvailabilityRequest{
+				RoomName: "101",
+				CheckIn:  time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut: time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC),
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT").WithArgs("101", time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC), time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC), "",
+					constant.BookingStatusConfirmed, constant.BookingStatusCheckedIn, constant.PaymentStatusFailed, constant.PaymentStatusCanceled, constant.PaymentStatusRefunded,
+					"101", time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC), time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC), constant.MaintenanceStatusOpen).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			response: 1,
+			err:      nil,
+		},
+		{
+			name: "Given error when count overlapping reservation then return error response",
+			request: &model.AvailabilityRequest{
+				RoomName: "101",
+				CheckIn:  time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
+				CheckOut: time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC),
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT").WillReturnError(sqlmock.ErrCancelled)
+			},
+			response: 0,
+			err:      sqlmock.ErrCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			resp, err := repo.CountOverlapping(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+		})
+	}
+}
+
+func Test_Cancel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	canceledAt := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	reference := "cancellation refund"
+	refund := &model.PaymentEntity{RegisterNumber: "register_number", Amount: -500, PaymentMethod: constant.PaymentMethodCash, Reference: &reference, PaidAt: canceledAt}
+
+	testCases := []struct {
+		name     string
+		booking  *model.BookingEntity
+		refund   *model.PaymentEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given refund when cancel reservation then save the cancellation and the refund together",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				Status:         constant.BookingStatusCancelled,
+				PaymentStatus:  constant.PaymentStatusRefunded,
+				RefundAmount:   500,
+				CanceledAt:     &canceledAt,
+				FromStatus:     constant.BookingStatusConfirmed,
+			},
+			refund: refund,
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE bookings SET status = (.+), payment_status").WithArgs(constant.BookingStatusCancelled, constant.PaymentStatusRefunded, 500.0, &canceledAt, "register_number", constant.BookingStatusConfirmed).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO payments").WithArgs("register_number", -500.0, constant.PaymentMethodCash, &reference, canceledAt, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given nothing to refund when cancel reservation then return success response",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				Status:         constant.BookingStatusCancelled,
+				PaymentStatus:  constant.PaymentStatusCanceled,
+				CanceledAt:     &canceledAt,
+				FromStatus:     constant.BookingStatusConfirmed,
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE bookings SET status = (.+), payment_status").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given booking no longer confirmed when cancel reservation then return conflict error",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				Status:         constant.BookingStatusCancelled,
+				PaymentStatus:  constant.PaymentStatusRefunded,
+				RefundAmount:   500,
+				CanceledAt:     &canceledAt,
+				FromStatus:     constant.BookingStatusConfirmed,
+			},
+			refund: refund,
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE bookings SET status = (.+), payment_status").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			err: model.NewConflictError("booking is no longer %s", constant.BookingStatusConfirmed.Enum()),
+		},
+		{
+			name: "Given error save refund when cancel reservation then roll back the cancellation",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				Status:         constant.BookingStatusCancelled,
+				PaymentStatus:  constant.PaymentStatusRefunded,
+				RefundAmount:   500,
+				CanceledAt:     &canceledAt,
+				FromStatus:     constant.BookingStatusConfirmed,
+			},
+			refund: refund,
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE bookings SET status = (.+), payment_status").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO payments").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
+			},
+			err: sqlmock.ErrCancelled,
+		},
+		{
+			name: "Given error when cancel reservation then return error response",
+			booking: &model.BookingEntity{
+				RegisterNumber: "register_number",
+				PaymentStatus:  constant.PaymentStatusCanceled,
+				CanceledAt:     &canceledAt,
+			},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE bookings SET status = (.+), payment_status").WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
+			},
+			err: sqlmock.ErrCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			err := repo.Cancel(tc.booking, tc.refund)
+			assert.Equal(t, tc.err, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_RevertCancel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	booking := &model.BookingEntity{
+		RegisterNumber: "register_number",
+		Status:         constant.BookingStatusConfirmed,
+		PaymentStatus:  constant.PaymentStatusCompleted,
+		FromStatus:     constant.BookingStatusCancelled,
+	}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given cancelled booking when revert cancel then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = \\?, payment_status = \\?, refund_amount = \\?, canceled_at = NULL").
+					WithArgs(constant.BookingStatusConfirmed, constant.PaymentStatusCompleted, 0.0, "register_number", constant.BookingStatusCancelled).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given booking no longer cancelled when revert cancel then return conflict error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("booking is no longer %s", constant.BookingStatusCancelled.Enum()),
+		},
+		{
+			name: "Given error when revert cancel then return error response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status").WillReturnError(sqlmock.ErrCancelled)
+			},
+			err: sqlmock.ErrCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			err := repo.RevertCancel(booking)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CheckIn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	checkedInAt := time.Date(2000, time.March, 01, 14, 00, 00, 00, time.UTC)
+	userID := 1
+
+	testCases := []struct {
+		name     string
+		booking  *model.BookingEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when check in reservation then return success response",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedIn, CheckedInAt: &checkedInAt, CheckedInBy: &userID, FromStatus: constant.BookingStatusConfirmed},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = (.+), checked_in_at").WithArgs(constant.BookingStatusCheckedIn, &checkedInAt, &userID, "register_number", constant.BookingStatusConfirmed).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given booking no longer confirmed when check in reservation then return conflict error",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedIn, CheckedInAt: &checkedInAt, CheckedInBy: &userID, FromStatus: constant.BookingStatusConfirmed},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = (.+), checked_in_at").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("booking is no longer %s", constant.BookingStatusConfirmed.Enum()),
+		},
+		{
+			name:    "Given error when check in reservation then return error response",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedIn, CheckedInAt: &checkedInAt, CheckedInBy: &userID, FromStatus: constant.BookingStatusConfirmed},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = (.+), checked_in_at").WillReturnError(sqlmock.ErrCancelled)
+			},
+			err: sqlmock.ErrCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			err := repo.CheckIn(tc.booking)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_CheckOut(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	checkedOutAt := time.Date(2000, time.March, 03, 12, 00, 00, 00, time.UTC)
+	userID := 1
+
+	testCases := []struct {
+		name     string
+		booking  *model.BookingEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when check out reservation then return success response",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedOut, CheckedOutAt: &checkedOutAt, CheckedOutBy: &userID, FromStatus: constant.BookingStatusCheckedIn},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = (.+), checked_out_at").WithArgs(constant.BookingStatusCheckedOut, &checkedOutAt, &userID, "register_number", constant.BookingStatusCheckedIn).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given booking no longer checked in when check out reservation then return conflict error",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedOut, CheckedOutAt: &checkedOutAt, CheckedOutBy: &userID, FromStatus: constant.BookingStatusCheckedIn},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = (.+), checked_out_at").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("booking is no longer %s", constant.BookingStatusCheckedIn.Enum()),
+		},
+		{
+			name:    "Given error when check out reservation then return error response",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusCheckedOut, CheckedOutAt: &checkedOutAt, CheckedOutBy: &userID, FromStatus: constant.BookingStatusCheckedIn},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status = (.+), checked_out_at").WillReturnError(sqlmock.ErrCancelled)
+			},
+			err: sqlmock.ErrCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			err := repo.CheckOut(tc.booking)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_UpdateStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		booking  *model.BookingEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when update reservation status then return success response",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusNoShow, FromStatus: constant.BookingStatusConfirmed},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status").WithArgs(constant.BookingStatusNoShow, "register_number", constant.BookingStatusConfirmed).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given booking no longer confirmed when update reservation status then return conflict error",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusNoShow, FromStatus: constant.BookingStatusConfirmed},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("booking is no longer %s", constant.BookingStatusConfirmed.Enum()),
+		},
+		{
+			name:    "Given error when update reservation status then return error response",
+			booking: &model.BookingEntity{RegisterNumber: "register_number", Status: constant.BookingStatusNoShow, FromStatus: constant.BookingStatusConfirmed},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE bookings SET status").WillReturnError(sqlmock.ErrCancelled)
+			},
+			err: sqlmock.ErrCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			err := repo.UpdateStatus(tc.booking)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetByGuestID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	checkIn := time.Date(2000, time.April, 01, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		response []*model.BookingDetail
+		err      error
+	}{
+		{
+			name: "Given guest with bookings when get bookings by guest then return the latest stay first",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM bookings b JOIN guests g ON b.guest_id = g.id JOIN rooms r ON r.name = b.room_name WHERE b.guest_id = \\? ORDER BY b.check_in DESC, b.id DESC").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"register_number", "guest_id", "first_name", "last_name", "room_name", "room.floor", "check_in", "check_out", "status"}).
+						AddRow("register_number_2", 1, "John", "Doe", "room", 1, checkIn.AddDate(0, 1, 0), checkIn.AddDate(0, 1, 2), constant.BookingStatusCheckedOut).
+						AddRow("register_number_1", 1, "John", "Doe", "room", 1, checkIn, checkIn.AddDate(0, 0, 1), constant.BookingStatusCancelled))
+			},
+			response: []*model.BookingDetail{
+				{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number_2", GuestID: 1, RoomName: "room", CheckIn: checkIn.AddDate(0, 1, 0), CheckOut: checkIn.AddDate(0, 1, 2), Status: constant.BookingStatusCheckedOut},
+					GuestEntity:   model.GuestEntity{FirstName: "John", LastName: "Doe"},
+					RoomEntity:    model.RoomEntity{Floor: 1},
+				},
+				{
+					BookingEntity: model.BookingEntity{RegisterNumber: "register_number_1", GuestID: 1, RoomName: "room", CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 1), Status: constant.BookingStatusCancelled},
+					GuestEntity:   model.GuestEntity{FirstName: "John", LastName: "Doe"},
+					RoomEntity:    model.RoomEntity{Floor: 1},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when get bookings by guest then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT").WithArgs(1).WillReturnError(errors.New("error"))
+			},
+			response: nil,
+			err:      errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewBookingRepository(sqlxDB, testCipher, folio.NewFolioRepository(sqlxDB), payment.NewPaymentRepository(sqlxDB))
+
+			resp, err := repo.GetByGuestID(1)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
 		})
 	}
 }
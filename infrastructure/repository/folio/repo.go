@@ -0,0 +1,13 @@
+package folio
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=FolioRepository --output=../mocks --outpkg=mocks
+type FolioRepository interface {
+	CreateCharge(charge *model.FolioChargeEntity) error
+	CreateCharges(tx *sqlx.Tx, charges []*model.FolioChargeEntity) error
+	GetByRegisterNumber(registerNumber string) ([]*model.FolioChargeEntity, error)
+}
@@ -0,0 +1,61 @@
+package folio
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+const insertChargeQuery = "INSERT INTO folio_charges (register_number, charge_type, description, charge_date, quantity, unit_price, amount, posted_by) VALUES (:register_number, :charge_type, :description, :charge_date, :quantity, :unit_price, :amount, :posted_by)"
+
+type folioRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewFolioRepository(db *sqlx.DB) FolioRepository {
+	return &folioRepoImpl{db: db}
+}
+
+func (f *folioRepoImpl) CreateCharge(charge *model.FolioChargeEntity) error {
+	res, err := f.db.NamedExec(insertChargeQuery, charge)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	charge.ID = int(id)
+	return nil
+}
+
+// CreateCharges posts the charges in the transaction of the caller, so they are saved
+// together with the booking they belong to or not at all.
+func (f *folioRepoImpl) CreateCharges(tx *sqlx.Tx, charges []*model.FolioChargeEntity) error {
+	for _, charge := range charges {
+		if _, err := tx.NamedExec(insertChargeQuery, charge); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByRegisterNumber returns the charges of a booking in the order they hit the folio.
+func (f *folioRepoImpl) GetByRegisterNumber(registerNumber string) ([]*model.FolioChargeEntity, error) {
+	charges := make([]*model.FolioChargeEntity, 0)
+	query := "SELECT id, register_number, charge_type, description, charge_date, quantity, unit_price, amount, posted_by, created_at FROM folio_charges WHERE register_number = ? ORDER BY charge_date ASC, id ASC"
+
+	if err := f.db.Select(&charges, query, registerNumber); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return charges, nil
+}
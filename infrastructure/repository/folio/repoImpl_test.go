@@ -0,0 +1,195 @@
+package folio
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var columns = []string{"id", "register_number", "charge_type", "description", "charge_date", "quantity", "unit_price", "amount", "posted_by", "created_at"}
+
+func Test_CreateCharge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	postedBy := 1
+
+	testCases := []struct {
+		name     string
+		charge   *model.FolioChargeEntity
+		mockCall func()
+		id       int
+		err      error
+	}{
+		{
+			name: "Given valid request when create folio charge then return success response",
+			charge: &model.FolioChargeEntity{
+				RegisterNumber: "register_number",
+				ChargeType:     constant.FolioChargeTypeMinibar,
+				Description:    "Soft drink",
+				ChargeDate:     time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
+				Quantity:       2,
+				UnitPrice:      25000,
+				Amount:         50000,
+				PostedBy:       &postedBy,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO folio_charges").WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			id:  5,
+			err: nil,
+		},
+		{
+			name: "Given error connection when create folio charge then return error",
+			charge: &model.FolioChargeEntity{
+				RegisterNumber: "register_number",
+				ChargeType:     constant.FolioChargeTypeMinibar,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO folio_charges").WillReturnError(sql.ErrConnDone)
+			},
+			id:  0,
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewFolioRepository(sqlxDB)
+
+			err := repo.CreateCharge(tc.charge)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.id, tc.charge.ID)
+		})
+	}
+}
+
+func Test_CreateCharges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	charges := []*model.FolioChargeEntity{
+		{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC), Quantity: 1, UnitPrice: 500000, Amount: 500000},
+		{RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: time.Date(2000, time.March, 02, 00, 00, 00, 00, time.UTC), Quantity: 1, UnitPrice: 600000, Amount: 600000},
+	}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when create folio charges then post every charge in the transaction",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO folio_charges").WithArgs("register_number", constant.FolioChargeTypeRoom, "Room 101", charges[0].ChargeDate, 1, 500000.0, 500000.0, nil).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO folio_charges").WithArgs("register_number", constant.FolioChargeTypeRoom, "Room 101", charges[1].ChargeDate, 1, 600000.0, 600000.0, nil).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when create folio charges then return error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO folio_charges").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			tx, err := sqlxDB.Beginx()
+			assert.NoError(t, err)
+
+			repo := NewFolioRepository(sqlxDB)
+
+			err = repo.CreateCharges(tx, charges)
+			assert.Equal(t, tc.err, err)
+
+			if err != nil {
+				tx.Rollback()
+			} else {
+				assert.NoError(t, tx.Commit())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	chargeDate := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	postedBy := 1
+
+	testCases := []struct {
+		name     string
+		result   []*model.FolioChargeEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when get folio charges then return charges in posting order",
+			result: []*model.FolioChargeEntity{
+				{ID: 1, RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeRoom, Description: "Room 101", ChargeDate: chargeDate, Quantity: 1, UnitPrice: 500000, Amount: 500000},
+				{ID: 2, RegisterNumber: "register_number", ChargeType: constant.FolioChargeTypeLaundry, Description: "Shirt", ChargeDate: chargeDate, Quantity: 1, UnitPrice: 30000, Amount: 30000, PostedBy: &postedBy},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM folio_charges WHERE register_number = \\? ORDER BY charge_date ASC, id ASC").WithArgs("register_number").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "register_number", constant.FolioChargeTypeRoom, "Room 101", chargeDate, 1, 500000, 500000, nil, nil).
+						AddRow(2, "register_number", constant.FolioChargeTypeLaundry, "Shirt", chargeDate, 1, 30000, 30000, 1, nil))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error connection when get folio charges then return error",
+			result: nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM folio_charges").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewFolioRepository(sqlxDB)
+
+			result, err := repo.GetByRegisterNumber("register_number")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
@@ -1,9 +1,19 @@
 package guest
 
-import "github.com/zakiyalmaya/hotel-management/model"
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/model"
+)
 
 //go:generate mockery --name=GuestRepository --output=../mocks --outpkg=mocks
 type GuestRepository interface {
 	Create(guest *model.GuestEntity) error
 	GetByID(id int) (*model.GuestEntity, error)
-}
\ No newline at end of file
+	GetAll() ([]*model.GuestEntity, error)
+	Search(request *model.SearchGuestRequest, pagination *model.PaginationRequest) ([]*model.GuestEntity, int, error)
+	Update(guest *model.GuestEntity) error
+	Anonymize(guest *model.GuestEntity) error
+	GetIDsWithoutStaySince(since time.Time) ([]int, error)
+	ReEncrypt() (int, error)
+}
@@ -2,22 +2,44 @@ package guest
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
+// guestRow is a guest as it is stored: the identity number, phone number and email are
+// encrypted and found by their blind indexes.
+type guestRow struct {
+	model.GuestEntity
+	IdentityIndex *string `db:"identity_number_index"`
+	PhoneIndex    *string `db:"phone_number_index"`
+	EmailIndex    *string `db:"email_index"`
+}
+
 type guestRepoImpl struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	cipher *fieldcipher.Cipher
 }
 
-func NewGuestRepository(db *sqlx.DB) GuestRepository {
-	return &guestRepoImpl{db: db}
+// NewGuestRepository encrypts the identity number, phone number and email of guests
+// with cipher, the guests it returns have them decrypted.
+func NewGuestRepository(db *sqlx.DB, cipher *fieldcipher.Cipher) GuestRepository {
+	return &guestRepoImpl{db: db, cipher: cipher}
 }
 
 func (g *guestRepoImpl) Create(guest *model.GuestEntity) error {
-	query := "INSERT INTO guests (first_name, last_name, identity_number, date_of_birth, phone_number, email) VALUES (:first_name, :last_name, :identity_number, :date_of_birth, :phone_number, :email)"
-	_, err := g.db.NamedExec(query, guest)
+	row, err := g.encrypt(guest)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	query := "INSERT INTO guests (first_name, last_name, identity_number, identity_number_index, date_of_birth, phone_number, phone_number_index, email, email_index) VALUES (:first_name, :last_name, :identity_number, :identity_number_index, :date_of_birth, :phone_number, :phone_number_index, :email, :email_index)"
+	_, err = g.db.NamedExec(query, row)
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
 		return err
@@ -28,13 +50,265 @@ func (g *guestRepoImpl) Create(guest *model.GuestEntity) error {
 
 func (g *guestRepoImpl) GetByID(id int) (*model.GuestEntity, error) {
 	guest := &model.GuestEntity{}
-	query := "SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at FROM guests WHERE id = ?"
-	
+	query := "SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at, anonymized_at, anonymized_by FROM guests WHERE id = ?"
+
 	err := g.db.Get(guest, query, id)
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
 		return nil, err
 	}
 
+	if err := g.decrypt(guest); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
 	return guest, nil
-}
\ No newline at end of file
+}
+
+// GetAll leaves out anonymized guests, there is nothing left of them to compare.
+func (g *guestRepoImpl) GetAll() ([]*model.GuestEntity, error) {
+	guests := make([]*model.GuestEntity, 0)
+	query := "SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at, anonymized_at, anonymized_by FROM guests WHERE anonymized_at IS NULL ORDER BY id"
+
+	if err := g.db.Select(&guests, query); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	for _, guest := range guests {
+		if err := g.decrypt(guest); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return nil, err
+		}
+	}
+
+	return guests, nil
+}
+
+// Search matches the name from the start of each word, ignoring case, so guests are found
+// from what they tell the front desk rather than their id. The phone number, email and
+// identity number are encrypted, they match only in full through their blind index.
+// Anonymized guests are left out, as in GetAll.
+func (g *guestRepoImpl) Search(request *model.SearchGuestRequest, pagination *model.PaginationRequest) ([]*model.GuestEntity, int, error) {
+	var params []interface{}
+	where := " WHERE anonymized_at IS NULL"
+	if request.Name != nil {
+		for _, word := range strings.Fields(*request.Name) {
+			where += ` AND (first_name LIKE ? ESCAPE '\' OR last_name LIKE ? ESCAPE '\')`
+			params = append(params, likePrefix(word), likePrefix(word))
+		}
+	}
+
+	if request.PhoneNumber != nil {
+		where += " AND phone_number_index = ?"
+		params = append(params, g.cipher.BlindIndex(*request.PhoneNumber))
+	}
+
+	if request.Email != nil {
+		where += " AND email_index = ?"
+		params = append(params, g.cipher.BlindIndex(*request.Email))
+	}
+
+	if request.Identity != nil {
+		where += " AND identity_number_index = ?"
+		params = append(params, g.cipher.BlindIndex(*request.Identity))
+	}
+
+	var total int
+	if err := g.db.Get(&total, "SELECT COUNT(*) FROM guests"+where, params...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, 0, err
+	}
+
+	guests := make([]*model.GuestEntity, 0)
+	query := "SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at, anonymized_at, anonymized_by FROM guests" + where + " ORDER BY last_name, first_name, id LIMIT ? OFFSET ?"
+	if err := g.db.Select(&guests, query, append(params, pagination.Limit, pagination.Offset())...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, 0, err
+	}
+
+	for _, guest := range guests {
+		if err := g.decrypt(guest); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return nil, 0, err
+		}
+	}
+
+	return guests, total, nil
+}
+
+func (g *guestRepoImpl) Update(guest *model.GuestEntity) error {
+	row, err := g.encrypt(guest)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	query := "UPDATE guests SET first_name = :first_name, last_name = :last_name, phone_number = :phone_number, phone_number_index = :phone_number_index, email = :email, email_index = :email_index, updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+	if _, err := g.db.NamedExec(query, row); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Anonymize overwrites the personal data of the guest with the values given and removes it
+// from their bookings and merge records. Bookings, payments, folio charges and invoices keep
+// their amounts, only who they belonged to is no longer known.
+func (g *guestRepoImpl) Anonymize(guest *model.GuestEntity) error {
+	row, err := g.encrypt(guest)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	tx, err := g.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE guests SET first_name = :first_name, last_name = :last_name, identity_number = :identity_number, identity_number_index = :identity_number_index, date_of_birth = :date_of_birth, phone_number = :phone_number, phone_number_index = :phone_number_index, email = :email, email_index = :email_index, anonymized_at = :anonymized_at, anonymized_by = :anonymized_by, updated_at = CURRENT_TIMESTAMP WHERE id = :id AND anonymized_at IS NULL"
+	res, err := tx.NamedExec(query, row)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("guest has already been anonymized")
+	}
+
+	if _, err := tx.Exec("UPDATE bookings SET additional_request = NULL, updated_at = CURRENT_TIMESTAMP WHERE guest_id = ? AND additional_request IS NOT NULL", guest.ID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	// the guests merged into this one were the same person
+	if _, err := tx.Exec("UPDATE guest_merges SET merged_guest = '{}' WHERE surviving_guest_id = ?", guest.ID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM guest_duplicates WHERE status = ? AND (guest_id = ? OR duplicate_guest_id = ?)",
+		constant.GuestDuplicateStatusPending, guest.ID, guest.ID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetIDsWithoutStaySince returns the guests that are not anonymized yet, were registered
+// before since and have no booking checking out since then nor one still going on.
+func (g *guestRepoImpl) GetIDsWithoutStaySince(since time.Time) ([]int, error) {
+	ids := make([]int, 0)
+	query := `SELECT g.id FROM guests g WHERE g.anonymized_at IS NULL AND g.created_at < ?
+		AND NOT EXISTS (SELECT 1 FROM bookings b WHERE b.guest_id = g.id AND (b.check_out >= ? OR b.status IN (?, ?)))
+		ORDER BY g.id`
+
+	if err := g.db.Select(&ids, query, since, since, constant.BookingStatusConfirmed, constant.BookingStatusCheckedIn); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ReEncrypt encrypts with the active key the guests stored in plaintext or with an older key
+// and fills in their blind indexes. It returns how many guests were rewritten.
+func (g *guestRepoImpl) ReEncrypt() (int, error) {
+	rows := make([]*guestRow, 0)
+	query := "SELECT id, identity_number, identity_number_index, phone_number, phone_number_index, email, email_index FROM guests ORDER BY id"
+	if err := g.db.Select(&rows, query); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	reEncrypted := 0
+	for _, row := range rows {
+		if g.cipher.IsCurrent(row.Identity) && g.cipher.IsCurrent(row.PhoneNumber) && g.cipher.IsCurrent(row.Email) &&
+			row.IdentityIndex != nil && row.PhoneIndex != nil && row.EmailIndex != nil {
+			continue
+		}
+
+		if err := g.decrypt(&row.GuestEntity); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return reEncrypted, err
+		}
+
+		encrypted, err := g.encrypt(&row.GuestEntity)
+		if err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return reEncrypted, err
+		}
+
+		query := "UPDATE guests SET identity_number = :identity_number, identity_number_index = :identity_number_index, phone_number = :phone_number, phone_number_index = :phone_number_index, email = :email, email_index = :email_index WHERE id = :id"
+		if _, err := g.db.NamedExec(query, encrypted); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return reEncrypted, err
+		}
+		reEncrypted++
+	}
+
+	return reEncrypted, nil
+}
+
+// encrypt returns the guest as it is stored, the guest itself is left as it is.
+func (g *guestRepoImpl) encrypt(guest *model.GuestEntity) (*guestRow, error) {
+	row := &guestRow{GuestEntity: *guest}
+	fields := []struct {
+		value *string
+		index **string
+	}{
+		{value: &row.Identity, index: &row.IdentityIndex},
+		{value: &row.PhoneNumber, index: &row.PhoneIndex},
+		{value: &row.Email, index: &row.EmailIndex},
+	}
+
+	for _, field := range fields {
+		index := g.cipher.BlindIndex(*field.value)
+		encrypted, err := g.cipher.Encrypt(*field.value)
+		if err != nil {
+			return nil, err
+		}
+
+		*field.value = encrypted
+		*field.index = &index
+	}
+
+	return row, nil
+}
+
+func (g *guestRepoImpl) decrypt(guest *model.GuestEntity) error {
+	for _, value := range []*string{&guest.Identity, &guest.PhoneNumber, &guest.Email} {
+		decrypted, err := g.cipher.Decrypt(*value)
+		if err != nil {
+			return err
+		}
+
+		*value = decrypted
+	}
+
+	return nil
+}
+
+// likePrefix escapes the wildcards of a search term so they match literally.
+func likePrefix(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(term) + "%"
+}
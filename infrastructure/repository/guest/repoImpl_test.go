@@ -2,15 +2,43 @@ package guest
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/assert/v2"
 	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
+var testCipher, _ = fieldcipher.NewCipher(config.GuestEncryptionConfig{
+	ActiveKeyID:   "test",
+	Keys:          []config.GuestEncryptionKeyConfig{{ID: "test", Key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}},
+	BlindIndexKey: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+})
+
+// encryptedArg matches a value stored encrypted with the active key.
+type encryptedArg string
+
+func (e encryptedArg) Match(value driver.Value) bool {
+	encrypted, ok := value.(string)
+	if !ok || !testCipher.IsCurrent(encrypted) {
+		return false
+	}
+
+	plaintext, err := testCipher.Decrypt(encrypted)
+	return err == nil && plaintext == string(e)
+}
+
+func encrypt(value string) string {
+	encrypted, _ := testCipher.Encrypt(value)
+	return encrypted
+}
+
 func Test_Create(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -27,7 +55,7 @@ func Test_Create(t *testing.T) {
 		err      error
 	}{
 		{
-			name: "Given valid request when create guest then return success response",
+			name: "Given valid request when create guest then store the identity and contact details encrypted",
 			guest: &model.GuestEntity{
 				FirstName:   "first",
 				LastName:    "last",
@@ -36,8 +64,12 @@ func Test_Create(t *testing.T) {
 				PhoneNumber: "phone",
 				Email:       "email",
 			},
-			mockCall: func() { mock.ExpectExec("INSERT INTO guests").WillReturnResult(sqlmock.NewResult(1, 1)) },
-			err:      nil,
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO guests").
+					WithArgs("first", "last", encryptedArg("identity"), testCipher.BlindIndex("identity"), sqlmock.AnyArg(), encryptedArg("phone"), testCipher.BlindIndex("phone"), encryptedArg("email"), testCipher.BlindIndex("email")).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
 		},
 		{
 			name: "Given error connection when create guest then return error",
@@ -58,7 +90,7 @@ func Test_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			repo := NewGuestRepository(sqlxDB)
+			repo := NewGuestRepository(sqlxDB, testCipher)
 
 			err := repo.Create(tc.guest)
 			assert.Equal(t, tc.err, err)
@@ -85,7 +117,7 @@ func Test_GetByID(t *testing.T) {
 		err      error
 	}{
 		{
-			name: "Given valid request when get guest by id then return success response",
+			name: "Given valid request when get guest by id then return it decrypted",
 			id:   1,
 			result: &model.GuestEntity{
 				ID:          1,
@@ -99,9 +131,9 @@ func Test_GetByID(t *testing.T) {
 				UpdatedAt:   &currentTime,
 			},
 			mockCall: func() {
-				mock.ExpectQuery("SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at FROM guests WHERE id = ?").WillReturnRows(
+				mock.ExpectQuery("SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at, anonymized_at, anonymized_by FROM guests WHERE id = ?").WillReturnRows(
 					sqlmock.NewRows([]string{"id", "first_name", "last_name", "identity_number", "date_of_birth", "phone_number", "email", "created_at", "updated_at"}).
-						AddRow(1, "first", "last", "identity", currentTime, "phone", "email", currentTime, currentTime),
+						AddRow(1, "first", "last", encrypt("identity"), currentTime, encrypt("phone"), encrypt("email"), currentTime, currentTime),
 				)
 			},
 			err: nil,
@@ -111,7 +143,7 @@ func Test_GetByID(t *testing.T) {
 			id:     1,
 			result: nil,
 			mockCall: func() {
-				mock.ExpectQuery("SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at FROM guests WHERE id = ?").WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at, anonymized_at, anonymized_by FROM guests WHERE id = ?").WillReturnError(sql.ErrNoRows)
 			},
 			err: sql.ErrNoRows,
 		},
@@ -121,7 +153,7 @@ func Test_GetByID(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockCall()
 
-			repo := NewGuestRepository(sqlxDB)
+			repo := NewGuestRepository(sqlxDB, testCipher)
 
 			result, err := repo.GetByID(tc.id)
 			assert.Equal(t, tc.result, result)
@@ -129,3 +161,338 @@ func Test_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	currentTime := time.Now()
+	name, email := "jo do", "John_Doe@mail.com"
+
+	testCases := []struct {
+		name     string
+		request  *model.SearchGuestRequest
+		mockCall func()
+		result   []*model.GuestEntity
+		total    int
+		err      error
+	}{
+		{
+			name:    "Given name and email when search guests then match each word escaping the wildcards and the email by its blind index",
+			request: &model.SearchGuestRequest{Name: &name, Email: &email},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM guests WHERE anonymized_at IS NULL AND \\(first_name LIKE (.+) OR last_name LIKE (.+)\\) AND \\(first_name LIKE (.+) OR last_name LIKE (.+)\\) AND email_index = \\?").
+					WithArgs("jo%", "jo%", "do%", "do%", testCipher.BlindIndex("john_doe@mail.com")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery("SELECT id, first_name, last_name, identity_number, date_of_birth, phone_number, email, created_at, updated_at, anonymized_at, anonymized_by FROM guests WHERE anonymized_at IS NULL (.+) ORDER BY last_name, first_name, id LIMIT \\? OFFSET \\?").
+					WithArgs("jo%", "jo%", "do%", "do%", testCipher.BlindIndex("john_doe@mail.com"), 2, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "identity_number", "date_of_birth", "phone_number", "email", "created_at", "updated_at"}).
+						AddRow(3, "John", "Doe", encrypt("identity"), currentTime, encrypt("phone"), encrypt("john_doe@mail.com"), currentTime, currentTime))
+			},
+			result: []*model.GuestEntity{
+				{ID: 3, FirstName: "John", LastName: "Doe", Identity: "identity", DateOfBirth: currentTime, PhoneNumber: "phone", Email: "john_doe@mail.com", CreatedAt: &currentTime, UpdatedAt: &currentTime},
+			},
+			total: 3,
+			err:   nil,
+		},
+		{
+			name:    "Given error connection when search guests then return error",
+			request: &model.SearchGuestRequest{},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM guests WHERE anonymized_at IS NULL").WillReturnError(sql.ErrConnDone)
+			},
+			result: nil,
+			total:  0,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestRepository(sqlxDB, testCipher)
+
+			result, total, err := repo.Search(tc.request, &model.PaginationRequest{Page: 2, Limit: 2})
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.total, total)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when update guest then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE guests SET first_name = (.+), last_name = (.+), phone_number = (.+), phone_number_index = (.+), email = (.+), email_index = (.+) WHERE id = ?").
+					WithArgs("John", "Doe", encryptedArg("08123456789"), testCipher.BlindIndex("08123456789"), encryptedArg("john@mail.com"), testCipher.BlindIndex("john@mail.com"), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when update guest then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE guests").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestRepository(sqlxDB, testCipher)
+
+			err := repo.Update(&model.GuestEntity{ID: 1, FirstName: "John", LastName: "Doe", PhoneNumber: "08123456789", Email: "john@mail.com"})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	currentTime := time.Now()
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   []*model.GuestEntity
+		err      error
+	}{
+		{
+			name: "Given guests when get all guests then return the ones not anonymized oldest first",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM guests WHERE anonymized_at IS NULL ORDER BY id").WillReturnRows(
+					sqlmock.NewRows([]string{"id", "first_name", "last_name", "identity_number", "date_of_birth", "phone_number", "email", "created_at", "updated_at"}).
+						AddRow(1, "first", "last", "identity", currentTime, "phone", "email", currentTime, currentTime))
+			},
+			result: []*model.GuestEntity{
+				{ID: 1, FirstName: "first", LastName: "last", Identity: "identity", DateOfBirth: currentTime, PhoneNumber: "phone", Email: "email", CreatedAt: &currentTime, UpdatedAt: &currentTime},
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when get all guests then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM guests").WillReturnError(sql.ErrConnDone)
+			},
+			result: nil,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestRepository(sqlxDB, testCipher)
+
+			result, err := repo.GetAll()
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Anonymize(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	currentTime := time.Now()
+	userID := 1
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given guest not anonymized when anonymize then scrub the guest and keep the bookings",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guests SET (.+) WHERE id = (.+) AND anonymized_at IS NULL").
+					WithArgs("Anonymized", "Guest", encryptedArg("anonymized-1"), testCipher.BlindIndex("anonymized-1"), time.Time{}, encryptedArg(""), testCipher.BlindIndex(""), encryptedArg(""), testCipher.BlindIndex(""), &currentTime, &userID, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE bookings SET additional_request = NULL").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE guest_merges SET merged_guest = '{}' WHERE surviving_guest_id = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM guest_duplicates").WithArgs(constant.GuestDuplicateStatusPending, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given guest already anonymized when anonymize then return conflict error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guests SET").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			err: model.NewConflictError("guest has already been anonymized"),
+		},
+		{
+			name: "Given error connection when anonymize then return error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guests SET").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE bookings").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestRepository(sqlxDB, testCipher)
+
+			err := repo.Anonymize(&model.GuestEntity{
+				ID:           1,
+				FirstName:    "Anonymized",
+				LastName:     "Guest",
+				Identity:     "anonymized-1",
+				AnonymizedAt: &currentTime,
+				AnonymizedBy: &userID,
+			})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, nil, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_GetIDsWithoutStaySince(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	since := time.Now().AddDate(-5, 0, 0)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   []int
+		err      error
+	}{
+		{
+			name: "Given guests without a stay when get ids without stay since then return them",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT g.id FROM guests g WHERE g.anonymized_at IS NULL AND g.created_at < (.+) AND NOT EXISTS").
+					WithArgs(since, since, constant.BookingStatusConfirmed, constant.BookingStatusCheckedIn).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(5))
+			},
+			result: []int{2, 5},
+			err:    nil,
+		},
+		{
+			name: "Given error connection when get ids without stay since then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT g.id FROM guests g").WillReturnError(sql.ErrConnDone)
+			},
+			result: nil,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestRepository(sqlxDB, testCipher)
+
+			result, err := repo.GetIDsWithoutStaySince(since)
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_ReEncrypt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   int
+		err      error
+	}{
+		{
+			name: "Given guests stored in plaintext when re-encrypt then encrypt them and skip the ones already current",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT id, identity_number, identity_number_index, phone_number, phone_number_index, email, email_index FROM guests ORDER BY id").WillReturnRows(
+					sqlmock.NewRows([]string{"id", "identity_number", "identity_number_index", "phone_number", "phone_number_index", "email", "email_index"}).
+						AddRow(1, encrypt("identity"), testCipher.BlindIndex("identity"), encrypt("phone"), testCipher.BlindIndex("phone"), encrypt("email"), testCipher.BlindIndex("email")).
+						AddRow(2, "3171234567890001", nil, "08123456789", nil, "john.doe@mail.com", nil))
+				mock.ExpectExec("UPDATE guests SET identity_number = (.+), identity_number_index = (.+), phone_number = (.+), phone_number_index = (.+), email = (.+), email_index = (.+) WHERE id = ?").
+					WithArgs(encryptedArg("3171234567890001"), testCipher.BlindIndex("3171234567890001"), encryptedArg("08123456789"), testCipher.BlindIndex("08123456789"), encryptedArg("john.doe@mail.com"), testCipher.BlindIndex("john.doe@mail.com"), 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			result: 1,
+			err:    nil,
+		},
+		{
+			name: "Given error connection when re-encrypt then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT id, identity_number").WillReturnError(sql.ErrConnDone)
+			},
+			result: 0,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestRepository(sqlxDB, testCipher)
+
+			result, err := repo.ReEncrypt()
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, nil, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package guestduplicate
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=GuestDuplicateRepository --output=../mocks --outpkg=mocks
+type GuestDuplicateRepository interface {
+	Upsert(duplicate *model.GuestDuplicateEntity) error
+	GetPending(pagination *model.PaginationRequest) ([]*model.GuestDuplicateEntity, int, error)
+	GetByID(id int) (*model.GuestDuplicateEntity, error)
+	Dismiss(duplicate *model.GuestDuplicateEntity) error
+	Merge(duplicate *model.GuestDuplicateEntity, merge *model.GuestMergeEntity) error
+	ReEncrypt() (int, error)
+}
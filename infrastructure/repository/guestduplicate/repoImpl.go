@@ -0,0 +1,200 @@
+package guestduplicate
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+const selectGuestDuplicateQuery = "SELECT id, guest_id, duplicate_guest_id, score, reasons, status, reviewed_by, reviewed_at, created_at, updated_at FROM guest_duplicates"
+
+type guestDuplicateRepoImpl struct {
+	db     *sqlx.DB
+	cipher *fieldcipher.Cipher
+}
+
+// NewGuestDuplicateRepository encrypts the copy of a merged guest kept by the merge audit record with cipher.
+func NewGuestDuplicateRepository(db *sqlx.DB, cipher *fieldcipher.Cipher) GuestDuplicateRepository {
+	return &guestDuplicateRepoImpl{db: db, cipher: cipher}
+}
+
+// Upsert suggests a pair or refreshes the score of a pending suggestion.
+// Pairs already reviewed keep their status, so a dismissed pair is not suggested again.
+func (g *guestDuplicateRepoImpl) Upsert(duplicate *model.GuestDuplicateEntity) error {
+	query := `INSERT INTO guest_duplicates (guest_id, duplicate_guest_id, score, reasons, status) VALUES (:guest_id, :duplicate_guest_id, :score, :reasons, :status)
+		ON CONFLICT(guest_id, duplicate_guest_id) DO UPDATE SET score = excluded.score, reasons = excluded.reasons, updated_at = CURRENT_TIMESTAMP WHERE guest_duplicates.status = :status`
+	if _, err := g.db.NamedExec(query, duplicate); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetPending returns the suggestions waiting for review, the most likely duplicates first.
+func (g *guestDuplicateRepoImpl) GetPending(pagination *model.PaginationRequest) ([]*model.GuestDuplicateEntity, int, error) {
+	var total int
+	if err := g.db.Get(&total, "SELECT COUNT(*) FROM guest_duplicates WHERE status = ?", constant.GuestDuplicateStatusPending); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, 0, err
+	}
+
+	duplicates := make([]*model.GuestDuplicateEntity, 0)
+	query := selectGuestDuplicateQuery + " WHERE status = ? ORDER BY score DESC, id LIMIT ? OFFSET ?"
+	if err := g.db.Select(&duplicates, query, constant.GuestDuplicateStatusPending, pagination.Limit, pagination.Offset()); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, 0, err
+	}
+
+	return duplicates, total, nil
+}
+
+func (g *guestDuplicateRepoImpl) GetByID(id int) (*model.GuestDuplicateEntity, error) {
+	duplicate := &model.GuestDuplicateEntity{}
+	if err := g.db.Get(duplicate, selectGuestDuplicateQuery+" WHERE id = ?", id); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return duplicate, nil
+}
+
+func (g *guestDuplicateRepoImpl) Dismiss(duplicate *model.GuestDuplicateEntity) error {
+	query := "UPDATE guest_duplicates SET status = ?, reviewed_by = ?, reviewed_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND status = ?"
+	res, err := g.db.Exec(query, constant.GuestDuplicateStatusDismissed, duplicate.ReviewedBy, duplicate.ReviewedAt, duplicate.ID, constant.GuestDuplicateStatusPending)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("duplicate suggestion has already been reviewed")
+	}
+
+	return nil
+}
+
+// Merge moves the bookings of the merged guest to the surviving one, removes the merged
+// guest and writes the audit record together. Other pending suggestions of the merged
+// guest are dropped, the next scan suggests them again against the surviving guest.
+func (g *guestDuplicateRepoImpl) Merge(duplicate *model.GuestDuplicateEntity, merge *model.GuestMergeEntity) error {
+	tx, err := g.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE guest_duplicates SET status = ?, reviewed_by = ?, reviewed_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND status = ?",
+		constant.GuestDuplicateStatusMerged, duplicate.ReviewedBy, duplicate.ReviewedAt, duplicate.ID, constant.GuestDuplicateStatusPending)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("duplicate suggestion has already been reviewed")
+	}
+
+	res, err = tx.Exec("UPDATE bookings SET guest_id = ?, updated_at = CURRENT_TIMESTAMP WHERE guest_id = ?", merge.SurvivingGuestID, merge.MergedGuestID)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	moved, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	merge.BookingsMoved = int(moved)
+
+	if _, err := tx.Exec("DELETE FROM guest_duplicates WHERE status = ? AND (guest_id = ? OR duplicate_guest_id = ?)",
+		constant.GuestDuplicateStatusPending, merge.MergedGuestID, merge.MergedGuestID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM guests WHERE id = ?", merge.MergedGuestID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	// guests merged earlier into the merged guest now belong to the surviving one,
+	// so anonymizing it also blanks them
+	if _, err := tx.Exec("UPDATE guest_merges SET surviving_guest_id = ? WHERE surviving_guest_id = ?", merge.SurvivingGuestID, merge.MergedGuestID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	stored := *merge
+	if stored.MergedGuest, err = g.cipher.Encrypt(merge.MergedGuest); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	query := "INSERT INTO guest_merges (duplicate_id, surviving_guest_id, merged_guest_id, merged_guest, bookings_moved, merged_by) VALUES (:duplicate_id, :surviving_guest_id, :merged_guest_id, :merged_guest, :bookings_moved, :merged_by)"
+	if _, err := tx.NamedExec(query, stored); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ReEncrypt encrypts with the active key the merged guests kept in plaintext or with an
+// older key. It returns how many merge records were rewritten.
+func (g *guestDuplicateRepoImpl) ReEncrypt() (int, error) {
+	merges := make([]*model.GuestMergeEntity, 0)
+	if err := g.db.Select(&merges, "SELECT id, merged_guest FROM guest_merges ORDER BY id"); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	reEncrypted := 0
+	for _, merge := range merges {
+		if g.cipher.IsCurrent(merge.MergedGuest) {
+			continue
+		}
+
+		snapshot, err := g.cipher.Decrypt(merge.MergedGuest)
+		if err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return reEncrypted, err
+		}
+
+		encrypted, err := g.cipher.Encrypt(snapshot)
+		if err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return reEncrypted, err
+		}
+
+		if _, err := g.db.Exec("UPDATE guest_merges SET merged_guest = ? WHERE id = ?", encrypted, merge.ID); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return reEncrypted, err
+		}
+		reEncrypted++
+	}
+
+	return reEncrypted, nil
+}
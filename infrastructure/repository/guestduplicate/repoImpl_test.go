@@ -0,0 +1,377 @@
+package guestduplicate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var testCipher, _ = fieldcipher.NewCipher(config.GuestEncryptionConfig{
+	ActiveKeyID:   "test",
+	Keys:          []config.GuestEncryptionKeyConfig{{ID: "test", Key: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}},
+	BlindIndexKey: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+})
+
+// encryptedArg matches a value stored encrypted with the active key.
+type encryptedArg string
+
+func (e encryptedArg) Match(value driver.Value) bool {
+	encrypted, ok := value.(string)
+	if !ok || !testCipher.IsCurrent(encrypted) {
+		return false
+	}
+
+	plaintext, err := testCipher.Decrypt(encrypted)
+	return err == nil && plaintext == string(e)
+}
+
+var columns = []string{"id", "guest_id", "duplicate_guest_id", "score", "reasons", "status", "reviewed_by", "reviewed_at", "created_at", "updated_at"}
+
+func Test_Upsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given new pair when upsert duplicate then insert or refresh the pending suggestion",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO guest_duplicates (.+) ON CONFLICT\\(guest_id, duplicate_guest_id\\) DO UPDATE SET score = excluded.score, reasons = excluded.reasons(.+) WHERE guest_duplicates.status = \\?").
+					WithArgs(1, 2, 0.7, "name,date_of_birth", constant.GuestDuplicateStatusPending, constant.GuestDuplicateStatusPending).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when upsert duplicate then return error",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO guest_duplicates").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := NewGuestDuplicateRepository(sqlxDB, testCipher).Upsert(&model.GuestDuplicateEntity{
+				GuestID:          1,
+				DuplicateGuestID: 2,
+				Score:            0.7,
+				Reasons:          "name,date_of_birth",
+				Status:           constant.GuestDuplicateStatusPending,
+			})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	createdAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   []*model.GuestDuplicateEntity
+		total    int
+		err      error
+	}{
+		{
+			name: "Given pending suggestions when get pending duplicates then return the most likely first",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM guest_duplicates WHERE status = \\?").WithArgs(constant.GuestDuplicateStatusPending).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectQuery("SELECT (.+) FROM guest_duplicates WHERE status = \\? ORDER BY score DESC, id LIMIT \\? OFFSET \\?").WithArgs(constant.GuestDuplicateStatusPending, 2, 2).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, 4, 0.6, "date_of_birth,email,phone_number", constant.GuestDuplicateStatusPending, nil, nil, createdAt, createdAt))
+			},
+			result: []*model.GuestDuplicateEntity{
+				{ID: 3, GuestID: 1, DuplicateGuestID: 4, Score: 0.6, Reasons: "date_of_birth,email,phone_number", Status: constant.GuestDuplicateStatusPending, CreatedAt: &createdAt, UpdatedAt: &createdAt},
+			},
+			total: 3,
+			err:   nil,
+		},
+		{
+			name: "Given error connection when get pending duplicates then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT").WillReturnError(sql.ErrConnDone)
+			},
+			result: nil,
+			total:  0,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, total, err := NewGuestDuplicateRepository(sqlxDB, testCipher).GetPending(&model.PaginationRequest{Page: 2, Limit: 2})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.total, total)
+		})
+	}
+}
+
+func Test_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	createdAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   *model.GuestDuplicateEntity
+		err      error
+	}{
+		{
+			name: "Given existing suggestion when get duplicate by id then return it",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM guest_duplicates WHERE id = \\?").WithArgs(3).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, 4, 0.7, "name,date_of_birth", constant.GuestDuplicateStatusPending, nil, nil, createdAt, createdAt))
+			},
+			result: &model.GuestDuplicateEntity{ID: 3, GuestID: 1, DuplicateGuestID: 4, Score: 0.7, Reasons: "name,date_of_birth", Status: constant.GuestDuplicateStatusPending, CreatedAt: &createdAt, UpdatedAt: &createdAt},
+			err:    nil,
+		},
+		{
+			name: "Given unknown suggestion when get duplicate by id then return no rows",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM guest_duplicates WHERE id = \\?").WithArgs(3).WillReturnError(sql.ErrNoRows)
+			},
+			result: nil,
+			err:    sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, err := NewGuestDuplicateRepository(sqlxDB, testCipher).GetByID(3)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Dismiss(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	reviewedAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+	reviewedBy := 1
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending suggestion when dismiss duplicate then return success",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE guest_duplicates SET status = \\?, reviewed_by = \\?, reviewed_at = \\?(.+) WHERE id = \\? AND status = \\?").
+					WithArgs(constant.GuestDuplicateStatusDismissed, 1, reviewedAt, 3, constant.GuestDuplicateStatusPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given reviewed suggestion when dismiss duplicate then return conflict",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE guest_duplicates").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("duplicate suggestion has already been reviewed"),
+		},
+		{
+			name: "Given error connection when dismiss duplicate then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE guest_duplicates").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := NewGuestDuplicateRepository(sqlxDB, testCipher).Dismiss(&model.GuestDuplicateEntity{ID: 3, ReviewedBy: &reviewedBy, ReviewedAt: &reviewedAt})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Merge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	reviewedAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+	reviewedBy := 1
+
+	testCases := []struct {
+		name          string
+		mockCall      func()
+		bookingsMoved int
+		err           error
+	}{
+		{
+			name: "Given pending suggestion when merge then move the bookings and earlier merges, remove the guest and write the audit record",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guest_duplicates SET status = \\?").
+					WithArgs(constant.GuestDuplicateStatusMerged, 1, reviewedAt, 3, constant.GuestDuplicateStatusPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE bookings SET guest_id = \\?(.+) WHERE guest_id = \\?").WithArgs(1, 4).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM guest_duplicates WHERE status = \\? AND \\(guest_id = \\? OR duplicate_guest_id = \\?\\)").
+					WithArgs(constant.GuestDuplicateStatusPending, 4, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM guests WHERE id = \\?").WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE guest_merges SET surviving_guest_id = \\? WHERE surviving_guest_id = \\?").WithArgs(1, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO guest_merges").WithArgs(3, 1, 4, encryptedArg(`{"ID":4}`), 2, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			bookingsMoved: 2,
+			err:           nil,
+		},
+		{
+			name: "Given reviewed suggestion when merge then return conflict",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guest_duplicates SET status = \\?").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			bookingsMoved: 0,
+			err:           model.NewConflictError("duplicate suggestion has already been reviewed"),
+		},
+		{
+			name: "Given error when moving bookings then roll back the merge",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guest_duplicates SET status = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE bookings").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			bookingsMoved: 0,
+			err:           sql.ErrConnDone,
+		},
+		{
+			name: "Given error when moving earlier merges then roll back the merge",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE guest_duplicates SET status = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE bookings").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM guest_duplicates").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM guests").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE guest_merges").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			bookingsMoved: 2,
+			err:           sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			merge := &model.GuestMergeEntity{DuplicateID: 3, SurvivingGuestID: 1, MergedGuestID: 4, MergedGuest: `{"ID":4}`, MergedBy: 1}
+			err := NewGuestDuplicateRepository(sqlxDB, testCipher).Merge(&model.GuestDuplicateEntity{ID: 3, ReviewedBy: &reviewedBy, ReviewedAt: &reviewedAt}, merge)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.bookingsMoved, merge.BookingsMoved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_ReEncrypt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	current, _ := testCipher.Encrypt(`{"ID":4}`)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   int
+		err      error
+	}{
+		{
+			name: "Given merged guests kept in plaintext when re-encrypt then encrypt them and skip the ones already current",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT id, merged_guest FROM guest_merges ORDER BY id").WillReturnRows(
+					sqlmock.NewRows([]string{"id", "merged_guest"}).AddRow(1, current).AddRow(2, `{"ID":5}`))
+				mock.ExpectExec("UPDATE guest_merges SET merged_guest = \\? WHERE id = \\?").
+					WithArgs(encryptedArg(`{"ID":5}`), 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			result: 1,
+			err:    nil,
+		},
+		{
+			name: "Given error connection when re-encrypt then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT id, merged_guest FROM guest_merges").WillReturnError(sql.ErrConnDone)
+			},
+			result: 0,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewGuestDuplicateRepository(sqlxDB, testCipher)
+
+			result, err := repo.ReEncrypt()
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.err, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
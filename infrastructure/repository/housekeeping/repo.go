@@ -0,0 +1,13 @@
+package housekeeping
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=HousekeepingRepository --output=../mocks --outpkg=mocks
+type HousekeepingRepository interface {
+	Create(task *model.HousekeepingTaskEntity) error
+	GetByID(id int) (*model.HousekeepingTaskEntity, error)
+	GetAll(request *model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskEntity, error)
+	Claim(task *model.HousekeepingTaskEntity) error
+	Complete(task *model.HousekeepingTaskEntity) error
+	Inspect(task *model.HousekeepingTaskEntity) error
+}
@@ -0,0 +1,127 @@
+package housekeeping
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type housekeepingRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewHousekeepingRepository(db *sqlx.DB) HousekeepingRepository {
+	return &housekeepingRepoImpl{db: db}
+}
+
+func (h *housekeepingRepoImpl) Create(task *model.HousekeepingTaskEntity) error {
+	query := "INSERT INTO housekeeping_tasks (room_name, register_number, status, notes) VALUES (:room_name, :register_number, :status, :notes)"
+	_, err := h.db.NamedExec(query, task)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (h *housekeepingRepoImpl) GetByID(id int) (*model.HousekeepingTaskEntity, error) {
+	task := &model.HousekeepingTaskEntity{}
+	query := "SELECT id, room_name, register_number, status, assigned_to, claimed_at, completed_at, inspected_by, inspected_at, notes, created_at, updated_at FROM housekeeping_tasks WHERE id = ?"
+
+	err := h.db.Get(task, query, id)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (h *housekeepingRepoImpl) GetAll(request *model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskEntity, error) {
+	var params []interface{}
+	tasks := make([]*model.HousekeepingTaskEntity, 0)
+
+	query := "SELECT id, room_name, register_number, status, assigned_to, claimed_at, completed_at, inspected_by, inspected_at, notes, created_at, updated_at FROM housekeeping_tasks WHERE TRUE"
+	if request.RoomName != nil {
+		query += " AND room_name = ?"
+		params = append(params, *request.RoomName)
+	}
+
+	if request.Status != nil {
+		query += " AND status = ?"
+		params = append(params, *request.Status)
+	}
+
+	query += " ORDER BY created_at ASC, id ASC"
+
+	if err := h.db.Select(&tasks, query, params...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Claim assigns a pending task to a housekeeper. The status guard in the WHERE clause
+// makes sure two housekeepers claiming the same task at once cannot both win.
+func (h *housekeepingRepoImpl) Claim(task *model.HousekeepingTaskEntity) error {
+	query := "UPDATE housekeeping_tasks SET status = :status, assigned_to = :assigned_to, claimed_at = :claimed_at, updated_at = CURRENT_TIMESTAMP WHERE id = :id AND status = :pending"
+	return h.guardedUpdate(query, map[string]interface{}{
+		"id":          task.ID,
+		"status":      task.Status,
+		"assigned_to": task.AssignedTo,
+		"claimed_at":  task.ClaimedAt,
+		"pending":     constant.HousekeepingTaskStatusPending,
+	}, "housekeeping task already claimed")
+}
+
+// Complete finishes a task in progress, guarded on its status like Claim.
+func (h *housekeepingRepoImpl) Complete(task *model.HousekeepingTaskEntity) error {
+	query := "UPDATE housekeeping_tasks SET status = :status, completed_at = :completed_at, notes = COALESCE(:notes, notes), updated_at = CURRENT_TIMESTAMP WHERE id = :id AND status = :in_progress"
+	return h.guardedUpdate(query, map[string]interface{}{
+		"id":           task.ID,
+		"status":       task.Status,
+		"completed_at": task.CompletedAt,
+		"notes":        task.Notes,
+		"in_progress":  constant.HousekeepingTaskStatusInProgress,
+	}, "housekeeping task already completed")
+}
+
+// Inspect records the inspection of a completed task. Guarded on its status like Claim, so two
+// supervisors inspecting the same task at once can not both queue a new task after it.
+func (h *housekeepingRepoImpl) Inspect(task *model.HousekeepingTaskEntity) error {
+	query := "UPDATE housekeeping_tasks SET status = :status, inspected_by = :inspected_by, inspected_at = :inspected_at, notes = COALESCE(:notes, notes), updated_at = CURRENT_TIMESTAMP WHERE id = :id AND status = :completed"
+	return h.guardedUpdate(query, map[string]interface{}{
+		"id":           task.ID,
+		"status":       task.Status,
+		"inspected_by": task.InspectedBy,
+		"inspected_at": task.InspectedAt,
+		"notes":        task.Notes,
+		"completed":    constant.HousekeepingTaskStatusCompleted,
+	}, "housekeeping task already inspected")
+}
+
+// guardedUpdate runs an update whose WHERE clause expects the current status of the task
+// and returns a conflict with message when the task has moved on meanwhile.
+func (h *housekeepingRepoImpl) guardedUpdate(query string, arg map[string]interface{}, message string) error {
+	res, err := h.db.NamedExec(query, arg)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError(message)
+	}
+
+	return nil
+}
@@ -0,0 +1,342 @@
+package housekeeping
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	registerNumber := "register_number"
+
+	testCases := []struct {
+		name     string
+		task     *model.HousekeepingTaskEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when create housekeeping task then return success response",
+			task: &model.HousekeepingTaskEntity{
+				RoomName:       "101",
+				RegisterNumber: &registerNumber,
+				Status:         constant.HousekeepingTaskStatusPending,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO housekeeping_tasks").WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when create housekeeping task then return error",
+			task: &model.HousekeepingTaskEntity{
+				RoomName: "101",
+				Status:   constant.HousekeepingTaskStatusPending,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO housekeeping_tasks").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewHousekeepingRepository(sqlxDB)
+
+			err := repo.Create(tc.task)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	columns := []string{"id", "room_name", "register_number", "status", "assigned_to", "claimed_at", "completed_at", "inspected_by", "inspected_at", "notes", "created_at", "updated_at"}
+
+	testCases := []struct {
+		name     string
+		request  int
+		result   *model.HousekeepingTaskEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when get housekeeping task by id then return success response",
+			request: 1,
+			result: &model.HousekeepingTaskEntity{
+				ID:       1,
+				RoomName: "101",
+				Status:   constant.HousekeepingTaskStatusPending,
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM housekeeping_tasks WHERE id = ?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "101", nil, 1, nil, nil, nil, nil, nil, nil, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error connection when get housekeeping task by id then return error",
+			request: 1,
+			result:  nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM housekeeping_tasks WHERE id = ?").WithArgs(1).WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewHousekeepingRepository(sqlxDB)
+
+			result, err := repo.GetByID(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	columns := []string{"id", "room_name", "register_number", "status", "assigned_to", "claimed_at", "completed_at", "inspected_by", "inspected_at", "notes", "created_at", "updated_at"}
+	roomName := "101"
+	status := "1"
+
+	testCases := []struct {
+		name     string
+		request  *model.GetAllHousekeepingTaskRequest
+		result   []*model.HousekeepingTaskEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when get all housekeeping tasks then return success response",
+			request: &model.GetAllHousekeepingTaskRequest{RoomName: &roomName, Status: &status},
+			result: []*model.HousekeepingTaskEntity{
+				{ID: 1, RoomName: "101", Status: constant.HousekeepingTaskStatusPending},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM housekeeping_tasks WHERE TRUE AND room_name = \\? AND status = \\? ORDER BY").WithArgs(roomName, status).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "101", nil, 1, nil, nil, nil, nil, nil, nil, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error connection when get all housekeeping tasks then return error",
+			request: &model.GetAllHousekeepingTaskRequest{},
+			result:  nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewHousekeepingRepository(sqlxDB)
+
+			result, err := repo.GetAll(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Claim(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	userID := 1
+	claimedAt := time.Date(2000, time.April, 01, 10, 00, 00, 00, time.UTC)
+	task := &model.HousekeepingTaskEntity{
+		ID:         1,
+		Status:     constant.HousekeepingTaskStatusInProgress,
+		AssignedTo: &userID,
+		ClaimedAt:  &claimedAt,
+	}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending task when claim housekeeping task then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks SET (.+) WHERE id = \\? AND status = \\?").
+					WithArgs(constant.HousekeepingTaskStatusInProgress, &userID, &claimedAt, 1, constant.HousekeepingTaskStatusPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given task claimed concurrently when claim housekeeping task then return conflict error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("housekeeping task already claimed"),
+		},
+		{
+			name: "Given error connection when claim housekeeping task then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewHousekeepingRepository(sqlxDB)
+
+			err := repo.Claim(task)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	completedAt := time.Date(2000, time.April, 01, 11, 00, 00, 00, time.UTC)
+	task := &model.HousekeepingTaskEntity{ID: 1, Status: constant.HousekeepingTaskStatusCompleted, CompletedAt: &completedAt}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given task in progress when complete housekeeping task then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks SET (.+) WHERE id = \\? AND status = \\?").
+					WithArgs(constant.HousekeepingTaskStatusCompleted, &completedAt, nil, 1, constant.HousekeepingTaskStatusInProgress).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given task completed concurrently when complete housekeeping task then return conflict error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("housekeeping task already completed"),
+		},
+		{
+			name: "Given error connection when complete housekeeping task then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks SET status").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewHousekeepingRepository(sqlxDB)
+
+			err := repo.Complete(task)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Inspect(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	userID := 2
+	inspectedAt := time.Date(2000, time.April, 01, 12, 00, 00, 00, time.UTC)
+	task := &model.HousekeepingTaskEntity{ID: 1, Status: constant.HousekeepingTaskStatusInspected, InspectedBy: &userID, InspectedAt: &inspectedAt}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given completed task when inspect housekeeping task then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks SET (.+) WHERE id = \\? AND status = \\?").
+					WithArgs(constant.HousekeepingTaskStatusInspected, &userID, &inspectedAt, nil, 1, constant.HousekeepingTaskStatusCompleted).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given task inspected concurrently when inspect housekeeping task then return conflict error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("housekeeping task already inspected"),
+		},
+		{
+			name: "Given error connection when inspect housekeeping task then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE housekeeping_tasks SET status").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewHousekeepingRepository(sqlxDB)
+
+			err := repo.Inspect(task)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package invitation
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=InvitationRepository --output=../mocks --outpkg=mocks
+type InvitationRepository interface {
+	Create(invitation *model.InvitationEntity) error
+	GetByID(id int) (*model.InvitationEntity, error)
+	GetByTokenHash(tokenHash string) (*model.InvitationEntity, error)
+	GetPending() ([]*model.InvitationEntity, error)
+	Revoke(invitation *model.InvitationEntity) error
+	Accept(invitation *model.InvitationEntity, user *model.UserEntity) error
+}
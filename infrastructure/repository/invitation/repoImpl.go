@@ -0,0 +1,138 @@
+package invitation
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+const selectInvitationQuery = "SELECT id, token_hash, email, role, expires_at, created_by, accepted_at, accepted_by, revoked_at, revoked_by, created_at FROM invitations"
+
+type invitationRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewInvitationRepository(db *sqlx.DB) InvitationRepository {
+	return &invitationRepoImpl{db: db}
+}
+
+func (i *invitationRepoImpl) Create(invitation *model.InvitationEntity) error {
+	query := "INSERT INTO invitations (token_hash, email, role, expires_at, created_by) VALUES (:token_hash, :email, :role, :expires_at, :created_by)"
+	res, err := i.db.NamedExec(query, invitation)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	invitation.ID = int(id)
+	return nil
+}
+
+func (i *invitationRepoImpl) GetByID(id int) (*model.InvitationEntity, error) {
+	invitation := &model.InvitationEntity{}
+	if err := i.db.Get(invitation, selectInvitationQuery+" WHERE id = ?", id); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (i *invitationRepoImpl) GetByTokenHash(tokenHash string) (*model.InvitationEntity, error) {
+	invitation := &model.InvitationEntity{}
+	if err := i.db.Get(invitation, selectInvitationQuery+" WHERE token_hash = ?", tokenHash); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetPending lists the invitations neither accepted nor revoked, expired ones included.
+func (i *invitationRepoImpl) GetPending() ([]*model.InvitationEntity, error) {
+	invitations := make([]*model.InvitationEntity, 0)
+	query := selectInvitationQuery + " WHERE accepted_at IS NULL AND revoked_at IS NULL ORDER BY expires_at ASC, id ASC"
+
+	if err := i.db.Select(&invitations, query); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (i *invitationRepoImpl) Revoke(invitation *model.InvitationEntity) error {
+	query := "UPDATE invitations SET revoked_at = :revoked_at, revoked_by = :revoked_by WHERE id = :id AND accepted_at IS NULL AND revoked_at IS NULL"
+	res, err := i.db.NamedExec(query, invitation)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("invitation is no longer pending")
+	}
+
+	return nil
+}
+
+// Accept creates the user and uses up the invitation together, so one invitation
+// can not register two accounts.
+func (i *invitationRepoImpl) Accept(invitation *model.InvitationEntity, user *model.UserEntity) error {
+	tx, err := i.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.NamedExec("INSERT INTO users (name, username, password, email, role) VALUES (:name, :username, :password, :email, :role)", user)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	user.ID = int(id)
+	invitation.AcceptedBy = &user.ID
+
+	res, err = tx.NamedExec("UPDATE invitations SET accepted_at = :accepted_at, accepted_by = :accepted_by WHERE id = :id AND accepted_at IS NULL AND revoked_at IS NULL", invitation)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("invitation is no longer pending")
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package invitation
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var columns = []string{"id", "token_hash", "email", "role", "expires_at", "created_by", "accepted_at", "accepted_by", "revoked_at", "revoked_by", "created_at"}
+
+func Test_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	expiresAt := time.Date(2000, time.March, 04, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		id       int
+		err      error
+	}{
+		{
+			name: "Given valid request when create invitation then return success response",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO invitations").WithArgs("token_hash", "jane@mail.com", constant.RoleFrontDesk, expiresAt, 1).WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			id:  5,
+			err: nil,
+		},
+		{
+			name: "Given error connection when create invitation then return error",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO invitations").WillReturnError(sql.ErrConnDone)
+			},
+			id:  0,
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewInvitationRepository(sqlxDB)
+
+			invitation := &model.InvitationEntity{TokenHash: "token_hash", Email: "jane@mail.com", Role: constant.RoleFrontDesk, ExpiresAt: expiresAt, CreatedBy: 1}
+			err := repo.Create(invitation)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.id, invitation.ID)
+		})
+	}
+}
+
+func Test_GetPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	expiresAt := time.Date(2000, time.March, 04, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		result   []*model.InvitationEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending invitations when get pending then return them by expiry",
+			result: []*model.InvitationEntity{
+				{ID: 1, TokenHash: "token_hash", Email: "jane@mail.com", Role: constant.RoleFrontDesk, ExpiresAt: expiresAt, CreatedBy: 1},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM invitations WHERE accepted_at IS NULL AND revoked_at IS NULL ORDER BY expires_at ASC").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "token_hash", "jane@mail.com", constant.RoleFrontDesk, expiresAt, 1, nil, nil, nil, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error connection when get pending then return error",
+			result: nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM invitations").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewInvitationRepository(sqlxDB)
+
+			result, err := repo.GetPending()
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Revoke(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	revokedAt := time.Date(2000, time.March, 02, 10, 00, 00, 00, time.UTC)
+	revokedBy := 1
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending invitation when revoke then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE invitations SET revoked_at = \\?, revoked_by = \\? WHERE id = \\? AND accepted_at IS NULL AND revoked_at IS NULL").
+					WithArgs(revokedAt, revokedBy, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given invitation used in the meantime when revoke then return conflict error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE invitations SET revoked_at").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("invitation is no longer pending"),
+		},
+		{
+			name: "Given error connection when revoke then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE invitations SET revoked_at").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewInvitationRepository(sqlxDB)
+
+			err := repo.Revoke(&model.InvitationEntity{ID: 3, RevokedAt: &revokedAt, RevokedBy: &revokedBy})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Accept(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	acceptedAt := time.Date(2000, time.March, 02, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given pending invitation when accept then create the user and use up the invitation",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO users").WithArgs("name", "username", "password", "jane@mail.com", constant.RoleFrontDesk).WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec("UPDATE invitations SET accepted_at = \\?, accepted_by = \\? WHERE id = \\? AND accepted_at IS NULL AND revoked_at IS NULL").
+					WithArgs(acceptedAt, 7, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given invitation used in the meantime when accept then roll back the user",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec("UPDATE invitations SET accepted_at").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			err: model.NewConflictError("invitation is no longer pending"),
+		},
+		{
+			name: "Given error insert user when accept then return error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO users").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewInvitationRepository(sqlxDB)
+
+			err := repo.Accept(
+				&model.InvitationEntity{ID: 3, AcceptedAt: &acceptedAt},
+				&model.UserEntity{Name: "name", Username: "username", Password: "password", Email: "jane@mail.com", Role: constant.RoleFrontDesk},
+			)
+			assert.Equal(t, tc.err, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
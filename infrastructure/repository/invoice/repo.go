@@ -0,0 +1,8 @@
+package invoice
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=InvoiceRepository --output=../mocks --outpkg=mocks
+type InvoiceRepository interface {
+	Issue(invoice *model.InvoiceEntity) error
+}
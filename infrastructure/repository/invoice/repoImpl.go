@@ -0,0 +1,70 @@
+package invoice
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+const invoiceNumberFormat = "INV-%06d"
+
+type invoiceRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewInvoiceRepository(db *sqlx.DB) InvoiceRepository {
+	return &invoiceRepoImpl{db: db}
+}
+
+// Issue gives the booking the next invoice number. A booking already invoiced keeps
+// its number, the invoice is loaded back into the entity instead.
+func (i *invoiceRepoImpl) Issue(invoice *model.InvoiceEntity) error {
+	tx, err := i.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "SELECT id, sequence, invoice_number, register_number, issued_by, issued_at, created_at FROM invoices WHERE register_number = ?"
+	err = tx.Get(invoice, query, invoice.RegisterNumber)
+	if err == nil {
+		return nil
+	}
+
+	if err != sql.ErrNoRows {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	// the write lock of the immediate transaction keeps the numbers free of gaps and duplicates
+	if err := tx.Get(&invoice.Sequence, "SELECT COALESCE(MAX(sequence), 0) + 1 FROM invoices"); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	invoice.InvoiceNumber = fmt.Sprintf(invoiceNumberFormat, invoice.Sequence)
+
+	query = "INSERT INTO invoices (sequence, invoice_number, register_number, issued_by, issued_at) VALUES (:sequence, :invoice_number, :register_number, :issued_by, :issued_at)"
+	res, err := tx.NamedExec(query, invoice)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	invoice.ID = int(id)
+	return nil
+}
@@ -0,0 +1,97 @@
+package invoice
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var columns = []string{"id", "sequence", "invoice_number", "register_number", "issued_by", "issued_at", "created_at"}
+
+func Test_Issue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	issuedAt := time.Date(2000, time.March, 02, 12, 00, 00, 00, time.UTC)
+	issuedBy := 1
+	previousIssuer := 2
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		result   *model.InvoiceEntity
+		err      error
+	}{
+		{
+			name: "Given booking not invoiced yet when issue invoice then give it the next number",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE register_number = \\?").WithArgs("register_number").WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT COALESCE\\(MAX\\(sequence\\), 0\\) \\+ 1 FROM invoices").WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(42))
+				mock.ExpectExec("INSERT INTO invoices").WithArgs(42, "INV-000042", "register_number", issuedBy, issuedAt).WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectCommit()
+			},
+			result: &model.InvoiceEntity{ID: 7, Sequence: 42, InvoiceNumber: "INV-000042", RegisterNumber: "register_number", IssuedBy: &issuedBy, IssuedAt: issuedAt},
+			err:    nil,
+		},
+		{
+			name: "Given booking already invoiced when issue invoice then keep its number",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE register_number = \\?").WithArgs("register_number").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 3, "INV-000003", "register_number", 2, issuedAt.AddDate(0, 0, -1), nil))
+				mock.ExpectRollback()
+			},
+			result: &model.InvoiceEntity{ID: 3, Sequence: 3, InvoiceNumber: "INV-000003", RegisterNumber: "register_number", IssuedBy: &previousIssuer, IssuedAt: issuedAt.AddDate(0, 0, -1)},
+			err:    nil,
+		},
+		{
+			name: "Given error insert invoice when issue invoice then return error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE register_number = \\?").WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO invoices").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			result: nil,
+			err:    sql.ErrConnDone,
+		},
+		{
+			name: "Given error connection when issue invoice then return error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM invoices WHERE register_number = \\?").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			result: nil,
+			err:    sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewInvoiceRepository(sqlxDB)
+
+			invoice := &model.InvoiceEntity{RegisterNumber: "register_number", IssuedBy: &issuedBy, IssuedAt: issuedAt}
+			err := repo.Issue(invoice)
+			assert.Equal(t, tc.err, err)
+			if tc.result != nil {
+				assert.Equal(t, tc.result, invoice)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package loginattempt
+
+import "time"
+
+// LoginAttemptRepository counts failed logins per key, e.g. a username or an IP,
+// and blocks a key from logging in for a while.
+//
+//go:generate mockery --name=LoginAttemptRepository --output=../mocks --outpkg=mocks
+type LoginAttemptRepository interface {
+	RecordFailure(key string, window time.Duration) (int, error)
+	Block(key string, duration time.Duration) error
+	BlockedFor(key string) (time.Duration, error)
+	Reset(key string) error
+}
@@ -0,0 +1,76 @@
+package loginattempt
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func failuresKey(key string) string {
+	return "login-failures-" + key
+}
+
+func blockedKey(key string) string {
+	return "login-blocked-" + key
+}
+
+type loginAttemptRepoImpl struct {
+	redcl *redis.Client
+}
+
+func NewLoginAttemptRepository(redcl *redis.Client) LoginAttemptRepository {
+	return &loginAttemptRepoImpl{redcl: redcl}
+}
+
+// RecordFailure adds a failure to the key and returns the failures so far. The count is
+// forgotten once window passes without another failure.
+func (l *loginAttemptRepoImpl) RecordFailure(key string, window time.Duration) (int, error) {
+	ctx := context.Background()
+	var incr *redis.IntCmd
+	if _, err := l.redcl.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, failuresKey(key))
+		pipe.Expire(ctx, failuresKey(key), window)
+		return nil
+	}); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	return int(incr.Val()), nil
+}
+
+func (l *loginAttemptRepoImpl) Block(key string, duration time.Duration) error {
+	if err := l.redcl.Set(context.Background(), blockedKey(key), 1, duration).Err(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// BlockedFor returns how long the key is still blocked, zero when it is not.
+func (l *loginAttemptRepoImpl) BlockedFor(key string) (time.Duration, error) {
+	ttl, err := l.redcl.PTTL(context.Background(), blockedKey(key)).Result()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	// a missing key has a negative ttl
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (l *loginAttemptRepoImpl) Reset(key string) error {
+	if err := l.redcl.Del(context.Background(), failuresKey(key), blockedKey(key)).Err(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package loginattempt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRepo(t *testing.T) (*miniredis.Miniredis, LoginAttemptRepository) {
+	mockRedisServer, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	t.Cleanup(mockRedisServer.Close)
+
+	return mockRedisServer, NewLoginAttemptRepository(redis.NewClient(&redis.Options{Addr: mockRedisServer.Addr()}))
+}
+
+func Test_RecordFailure(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+
+	for i := 1; i <= 3; i++ {
+		count, err := repo.RecordFailure("user-janedoe", time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+	assert.Equal(t, time.Hour, mockRedisServer.TTL("login-failures-user-janedoe"))
+
+	// the window starts over once it passes without a failure
+	mockRedisServer.FastForward(time.Hour)
+	count, err := repo.RecordFailure("user-janedoe", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func Test_Block(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+
+	blockedFor, err := repo.BlockedFor("ip-10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), blockedFor)
+
+	assert.NoError(t, repo.Block("ip-10.0.0.1", 30*time.Second))
+	blockedFor, err = repo.BlockedFor("ip-10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, blockedFor)
+
+	mockRedisServer.FastForward(30 * time.Second)
+	blockedFor, err = repo.BlockedFor("ip-10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), blockedFor)
+}
+
+func Test_Reset(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	_, err := repo.RecordFailure("user-janedoe", time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.Block("user-janedoe", time.Hour))
+
+	assert.NoError(t, repo.Reset("user-janedoe"))
+	assert.False(t, mockRedisServer.Exists("login-failures-user-janedoe"))
+	assert.False(t, mockRedisServer.Exists("login-blocked-user-janedoe"))
+}
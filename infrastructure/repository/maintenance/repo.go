@@ -0,0 +1,17 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=MaintenanceRepository --output=../mocks --outpkg=mocks
+type MaintenanceRepository interface {
+	Create(order *model.MaintenanceOrderEntity) error
+	GetByID(id int) (*model.MaintenanceOrderEntity, error)
+	GetAll(request *model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderEntity, error)
+	Update(order *model.MaintenanceOrderEntity) error
+	Close(order *model.MaintenanceOrderEntity) error
+	CountActive(roomName string, date time.Time) (int, error)
+}
@@ -0,0 +1,123 @@
+package maintenance
+
+import (
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type maintenanceRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewMaintenanceRepository(db *sqlx.DB) MaintenanceRepository {
+	return &maintenanceRepoImpl{db: db}
+}
+
+func (m *maintenanceRepoImpl) Create(order *model.MaintenanceOrderEntity) error {
+	query := "INSERT INTO maintenance_orders (room_name, issue, priority, status, reported_by, assigned_to, start_date, expected_completion) VALUES (:room_name, :issue, :priority, :status, :reported_by, :assigned_to, :start_date, :expected_completion)"
+	res, err := m.db.NamedExec(query, order)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	order.ID = int(id)
+	return nil
+}
+
+func (m *maintenanceRepoImpl) GetByID(id int) (*model.MaintenanceOrderEntity, error) {
+	order := &model.MaintenanceOrderEntity{}
+	query := "SELECT id, room_name, issue, priority, status, reported_by, assigned_to, start_date, expected_completion, resolution, closed_by, closed_at, created_at, updated_at FROM maintenance_orders WHERE id = ?"
+
+	err := m.db.Get(order, query, id)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (m *maintenanceRepoImpl) GetAll(request *model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderEntity, error) {
+	var params []interface{}
+	orders := make([]*model.MaintenanceOrderEntity, 0)
+
+	query := "SELECT id, room_name, issue, priority, status, reported_by, assigned_to, start_date, expected_completion, resolution, closed_by, closed_at, created_at, updated_at FROM maintenance_orders WHERE TRUE"
+	if request.RoomName != nil {
+		query += " AND room_name = ?"
+		params = append(params, *request.RoomName)
+	}
+
+	if request.Status != nil {
+		query += " AND status = ?"
+		params = append(params, *request.Status)
+	}
+
+	query += " ORDER BY start_date DESC, id DESC"
+
+	if err := m.db.Select(&orders, query, params...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (m *maintenanceRepoImpl) Update(order *model.MaintenanceOrderEntity) error {
+	query := "UPDATE maintenance_orders SET"
+	if order.Priority != 0 {
+		query += " priority = :priority,"
+	}
+
+	if order.AssignedTo != nil {
+		query += " assigned_to = :assigned_to,"
+	}
+
+	if !order.ExpectedCompletion.IsZero() {
+		query += " expected_completion = :expected_completion,"
+	}
+
+	query += " updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+
+	_, err := m.db.NamedExec(query, order)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (m *maintenanceRepoImpl) Close(order *model.MaintenanceOrderEntity) error {
+	query := "UPDATE maintenance_orders SET status = :status, resolution = :resolution, closed_by = :closed_by, closed_at = :closed_at, updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+	_, err := m.db.NamedExec(query, order)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// CountActive counts the open orders of a room whose work has started by the given date,
+// including overdue ones that are still open past their expected completion.
+func (m *maintenanceRepoImpl) CountActive(roomName string, date time.Time) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM maintenance_orders WHERE room_name = ? AND status = ? AND start_date <= ?"
+	if err := m.db.Get(&count, query, roomName, constant.MaintenanceStatusOpen, date); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,341 @@
+package maintenance
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var columns = []string{"id", "room_name", "issue", "priority", "status", "reported_by", "assigned_to", "start_date", "expected_completion", "resolution", "closed_by", "closed_at", "created_at", "updated_at"}
+
+func Test_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		order    *model.MaintenanceOrderEntity
+		mockCall func()
+		id       int
+		err      error
+	}{
+		{
+			name: "Given valid request when create maintenance order then return success response",
+			order: &model.MaintenanceOrderEntity{
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           constant.MaintenancePriorityHigh,
+				Status:             constant.MaintenanceStatusOpen,
+				ReportedBy:         1,
+				StartDate:          time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC),
+				ExpectedCompletion: time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC),
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO maintenance_orders").WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+			id:  7,
+			err: nil,
+		},
+		{
+			name: "Given error connection when create maintenance order then return error",
+			order: &model.MaintenanceOrderEntity{
+				RoomName: "101",
+				Issue:    "leaking shower",
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO maintenance_orders").WillReturnError(sql.ErrConnDone)
+			},
+			id:  0,
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewMaintenanceRepository(sqlxDB)
+
+			err := repo.Create(tc.order)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.id, tc.order.ID)
+		})
+	}
+}
+
+func Test_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	startDate := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	expectedCompletion := time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		request  int
+		result   *model.MaintenanceOrderEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when get maintenance order by id then return success response",
+			request: 1,
+			result: &model.MaintenanceOrderEntity{
+				ID:                 1,
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           constant.MaintenancePriorityHigh,
+				Status:             constant.MaintenanceStatusOpen,
+				ReportedBy:         1,
+				StartDate:          startDate,
+				ExpectedCompletion: expectedCompletion,
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM maintenance_orders WHERE id = ?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "101", "leaking shower", 3, 1, 1, nil, startDate, expectedCompletion, nil, nil, nil, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error connection when get maintenance order by id then return error",
+			request: 1,
+			result:  nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM maintenance_orders WHERE id = ?").WithArgs(1).WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewMaintenanceRepository(sqlxDB)
+
+			result, err := repo.GetByID(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	roomName := "101"
+	startDate := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	expectedCompletion := time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		request  *model.GetAllMaintenanceOrderRequest
+		result   []*model.MaintenanceOrderEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given room name when get all maintenance orders then return room history",
+			request: &model.GetAllMaintenanceOrderRequest{RoomName: &roomName},
+			result: []*model.MaintenanceOrderEntity{
+				{
+					ID:                 1,
+					RoomName:           "101",
+					Issue:              "leaking shower",
+					Priority:           constant.MaintenancePriorityHigh,
+					Status:             constant.MaintenanceStatusOpen,
+					ReportedBy:         1,
+					StartDate:          startDate,
+					ExpectedCompletion: expectedCompletion,
+				},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM maintenance_orders WHERE TRUE AND room_name = \\? ORDER BY start_date DESC").WithArgs(roomName).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "101", "leaking shower", 3, 1, 1, nil, startDate, expectedCompletion, nil, nil, nil, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error connection when get all maintenance orders then return error",
+			request: &model.GetAllMaintenanceOrderRequest{},
+			result:  nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewMaintenanceRepository(sqlxDB)
+
+			result, err := repo.GetAll(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	assignee := 2
+
+	testCases := []struct {
+		name     string
+		request  *model.MaintenanceOrderEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when update maintenance order then return success response",
+			request: &model.MaintenanceOrderEntity{ID: 1, AssignedTo: &assignee},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE maintenance_orders SET assigned_to = \\?, updated_at = CURRENT_TIMESTAMP WHERE id = \\?").
+					WithArgs(&assignee, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error connection when update maintenance order then return error",
+			request: &model.MaintenanceOrderEntity{ID: 1, Priority: constant.MaintenancePriorityUrgent},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE maintenance_orders").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewMaintenanceRepository(sqlxDB)
+
+			err := repo.Update(tc.request)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Close(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	resolution := "replaced the valve"
+	closedBy := 1
+	closedAt := time.Date(2000, time.March, 02, 10, 00, 00, 00, time.UTC)
+	order := &model.MaintenanceOrderEntity{ID: 1, Status: constant.MaintenanceStatusClosed, Resolution: &resolution, ClosedBy: &closedBy, ClosedAt: &closedAt}
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when close maintenance order then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE maintenance_orders SET status").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when close maintenance order then return error",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE maintenance_orders SET status").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewMaintenanceRepository(sqlxDB)
+
+			err := repo.Close(order)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_CountActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	date := time.Date(2000, time.March, 02, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		response int
+		err      error
+	}{
+		{
+			name: "Given valid request when count active maintenance orders then return success response",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT").WithArgs("101", constant.MaintenanceStatusOpen, date).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			response: 1,
+			err:      nil,
+		},
+		{
+			name: "Given error when count active maintenance orders then return error",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT").WillReturnError(sql.ErrConnDone)
+			},
+			response: 0,
+			err:      sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewMaintenanceRepository(sqlxDB)
+
+			resp, err := repo.CountActive("101", date)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+		})
+	}
+}
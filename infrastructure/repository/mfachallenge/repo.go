@@ -0,0 +1,11 @@
+package mfachallenge
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=MfaChallengeRepository --output=../mocks --outpkg=mocks
+type MfaChallengeRepository interface {
+	Create(challenge *model.MfaChallengeEntity) error
+	GetByTokenHash(tokenHash string) (*model.MfaChallengeEntity, error)
+	RecordFailure(tokenHash string) (int, error)
+	Delete(tokenHash string) error
+}
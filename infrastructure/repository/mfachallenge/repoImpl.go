@@ -0,0 +1,96 @@
+package mfachallenge
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// A challenge is stored under mfa-challenge-<hash> and the wrong codes sent for it are
+// counted under mfa-challenge-attempts-<hash>, both expire with the challenge.
+func challengeKey(tokenHash string) string {
+	return "mfa-challenge-" + tokenHash
+}
+
+func attemptsKey(tokenHash string) string {
+	return "mfa-challenge-attempts-" + tokenHash
+}
+
+type mfaChallengeRepoImpl struct {
+	redcl *redis.Client
+}
+
+func NewMfaChallengeRepository(redcl *redis.Client) MfaChallengeRepository {
+	return &mfaChallengeRepoImpl{redcl: redcl}
+}
+
+func (m *mfaChallengeRepoImpl) Create(challenge *model.MfaChallengeEntity) error {
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := m.redcl.Set(context.Background(), challengeKey(challenge.TokenHash), value, time.Until(challenge.ExpiresAt)).Err(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (m *mfaChallengeRepoImpl) GetByTokenHash(tokenHash string) (*model.MfaChallengeEntity, error) {
+	value, err := m.redcl.Get(context.Background(), challengeKey(tokenHash)).Bytes()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	challenge := &model.MfaChallengeEntity{}
+	if err := json.Unmarshal(value, challenge); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// RecordFailure adds a wrong code to the challenge and returns the wrong codes so far.
+func (m *mfaChallengeRepoImpl) RecordFailure(tokenHash string) (int, error) {
+	ctx := context.Background()
+	ttl, err := m.redcl.PTTL(ctx, challengeKey(tokenHash)).Result()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	// the challenge is gone, count the failure for as long as it could have lived
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+
+	var incr *redis.IntCmd
+	if _, err := m.redcl.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, attemptsKey(tokenHash))
+		pipe.PExpire(ctx, attemptsKey(tokenHash), ttl)
+		return nil
+	}); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	return int(incr.Val()), nil
+}
+
+func (m *mfaChallengeRepoImpl) Delete(tokenHash string) error {
+	if err := m.redcl.Del(context.Background(), challengeKey(tokenHash), attemptsKey(tokenHash)).Err(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package mfachallenge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func newRepo(t *testing.T) (*miniredis.Miniredis, MfaChallengeRepository) {
+	mockRedisServer, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	t.Cleanup(mockRedisServer.Close)
+
+	return mockRedisServer, NewMfaChallengeRepository(redis.NewClient(&redis.Options{Addr: mockRedisServer.Addr()}))
+}
+
+func Test_CreateAndGet(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	challenge := &model.MfaChallengeEntity{
+		TokenHash: "hash",
+		UserID:    1,
+		Device:    "curl/8.0",
+		Enroll:    true,
+		ExpiresAt: time.Now().Add(5 * time.Minute).Truncate(time.Second),
+	}
+
+	assert.NoError(t, repo.Create(challenge))
+	assert.True(t, mockRedisServer.TTL("mfa-challenge-hash") > 4*time.Minute)
+
+	result, err := repo.GetByTokenHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, challenge.UserID, result.UserID)
+	assert.Equal(t, challenge.Enroll, result.Enroll)
+	assert.True(t, challenge.ExpiresAt.Equal(result.ExpiresAt))
+
+	_, err = repo.GetByTokenHash("unknown")
+	assert.Equal(t, redis.Nil, err)
+
+	mockRedisServer.FastForward(5 * time.Minute)
+	_, err = repo.GetByTokenHash("hash")
+	assert.Equal(t, redis.Nil, err)
+}
+
+func Test_RecordFailure(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	assert.NoError(t, repo.Create(&model.MfaChallengeEntity{TokenHash: "hash", UserID: 1, ExpiresAt: time.Now().Add(5 * time.Minute)}))
+
+	for i := 1; i <= 3; i++ {
+		count, err := repo.RecordFailure("hash")
+		assert.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+	assert.True(t, mockRedisServer.TTL("mfa-challenge-attempts-hash") > 4*time.Minute)
+}
+
+func Test_Delete(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	assert.NoError(t, repo.Create(&model.MfaChallengeEntity{TokenHash: "hash", UserID: 1, ExpiresAt: time.Now().Add(5 * time.Minute)}))
+	_, err := repo.RecordFailure("hash")
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.Delete("hash"))
+	assert.False(t, mockRedisServer.Exists("mfa-challenge-hash"))
+	assert.False(t, mockRedisServer.Exists("mfa-challenge-attempts-hash"))
+}
@@ -0,0 +1,13 @@
+package mfapolicy
+
+import (
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=MfaPolicyRepository --output=../mocks --outpkg=mocks
+type MfaPolicyRepository interface {
+	GetAll() ([]*model.MfaPolicyEntity, error)
+	GetByRole(role constant.Role) (*model.MfaPolicyEntity, error)
+	Upsert(policy *model.MfaPolicyEntity) error
+}
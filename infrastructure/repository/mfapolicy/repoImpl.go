@@ -0,0 +1,48 @@
+package mfapolicy
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type mfaPolicyRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewMfaPolicyRepository(db *sqlx.DB) MfaPolicyRepository {
+	return &mfaPolicyRepoImpl{db: db}
+}
+
+func (m *mfaPolicyRepoImpl) GetAll() ([]*model.MfaPolicyEntity, error) {
+	policies := make([]*model.MfaPolicyEntity, 0)
+	if err := m.db.Select(&policies, "SELECT role, required, updated_by, updated_at FROM mfa_policies ORDER BY role"); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (m *mfaPolicyRepoImpl) GetByRole(role constant.Role) (*model.MfaPolicyEntity, error) {
+	policy := &model.MfaPolicyEntity{}
+	if err := m.db.Get(policy, "SELECT role, required, updated_by, updated_at FROM mfa_policies WHERE role = ?", role); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (m *mfaPolicyRepoImpl) Upsert(policy *model.MfaPolicyEntity) error {
+	query := `INSERT INTO mfa_policies (role, required, updated_by) VALUES (:role, :required, :updated_by)
+		ON CONFLICT (role) DO UPDATE SET required = excluded.required, updated_by = excluded.updated_by, updated_at = CURRENT_TIMESTAMP`
+	if _, err := m.db.NamedExec(query, policy); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
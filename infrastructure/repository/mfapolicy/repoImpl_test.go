@@ -0,0 +1,123 @@
+package mfapolicy
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	currentTime := time.Now()
+	updatedBy := 1
+
+	rows := sqlmock.NewRows([]string{"role", "required", "updated_by", "updated_at"}).
+		AddRow(constant.RoleAdmin, true, 1, currentTime).
+		AddRow(constant.RoleAccountant, false, 1, currentTime)
+	mock.ExpectQuery("SELECT role, required, updated_by, updated_at FROM mfa_policies ORDER BY role").WillReturnRows(rows)
+
+	result, err := NewMfaPolicyRepository(sqlxDB).GetAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.MfaPolicyEntity{
+		{Role: constant.RoleAdmin, Required: true, UpdatedBy: &updatedBy, UpdatedAt: &currentTime},
+		{Role: constant.RoleAccountant, Required: false, UpdatedBy: &updatedBy, UpdatedAt: &currentTime},
+	}, result)
+}
+
+func Test_GetByRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		result   *model.MfaPolicyEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given policy of the role when get by role then return it",
+			result: &model.MfaPolicyEntity{Role: constant.RoleAccountant, Required: true},
+			mockCall: func() {
+				rows := sqlmock.NewRows([]string{"role", "required"}).AddRow(constant.RoleAccountant, true)
+				mock.ExpectQuery("SELECT (.+) FROM mfa_policies WHERE role = \\?").WithArgs(constant.RoleAccountant).WillReturnRows(rows)
+			},
+			err: nil,
+		},
+		{
+			name: "Given no policy of the role when get by role then return no rows",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM mfa_policies WHERE role = \\?").WithArgs(constant.RoleAccountant).WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, err := NewMfaPolicyRepository(sqlxDB).GetByRole(constant.RoleAccountant)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Upsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	updatedBy := 1
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when upsert then save the policy",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO mfa_policies (.+) ON CONFLICT \\(role\\) DO UPDATE").WithArgs(constant.RoleAdmin, true, &updatedBy).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when upsert then return error response",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO mfa_policies").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := NewMfaPolicyRepository(sqlxDB).Upsert(&model.MfaPolicyEntity{Role: constant.RoleAdmin, Required: true, UpdatedBy: &updatedBy})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
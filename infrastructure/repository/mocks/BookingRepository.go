@@ -30,6 +30,118 @@ func (_m *BookingRepository) Books(_a0 *model.BookingEntity) error {
 	return r0
 }
 
+// Cancel provides a mock function with given fields: _a0, refund
+func (_m *BookingRepository) Cancel(_a0 *model.BookingEntity, refund *model.PaymentEntity) error {
+	ret := _m.Called(_a0, refund)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.BookingEntity, *model.PaymentEntity) error); ok {
+		r0 = rf(_a0, refund)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckIn provides a mock function with given fields: _a0
+func (_m *BookingRepository) CheckIn(_a0 *model.BookingEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIn")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.BookingEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckOut provides a mock function with given fields: _a0
+func (_m *BookingRepository) CheckOut(_a0 *model.BookingEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckOut")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.BookingEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountOverlapping provides a mock function with given fields: request
+func (_m *BookingRepository) CountOverlapping(request *model.AvailabilityRequest) (int, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOverlapping")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.AvailabilityRequest) (int, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.AvailabilityRequest) int); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.AvailabilityRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByGuestID provides a mock function with given fields: guestID
+func (_m *BookingRepository) GetByGuestID(guestID int) ([]*model.BookingDetail, error) {
+	ret := _m.Called(guestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByGuestID")
+	}
+
+	var r0 []*model.BookingDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*model.BookingDetail, error)); ok {
+		return rf(guestID)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*model.BookingDetail); ok {
+		r0 = rf(guestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BookingDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(guestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByRegisterNumber provides a mock function with given fields: registerNumber
 func (_m *BookingRepository) GetByRegisterNumber(registerNumber string) (*model.BookingDetail, error) {
 	ret := _m.Called(registerNumber)
@@ -78,6 +190,24 @@ func (_m *BookingRepository) Reschedule(booing *model.BookingEntity) error {
 	return r0
 }
 
+// RevertCancel provides a mock function with given fields: _a0
+func (_m *BookingRepository) RevertCancel(_a0 *model.BookingEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevertCancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.BookingEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePayment provides a mock function with given fields: _a0
 func (_m *BookingRepository) UpdatePayment(_a0 *model.BookingEntity) error {
 	ret := _m.Called(_a0)
@@ -96,6 +226,24 @@ func (_m *BookingRepository) UpdatePayment(_a0 *model.BookingEntity) error {
 	return r0
 }
 
+// UpdateStatus provides a mock function with given fields: _a0
+func (_m *BookingRepository) UpdateStatus(_a0 *model.BookingEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.BookingEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewBookingRepository creates a new instance of BookingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBookingRepository(t interface {
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	sqlx "github.com/jmoiron/sqlx"
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// FolioRepository is an autogenerated mock type for the FolioRepository type
+type FolioRepository struct {
+	mock.Mock
+}
+
+// CreateCharge provides a mock function with given fields: charge
+func (_m *FolioRepository) CreateCharge(charge *model.FolioChargeEntity) error {
+	ret := _m.Called(charge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCharge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.FolioChargeEntity) error); ok {
+		r0 = rf(charge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCharges provides a mock function with given fields: tx, charges
+func (_m *FolioRepository) CreateCharges(tx *sqlx.Tx, charges []*model.FolioChargeEntity) error {
+	ret := _m.Called(tx, charges)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCharges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sqlx.Tx, []*model.FolioChargeEntity) error); ok {
+		r0 = rf(tx, charges)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByRegisterNumber provides a mock function with given fields: registerNumber
+func (_m *FolioRepository) GetByRegisterNumber(registerNumber string) ([]*model.FolioChargeEntity, error) {
+	ret := _m.Called(registerNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRegisterNumber")
+	}
+
+	var r0 []*model.FolioChargeEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.FolioChargeEntity, error)); ok {
+		return rf(registerNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.FolioChargeEntity); ok {
+		r0 = rf(registerNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FolioChargeEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(registerNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFolioRepository creates a new instance of FolioRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFolioRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FolioRepository {
+	mock := &FolioRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
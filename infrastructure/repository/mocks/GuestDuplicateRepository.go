@@ -0,0 +1,176 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// GuestDuplicateRepository is an autogenerated mock type for the GuestDuplicateRepository type
+type GuestDuplicateRepository struct {
+	mock.Mock
+}
+
+// Dismiss provides a mock function with given fields: duplicate
+func (_m *GuestDuplicateRepository) Dismiss(duplicate *model.GuestDuplicateEntity) error {
+	ret := _m.Called(duplicate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dismiss")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.GuestDuplicateEntity) error); ok {
+		r0 = rf(duplicate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *GuestDuplicateRepository) GetByID(id int) (*model.GuestDuplicateEntity, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.GuestDuplicateEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.GuestDuplicateEntity, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.GuestDuplicateEntity); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.GuestDuplicateEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPending provides a mock function with given fields: pagination
+func (_m *GuestDuplicateRepository) GetPending(pagination *model.PaginationRequest) ([]*model.GuestDuplicateEntity, int, error) {
+	ret := _m.Called(pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPending")
+	}
+
+	var r0 []*model.GuestDuplicateEntity
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) ([]*model.GuestDuplicateEntity, int, error)); ok {
+		return rf(pagination)
+	}
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) []*model.GuestDuplicateEntity); ok {
+		r0 = rf(pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GuestDuplicateEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.PaginationRequest) int); ok {
+		r1 = rf(pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*model.PaginationRequest) error); ok {
+		r2 = rf(pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Merge provides a mock function with given fields: duplicate, merge
+func (_m *GuestDuplicateRepository) Merge(duplicate *model.GuestDuplicateEntity, merge *model.GuestMergeEntity) error {
+	ret := _m.Called(duplicate, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.GuestDuplicateEntity, *model.GuestMergeEntity) error); ok {
+		r0 = rf(duplicate, merge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReEncrypt provides a mock function with given fields:
+func (_m *GuestDuplicateRepository) ReEncrypt() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReEncrypt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: duplicate
+func (_m *GuestDuplicateRepository) Upsert(duplicate *model.GuestDuplicateEntity) error {
+	ret := _m.Called(duplicate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.GuestDuplicateEntity) error); ok {
+		r0 = rf(duplicate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewGuestDuplicateRepository creates a new instance of GuestDuplicateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGuestDuplicateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GuestDuplicateRepository {
+	mock := &GuestDuplicateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 	model "github.com/zakiyalmaya/hotel-management/model"
 )
@@ -12,6 +14,24 @@ type GuestRepository struct {
 	mock.Mock
 }
 
+// Anonymize provides a mock function with given fields: _a0
+func (_m *GuestRepository) Anonymize(_a0 *model.GuestEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.GuestEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: _a0
 func (_m *GuestRepository) Create(_a0 *model.GuestEntity) error {
 	ret := _m.Called(_a0)
@@ -30,6 +50,36 @@ func (_m *GuestRepository) Create(_a0 *model.GuestEntity) error {
 	return r0
 }
 
+// GetAll provides a mock function with given fields:
+func (_m *GuestRepository) GetAll() ([]*model.GuestEntity, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.GuestEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.GuestEntity, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.GuestEntity); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GuestEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *GuestRepository) GetByID(id int) (*model.GuestEntity, error) {
 	ret := _m.Called(id)
@@ -60,6 +110,119 @@ func (_m *GuestRepository) GetByID(id int) (*model.GuestEntity, error) {
 	return r0, r1
 }
 
+// GetIDsWithoutStaySince provides a mock function with given fields: since
+func (_m *GuestRepository) GetIDsWithoutStaySince(since time.Time) ([]int, error) {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIDsWithoutStaySince")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]int, error)); ok {
+		return rf(since)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []int); ok {
+		r0 = rf(since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReEncrypt provides a mock function with given fields:
+func (_m *GuestRepository) ReEncrypt() (int, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReEncrypt")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: request, pagination
+func (_m *GuestRepository) Search(request *model.SearchGuestRequest, pagination *model.PaginationRequest) ([]*model.GuestEntity, int, error) {
+	ret := _m.Called(request, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*model.GuestEntity
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*model.SearchGuestRequest, *model.PaginationRequest) ([]*model.GuestEntity, int, error)); ok {
+		return rf(request, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SearchGuestRequest, *model.PaginationRequest) []*model.GuestEntity); ok {
+		r0 = rf(request, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.GuestEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SearchGuestRequest, *model.PaginationRequest) int); ok {
+		r1 = rf(request, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*model.SearchGuestRequest, *model.PaginationRequest) error); ok {
+		r2 = rf(request, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: _a0
+func (_m *GuestRepository) Update(_a0 *model.GuestEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.GuestEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewGuestRepository creates a new instance of GuestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGuestRepository(t interface {
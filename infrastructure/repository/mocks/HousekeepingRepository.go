@@ -0,0 +1,159 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// HousekeepingRepository is an autogenerated mock type for the HousekeepingRepository type
+type HousekeepingRepository struct {
+	mock.Mock
+}
+
+// Claim provides a mock function with given fields: task
+func (_m *HousekeepingRepository) Claim(task *model.HousekeepingTaskEntity) error {
+	ret := _m.Called(task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.HousekeepingTaskEntity) error); ok {
+		r0 = rf(task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Complete provides a mock function with given fields: task
+func (_m *HousekeepingRepository) Complete(task *model.HousekeepingTaskEntity) error {
+	ret := _m.Called(task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.HousekeepingTaskEntity) error); ok {
+		r0 = rf(task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: task
+func (_m *HousekeepingRepository) Create(task *model.HousekeepingTaskEntity) error {
+	ret := _m.Called(task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.HousekeepingTaskEntity) error); ok {
+		r0 = rf(task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: request
+func (_m *HousekeepingRepository) GetAll(request *model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskEntity, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.HousekeepingTaskEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.GetAllHousekeepingTaskRequest) ([]*model.HousekeepingTaskEntity, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.GetAllHousekeepingTaskRequest) []*model.HousekeepingTaskEntity); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HousekeepingTaskEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.GetAllHousekeepingTaskRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *HousekeepingRepository) GetByID(id int) (*model.HousekeepingTaskEntity, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.HousekeepingTaskEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.HousekeepingTaskEntity, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.HousekeepingTaskEntity); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HousekeepingTaskEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Inspect provides a mock function with given fields: task
+func (_m *HousekeepingRepository) Inspect(task *model.HousekeepingTaskEntity) error {
+	ret := _m.Called(task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inspect")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.HousekeepingTaskEntity) error); ok {
+		r0 = rf(task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHousekeepingRepository creates a new instance of HousekeepingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHousekeepingRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HousekeepingRepository {
+	mock := &HousekeepingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
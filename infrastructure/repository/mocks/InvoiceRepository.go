@@ -0,0 +1,45 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// InvoiceRepository is an autogenerated mock type for the InvoiceRepository type
+type InvoiceRepository struct {
+	mock.Mock
+}
+
+// Issue provides a mock function with given fields: _a0
+func (_m *InvoiceRepository) Issue(_a0 *model.InvoiceEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Issue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.InvoiceEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewInvoiceRepository creates a new instance of InvoiceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewInvoiceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *InvoiceRepository {
+	mock := &InvoiceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,171 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// MaintenanceRepository is an autogenerated mock type for the MaintenanceRepository type
+type MaintenanceRepository struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: order
+func (_m *MaintenanceRepository) Close(order *model.MaintenanceOrderEntity) error {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.MaintenanceOrderEntity) error); ok {
+		r0 = rf(order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountActive provides a mock function with given fields: roomName, date
+func (_m *MaintenanceRepository) CountActive(roomName string, date time.Time) (int, error) {
+	ret := _m.Called(roomName, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountActive")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) (int, error)); ok {
+		return rf(roomName, date)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time) int); ok {
+		r0 = rf(roomName, date)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(roomName, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: order
+func (_m *MaintenanceRepository) Create(order *model.MaintenanceOrderEntity) error {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.MaintenanceOrderEntity) error); ok {
+		r0 = rf(order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: request
+func (_m *MaintenanceRepository) GetAll(request *model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderEntity, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.MaintenanceOrderEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.GetAllMaintenanceOrderRequest) ([]*model.MaintenanceOrderEntity, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.GetAllMaintenanceOrderRequest) []*model.MaintenanceOrderEntity); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MaintenanceOrderEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.GetAllMaintenanceOrderRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *MaintenanceRepository) GetByID(id int) (*model.MaintenanceOrderEntity, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.MaintenanceOrderEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.MaintenanceOrderEntity, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.MaintenanceOrderEntity); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MaintenanceOrderEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: order
+func (_m *MaintenanceRepository) Update(order *model.MaintenanceOrderEntity) error {
+	ret := _m.Called(order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.MaintenanceOrderEntity) error); ok {
+		r0 = rf(order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMaintenanceRepository creates a new instance of MaintenanceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMaintenanceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MaintenanceRepository {
+	mock := &MaintenanceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// MfaChallengeRepository is an autogenerated mock type for the MfaChallengeRepository type
+type MfaChallengeRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: challenge
+func (_m *MfaChallengeRepository) Create(challenge *model.MfaChallengeEntity) error {
+	ret := _m.Called(challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.MfaChallengeEntity) error); ok {
+		r0 = rf(challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: tokenHash
+func (_m *MfaChallengeRepository) Delete(tokenHash string) error {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByTokenHash provides a mock function with given fields: tokenHash
+func (_m *MfaChallengeRepository) GetByTokenHash(tokenHash string) (*model.MfaChallengeEntity, error) {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTokenHash")
+	}
+
+	var r0 *model.MfaChallengeEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.MfaChallengeEntity, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.MfaChallengeEntity); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MfaChallengeEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFailure provides a mock function with given fields: tokenHash
+func (_m *MfaChallengeRepository) RecordFailure(tokenHash string) (int, error) {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMfaChallengeRepository creates a new instance of MfaChallengeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMfaChallengeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MfaChallengeRepository {
+	mock := &MfaChallengeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,108 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	constant "github.com/zakiyalmaya/hotel-management/constant"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/zakiyalmaya/hotel-management/model"
+)
+
+// MfaPolicyRepository is an autogenerated mock type for the MfaPolicyRepository type
+type MfaPolicyRepository struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *MfaPolicyRepository) GetAll() ([]*model.MfaPolicyEntity, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.MfaPolicyEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.MfaPolicyEntity, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.MfaPolicyEntity); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MfaPolicyEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByRole provides a mock function with given fields: role
+func (_m *MfaPolicyRepository) GetByRole(role constant.Role) (*model.MfaPolicyEntity, error) {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRole")
+	}
+
+	var r0 *model.MfaPolicyEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(constant.Role) (*model.MfaPolicyEntity, error)); ok {
+		return rf(role)
+	}
+	if rf, ok := ret.Get(0).(func(constant.Role) *model.MfaPolicyEntity); ok {
+		r0 = rf(role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.MfaPolicyEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(constant.Role) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: policy
+func (_m *MfaPolicyRepository) Upsert(policy *model.MfaPolicyEntity) error {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.MfaPolicyEntity) error); ok {
+		r0 = rf(policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMfaPolicyRepository creates a new instance of MfaPolicyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMfaPolicyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MfaPolicyRepository {
+	mock := &MfaPolicyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
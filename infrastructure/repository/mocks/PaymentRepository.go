@@ -0,0 +1,123 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+
+	sqlx "github.com/jmoiron/sqlx"
+)
+
+// PaymentRepository is an autogenerated mock type for the PaymentRepository type
+type PaymentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: _a0
+func (_m *PaymentRepository) Create(_a0 *model.PaymentEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.PaymentEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateTx provides a mock function with given fields: tx, _a1
+func (_m *PaymentRepository) CreateTx(tx *sqlx.Tx, _a1 *model.PaymentEntity) error {
+	ret := _m.Called(tx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sqlx.Tx, *model.PaymentEntity) error); ok {
+		r0 = rf(tx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByRegisterNumber provides a mock function with given fields: registerNumber
+func (_m *PaymentRepository) GetByRegisterNumber(registerNumber string) ([]*model.PaymentEntity, error) {
+	ret := _m.Called(registerNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRegisterNumber")
+	}
+
+	var r0 []*model.PaymentEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.PaymentEntity, error)); ok {
+		return rf(registerNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.PaymentEntity); ok {
+		r0 = rf(registerNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PaymentEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(registerNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumByGuestID provides a mock function with given fields: guestID
+func (_m *PaymentRepository) SumByGuestID(guestID int) (float64, error) {
+	ret := _m.Called(guestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByGuestID")
+	}
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (float64, error)); ok {
+		return rf(guestID)
+	}
+	if rf, ok := ret.Get(0).(func(int) float64); ok {
+		r0 = rf(guestID)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(guestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRepository {
+	mock := &PaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
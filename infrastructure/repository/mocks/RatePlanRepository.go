@@ -0,0 +1,251 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	model "github.com/zakiyalmaya/hotel-management/model"
+
+	time "time"
+)
+
+// RatePlanRepository is an autogenerated mock type for the RatePlanRepository type
+type RatePlanRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: plan
+func (_m *RatePlanRepository) Create(plan *model.RatePlanEntity) error {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.RatePlanEntity) error); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSeasonalRate provides a mock function with given fields: season
+func (_m *RatePlanRepository) CreateSeasonalRate(season *model.SeasonalRateEntity) error {
+	ret := _m.Called(season)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeasonalRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.SeasonalRateEntity) error); ok {
+		r0 = rf(season)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSeasonalRate provides a mock function with given fields: id
+func (_m *RatePlanRepository) DeleteSeasonalRate(id int) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSeasonalRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *RatePlanRepository) GetAll() ([]*model.RatePlanEntity, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.RatePlanEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*model.RatePlanEntity, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*model.RatePlanEntity); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RatePlanEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *RatePlanRepository) GetByID(id int) (*model.RatePlanEntity, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.RatePlanEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.RatePlanEntity, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.RatePlanEntity); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RatePlanEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByRoomType provides a mock function with given fields: roomType
+func (_m *RatePlanRepository) GetByRoomType(roomType string) (*model.RatePlanEntity, error) {
+	ret := _m.Called(roomType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRoomType")
+	}
+
+	var r0 *model.RatePlanEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.RatePlanEntity, error)); ok {
+		return rf(roomType)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.RatePlanEntity); ok {
+		r0 = rf(roomType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RatePlanEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(roomType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverlappingSeasonalRates provides a mock function with given fields: roomType, checkIn, checkOut
+func (_m *RatePlanRepository) GetOverlappingSeasonalRates(roomType string, checkIn time.Time, checkOut time.Time) ([]*model.SeasonalRateEntity, error) {
+	ret := _m.Called(roomType, checkIn, checkOut)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOverlappingSeasonalRates")
+	}
+
+	var r0 []*model.SeasonalRateEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) ([]*model.SeasonalRateEntity, error)); ok {
+		return rf(roomType, checkIn, checkOut)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) []*model.SeasonalRateEntity); ok {
+		r0 = rf(roomType, checkIn, checkOut)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SeasonalRateEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(roomType, checkIn, checkOut)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSeasonalRates provides a mock function with given fields: roomType
+func (_m *RatePlanRepository) GetSeasonalRates(roomType *string) ([]*model.SeasonalRateEntity, error) {
+	ret := _m.Called(roomType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSeasonalRates")
+	}
+
+	var r0 []*model.SeasonalRateEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*string) ([]*model.SeasonalRateEntity, error)); ok {
+		return rf(roomType)
+	}
+	if rf, ok := ret.Get(0).(func(*string) []*model.SeasonalRateEntity); ok {
+		r0 = rf(roomType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SeasonalRateEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*string) error); ok {
+		r1 = rf(roomType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: plan
+func (_m *RatePlanRepository) Update(plan *model.RatePlanEntity) error {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.RatePlanEntity) error); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewRatePlanRepository creates a new instance of RatePlanRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRatePlanRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RatePlanRepository {
+	mock := &RatePlanRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
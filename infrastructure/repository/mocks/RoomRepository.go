@@ -90,6 +90,36 @@ func (_m *RoomRepository) GetByName(name string) (*model.RoomEntity, error) {
 	return r0, r1
 }
 
+// Search provides a mock function with given fields: request
+func (_m *RoomRepository) Search(request *model.SearchRoomRequest) ([]*model.RoomEntity, error) {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*model.RoomEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SearchRoomRequest) ([]*model.RoomEntity, error)); ok {
+		return rf(request)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SearchRoomRequest) []*model.RoomEntity); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RoomEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SearchRoomRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: _a0
 func (_m *RoomRepository) Update(_a0 *model.RoomEntity) error {
 	ret := _m.Called(_a0)
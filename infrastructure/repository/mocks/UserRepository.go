@@ -4,6 +4,8 @@ package mocks
 
 import (
 	mock "github.com/stretchr/testify/mock"
+	constant "github.com/zakiyalmaya/hotel-management/constant"
+
 	model "github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -12,6 +14,34 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CountByRole provides a mock function with given fields: role
+func (_m *UserRepository) CountByRole(role constant.Role) (int, error) {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByRole")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(constant.Role) (int, error)); ok {
+		return rf(role)
+	}
+	if rf, ok := ret.Get(0).(func(constant.Role) int); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(constant.Role) error); ok {
+		r1 = rf(role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: _a0
 func (_m *UserRepository) Create(_a0 *model.UserEntity) error {
 	ret := _m.Called(_a0)
@@ -30,6 +60,121 @@ func (_m *UserRepository) Create(_a0 *model.UserEntity) error {
 	return r0
 }
 
+// Deactivate provides a mock function with given fields: _a0
+func (_m *UserRepository) Deactivate(_a0 *model.UserEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deactivate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.UserEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: pagination
+func (_m *UserRepository) GetAll(pagination *model.PaginationRequest) ([]*model.UserEntity, int, error) {
+	ret := _m.Called(pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.UserEntity
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) ([]*model.UserEntity, int, error)); ok {
+		return rf(pagination)
+	}
+	if rf, ok := ret.Get(0).(func(*model.PaginationRequest) []*model.UserEntity); ok {
+		r0 = rf(pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.PaginationRequest) int); ok {
+		r1 = rf(pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(*model.PaginationRequest) error); ok {
+		r2 = rf(pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByEmail provides a mock function with given fields: email
+func (_m *UserRepository) GetByEmail(email string) ([]*model.UserEntity, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 []*model.UserEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.UserEntity, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.UserEntity); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *UserRepository) GetByID(id int) (*model.UserEntity, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.UserEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (*model.UserEntity, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) *model.UserEntity); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUsername provides a mock function with given fields: username
 func (_m *UserRepository) GetByUsername(username string) (*model.UserEntity, error) {
 	ret := _m.Called(username)
@@ -60,6 +205,24 @@ func (_m *UserRepository) GetByUsername(username string) (*model.UserEntity, err
 	return r0, r1
 }
 
+// Update provides a mock function with given fields: _a0
+func (_m *UserRepository) Update(_a0 *model.UserEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.UserEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePassword provides a mock function with given fields: _a0
 func (_m *UserRepository) UpdatePassword(_a0 *model.UserEntity) error {
 	ret := _m.Called(_a0)
@@ -78,6 +241,98 @@ func (_m *UserRepository) UpdatePassword(_a0 *model.UserEntity) error {
 	return r0
 }
 
+// UpdateRole provides a mock function with given fields: _a0
+func (_m *UserRepository) UpdateRole(_a0 *model.UserEntity) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.UserEntity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTOTP provides a mock function with given fields: _a0, recoveryCodeHashes
+func (_m *UserRepository) UpdateTOTP(_a0 *model.UserEntity, recoveryCodeHashes []string) error {
+	ret := _m.Called(_a0, recoveryCodeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.UserEntity, []string) error); ok {
+		r0 = rf(_a0, recoveryCodeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: id, codeHash
+func (_m *UserRepository) UseRecoveryCode(id int, codeHash string) (bool, error) {
+	ret := _m.Called(id, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (bool, error)); ok {
+		return rf(id, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) bool); ok {
+		r0 = rf(id, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(id, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTOTPStep provides a mock function with given fields: id, step
+func (_m *UserRepository) UseTOTPStep(id int, step int64) (bool, error) {
+	ret := _m.Called(id, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPStep")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int64) (bool, error)); ok {
+		return rf(id, step)
+	}
+	if rf, ok := ret.Get(0).(func(int, int64) bool); ok {
+		r0 = rf(id, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int64) error); ok {
+		r1 = rf(id, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
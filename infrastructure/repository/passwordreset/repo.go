@@ -0,0 +1,10 @@
+package passwordreset
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=PasswordResetRepository --output=../mocks --outpkg=mocks
+type PasswordResetRepository interface {
+	Create(reset *model.PasswordResetEntity) error
+	GetByTokenHash(tokenHash string) (*model.PasswordResetEntity, error)
+	Use(reset *model.PasswordResetEntity, user *model.UserEntity) error
+}
@@ -0,0 +1,89 @@
+package passwordreset
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type passwordResetRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewPasswordResetRepository(db *sqlx.DB) PasswordResetRepository {
+	return &passwordResetRepoImpl{db: db}
+}
+
+func (p *passwordResetRepoImpl) Create(reset *model.PasswordResetEntity) error {
+	query := "INSERT INTO password_resets (user_id, token_hash, expires_at) VALUES (:user_id, :token_hash, :expires_at)"
+	res, err := p.db.NamedExec(query, reset)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	reset.ID = int(id)
+	return nil
+}
+
+func (p *passwordResetRepoImpl) GetByTokenHash(tokenHash string) (*model.PasswordResetEntity, error) {
+	reset := &model.PasswordResetEntity{}
+	query := "SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_resets WHERE token_hash = ?"
+	if err := p.db.Get(reset, query, tokenHash); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return reset, nil
+}
+
+// Use saves the new password of the user and uses up the reset together, so a token
+// can not set the password twice. Other resets the user asked for stop working as well.
+func (p *passwordResetRepoImpl) Use(reset *model.PasswordResetEntity, user *model.UserEntity) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE password_resets SET used_at = ? WHERE id = ? AND used_at IS NULL", reset.UsedAt, reset.ID)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewValidationError("invalid or expired reset token")
+	}
+
+	if _, err := tx.Exec("UPDATE password_resets SET used_at = ? WHERE user_id = ? AND used_at IS NULL", reset.UsedAt, reset.UserID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if _, err := tx.NamedExec("UPDATE users SET password = :password, updated_at = CURRENT_TIMESTAMP WHERE id = :id", user); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
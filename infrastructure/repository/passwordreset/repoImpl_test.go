@@ -0,0 +1,165 @@
+package passwordreset
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	expiresAt := time.Date(2000, time.March, 04, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		id       int
+		err      error
+	}{
+		{
+			name: "Given valid request when create password reset then return success response",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO password_resets").WithArgs(1, "token_hash", expiresAt).WillReturnResult(sqlmock.NewResult(5, 1))
+			},
+			id:  5,
+			err: nil,
+		},
+		{
+			name: "Given error connection when create password reset then return error",
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO password_resets").WillReturnError(sql.ErrConnDone)
+			},
+			id:  0,
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			reset := &model.PasswordResetEntity{UserID: 1, TokenHash: "token_hash", ExpiresAt: expiresAt}
+			err := NewPasswordResetRepository(sqlxDB).Create(reset)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.id, reset.ID)
+		})
+	}
+}
+
+func Test_GetByTokenHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	expiresAt := time.Date(2000, time.March, 04, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		result   *model.PasswordResetEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given existing token when get password reset then return it",
+			result: &model.PasswordResetEntity{ID: 5, UserID: 1, TokenHash: "token_hash", ExpiresAt: expiresAt},
+			mockCall: func() {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at"}).AddRow(5, 1, "token_hash", expiresAt, nil)
+				mock.ExpectQuery("SELECT (.+) FROM password_resets WHERE token_hash = \\?").WithArgs("token_hash").WillReturnRows(rows)
+			},
+			err: nil,
+		},
+		{
+			name: "Given unknown token when get password reset then return no rows",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM password_resets WHERE token_hash = \\?").WithArgs("token_hash").WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, err := NewPasswordResetRepository(sqlxDB).GetByTokenHash("token_hash")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Use(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	usedAt := time.Date(2000, time.March, 04, 10, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given unused reset when use then save the password and use up the resets of the user",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE password_resets SET used_at = \\? WHERE id = \\? AND used_at IS NULL").WithArgs(&usedAt, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE password_resets SET used_at = \\? WHERE user_id = \\? AND used_at IS NULL").WithArgs(&usedAt, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE users SET password = \\?").WithArgs("hashed", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given used reset when use then return validation error",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE password_resets SET used_at = \\? WHERE id = \\? AND used_at IS NULL").WithArgs(&usedAt, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			err: model.NewValidationError("invalid or expired reset token"),
+		},
+		{
+			name: "Given error update password when use then roll back",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE password_resets SET used_at = \\? WHERE id = \\? AND used_at IS NULL").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE password_resets SET used_at = \\? WHERE user_id = \\? AND used_at IS NULL").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE users SET password = \\?").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := NewPasswordResetRepository(sqlxDB).Use(&model.PasswordResetEntity{ID: 5, UserID: 1, UsedAt: &usedAt}, &model.UserEntity{ID: 1, Password: "hashed"})
+			assert.Equal(t, tc.err, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
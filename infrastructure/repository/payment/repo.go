@@ -0,0 +1,14 @@
+package payment
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=PaymentRepository --output=../mocks --outpkg=mocks
+type PaymentRepository interface {
+	Create(payment *model.PaymentEntity) error
+	CreateTx(tx *sqlx.Tx, payment *model.PaymentEntity) error
+	GetByRegisterNumber(registerNumber string) ([]*model.PaymentEntity, error)
+	SumByGuestID(guestID int) (float64, error)
+}
@@ -0,0 +1,81 @@
+package payment
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type paymentRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepository(db *sqlx.DB) PaymentRepository {
+	return &paymentRepoImpl{db: db}
+}
+
+// Create saves the payment and sets its ID. A payment whose gateway reference is already
+// recorded is skipped and keeps a zero ID.
+func (p *paymentRepoImpl) Create(payment *model.PaymentEntity) error {
+	return create(p.db, payment)
+}
+
+// CreateTx saves the payment like Create, in the transaction of the caller.
+func (p *paymentRepoImpl) CreateTx(tx *sqlx.Tx, payment *model.PaymentEntity) error {
+	return create(tx, payment)
+}
+
+func create(db sqlx.Ext, payment *model.PaymentEntity) error {
+	query := "INSERT INTO payments (register_number, amount, payment_method, reference, paid_at, received_by, gateway_reference) VALUES (:register_number, :amount, :payment_method, :reference, :paid_at, :received_by, :gateway_reference)" +
+		" ON CONFLICT (gateway_reference) WHERE gateway_reference IS NOT NULL DO NOTHING"
+	res, err := sqlx.NamedExec(db, query, payment)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	payment.ID = int(id)
+	return nil
+}
+
+func (p *paymentRepoImpl) GetByRegisterNumber(registerNumber string) ([]*model.PaymentEntity, error) {
+	payments := make([]*model.PaymentEntity, 0)
+	query := "SELECT id, register_number, amount, payment_method, reference, paid_at, received_by, gateway_reference, created_at FROM payments WHERE register_number = ? ORDER BY paid_at ASC, id ASC"
+
+	if err := p.db.Select(&payments, query, registerNumber); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// SumByGuestID adds up what a guest paid over all their bookings, less refunds.
+func (p *paymentRepoImpl) SumByGuestID(guestID int) (float64, error) {
+	var total float64
+	query := "SELECT COALESCE(SUM(p.amount), 0) FROM payments p JOIN bookings b ON b.register_number = p.register_number WHERE b.guest_id = ?"
+
+	if err := p.db.Get(&total, query, guestID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,257 @@
+package payment
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var columns = []string{"id", "register_number", "amount", "payment_method", "reference", "paid_at", "received_by", "created_at"}
+
+func Test_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	receivedBy := 1
+	gatewayReference := "sim_auth_1"
+
+	testCases := []struct {
+		name     string
+		payment  *model.PaymentEntity
+		mockCall func()
+		id       int
+		err      error
+	}{
+		{
+			name: "Given valid request when create payment then return success response",
+			payment: &model.PaymentEntity{
+				RegisterNumber: "register_number",
+				Amount:         300000,
+				PaymentMethod:  constant.PaymentMethodCash,
+				PaidAt:         time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC),
+				ReceivedBy:     &receivedBy,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO payments").WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			id:  3,
+			err: nil,
+		},
+		{
+			name: "Given gateway transaction already recorded when create payment then skip it",
+			payment: &model.PaymentEntity{
+				RegisterNumber:   "register_number",
+				Amount:           300000,
+				PaymentMethod:    constant.PaymentMethodCreditCard,
+				GatewayReference: &gatewayReference,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO payments (.+) ON CONFLICT \\(gateway_reference\\) WHERE gateway_reference IS NOT NULL DO NOTHING").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			id:  0,
+			err: nil,
+		},
+		{
+			name: "Given error connection when create payment then return error",
+			payment: &model.PaymentEntity{
+				RegisterNumber: "register_number",
+				Amount:         -100000,
+				PaymentMethod:  constant.PaymentMethodCash,
+			},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO payments").WillReturnError(sql.ErrConnDone)
+			},
+			id:  0,
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewPaymentRepository(sqlxDB)
+
+			err := repo.Create(tc.payment)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.id, tc.payment.ID)
+		})
+	}
+}
+
+func Test_CreateTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		payment  *model.PaymentEntity
+		mockCall func()
+		id       int
+		err      error
+	}{
+		{
+			name:    "Given valid request when create payment in a transaction then return success response",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -300000, PaymentMethod: constant.PaymentMethodCash},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO payments").WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectCommit()
+			},
+			id:  4,
+			err: nil,
+		},
+		{
+			name:    "Given error connection when create payment in a transaction then return error",
+			payment: &model.PaymentEntity{RegisterNumber: "register_number", Amount: -300000, PaymentMethod: constant.PaymentMethodCash},
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO payments").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			id:  0,
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			tx, err := sqlxDB.Beginx()
+			assert.NoError(t, err)
+
+			repo := NewPaymentRepository(sqlxDB)
+
+			err = repo.CreateTx(tx, tc.payment)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.id, tc.payment.ID)
+
+			if err != nil {
+				tx.Rollback()
+			} else {
+				assert.NoError(t, tx.Commit())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	paidAt := time.Date(2000, time.March, 01, 10, 00, 00, 00, time.UTC)
+	reference := "receipt-001"
+	receivedBy := 1
+
+	testCases := []struct {
+		name     string
+		result   []*model.PaymentEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when get payments then return payments in the order they were made",
+			result: []*model.PaymentEntity{
+				{ID: 1, RegisterNumber: "register_number", Amount: 300000, PaymentMethod: constant.PaymentMethodCash, Reference: &reference, PaidAt: paidAt, ReceivedBy: &receivedBy},
+				{ID: 2, RegisterNumber: "register_number", Amount: -100000, PaymentMethod: constant.PaymentMethodCash, PaidAt: paidAt.Add(time.Hour)},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM payments WHERE register_number = \\? ORDER BY paid_at ASC, id ASC").WithArgs("register_number").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "register_number", 300000, constant.PaymentMethodCash, "receipt-001", paidAt, 1, nil).
+						AddRow(2, "register_number", -100000, constant.PaymentMethodCash, nil, paidAt.Add(time.Hour), nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error connection when get payments then return error",
+			result: nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM payments").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewPaymentRepository(sqlxDB)
+
+			result, err := repo.GetByRegisterNumber("register_number")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_SumByGuestID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		result   float64
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given guest with payments when sum payments by guest then return the total less refunds",
+			result: 1250000,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COALESCE\\(SUM\\(p.amount\\), 0\\) FROM payments p JOIN bookings b ON b.register_number = p.register_number WHERE b.guest_id = \\?").WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1250000))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error connection when sum payments by guest then return error",
+			result: 0,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COALESCE").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewPaymentRepository(sqlxDB)
+
+			result, err := repo.SumByGuestID(1)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
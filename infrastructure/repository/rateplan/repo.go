@@ -0,0 +1,20 @@
+package rateplan
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+//go:generate mockery --name=RatePlanRepository --output=../mocks --outpkg=mocks
+type RatePlanRepository interface {
+	Create(plan *model.RatePlanEntity) error
+	GetByID(id int) (*model.RatePlanEntity, error)
+	GetByRoomType(roomType string) (*model.RatePlanEntity, error)
+	GetAll() ([]*model.RatePlanEntity, error)
+	Update(plan *model.RatePlanEntity) error
+	CreateSeasonalRate(season *model.SeasonalRateEntity) error
+	GetSeasonalRates(roomType *string) ([]*model.SeasonalRateEntity, error)
+	GetOverlappingSeasonalRates(roomType string, checkIn, checkOut time.Time) ([]*model.SeasonalRateEntity, error)
+	DeleteSeasonalRate(id int) error
+}
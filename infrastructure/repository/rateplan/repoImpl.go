@@ -0,0 +1,153 @@
+package rateplan
+
+import (
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type ratePlanRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewRatePlanRepository(db *sqlx.DB) RatePlanRepository {
+	return &ratePlanRepoImpl{db: db}
+}
+
+func (r *ratePlanRepoImpl) Create(plan *model.RatePlanEntity) error {
+	query := "INSERT INTO rate_plans (room_type, base_rate, weekend_surcharge, min_stay) VALUES (:room_type, :base_rate, :weekend_surcharge, :min_stay)"
+	_, err := r.db.NamedExec(query, plan)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *ratePlanRepoImpl) GetByID(id int) (*model.RatePlanEntity, error) {
+	plan := &model.RatePlanEntity{}
+	query := "SELECT id, room_type, base_rate, weekend_surcharge, min_stay, created_at, updated_at FROM rate_plans WHERE id = ?"
+
+	err := r.db.Get(plan, query, id)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (r *ratePlanRepoImpl) GetByRoomType(roomType string) (*model.RatePlanEntity, error) {
+	plan := &model.RatePlanEntity{}
+	query := "SELECT id, room_type, base_rate, weekend_surcharge, min_stay, created_at, updated_at FROM rate_plans WHERE room_type = ? COLLATE NOCASE"
+
+	err := r.db.Get(plan, query, roomType)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (r *ratePlanRepoImpl) GetAll() ([]*model.RatePlanEntity, error) {
+	plans := make([]*model.RatePlanEntity, 0)
+	query := "SELECT id, room_type, base_rate, weekend_surcharge, min_stay, created_at, updated_at FROM rate_plans ORDER BY room_type ASC"
+
+	if err := r.db.Select(&plans, query); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (r *ratePlanRepoImpl) Update(plan *model.RatePlanEntity) error {
+	query := "UPDATE rate_plans SET base_rate = :base_rate, weekend_surcharge = :weekend_surcharge, min_stay = :min_stay, updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+	res, err := r.db.NamedExec(query, plan)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewNotFoundError("rate plan not found")
+	}
+
+	return nil
+}
+
+func (r *ratePlanRepoImpl) CreateSeasonalRate(season *model.SeasonalRateEntity) error {
+	query := "INSERT INTO seasonal_rates (room_type, name, start_date, end_date, rate, min_stay) VALUES (:room_type, :name, :start_date, :end_date, :rate, :min_stay)"
+	_, err := r.db.NamedExec(query, season)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (r *ratePlanRepoImpl) GetSeasonalRates(roomType *string) ([]*model.SeasonalRateEntity, error) {
+	var params []interface{}
+	seasons := make([]*model.SeasonalRateEntity, 0)
+
+	query := "SELECT id, room_type, name, start_date, end_date, rate, min_stay, created_at, updated_at FROM seasonal_rates WHERE TRUE"
+	if roomType != nil {
+		query += " AND room_type = ? COLLATE NOCASE"
+		params = append(params, *roomType)
+	}
+
+	query += " ORDER BY start_date ASC, id ASC"
+
+	if err := r.db.Select(&seasons, query, params...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+// GetOverlappingSeasonalRates returns the seasons of a room type touching any night of [check_in, check_out).
+// Seasons include their end date, and the most recently created season comes first so it wins when seasons overlap.
+func (r *ratePlanRepoImpl) GetOverlappingSeasonalRates(roomType string, checkIn, checkOut time.Time) ([]*model.SeasonalRateEntity, error) {
+	seasons := make([]*model.SeasonalRateEntity, 0)
+	query := "SELECT id, room_type, name, start_date, end_date, rate, min_stay, created_at, updated_at FROM seasonal_rates WHERE room_type = ? COLLATE NOCASE AND start_date < ? AND end_date >= ? ORDER BY id DESC"
+
+	if err := r.db.Select(&seasons, query, roomType, checkOut, checkIn); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+func (r *ratePlanRepoImpl) DeleteSeasonalRate(id int) error {
+	query := "DELETE FROM seasonal_rates WHERE id = ?"
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewNotFoundError("seasonal rate not found")
+	}
+
+	return nil
+}
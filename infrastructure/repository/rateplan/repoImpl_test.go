@@ -0,0 +1,325 @@
+package rateplan
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	planColumns   = []string{"id", "room_type", "base_rate", "weekend_surcharge", "min_stay", "created_at", "updated_at"}
+	seasonColumns = []string{"id", "room_type", "name", "start_date", "end_date", "rate", "min_stay", "created_at", "updated_at"}
+)
+
+func Test_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		plan     *model.RatePlanEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when create rate plan then return success response",
+			plan: &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, WeekendSurcharge: 20, MinStay: 1},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO rate_plans").WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when create rate plan then return error",
+			plan: &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500},
+			mockCall: func() {
+				mock.ExpectExec("INSERT INTO rate_plans").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRatePlanRepository(sqlxDB)
+
+			err := repo.Create(tc.plan)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetByRoomType(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		request  string
+		result   *model.RatePlanEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given valid request when get rate plan by room type then return success response",
+			request: "deluxe",
+			result:  &model.RatePlanEntity{ID: 1, RoomType: "deluxe", BaseRate: 500, WeekendSurcharge: 20, MinStay: 2},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM rate_plans WHERE room_type = \\? COLLATE NOCASE").WithArgs("deluxe").
+					WillReturnRows(sqlmock.NewRows(planColumns).AddRow(1, "deluxe", 500, 20, 2, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given room type without rate plan when get rate plan by room type then return no rows error",
+			request: "suite",
+			result:  nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM rate_plans").WithArgs("suite").WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRatePlanRepository(sqlxDB)
+
+			result, err := repo.GetByRoomType(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		plan     *model.RatePlanEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when update rate plan then return success response",
+			plan: &model.RatePlanEntity{ID: 1, BaseRate: 600, WeekendSurcharge: 10, MinStay: 1},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE rate_plans SET").WithArgs(600.0, 10.0, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when update rate plan then return error",
+			plan: &model.RatePlanEntity{ID: 1, BaseRate: 600, MinStay: 1},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE rate_plans SET").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+		{
+			name: "Given unknown rate plan when update rate plan then return not found",
+			plan: &model.RatePlanEntity{ID: 99, BaseRate: 600, MinStay: 1},
+			mockCall: func() {
+				mock.ExpectExec("UPDATE rate_plans SET").WithArgs(600.0, 0.0, 1, 99).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewNotFoundError("rate plan not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRatePlanRepository(sqlxDB)
+
+			err := repo.Update(tc.plan)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetSeasonalRates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	roomType := "deluxe"
+	startDate := time.Date(2000, time.December, 20, 00, 00, 00, 00, time.UTC)
+	endDate := time.Date(2000, time.December, 31, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		request  *string
+		result   []*model.SeasonalRateEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name:    "Given room type filter when get seasonal rates then return success response",
+			request: &roomType,
+			result: []*model.SeasonalRateEntity{
+				{ID: 1, RoomType: "deluxe", Name: "holiday", StartDate: startDate, EndDate: endDate, Rate: 800, MinStay: 3},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM seasonal_rates WHERE TRUE AND room_type = \\? COLLATE NOCASE ORDER BY start_date ASC").WithArgs(roomType).
+					WillReturnRows(sqlmock.NewRows(seasonColumns).AddRow(1, "deluxe", "holiday", startDate, endDate, 800, 3, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:    "Given error connection when get seasonal rates then return error",
+			request: nil,
+			result:  nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM seasonal_rates WHERE TRUE ORDER BY start_date ASC").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRatePlanRepository(sqlxDB)
+
+			result, err := repo.GetSeasonalRates(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_GetOverlappingSeasonalRates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	checkIn := time.Date(2000, time.December, 30, 00, 00, 00, 00, time.UTC)
+	checkOut := time.Date(2001, time.January, 02, 00, 00, 00, 00, time.UTC)
+	startDate := time.Date(2000, time.December, 20, 00, 00, 00, 00, time.UTC)
+	endDate := time.Date(2000, time.December, 31, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		result   []*model.SeasonalRateEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given stay crossing a season when get overlapping seasonal rates then return the season",
+			result: []*model.SeasonalRateEntity{
+				{ID: 1, RoomType: "deluxe", Name: "holiday", StartDate: startDate, EndDate: endDate, Rate: 800, MinStay: 3},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM seasonal_rates WHERE room_type = \\? COLLATE NOCASE AND start_date < \\? AND end_date >= \\? ORDER BY id DESC").
+					WithArgs("deluxe", checkOut, checkIn).
+					WillReturnRows(sqlmock.NewRows(seasonColumns).AddRow(1, "deluxe", "holiday", startDate, endDate, 800, 3, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error connection when get overlapping seasonal rates then return error",
+			result: nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM seasonal_rates").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRatePlanRepository(sqlxDB)
+
+			result, err := repo.GetOverlappingSeasonalRates("deluxe", checkIn, checkOut)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_DeleteSeasonalRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when delete seasonal rate then return success response",
+			mockCall: func() {
+				mock.ExpectExec("DELETE FROM seasonal_rates WHERE id = \\?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when delete seasonal rate then return error",
+			mockCall: func() {
+				mock.ExpectExec("DELETE FROM seasonal_rates").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+		{
+			name: "Given unknown seasonal rate when delete seasonal rate then return not found",
+			mockCall: func() {
+				mock.ExpectExec("DELETE FROM seasonal_rates WHERE id = \\?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewNotFoundError("seasonal rate not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRatePlanRepository(sqlxDB)
+
+			err := repo.DeleteSeasonalRate(1)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -8,34 +8,80 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/booking"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/folio"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/guest"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/guestduplicate"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/housekeeping"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/invitation"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/invoice"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/loginattempt"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/maintenance"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mfachallenge"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/mfapolicy"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/passwordreset"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/payment"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/rateplan"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/room"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/session"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/user"
 )
 
 type Repositories struct {
-	db          *sqlx.DB
-	RedCl       *redis.Client
-	RoomRepo    room.RoomRepository
-	GuestRepo   guest.GuestRepository
-	BookingRepo booking.BookingRepository
-	UserRepo    user.UserRepository
+	db                 *sqlx.DB
+	RedCl              *redis.Client
+	RoomRepo           room.RoomRepository
+	GuestRepo          guest.GuestRepository
+	BookingRepo        booking.BookingRepository
+	UserRepo           user.UserRepository
+	HousekeepingRepo   housekeeping.HousekeepingRepository
+	MaintenanceRepo    maintenance.MaintenanceRepository
+	RatePlanRepo       rateplan.RatePlanRepository
+	FolioRepo          folio.FolioRepository
+	PaymentRepo        payment.PaymentRepository
+	InvoiceRepo        invoice.InvoiceRepository
+	InvitationRepo     invitation.InvitationRepository
+	SessionRepo        session.SessionRepository
+	LoginAttemptRepo   loginattempt.LoginAttemptRepository
+	MfaPolicyRepo      mfapolicy.MfaPolicyRepository
+	MfaChallengeRepo   mfachallenge.MfaChallengeRepository
+	PasswordResetRepo  passwordreset.PasswordResetRepository
+	GuestDuplicateRepo guestduplicate.GuestDuplicateRepository
 }
 
-func NewRespository(db *sqlx.DB, redcl *redis.Client) *Repositories {
+func NewRespository(db *sqlx.DB, redcl *redis.Client, fieldCipher *fieldcipher.Cipher) *Repositories {
+	folioRepo := folio.NewFolioRepository(db)
+	paymentRepo := payment.NewPaymentRepository(db)
+
 	return &Repositories{
-		db:          db,
-		RedCl:       redcl,
-		RoomRepo:    room.NewRoomRepository(db),
-		GuestRepo:   guest.NewGuestRepository(db),
-		BookingRepo: booking.NewBookingRepository(db),
-		UserRepo:    user.NewUserRepository(db),
+		db:                 db,
+		RedCl:              redcl,
+		RoomRepo:           room.NewRoomRepository(db),
+		GuestRepo:          guest.NewGuestRepository(db, fieldCipher),
+		BookingRepo:        booking.NewBookingRepository(db, fieldCipher, folioRepo, paymentRepo),
+		UserRepo:           user.NewUserRepository(db),
+		HousekeepingRepo:   housekeeping.NewHousekeepingRepository(db),
+		MaintenanceRepo:    maintenance.NewMaintenanceRepository(db),
+		RatePlanRepo:       rateplan.NewRatePlanRepository(db),
+		FolioRepo:          folioRepo,
+		PaymentRepo:        paymentRepo,
+		InvoiceRepo:        invoice.NewInvoiceRepository(db),
+		InvitationRepo:     invitation.NewInvitationRepository(db),
+		SessionRepo:        session.NewSessionRepository(redcl),
+		LoginAttemptRepo:   loginattempt.NewLoginAttemptRepository(redcl),
+		MfaPolicyRepo:      mfapolicy.NewMfaPolicyRepository(db),
+		MfaChallengeRepo:   mfachallenge.NewMfaChallengeRepository(redcl),
+		PasswordResetRepo:  passwordreset.NewPasswordResetRepository(db),
+		GuestDuplicateRepo: guestduplicate.NewGuestDuplicateRepository(db, fieldCipher),
 	}
 }
 
 func DBConnection(dbfile string) *sqlx.DB {
-	db, err := sqlx.Open("sqlite3", dbfile)
+	// immediate transactions take the write lock up front so concurrent
+	// availability checks cannot both pass before either booking is inserted
+	db, err := sqlx.Open("sqlite3", dbfile+"?_txlock=immediate")
 	if err != nil {
 		log.Panicln("error connecting to database: ", err.Error())
 		return nil
@@ -45,6 +91,15 @@ func DBConnection(dbfile string) *sqlx.DB {
 	createBookingTable(db)
 	createGuestTable(db)
 	createUserTable(db)
+	createHousekeepingTaskTable(db)
+	createMaintenanceOrderTable(db)
+	createRatePlanTable(db)
+	createFolioChargeTable(db)
+	createPaymentTable(db)
+	createInvoiceTable(db)
+	createInvitationTable(db)
+	createPasswordResetTable(db)
+	createGuestDuplicateTable(db)
 	return db
 }
 
@@ -83,6 +138,7 @@ func createRoomTable(db *sqlx.DB) {
 		type VARCHAR(255) NOT NULL,
 		price DECIMAL(10, 2) NOT NULL,
 		status INTEGER NOT NULL,
+		capacity INTEGER NOT NULL DEFAULT 2,
 		description TEXT NULL,
 		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
@@ -90,6 +146,8 @@ func createRoomTable(db *sqlx.DB) {
 	if err != nil {
 		log.Panicln("error creating rooms table: ", err.Error())
 	}
+
+	addColumnIfNotExists(db, "rooms", "capacity", "INTEGER NOT NULL DEFAULT 2")
 }
 
 func createBookingTable(db *sqlx.DB) {
@@ -103,13 +161,51 @@ func createBookingTable(db *sqlx.DB) {
 		paid_amount DECIMAL(10, 2) NOT NULL,
 		payment_method INTEGER NOT NULL,
 		payment_status INTEGER NOT NULL,
+		status INTEGER NOT NULL DEFAULT 1,
 		additional_request TEXT NULL,
+		refund_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+		canceled_at TIMESTAMP NULL,
+		checked_in_at TIMESTAMP NULL,
+		checked_in_by INTEGER NULL,
+		checked_out_at TIMESTAMP NULL,
+		checked_out_by INTEGER NULL,
+		gateway_transaction_id VARCHAR(255) NULL,
+		authorized_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
 		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 	)`)
 	if err != nil {
 		log.Panicln("error creating bookings table: ", err.Error())
 	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_bookings_guest_id ON bookings (guest_id)")
+	if err != nil {
+		log.Panicln("error creating bookings index: ", err.Error())
+	}
+
+	addColumnIfNotExists(db, "bookings", "refund_amount", "DECIMAL(10, 2) NOT NULL DEFAULT 0")
+	addColumnIfNotExists(db, "bookings", "canceled_at", "TIMESTAMP NULL")
+	addColumnIfNotExists(db, "bookings", "checked_in_at", "TIMESTAMP NULL")
+	addColumnIfNotExists(db, "bookings", "checked_in_by", "INTEGER NULL")
+	addColumnIfNotExists(db, "bookings", "checked_out_at", "TIMESTAMP NULL")
+	addColumnIfNotExists(db, "bookings", "checked_out_by", "INTEGER NULL")
+	addColumnIfNotExists(db, "bookings", "gateway_transaction_id", "VARCHAR(255) NULL")
+	addColumnIfNotExists(db, "bookings", "authorized_amount", "DECIMAL(10, 2) NOT NULL DEFAULT 0")
+
+	// derive the lifecycle status of bookings made before it was tracked
+	if addColumnIfNotExists(db, "bookings", "status", "INTEGER NOT NULL DEFAULT 1") {
+		_, err := db.Exec(`UPDATE bookings SET status = CASE
+			WHEN checked_out_at IS NOT NULL THEN ?
+			WHEN checked_in_at IS NOT NULL THEN ?
+			WHEN payment_status IN (?, ?) THEN ?
+			ELSE ? END`,
+			constant.BookingStatusCheckedOut, constant.BookingStatusCheckedIn,
+			constant.PaymentStatusCanceled, constant.PaymentStatusRefunded, constant.BookingStatusCancelled,
+			constant.BookingStatusConfirmed)
+		if err != nil {
+			log.Panicln("error migrating bookings status: ", err.Error())
+		}
+	}
 }
 
 func createGuestTable(db *sqlx.DB) {
@@ -117,16 +213,59 @@ func createGuestTable(db *sqlx.DB) {
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		first_name VARCHAR(255) NOT NULL,
 		last_name VARCHAR(255) NOT NULL,
-		identity_number VARCHAR(255) UNIQUE NOT NULL,
+		identity_number TEXT UNIQUE NOT NULL,
+		identity_number_index VARCHAR(64) NULL,
 		date_of_birth DATE NOT NULL,
-		phone_number VARCHAR(255) NOT NULL,
-		email VARCHAR(255) NOT NULL,
+		phone_number TEXT NOT NULL,
+		phone_number_index VARCHAR(64) NULL,
+		email TEXT NOT NULL,
+		email_index VARCHAR(64) NULL,
+		anonymized_at TIMESTAMP NULL,
+		anonymized_by INTEGER NULL,
 		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 	)`)
 	if err != nil {
 		log.Panicln("error creating guests table: ", err.Error())
 	}
+
+	addColumnIfNotExists(db, "guests", "anonymized_at", "TIMESTAMP NULL")
+	addColumnIfNotExists(db, "guests", "anonymized_by", "INTEGER NULL")
+	addColumnIfNotExists(db, "guests", "identity_number_index", "VARCHAR(64) NULL")
+	addColumnIfNotExists(db, "guests", "phone_number_index", "VARCHAR(64) NULL")
+	addColumnIfNotExists(db, "guests", "email_index", "VARCHAR(64) NULL")
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_guests_first_name ON guests (first_name COLLATE NOCASE)")
+	if err != nil {
+		log.Panicln("error creating guests index: ", err.Error())
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_guests_last_name ON guests (last_name COLLATE NOCASE)")
+	if err != nil {
+		log.Panicln("error creating guests index: ", err.Error())
+	}
+
+	// identity numbers, phone numbers and emails are encrypted, they are looked up by their blind index
+	for _, index := range []string{"idx_guests_phone_number", "idx_guests_email", "idx_guests_identity_number"} {
+		if _, err := db.Exec("DROP INDEX IF EXISTS " + index); err != nil {
+			log.Panicln("error dropping guests index: ", err.Error())
+		}
+	}
+
+	_, err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_guests_identity_number_index ON guests (identity_number_index)")
+	if err != nil {
+		log.Panicln("error creating guests index: ", err.Error())
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_guests_phone_number_index ON guests (phone_number_index)")
+	if err != nil {
+		log.Panicln("error creating guests index: ", err.Error())
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_guests_email_index ON guests (email_index)")
+	if err != nil {
+		log.Panicln("error creating guests index: ", err.Error())
+	}
 }
 
 func createUserTable(db *sqlx.DB) {
@@ -136,10 +275,326 @@ func createUserTable(db *sqlx.DB) {
 		username VARCHAR(255) UNIQUE NOT NULL,
 		password TEXT NOT NULL,
 		email VARCHAR(255) NOT NULL,
+		role INTEGER NOT NULL DEFAULT 5,
+		totp_secret VARCHAR(255) NULL,
+		totp_enabled BOOLEAN NOT NULL DEFAULT 0,
+		totp_last_step INTEGER NOT NULL DEFAULT 0,
+		deactivated_at TIMESTAMP NULL,
+		deactivated_by INTEGER NULL,
 		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 	)`)
 	if err != nil {
 		log.Panicln("error creating users table: ", err.Error())
 	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS user_recovery_codes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		code_hash VARCHAR(255) NOT NULL,
+		used_at TIMESTAMP NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating user_recovery_codes table: ", err.Error())
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS mfa_policies (
+		role INTEGER PRIMARY KEY,
+		required BOOLEAN NOT NULL DEFAULT 0,
+		updated_by INTEGER NULL,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating mfa_policies table: ", err.Error())
+	}
+
+	addColumnIfNotExists(db, "users", "totp_secret", "VARCHAR(255) NULL")
+	addColumnIfNotExists(db, "users", "totp_enabled", "BOOLEAN NOT NULL DEFAULT 0")
+	addColumnIfNotExists(db, "users", "totp_last_step", "INTEGER NOT NULL DEFAULT 0")
+	addColumnIfNotExists(db, "users", "deactivated_at", "TIMESTAMP NULL")
+	addColumnIfNotExists(db, "users", "deactivated_by", "INTEGER NULL")
+
+	// hoteliers registered before roles existed could do everything, they keep it as admins
+	if addColumnIfNotExists(db, "users", "role", "INTEGER NOT NULL DEFAULT 5") {
+		if _, err := db.Exec("UPDATE users SET role = ?", constant.RoleAdmin); err != nil {
+			log.Panicln("error migrating users role: ", err.Error())
+		}
+	}
+}
+
+func createHousekeepingTaskTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS housekeeping_tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		room_name VARCHAR(255) NOT NULL,
+		register_number VARCHAR(255) NULL,
+		status INTEGER NOT NULL,
+		assigned_to INTEGER NULL,
+		claimed_at TIMESTAMP NULL,
+		completed_at TIMESTAMP NULL,
+		inspected_by INTEGER NULL,
+		inspected_at TIMESTAMP NULL,
+		notes TEXT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating housekeeping_tasks table: ", err.Error())
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_housekeeping_tasks_room_status ON housekeeping_tasks (room_name, status)")
+	if err != nil {
+		log.Panicln("error creating housekeeping_tasks index: ", err.Error())
+	}
+}
+
+func createMaintenanceOrderTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS maintenance_orders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		room_name VARCHAR(255) NOT NULL,
+		issue TEXT NOT NULL,
+		priority INTEGER NOT NULL,
+		status INTEGER NOT NULL,
+		reported_by INTEGER NOT NULL,
+		assigned_to INTEGER NULL,
+		start_date DATE NOT NULL,
+		expected_completion DATE NOT NULL,
+		resolution TEXT NULL,
+		closed_by INTEGER NULL,
+		closed_at TIMESTAMP NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating maintenance_orders table: ", err.Error())
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_maintenance_orders_room_status ON maintenance_orders (room_name, status)")
+	if err != nil {
+		log.Panicln("error creating maintenance_orders index: ", err.Error())
+	}
+}
+
+func createRatePlanTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS rate_plans (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		room_type VARCHAR(255) UNIQUE NOT NULL COLLATE NOCASE,
+		base_rate DECIMAL(10, 2) NOT NULL,
+		weekend_surcharge DECIMAL(5, 2) NOT NULL DEFAULT 0,
+		min_stay INTEGER NOT NULL DEFAULT 1,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating rate_plans table: ", err.Error())
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS seasonal_rates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		room_type VARCHAR(255) NOT NULL COLLATE NOCASE,
+		name VARCHAR(255) NOT NULL,
+		start_date DATE NOT NULL,
+		end_date DATE NOT NULL,
+		rate DECIMAL(10, 2) NOT NULL,
+		min_stay INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating seasonal_rates table: ", err.Error())
+	}
+}
+
+func createFolioChargeTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS folio_charges (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		register_number VARCHAR(255) NOT NULL,
+		charge_type INTEGER NOT NULL,
+		description VARCHAR(255) NOT NULL,
+		charge_date DATE NOT NULL,
+		quantity INTEGER NOT NULL DEFAULT 1,
+		unit_price DECIMAL(10, 2) NOT NULL,
+		amount DECIMAL(10, 2) NOT NULL,
+		posted_by INTEGER NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating folio_charges table: ", err.Error())
+	}
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_folio_charges_register_number ON folio_charges (register_number)")
+	if err != nil {
+		log.Panicln("error creating folio_charges index: ", err.Error())
+	}
+}
+
+func createPaymentTable(db *sqlx.DB) {
+	var exists int
+	if err := db.Get(&exists, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'payments'"); err != nil {
+		log.Panicln("error reading payments table info: ", err.Error())
+	}
+
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS payments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		register_number VARCHAR(255) NOT NULL,
+		amount DECIMAL(10, 2) NOT NULL,
+		payment_method INTEGER NOT NULL,
+		reference VARCHAR(255) NULL,
+		paid_at TIMESTAMP NOT NULL,
+		received_by INTEGER NULL,
+		gateway_reference VARCHAR(255) NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating payments table: ", err.Error())
+	}
+
+	addColumnIfNotExists(db, "payments", "gateway_reference", "VARCHAR(255) NULL")
+
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_payments_register_number ON payments (register_number)")
+	if err != nil {
+		log.Panicln("error creating payments index: ", err.Error())
+	}
+
+	// a gateway transaction is reported both by the gateway reply and by its webhook, it must be recorded once
+	_, err = db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_gateway_reference ON payments (gateway_reference) WHERE gateway_reference IS NOT NULL")
+	if err != nil {
+		log.Panicln("error creating payments index: ", err.Error())
+	}
+
+	if exists > 0 {
+		return
+	}
+
+	// carry over bookings settled before payments were recorded one by one,
+	// a settled booking paid its amount in full and a refunded one got its refund back
+	_, err = db.Exec(`INSERT INTO payments (register_number, amount, payment_method, reference, paid_at)
+		SELECT register_number, paid_amount, payment_method, 'settled before payment transactions', created_at FROM bookings WHERE payment_status IN (?, ?)`,
+		constant.PaymentStatusCompleted, constant.PaymentStatusRefunded)
+	if err != nil {
+		log.Panicln("error migrating payments: ", err.Error())
+	}
+
+	_, err = db.Exec(`INSERT INTO payments (register_number, amount, payment_method, reference, paid_at)
+		SELECT register_number, -refund_amount, payment_method, 'refunded before payment transactions', COALESCE(canceled_at, updated_at) FROM bookings WHERE payment_status = ? AND refund_amount > 0`,
+		constant.PaymentStatusRefunded)
+	if err != nil {
+		log.Panicln("error migrating refunds: ", err.Error())
+	}
+}
+
+func createInvoiceTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS invoices (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		sequence INTEGER UNIQUE NOT NULL,
+		invoice_number VARCHAR(255) UNIQUE NOT NULL,
+		register_number VARCHAR(255) UNIQUE NOT NULL,
+		issued_by INTEGER NULL,
+		issued_at TIMESTAMP NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating invoices table: ", err.Error())
+	}
+}
+
+func createInvitationTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS invitations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token_hash VARCHAR(255) UNIQUE NOT NULL,
+		email VARCHAR(255) NOT NULL,
+		role INTEGER NOT NULL,
+		expires_at TIMESTAMP NOT NULL,
+		created_by INTEGER NOT NULL,
+		accepted_at TIMESTAMP NULL,
+		accepted_by INTEGER NULL,
+		revoked_at TIMESTAMP NULL,
+		revoked_by INTEGER NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating invitations table: ", err.Error())
+	}
+}
+
+func createPasswordResetTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS password_resets (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		token_hash VARCHAR(255) UNIQUE NOT NULL,
+		expires_at TIMESTAMP NOT NULL,
+		used_at TIMESTAMP NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating password_resets table: ", err.Error())
+	}
+}
+
+func createGuestDuplicateTable(db *sqlx.DB) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS guest_duplicates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guest_id INTEGER NOT NULL,
+		duplicate_guest_id INTEGER NOT NULL,
+		score DECIMAL(3, 2) NOT NULL,
+		reasons VARCHAR(255) NOT NULL,
+		status INTEGER NOT NULL,
+		reviewed_by INTEGER NULL,
+		reviewed_at TIMESTAMP NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (guest_id, duplicate_guest_id)
+	)`)
+	if err != nil {
+		log.Panicln("error creating guest_duplicates table: ", err.Error())
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS guest_merges (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		duplicate_id INTEGER NOT NULL,
+		surviving_guest_id INTEGER NOT NULL,
+		merged_guest_id INTEGER NOT NULL,
+		merged_guest TEXT NOT NULL,
+		bookings_moved INTEGER NOT NULL,
+		merged_by INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		log.Panicln("error creating guest_merges table: ", err.Error())
+	}
+
+	// merges recorded before chains were followed still name a guest that was merged
+	// away later, they are moved along the chain to the guest that survived
+	for {
+		res, err := db.Exec(`UPDATE guest_merges SET surviving_guest_id = (
+			SELECT m.surviving_guest_id FROM guest_merges m WHERE m.merged_guest_id = guest_merges.surviving_guest_id ORDER BY m.id DESC LIMIT 1
+		) WHERE surviving_guest_id IN (SELECT merged_guest_id FROM guest_merges)`)
+		if err != nil {
+			log.Panicln("error updating guest_merges table: ", err.Error())
+		}
+
+		if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+			break
+		}
+	}
+}
+
+// addColumnIfNotExists upgrades tables created by an older version of the schema,
+// since CREATE TABLE IF NOT EXISTS leaves existing tables untouched.
+// It reports whether the column had to be added.
+func addColumnIfNotExists(db *sqlx.DB, table, column, definition string) bool {
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column); err != nil {
+		log.Panicln("error reading "+table+" table info: ", err.Error())
+	}
+
+	if count > 0 {
+		return false
+	}
+
+	if _, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition); err != nil {
+		log.Panicln("error adding "+column+" column to "+table+" table: ", err.Error())
+	}
+
+	return true
 }
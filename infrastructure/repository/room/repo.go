@@ -8,4 +8,5 @@ type RoomRepository interface {
 	GetByName(name string) (*model.RoomEntity, error)
 	GetAll(request *model.GetAllRoomRequest) ([]*model.RoomEntity, error)
 	Update(room *model.RoomEntity) error
-}
\ No newline at end of file
+	Search(request *model.SearchRoomRequest) ([]*model.RoomEntity, error)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/booking"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -19,7 +21,7 @@ func NewRoomRepository(db *sqlx.DB) RoomRepository {
 }
 
 func (r *roomRepoImpl) Create(room *model.RoomEntity) error {
-	query := "INSERT INTO rooms (name, floor, type, price, status, description) VALUES (:name, :floor, :type, :price, :status, :description)"
+	query := "INSERT INTO rooms (name, floor, type, price, status, capacity, description) VALUES (:name, :floor, :type, :price, :status, :capacity, :description)"
 	_, err := r.db.NamedExec(query, room)
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
@@ -31,8 +33,8 @@ func (r *roomRepoImpl) Create(room *model.RoomEntity) error {
 
 func (r *roomRepoImpl) GetByName(name string) (*model.RoomEntity, error) {
 	room := &model.RoomEntity{}
-	query := "SELECT id, name, floor, type, price, status, description, created_at, updated_at FROM rooms WHERE name = ?"
-	
+	query := "SELECT id, name, floor, type, price, status, capacity, description, created_at, updated_at FROM rooms WHERE name = ?"
+
 	err := r.db.Get(room, query, name)
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
@@ -45,8 +47,8 @@ func (r *roomRepoImpl) GetByName(name string) (*model.RoomEntity, error) {
 func (r *roomRepoImpl) GetAll(request *model.GetAllRoomRequest) ([]*model.RoomEntity, error) {
 	var params []interface{}
 	rooms := make([]*model.RoomEntity, 0)
-	
-	query := "SELECT id, name, floor, type, price, status, description, created_at, updated_at FROM rooms WHERE TRUE"
+
+	query := "SELECT id, name, floor, type, price, status, capacity, description, created_at, updated_at FROM rooms WHERE TRUE"
 	if request.Floor != nil {
 		query += " AND floor = ?"
 		params = append(params, request.Floor)
@@ -72,6 +74,7 @@ func (r *roomRepoImpl) GetAll(request *model.GetAllRoomRequest) ([]*model.RoomEn
 			&room.Type,
 			&room.Price,
 			&room.Status,
+			&room.Capacity,
 			&room.Description,
 			&room.CreatedAt,
 			&room.UpdatedAt,
@@ -99,6 +102,10 @@ func (r *roomRepoImpl) Update(room *model.RoomEntity) error {
 		query += " status = :status,"
 	}
 
+	if room.Capacity != 0 {
+		query += " capacity = :capacity,"
+	}
+
 	if room.Description != nil {
 		query += " description = :description,"
 	}
@@ -114,3 +121,38 @@ func (r *roomRepoImpl) Update(room *model.RoomEntity) error {
 
 	return nil
 }
+
+func (r *roomRepoImpl) Search(request *model.SearchRoomRequest) ([]*model.RoomEntity, error) {
+	rooms := make([]*model.RoomEntity, 0)
+	params := []interface{}{constant.RoomStatusMaintenance, constant.RoomStatusUnavailable, request.Guests}
+
+	query := "SELECT id, name, floor, type, price, status, capacity, description, created_at, updated_at FROM rooms r WHERE status NOT IN (?, ?) AND capacity >= ?"
+	if request.Type != nil {
+		query += " AND type = ? COLLATE NOCASE"
+		params = append(params, *request.Type)
+	}
+
+	if request.Floor != nil {
+		query += " AND floor = ?"
+		params = append(params, *request.Floor)
+	}
+
+	// exclude rooms holding an active booking or an open maintenance order during the stay window,
+	// with the conditions booking itself checks so a room found here can be booked
+	bookings, maintenance := booking.OverlapConditions("r.name")
+	query += " AND NOT EXISTS (SELECT 1 FROM bookings b WHERE " + bookings + ")"
+	params = append(params, booking.OverlapBookingArgs(request.CheckIn, request.CheckOut, "")...)
+
+	query += " AND NOT EXISTS (SELECT 1 FROM maintenance_orders m WHERE " + maintenance + ")"
+	params = append(params, booking.OverlapMaintenanceArgs(request.CheckIn, request.CheckOut)...)
+
+	// the price range and the order follow the quoted stay price, which the service works out
+	query += " ORDER BY name ASC"
+
+	if err := r.db.Select(&rooms, query, params...); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return rooms, nil
+}
@@ -4,10 +4,12 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/assert/v2"
 	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -94,13 +96,14 @@ func Test_GetByName(t *testing.T) {
 				Type:        "type",
 				Price:       100,
 				Status:      1,
+				Capacity:    2,
 				Description: nil,
 				CreatedAt:   nil,
 				UpdatedAt:   nil,
 			},
 			mockCall: func() {
-				mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "floor", "type", "price", "status", "description", "created_at", "updated_at"}).
-					AddRow(1, "name", 1, "type", 100, 1, nil, nil, nil))
+				mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "floor", "type", "price", "status", "capacity", "description", "created_at", "updated_at"}).
+					AddRow(1, "name", 1, "type", 100, 1, 2, nil, nil, nil))
 			},
 			err: nil,
 		},
@@ -161,14 +164,15 @@ func Test_GetAll(t *testing.T) {
 					Type:        "type",
 					Price:       100,
 					Status:      1,
+					Capacity:    2,
 					Description: nil,
 					CreatedAt:   nil,
 					UpdatedAt:   nil,
 				},
 			},
 			mockCall: func() {
-				mock.ExpectQuery("SELECT").WithArgs(floor, status).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "floor", "type", "price", "status", "description", "created_at", "updated_at"}).
-					AddRow(1, "name", 1, "type", 100, 1, nil, nil, nil))
+				mock.ExpectQuery("SELECT").WithArgs(floor, status).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "floor", "type", "price", "status", "capacity", "description", "created_at", "updated_at"}).
+					AddRow(1, "name", 1, "type", 100, 1, 2, nil, nil, nil))
 			},
 			err: nil,
 		},
@@ -194,7 +198,7 @@ func Test_GetAll(t *testing.T) {
 			mockCall: func() {
 				mock.ExpectQuery("SELECT").WithArgs(floor, status).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "floor", "type", "price"}).AddRow(1, "name", 1, "type", 100))
 			},
-			err: errors.New("sql: expected 5 destination arguments in Scan, not 9"),
+			err: errors.New("sql: expected 5 destination arguments in Scan, not 10"),
 		},
 	}
 
@@ -271,3 +275,80 @@ func Test_Update(t *testing.T) {
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	roomType := "deluxe"
+	floor := 2
+	minPrice := 100.0
+	maxPrice := 500.0
+	checkIn := time.Date(2000, time.March, 01, 00, 00, 00, 00, time.UTC)
+	checkOut := time.Date(2000, time.March, 03, 00, 00, 00, 00, time.UTC)
+
+	testCases := []struct {
+		name     string
+		request  *model.SearchRoomRequest
+		result   []*model.RoomEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when search available room then return success response",
+			request: &model.SearchRoomRequest{
+				CheckIn:  checkIn,
+				CheckOut: checkOut,
+				Guests:   2,
+				Type:     &roomType,
+				Floor:    &floor,
+				MinPrice: &minPrice,
+				MaxPrice: &maxPrice,
+			},
+			result: []*model.RoomEntity{
+				{ID: 1, Name: "201", Floor: 2, Type: "deluxe", Price: 200, Status: constant.RoomStatusAvailable, Capacity: 2},
+				{ID: 2, Name: "202", Floor: 2, Type: "deluxe", Price: 300, Status: constant.RoomStatusBooked, Capacity: 3},
+			},
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM rooms r WHERE (.+) AND NOT EXISTS (.+) AND NOT EXISTS (.+) ORDER BY name ASC").
+					WithArgs(constant.RoomStatusMaintenance, constant.RoomStatusUnavailable, 2, roomType, floor, checkOut, checkIn, "",
+						constant.BookingStatusConfirmed, constant.BookingStatusCheckedIn, constant.PaymentStatusFailed, constant.PaymentStatusCanceled, constant.PaymentStatusRefunded,
+						checkOut, checkIn, constant.MaintenanceStatusOpen).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "floor", "type", "price", "status", "capacity", "description", "created_at", "updated_at"}).
+						AddRow(1, "201", 2, "deluxe", 200, 1, 2, nil, nil, nil).
+						AddRow(2, "202", 2, "deluxe", 300, 2, 3, nil, nil, nil))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error connection when search available room then return error",
+			request: &model.SearchRoomRequest{
+				CheckIn:  checkIn,
+				CheckOut: checkOut,
+				Guests:   1,
+			},
+			result: nil,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			repo := NewRoomRepository(sqlxDB)
+
+			result, err := repo.Search(tc.request)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
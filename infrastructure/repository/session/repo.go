@@ -0,0 +1,15 @@
+package session
+
+import "github.com/zakiyalmaya/hotel-management/model"
+
+//go:generate mockery --name=SessionRepository --output=../mocks --outpkg=mocks
+type SessionRepository interface {
+	Save(session *model.SessionEntity) error
+	GetByID(id string) (*model.SessionEntity, error)
+	GetByRefreshTokenHash(refreshTokenHash string) (*model.SessionEntity, error)
+	GetByUserID(userID int) ([]*model.SessionEntity, error)
+	Rotate(session *model.SessionEntity, oldRefreshTokenHash string) error
+	Touch(session *model.SessionEntity) error
+	Delete(session *model.SessionEntity) error
+	DeleteByUserID(userID int) error
+}
@@ -0,0 +1,229 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// A session is stored under session-<id> until it expires, session-refresh-<hash> points a
+// refresh token at its session and sessions-<user_id> is the set of sessions of a hotelier.
+// The keys of rotated refresh tokens are kept until they expire, so a reused one still
+// finds its session and can be told apart from a token that never existed.
+func sessionKey(id string) string {
+	return "session-" + id
+}
+
+func refreshTokenKey(refreshTokenHash string) string {
+	return "session-refresh-" + refreshTokenHash
+}
+
+func userSessionsKey(userID int) string {
+	return "sessions-" + strconv.Itoa(userID)
+}
+
+type sessionRepoImpl struct {
+	redcl *redis.Client
+}
+
+func NewSessionRepository(redcl *redis.Client) SessionRepository {
+	return &sessionRepoImpl{redcl: redcl}
+}
+
+func (s *sessionRepoImpl) Save(session *model.SessionEntity) error {
+	ctx := context.Background()
+	if _, err := s.redcl.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return save(ctx, pipe, session)
+	}); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *sessionRepoImpl) GetByID(id string) (*model.SessionEntity, error) {
+	session, err := get(context.Background(), s.redcl, sessionKey(id))
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *sessionRepoImpl) GetByRefreshTokenHash(refreshTokenHash string) (*model.SessionEntity, error) {
+	id, err := s.redcl.Get(context.Background(), refreshTokenKey(refreshTokenHash)).Result()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return s.GetByID(id)
+}
+
+// GetByUserID lists the sessions of a hotelier, the last seen first. Expired
+// sessions are removed from the set of the hotelier on the way.
+func (s *sessionRepoImpl) GetByUserID(userID int) ([]*model.SessionEntity, error) {
+	ctx := context.Background()
+	ids, err := s.redcl.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	sessions := make([]*model.SessionEntity, 0)
+	for _, id := range ids {
+		session, err := get(ctx, s.redcl, sessionKey(id))
+		if err == redis.Nil {
+			s.redcl.SRem(ctx, userSessionsKey(userID), id)
+			continue
+		}
+
+		if err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Rotate saves the session with its new refresh token, as long as the session still holds
+// oldRefreshTokenHash. Of two refreshes racing with the same token only one wins.
+func (s *sessionRepoImpl) Rotate(session *model.SessionEntity, oldRefreshTokenHash string) error {
+	ctx := context.Background()
+	key := sessionKey(session.ID)
+	err := s.redcl.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := get(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current.RefreshTokenHash != oldRefreshTokenHash {
+			return model.NewUnauthorizedError("refresh token has already been used")
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return save(ctx, pipe, session)
+		})
+		return err
+	}, key)
+	if err == redis.TxFailedErr {
+		return model.NewUnauthorizedError("refresh token has already been used")
+	}
+
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Touch updates where and when the session was last seen, leaving the rest of it as it is.
+func (s *sessionRepoImpl) Touch(session *model.SessionEntity) error {
+	ctx := context.Background()
+	key := sessionKey(session.ID)
+	err := s.redcl.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := get(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		current.IP = session.IP
+		current.LastSeenAt = session.LastSeenAt
+		data, err := json.Marshal(current)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, redis.KeepTTL)
+			return nil
+		})
+		return err
+	}, key)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *sessionRepoImpl) Delete(session *model.SessionEntity) error {
+	ctx := context.Background()
+	if _, err := s.redcl.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(session.ID))
+		pipe.SRem(ctx, userSessionsKey(session.UserID), session.ID)
+		return nil
+	}); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *sessionRepoImpl) DeleteByUserID(userID int) error {
+	ctx := context.Background()
+	ids, err := s.redcl.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if _, err := s.redcl.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Del(ctx, sessionKey(id))
+		}
+		pipe.Del(ctx, userSessionsKey(userID))
+		return nil
+	}); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func get(ctx context.Context, cmd redis.Cmdable, key string) (*model.SessionEntity, error) {
+	data, err := cmd.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &model.SessionEntity{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// save queues the writes of a session, all of its keys live until the session expires.
+func save(ctx context.Context, pipe redis.Pipeliner, session *model.SessionEntity) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(session.ExpiresAt)
+	pipe.Set(ctx, sessionKey(session.ID), data, ttl)
+	pipe.Set(ctx, refreshTokenKey(session.RefreshTokenHash), session.ID, ttl)
+	pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+	pipe.Expire(ctx, userSessionsKey(session.UserID), ttl)
+	return nil
+}
@@ -0,0 +1,154 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func newRepo(t *testing.T) (*miniredis.Miniredis, SessionRepository) {
+	mockRedisServer, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	t.Cleanup(mockRedisServer.Close)
+
+	return mockRedisServer, NewSessionRepository(redis.NewClient(&redis.Options{Addr: mockRedisServer.Addr()}))
+}
+
+func newSession(id string, refreshTokenHash string) *model.SessionEntity {
+	now := time.Now().Truncate(time.Second)
+	return &model.SessionEntity{
+		ID:               id,
+		UserID:           1,
+		Device:           "Front desk PC",
+		IP:               "10.0.0.1",
+		RefreshTokenHash: refreshTokenHash,
+		CreatedAt:        now,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(24 * time.Hour),
+	}
+}
+
+func Test_SaveAndGet(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	session := newSession("a", "hash-a")
+
+	assert.NoError(t, repo.Save(session))
+	assert.True(t, mockRedisServer.TTL("session-a") > 23*time.Hour)
+
+	got, err := repo.GetByID("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "Front desk PC", got.Device)
+	assert.True(t, session.ExpiresAt.Equal(got.ExpiresAt))
+
+	got, err = repo.GetByRefreshTokenHash("hash-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", got.ID)
+
+	_, err = repo.GetByRefreshTokenHash("unknown")
+	assert.Equal(t, redis.Nil, err)
+
+	_, err = repo.GetByID("unknown")
+	assert.Equal(t, redis.Nil, err)
+}
+
+func Test_GetByUserID(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	older := newSession("a", "hash-a")
+	older.LastSeenAt = older.LastSeenAt.Add(-time.Hour)
+	newer := newSession("b", "hash-b")
+	expired := newSession("c", "hash-c")
+
+	assert.NoError(t, repo.Save(older))
+	assert.NoError(t, repo.Save(newer))
+	assert.NoError(t, repo.Save(expired))
+	mockRedisServer.Del("session-c")
+
+	sessions, err := repo.GetByUserID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sessions))
+	assert.Equal(t, "b", sessions[0].ID)
+	assert.Equal(t, "a", sessions[1].ID)
+
+	members, _ := mockRedisServer.Members("sessions-1")
+	assert.Equal(t, []string{"a", "b"}, members)
+}
+
+func Test_Rotate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oldHash string
+		err     error
+	}{
+		{
+			name:    "Given current refresh token when rotate then save the new one",
+			oldHash: "hash-a",
+			err:     nil,
+		},
+		{
+			name:    "Given refresh token already rotated when rotate then return unauthorized",
+			oldHash: "hash-old",
+			err:     model.NewUnauthorizedError("refresh token has already been used"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, repo := newRepo(t)
+			assert.NoError(t, repo.Save(newSession("a", "hash-a")))
+
+			err := repo.Rotate(newSession("a", "hash-new"), tc.oldHash)
+			assert.Equal(t, tc.err, err)
+
+			got, _ := repo.GetByID("a")
+			if tc.err == nil {
+				assert.Equal(t, "hash-new", got.RefreshTokenHash)
+
+				// the rotated token still points at its session to catch a reuse
+				old, err := repo.GetByRefreshTokenHash("hash-a")
+				assert.NoError(t, err)
+				assert.Equal(t, "a", old.ID)
+			} else {
+				assert.Equal(t, "hash-a", got.RefreshTokenHash)
+			}
+		})
+	}
+}
+
+func Test_Touch(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	assert.NoError(t, repo.Save(newSession("a", "hash-a")))
+
+	seen := time.Now().Add(time.Hour).Truncate(time.Second)
+	assert.NoError(t, repo.Touch(&model.SessionEntity{ID: "a", IP: "10.0.0.2", LastSeenAt: seen, RefreshTokenHash: "ignored"}))
+
+	got, _ := repo.GetByID("a")
+	assert.Equal(t, "10.0.0.2", got.IP)
+	assert.True(t, seen.Equal(got.LastSeenAt))
+	assert.Equal(t, "hash-a", got.RefreshTokenHash)
+	assert.True(t, mockRedisServer.TTL("session-a") > 23*time.Hour)
+
+	// a revoked session is not brought back
+	assert.Error(t, repo.Touch(&model.SessionEntity{ID: "b", LastSeenAt: seen}))
+	assert.False(t, mockRedisServer.Exists("session-b"))
+}
+
+func Test_Delete(t *testing.T) {
+	mockRedisServer, repo := newRepo(t)
+	assert.NoError(t, repo.Save(newSession("a", "hash-a")))
+	assert.NoError(t, repo.Save(newSession("b", "hash-b")))
+
+	assert.NoError(t, repo.Delete(&model.SessionEntity{ID: "a", UserID: 1}))
+	assert.False(t, mockRedisServer.Exists("session-a"))
+	members, _ := mockRedisServer.Members("sessions-1")
+	assert.Equal(t, []string{"b"}, members)
+
+	assert.NoError(t, repo.DeleteByUserID(1))
+	assert.False(t, mockRedisServer.Exists("session-b"))
+	assert.False(t, mockRedisServer.Exists("sessions-1"))
+}
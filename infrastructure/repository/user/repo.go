@@ -1,10 +1,23 @@
 package user
 
-import "github.com/zakiyalmaya/hotel-management/model"
+import (
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
 
 //go:generate mockery --name=UserRepository --output=../mocks --outpkg=mocks
 type UserRepository interface {
 	Create(user *model.UserEntity) error
 	GetByUsername(username string) (*model.UserEntity, error)
+	GetByID(id int) (*model.UserEntity, error)
+	GetByEmail(email string) ([]*model.UserEntity, error)
 	UpdatePassword(user *model.UserEntity) error
+	UpdateRole(user *model.UserEntity) error
+	GetAll(pagination *model.PaginationRequest) ([]*model.UserEntity, int, error)
+	Update(user *model.UserEntity) error
+	Deactivate(user *model.UserEntity) error
+	CountByRole(role constant.Role) (int, error)
+	UpdateTOTP(user *model.UserEntity, recoveryCodeHashes []string) error
+	UseTOTPStep(id int, step int64) (bool, error)
+	UseRecoveryCode(id int, codeHash string) (bool, error)
 }
@@ -4,9 +4,12 @@ import (
 	"log"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
+const selectUserQuery = "SELECT id, name, username, password, email, role, totp_secret, totp_enabled, totp_last_step, deactivated_at, deactivated_by, created_at, updated_at FROM users"
+
 type userRepoImpl struct {
 	db *sqlx.DB
 }
@@ -18,7 +21,7 @@ func NewUserRepository(db *sqlx.DB) UserRepository {
 }
 
 func (u *userRepoImpl) Create(user *model.UserEntity) error {
-	query := "INSERT INTO users (name, username, password, email) VALUES (:name, :username, :password, :email)"
+	query := "INSERT INTO users (name, username, password, email, role) VALUES (:name, :username, :password, :email, :role)"
 	_, err := u.db.NamedExec(query, user)
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
@@ -30,8 +33,8 @@ func (u *userRepoImpl) Create(user *model.UserEntity) error {
 
 func (u *userRepoImpl) GetByUsername(username string) (*model.UserEntity, error) {
 	user := &model.UserEntity{}
-	query := "SELECT id, name, username, password, email, created_at, updated_at FROM users WHERE username = ?"
-	
+	query := selectUserQuery + " WHERE username = ?"
+
 	err := u.db.Get(user, query, username)
 	if err != nil {
 		log.Println("errorRepository: ", err.Error())
@@ -41,6 +44,20 @@ func (u *userRepoImpl) GetByUsername(username string) (*model.UserEntity, error)
 	return user, nil
 }
 
+// GetByEmail lists the hoteliers registered with the email, ignoring case. An email is
+// not unique, e.g. one person may have an admin and a front desk account.
+func (u *userRepoImpl) GetByEmail(email string) ([]*model.UserEntity, error) {
+	users := make([]*model.UserEntity, 0)
+	query := selectUserQuery + " WHERE LOWER(email) = LOWER(?) ORDER BY id"
+
+	if err := u.db.Select(&users, query, email); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (u *userRepoImpl) UpdatePassword(user *model.UserEntity) error {
 	query := "UPDATE users SET password = :password WHERE username = :username"
 	_, err := u.db.NamedExec(query, user)
@@ -50,4 +67,158 @@ func (u *userRepoImpl) UpdatePassword(user *model.UserEntity) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+func (u *userRepoImpl) GetByID(id int) (*model.UserEntity, error) {
+	user := &model.UserEntity{}
+	query := selectUserQuery + " WHERE id = ?"
+
+	err := u.db.Get(user, query, id)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *userRepoImpl) UpdateRole(user *model.UserEntity) error {
+	query := "UPDATE users SET role = :role, updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+	_, err := u.db.NamedExec(query, user)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns a page of the hoteliers, deactivated ones included, and how many there are in all.
+func (u *userRepoImpl) GetAll(pagination *model.PaginationRequest) ([]*model.UserEntity, int, error) {
+	var total int
+	if err := u.db.Get(&total, "SELECT COUNT(*) FROM users"); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, 0, err
+	}
+
+	users := make([]*model.UserEntity, 0)
+	query := selectUserQuery + " ORDER BY id LIMIT ? OFFSET ?"
+	if err := u.db.Select(&users, query, pagination.Limit, pagination.Offset()); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (u *userRepoImpl) Update(user *model.UserEntity) error {
+	query := "UPDATE users SET name = :name, email = :email, role = :role, updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+	if _, err := u.db.NamedExec(query, user); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (u *userRepoImpl) Deactivate(user *model.UserEntity) error {
+	query := "UPDATE users SET deactivated_at = :deactivated_at, deactivated_by = :deactivated_by, updated_at = CURRENT_TIMESTAMP WHERE id = :id AND deactivated_at IS NULL"
+	res, err := u.db.NamedExec(query, user)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if affected == 0 {
+		return model.NewConflictError("user is already deactivated")
+	}
+
+	return nil
+}
+
+func (u *userRepoImpl) CountByRole(role constant.Role) (int, error) {
+	var count int
+	if err := u.db.Get(&count, "SELECT COUNT(*) FROM users WHERE role = ?", role); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdateTOTP saves the two-factor settings of the user and replaces the recovery codes
+// with recoveryCodeHashes, no codes are left when it is empty.
+func (u *userRepoImpl) UpdateTOTP(user *model.UserEntity, recoveryCodeHashes []string) error {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE users SET totp_secret = :totp_secret, totp_enabled = :totp_enabled, totp_last_step = :totp_last_step, updated_at = CURRENT_TIMESTAMP WHERE id = :id"
+	if _, err := tx.NamedExec(query, user); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM user_recovery_codes WHERE user_id = ?", user.ID); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err := tx.Exec("INSERT INTO user_recovery_codes (user_id, code_hash) VALUES (?, ?)", user.ID, codeHash); err != nil {
+			log.Println("errorRepository: ", err.Error())
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the step of an accepted code. It reports false when that step
+// or a later one was already used, so a code can not be replayed.
+func (u *userRepoImpl) UseTOTPStep(id int, step int64) (bool, error) {
+	res, err := u.db.Exec("UPDATE users SET totp_last_step = ? WHERE id = ? AND totp_last_step < ?", step, id, step)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// UseRecoveryCode marks a recovery code of the user as used. It reports false when
+// the user has no such code left.
+func (u *userRepoImpl) UseRecoveryCode(id int, codeHash string) (bool, error) {
+	res, err := u.db.Exec("UPDATE user_recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE user_id = ? AND code_hash = ? AND used_at IS NULL", id, codeHash)
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println("errorRepository: ", err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
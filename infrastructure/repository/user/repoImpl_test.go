@@ -8,6 +8,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-playground/assert/v2"
 	"github.com/jmoiron/sqlx"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -92,13 +93,14 @@ func Test_GetByUsername(t *testing.T) {
 				Password:  "password",
 				Name:      "name",
 				Email:     "email",
+				Role:      constant.RoleFrontDesk,
 				CreatedAt: &currentTime,
 				UpdatedAt: &currentTime,
 			},
 			mockCall: func() {
-				rows := sqlmock.NewRows([]string{"id", "username", "password", "name", "email", "created_at", "updated_at"}).
-					AddRow(1, "username", "password", "name", "email", currentTime, currentTime)
-				mock.ExpectQuery("SELECT id, name, username, password, email, created_at, updated_at FROM users WHERE username = ?").WithArgs("username").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "username", "password", "name", "email", "role", "created_at", "updated_at"}).
+					AddRow(1, "username", "password", "name", "email", constant.RoleFrontDesk, currentTime, currentTime)
+				mock.ExpectQuery("SELECT id, name, username, password, email, role, totp_secret, totp_enabled, totp_last_step, deactivated_at, deactivated_by, created_at, updated_at FROM users WHERE username = ?").WithArgs("username").WillReturnRows(rows)
 			},
 			err: nil,
 		},
@@ -106,7 +108,7 @@ func Test_GetByUsername(t *testing.T) {
 			name:     "Given error when get user by username then return error response",
 			username: "username",
 			mockCall: func() {
-				mock.ExpectQuery("SELECT id, name, username, password, email, created_at, updated_at FROM users WHERE username = ?").WithArgs("username").WillReturnError(errors.New("error"))
+				mock.ExpectQuery("SELECT id, name, username, password, email, role, totp_secret, totp_enabled, totp_last_step, deactivated_at, deactivated_by, created_at, updated_at FROM users WHERE username = ?").WithArgs("username").WillReturnError(errors.New("error"))
 			},
 			err: errors.New("error"),
 		},
@@ -140,7 +142,7 @@ func Test_UpdatePassword(t *testing.T) {
 		err      error
 	}{
 		{
-			name:   "Given valid request when update password user then return success response",
+			name: "Given valid request when update password user then return success response",
 			request: &model.UserEntity{
 				Username: "username",
 				Password: "password",
@@ -151,7 +153,7 @@ func Test_UpdatePassword(t *testing.T) {
 			err: nil,
 		},
 		{
-			name:   "Given error when update password user then return error response",
+			name: "Given error when update password user then return error response",
 			request: &model.UserEntity{
 				Username: "username",
 				Password: "password",
@@ -173,3 +175,491 @@ func Test_UpdatePassword(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	currentTime := time.Now()
+
+	testCases := []struct {
+		name     string
+		result   *model.UserEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when get user by id then return success response",
+			result: &model.UserEntity{
+				ID:        1,
+				Username:  "username",
+				Password:  "password",
+				Name:      "name",
+				Email:     "email",
+				Role:      constant.RoleAdmin,
+				CreatedAt: &currentTime,
+				UpdatedAt: &currentTime,
+			},
+			mockCall: func() {
+				rows := sqlmock.NewRows([]string{"id", "username", "password", "name", "email", "role", "created_at", "updated_at"}).
+					AddRow(1, "username", "password", "name", "email", constant.RoleAdmin, currentTime, currentTime)
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\?").WithArgs(1).WillReturnRows(rows)
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when get user by id then return error response",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\?").WithArgs(1).WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+			repo := NewUserRepository(sqlxDB)
+
+			result, err := repo.GetByID(1)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_GetByEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		result   []*model.UserEntity
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given accounts with the email when get users by email then return all of them",
+			result: []*model.UserEntity{
+				{ID: 1, Username: "janedoe", Email: "jane@mail.com", Role: constant.RoleAdmin},
+				{ID: 3, Username: "janedesk", Email: "Jane@Mail.com", Role: constant.RoleFrontDesk},
+			},
+			mockCall: func() {
+				rows := sqlmock.NewRows([]string{"id", "username", "email", "role"}).
+					AddRow(1, "janedoe", "jane@mail.com", constant.RoleAdmin).
+					AddRow(3, "janedesk", "Jane@Mail.com", constant.RoleFrontDesk)
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE LOWER\\(email\\) = LOWER\\(\\?\\) ORDER BY id").WithArgs("JANE@mail.com").WillReturnRows(rows)
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when get users by email then return error response",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT (.+) FROM users WHERE LOWER").WithArgs("JANE@mail.com").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, err := NewUserRepository(sqlxDB).GetByEmail("JANE@mail.com")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_UpdateRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when update role user then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET role = \\?, updated_at = CURRENT_TIMESTAMP WHERE id = \\?").WithArgs(constant.RoleAccountant, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when update role user then return error response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET role").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+			repo := NewUserRepository(sqlxDB)
+
+			err := repo.UpdateRole(&model.UserEntity{ID: 1, Role: constant.RoleAccountant})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	deactivatedAt := time.Now()
+	deactivatedBy := 1
+
+	testCases := []struct {
+		name     string
+		result   []*model.UserEntity
+		total    int
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given second page when get all users then return the page and the total",
+			result: []*model.UserEntity{
+				{ID: 3, Username: "janedesk", Role: constant.RoleFrontDesk},
+				{ID: 4, Username: "johndoe", Role: constant.RoleReadOnly, DeactivatedAt: &deactivatedAt, DeactivatedBy: &deactivatedBy},
+			},
+			total: 4,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+				rows := sqlmock.NewRows([]string{"id", "username", "role", "deactivated_at", "deactivated_by"}).
+					AddRow(3, "janedesk", constant.RoleFrontDesk, nil, nil).
+					AddRow(4, "johndoe", constant.RoleReadOnly, deactivatedAt, 1)
+				mock.ExpectQuery("SELECT (.+) FROM users ORDER BY id LIMIT \\? OFFSET \\?").WithArgs(2, 2).WillReturnRows(rows)
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when get all users then return error response",
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			result, total, err := NewUserRepository(sqlxDB).GetAll(&model.PaginationRequest{Page: 2, Limit: 2})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+			assert.Equal(t, tc.total, total)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when update user then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET name = \\?, email = \\?, role = \\?, updated_at = CURRENT_TIMESTAMP WHERE id = \\?").WithArgs("Jane Doe", "jane@mail.com", constant.RoleAccountant, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given error when update user then return error response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET name").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := NewUserRepository(sqlxDB).Update(&model.UserEntity{ID: 1, Name: "Jane Doe", Email: "jane@mail.com", Role: constant.RoleAccountant})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_Deactivate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	deactivatedAt := time.Now()
+	deactivatedBy := 1
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given active user when deactivate then return success response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET deactivated_at = \\?, deactivated_by = \\?, updated_at = CURRENT_TIMESTAMP WHERE id = \\? AND deactivated_at IS NULL").WithArgs(&deactivatedAt, &deactivatedBy, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name: "Given deactivated user when deactivate then return conflict",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET deactivated_at").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: model.NewConflictError("user is already deactivated"),
+		},
+		{
+			name: "Given error when deactivate then return error response",
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET deactivated_at").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+
+			err := NewUserRepository(sqlxDB).Deactivate(&model.UserEntity{ID: 2, DeactivatedAt: &deactivatedAt, DeactivatedBy: &deactivatedBy})
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
+
+func Test_CountByRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		result   int
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given admins when count by role then return how many there are",
+			result: 2,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM users WHERE role = \\?").WithArgs(constant.RoleAdmin).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error when count by role then return error response",
+			result: 0,
+			mockCall: func() {
+				mock.ExpectQuery("SELECT COUNT").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+			repo := NewUserRepository(sqlxDB)
+
+			result, err := repo.CountByRole(constant.RoleAdmin)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_UpdateTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	secret := "JBSWY3DPEHPK3PXP"
+
+	testCases := []struct {
+		name     string
+		mockCall func()
+		err      error
+	}{
+		{
+			name: "Given valid request when update totp then save it and replace the recovery codes",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users SET totp_secret = \\?, totp_enabled = \\?, totp_last_step = \\?").WithArgs(&secret, true, int64(10), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM user_recovery_codes WHERE user_id = \\?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 10))
+				mock.ExpectExec("INSERT INTO user_recovery_codes").WithArgs(1, "hash-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO user_recovery_codes").WithArgs(1, "hash-2").WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		{
+			name: "Given error insert code when update totp then roll back",
+			mockCall: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE users SET totp_secret").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM user_recovery_codes").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO user_recovery_codes").WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+			repo := NewUserRepository(sqlxDB)
+
+			err := repo.UpdateTOTP(&model.UserEntity{ID: 1, TOTPSecret: &secret, TOTPEnabled: true, TOTPLastStep: 10}, []string{"hash-1", "hash-2"})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, nil, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_UseTOTPStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		result   bool
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given newer step when use totp step then record it",
+			result: true,
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET totp_last_step = \\? WHERE id = \\? AND totp_last_step < \\?").WithArgs(int64(10), 1, int64(10)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given step already used when use totp step then return false",
+			result: false,
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET totp_last_step").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given error when use totp step then return error response",
+			result: false,
+			mockCall: func() {
+				mock.ExpectExec("UPDATE users SET totp_last_step").WillReturnError(errors.New("error"))
+			},
+			err: errors.New("error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+			repo := NewUserRepository(sqlxDB)
+
+			result, err := repo.UseTOTPStep(1, 10)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+func Test_UseRecoveryCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+	testCases := []struct {
+		name     string
+		result   bool
+		mockCall func()
+		err      error
+	}{
+		{
+			name:   "Given unused code when use recovery code then mark it used",
+			result: true,
+			mockCall: func() {
+				mock.ExpectExec("UPDATE user_recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE user_id = \\? AND code_hash = \\? AND used_at IS NULL").WithArgs(1, "hash").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		{
+			name:   "Given used or unknown code when use recovery code then return false",
+			result: false,
+			mockCall: func() {
+				mock.ExpectExec("UPDATE user_recovery_codes").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockCall()
+			repo := NewUserRepository(sqlxDB)
+
+			result, err := repo.UseRecoveryCode(1, "hash")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
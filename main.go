@@ -1,13 +1,21 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/zakiyalmaya/hotel-management/application"
+	"github.com/zakiyalmaya/hotel-management/application/user"
 	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/fieldcipher"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/mailer"
 	"github.com/zakiyalmaya/hotel-management/infrastructure/repository"
+	"github.com/zakiyalmaya/hotel-management/model"
 	"github.com/zakiyalmaya/hotel-management/transport"
+	"github.com/zakiyalmaya/hotel-management/utils"
 )
 
 func main() {
@@ -20,19 +28,95 @@ func main() {
 
 	// instatiate repository
 	db := repository.DBConnection(config.Database.File)
-	redcl := repository.RedisClient(config.Redis.Host, config.Redis.Port)
 	defer db.Close()
 
-	repository := repository.NewRespository(db, redcl)
+	fieldCipher, err := fieldcipher.NewCipher(config.GuestEncryption)
+	if err != nil {
+		log.Panicln("invalid guest encryption configuration: ", err.Error())
+	}
+
+	// bootstrap the first admin without starting the server
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		createAdmin(repository.NewRespository(db, nil, fieldCipher), config, os.Args[2:])
+		return
+	}
+
+	redcl := repository.RedisClient(config.Redis.Host, config.Redis.Port)
+
+	repository := repository.NewRespository(db, redcl, fieldCipher)
 
 	// instantiate application
-	application := application.NewApplication(repository)
+	application := application.NewApplication(repository, config)
+
+	// encrypt guests stored before encryption or with a key that is no longer active
+	reEncrypted, err := application.GuestSvc.ReEncrypt()
+	if err != nil {
+		log.Panicln("failed to re-encrypt guests: ", err.Error())
+	}
+
+	if reEncrypted > 0 {
+		log.Println("re-encrypted", reEncrypted, "guest records")
+	}
+
+	// start background jobs
+	schedule("guest duplicate detection", time.Duration(config.GuestDuplicate.ScanIntervalMinutes)*time.Minute, func() error {
+		_, err := application.GuestSvc.DetectDuplicates()
+		return err
+	})
+	schedule("guest retention", time.Duration(config.GuestRetention.ScanIntervalMinutes)*time.Minute, func() error {
+		result, err := application.GuestSvc.AnonymizeInactive()
+		if err == nil && len(result.Failed) > 0 {
+			log.Println("guest retention could not anonymize", len(result.Failed), "of", result.Processed, "guests: ", result.Failed)
+		}
+
+		return err
+	})
 
 	// instantiate fiber
 	r := fiber.New()
 
 	// instantiate transport
-	transport.Handler(application, redcl, r)
+	transport.Handler(application, r)
 
 	r.Listen(config.Server.Port)
 }
+
+// schedule runs job every interval for as long as the server runs, a zero interval turns it off.
+func schedule(name string, interval time.Duration, job func() error) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := job(); err != nil {
+				log.Println("error running "+name+": ", err.Error())
+			}
+		}
+	}()
+}
+
+// createAdmin handles `go run main.go create-admin -name "Jane Doe" -username janedoe -password JaneDoe-123 -email jane.doe@mail.com`.
+// It only works while there is no admin yet, later hoteliers are invited by an admin.
+func createAdmin(repos *repository.Repositories, cfg *config.Config, args []string) {
+	request := model.CreateAdminRequest{}
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	flags.StringVar(&request.Name, "name", "", "full name of the admin")
+	flags.StringVar(&request.Username, "username", "", "username of the admin")
+	flags.StringVar(&request.Password, "password", "", "password of the admin")
+	flags.StringVar(&request.Email, "email", "", "email of the admin")
+	flags.Parse(args)
+
+	if err := utils.Validator(request); err != nil {
+		log.Fatalln("invalid admin: ", err.Error())
+	}
+
+	if err := user.NewUserServiceImpl(repos, cfg, mailer.NewMailer(cfg.Mailer)).CreateAdmin(&request); err != nil {
+		log.Fatalln("failed to create admin: ", err.Error())
+	}
+
+	log.Println("admin " + request.Username + " created")
+}
@@ -1,18 +1,18 @@
 package middleware
 
 import (
-	"context"
 	"log"
-	"net/http"
 	"strings"
+	"time"
 
-	"github.com/dgrijalva/jwt-go"
-	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/keyset"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/session"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
-func AuthMiddleware(redcl *redis.Client) fiber.Handler {
+func AuthMiddleware(sessionRepo session.SessionRepository, keySet *keyset.KeySet) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 
 		authHeader := c.Get("Authorization")
@@ -21,46 +21,68 @@ func AuthMiddleware(redcl *redis.Client) fiber.Handler {
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validate the token signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fiber.NewError(http.StatusUnauthorized, "invalid signing method")
-			}
-			return []byte("hotel-management-secret-key"), nil
-		})
-
+		// the key is picked by the kid header, the signing method has to match it
+		claims := &model.AuthClaims{}
+		token, err := keySet.Parse(tokenString, claims)
 		if err != nil || !token.Valid {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid or expired token", nil))
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid token claims", nil))
-		}
-
-		username, ok := claims["username"].(string)
-		if !ok {
+		username := claims.Username
+		if username == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid username in token claims", nil))
 		}
 		c.Locals("username", username)
 
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
+		if claims.UserID == 0 {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid user_id in token claims", nil))
 		}
-		c.Locals("user_id", int(userID))
+		c.Locals("user_id", claims.UserID)
 
-		// Check the token in Redis cache
-		tokenCache, err := redcl.Get(context.Background(), "jwt-token-"+username).Result()
-		if err != nil {
-			log.Println("Redis error:", err)
-			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid or expired token", nil))
+		if !claims.Role.Validation() {
+			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid role in token claims", nil))
 		}
+		c.Locals("role", claims.Role)
 
-		if tokenCache != tokenString {
+		// a token is only good while its session is, so revoking a session logs it out at once
+		session, err := sessionRepo.GetByID(claims.SessionID)
+		if err != nil || session.UserID != claims.UserID {
 			return c.Status(fiber.StatusUnauthorized).JSON(model.NewHttpResponse(fiber.StatusUnauthorized, "invalid or expired token", nil))
 		}
+		c.Locals("session_id", session.ID)
+
+		// last seen is kept to the minute so not every request writes to Redis
+		if time.Since(session.LastSeenAt) > time.Minute || session.IP != c.IP() {
+			session.LastSeenAt = time.Now()
+			session.IP = c.IP()
+			if err := sessionRepo.Touch(session); err != nil {
+				log.Println("Failed to update session:", err)
+			}
+		}
 
 		return c.Next()
 	}
 }
+
+// RequireRole lets the request through when the logged in user has one of the roles.
+// Admins are always let through. It must run after AuthMiddleware.
+func RequireRole(roles ...constant.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, ok := c.Locals("role").(constant.Role)
+		if !ok {
+			return c.Status(fiber.StatusForbidden).JSON(model.NewHttpResponse(fiber.StatusForbidden, "missing role", nil))
+		}
+
+		if role == constant.RoleAdmin {
+			return c.Next()
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(model.NewHttpResponse(fiber.StatusForbidden, "your role is not allowed to do this", nil))
+	}
+}
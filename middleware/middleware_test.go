@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/go-playground/assert/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/config"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/keyset"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/repository/session"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_AuthMiddleware(t *testing.T) {
+	mockRedisServer, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer mockRedisServer.Close()
+
+	sessionRepo := session.NewSessionRepository(redis.NewClient(&redis.Options{
+		Addr: mockRedisServer.Addr(),
+	}))
+
+	keySet, err := keyset.NewKeySet(config.JWTConfig{
+		SigningKeyID: "current",
+		Keys: []config.JWTKeyConfig{
+			{ID: "current", Algorithm: "HS256", Secret: "current-secret"},
+		},
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	sign := func(kid string, sessionID string, role constant.Role) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, &model.AuthClaims{
+			UserID:    1,
+			Username:  "janedoe",
+			Role:      role,
+			SessionID: sessionID,
+			StandardClaims: jwt.StandardClaims{
+				ExpiresAt: time.Now().Add(time.Minute).Unix(),
+			},
+		})
+		token.Header["kid"] = kid
+		signed, _ := token.SignedString([]byte("current-secret"))
+		return signed
+	}
+
+	testCases := []struct {
+		name       string
+		token      string
+		session    *model.SessionEntity
+		statusCode int
+	}{
+		{
+			name:       "Given token of a live session when authenticate then let the request through",
+			token:      sign("current", "a", constant.RoleFrontDesk),
+			session:    &model.SessionEntity{ID: "a", UserID: 1},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given token of a revoked session when authenticate then return unauthorized",
+			token:      sign("current", "a", constant.RoleFrontDesk),
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Given session of another user when authenticate then return unauthorized",
+			token:      sign("current", "a", constant.RoleFrontDesk),
+			session:    &model.SessionEntity{ID: "a", UserID: 2},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Given unknown kid when authenticate then return unauthorized",
+			token:      sign("retired", "a", constant.RoleFrontDesk),
+			session:    &model.SessionEntity{ID: "a", UserID: 1},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Given invalid role when authenticate then return unauthorized",
+			token:      sign("current", "a", 0),
+			session:    &model.SessionEntity{ID: "a", UserID: 1},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Given missing token when authenticate then return unauthorized",
+			statusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRedisServer.FlushAll()
+			if tc.session != nil {
+				tc.session.ExpiresAt = time.Now().Add(time.Hour)
+				sessionRepo.Save(tc.session)
+			}
+
+			app := fiber.New()
+			app.Get("/", AuthMiddleware(sessionRepo, keySet), func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+
+			// the first request of a session records when and where it was seen
+			if tc.statusCode == http.StatusOK {
+				seen, _ := sessionRepo.GetByID(tc.session.ID)
+				assert.Equal(t, "0.0.0.0", seen.IP)
+				assert.Equal(t, true, time.Since(seen.LastSeenAt) < time.Minute)
+			}
+		})
+	}
+}
+
+func Test_RequireRole(t *testing.T) {
+	testCases := []struct {
+		name       string
+		role       interface{}
+		statusCode int
+	}{
+		{
+			name:       "Given allowed role when require role then let the request through",
+			role:       constant.RoleAccountant,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given admin when require role then let the request through",
+			role:       constant.RoleAdmin,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given other role when require role then return forbidden",
+			role:       constant.RoleHousekeeping,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Given read only when require role then return forbidden",
+			role:       constant.RoleReadOnly,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Given no role when require role then return forbidden",
+			role:       nil,
+			statusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(ctx *fiber.Ctx) error {
+				ctx.Locals("role", tc.role)
+				return ctx.Next()
+			}, RequireRole(constant.RoleFrontDesk, constant.RoleAccountant), func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest("GET", "/", nil)
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -1,20 +1,55 @@
 package model
 
-import "github.com/dgrijalva/jwt-go"
+import (
+	"github.com/dgrijalva/jwt-go"
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
 
+// AuthRequest logs in on a device, Device names it in the session list and
+// defaults to the User-Agent of the request.
 type AuthRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	Device   string `json:"device"`
+	IP       string `json:"-"`
 }
 
+// AuthResponse holds the tokens of a new session or, when a second factor is needed, the
+// MfaToken to finish the login with. Enrolment is sent along when the hotelier has to set
+// up an authenticator app first and RecoveryCodes once that is done.
 type AuthResponse struct {
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Token    string `json:"token"`
+	Username      string                 `json:"username"`
+	Name          string                 `json:"name"`
+	Role          string                 `json:"role"`
+	Token         string                 `json:"token,omitempty"`
+	RefreshToken  string                 `json:"refresh_token,omitempty"`
+	SessionID     string                 `json:"session_id,omitempty"`
+	MfaRequired   bool                   `json:"mfa_required,omitempty"`
+	MfaToken      string                 `json:"mfa_token,omitempty"`
+	MfaEnrollment *MfaEnrollmentResponse `json:"mfa_enrollment,omitempty"`
+	RecoveryCodes []string               `json:"recovery_codes,omitempty"`
 }
 
 type AuthClaims struct {
-	UserID   int    `json:"user_id"`
-	Username string `json:"username"`
+	UserID    int           `json:"user_id"`
+	Username  string        `json:"username"`
+	Role      constant.Role `json:"role"`
+	SessionID string        `json:"sid"`
 	jwt.StandardClaims
 }
+
+// JWKS is the JSON Web Key Set (RFC 7517) other services verify access tokens with.
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type AvailabilityRequest struct {
+	RoomName              string
+	CheckIn               time.Time
+	CheckOut              time.Time
+	ExcludeRegisterNumber string
+}
+
+type AvailabilityResponse struct {
+	RoomName  string `json:"room_name"`
+	CheckIn   string `json:"check_in"`
+	CheckOut  string `json:"check_out"`
+	Available bool   `json:"available"`
+}
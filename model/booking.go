@@ -7,24 +7,40 @@ import (
 )
 
 type BookingEntity struct {
-	ID                int                    `db:"id"`
-	RegisterNumber    string                 `db:"register_number"`
-	GuestID           int                    `db:"guest_id"`
-	RoomName          string                 `db:"room_name"`
-	CheckIn           time.Time              `db:"check_in"`
-	CheckOut          time.Time              `db:"check_out"`
-	PaidAmount        float64                `db:"paid_amount"`
-	PaymentMethod     constant.PaymentMethod `db:"payment_method"`
-	PaymentStatus     constant.PaymentStatus `db:"payment_status"`
-	AdditionalRequest *string                `db:"additional_request"`
-	CreatedAt         *time.Time             `db:"created_at"`
-	UpdatedAt         *time.Time             `db:"updated_at"`
+	ID                   int                    `db:"id"`
+	RegisterNumber       string                 `db:"register_number"`
+	GuestID              int                    `db:"guest_id"`
+	RoomName             string                 `db:"room_name"`
+	CheckIn              time.Time              `db:"check_in"`
+	CheckOut             time.Time              `db:"check_out"`
+	PaidAmount           float64                `db:"paid_amount"`
+	PaymentMethod        constant.PaymentMethod `db:"payment_method"`
+	PaymentStatus        constant.PaymentStatus `db:"payment_status"`
+	Status               constant.BookingStatus `db:"status"`
+	AdditionalRequest    *string                `db:"additional_request"`
+	RefundAmount         float64                `db:"refund_amount"`
+	CanceledAt           *time.Time             `db:"canceled_at"`
+	CheckedInAt          *time.Time             `db:"checked_in_at"`
+	CheckedInBy          *int                   `db:"checked_in_by"`
+	CheckedOutAt         *time.Time             `db:"checked_out_at"`
+	CheckedOutBy         *int                   `db:"checked_out_by"`
+	GatewayTransactionID *string                `db:"gateway_transaction_id"`
+	AuthorizedAmount     float64                `db:"authorized_amount"`
+	CreatedAt            *time.Time             `db:"created_at"`
+	UpdatedAt            *time.Time             `db:"updated_at"`
+
+	// RoomCharges are the room nights written to the folio together with the booking
+	RoomCharges []*FolioChargeEntity `db:"-"`
+	// CardToken is only used to authorize the booking on the payment gateway, it is never stored
+	CardToken string `db:"-"`
+	// FromStatus is the status a status change, or a reschedule, expects the booking to still be in
+	FromStatus constant.BookingStatus `db:"from_status"`
 }
 
 type BookingDetail struct {
 	BookingEntity
 	GuestEntity
-	RoomEntity
+	RoomEntity `db:"room"`
 }
 
 type BookingRequest struct {
@@ -34,6 +50,7 @@ type BookingRequest struct {
 	CheckOut        string `json:"check_out" validate:"required"`
 	PaymentMethod   int    `json:"payment_method" validate:"required"`
 	AdditionRequest string `json:"additional_request,omitempty"`
+	CardToken       string `json:"card_token,omitempty"`
 }
 
 type BookingResponse struct {
@@ -50,17 +67,24 @@ type BookingResponse struct {
 	PaidAmount        float64 `json:"paid_amount"`
 	PaymentMethod     string  `json:"payment_method"`
 	PaymentStatus     string  `json:"payment_status"`
+	Status            string  `json:"status"`
 	AdditionalRequest *string `json:"additional_request"`
+	RefundAmount      float64 `json:"refund_amount"`
+	CheckedInAt       *string `json:"checked_in_at"`
+	CheckedOutAt      *string `json:"checked_out_at"`
 	CreatedAt         string  `json:"created_at"`
 }
 
-type UpdatePaymentRequest struct {
-	RegisterNumber string `json:"register_number" validate:"required"`
-	PaymentStatus  int    `json:"payment_status" validate:"required"`
-}
-
 type ResceduleRequest struct {
 	RegisterNumber string `json:"register_number" validate:"required"`
 	CheckIn        string `json:"check_in" validate:"required"`
 	CheckOut       string `json:"check_out" validate:"required"`
 }
+
+type CancelBookingResponse struct {
+	RegisterNumber string  `json:"register_number"`
+	PaidAmount     float64 `json:"paid_amount"`
+	PenaltyAmount  float64 `json:"penalty_amount"`
+	RefundAmount   float64 `json:"refund_amount"`
+	PaymentStatus  string  `json:"payment_status"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConflictError is returned when a request is not allowed in the current state of a resource.
+type ConflictError struct {
+	Message string
+}
+
+func NewConflictError(format string, args ...interface{}) error {
+	return &ConflictError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// ValidationError is returned when a request breaks a business rule, e.g. a stay shorter than the minimum.
+type ValidationError struct {
+	Message string
+}
+
+func NewValidationError(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// UnauthorizedError is returned when a caller can not prove who it is, e.g. a webhook with a bad signature.
+type UnauthorizedError struct {
+	Message string
+}
+
+func NewUnauthorizedError(format string, args ...interface{}) error {
+	return &UnauthorizedError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+// TooManyRequestsError is returned when a caller has to wait RetryAfter before trying again.
+type TooManyRequestsError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func NewTooManyRequestsError(retryAfter time.Duration, format string, args ...interface{}) error {
+	return &TooManyRequestsError{Message: fmt.Sprintf(format, args...), RetryAfter: retryAfter}
+}
+
+func (e *TooManyRequestsError) Error() string {
+	return e.Message
+}
+
+// NotFoundError is returned when the resource a request points at does not exist.
+type NotFoundError struct {
+	Message string
+}
+
+func NewNotFoundError(format string, args ...interface{}) error {
+	return &NotFoundError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
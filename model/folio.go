@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+type FolioChargeEntity struct {
+	ID             int                      `db:"id"`
+	RegisterNumber string                   `db:"register_number"`
+	ChargeType     constant.FolioChargeType `db:"charge_type"`
+	Description    string                   `db:"description"`
+	ChargeDate     time.Time                `db:"charge_date"`
+	Quantity       int                      `db:"quantity"`
+	UnitPrice      float64                  `db:"unit_price"`
+	Amount         float64                  `db:"amount"`
+	PostedBy       *int                     `db:"posted_by"`
+	CreatedAt      *time.Time               `db:"created_at"`
+}
+
+type FolioChargeResponse struct {
+	ID            int     `json:"id"`
+	ChargeType    string  `json:"charge_type"`
+	Description   string  `json:"description"`
+	ChargeDate    string  `json:"charge_date"`
+	Quantity      int     `json:"quantity"`
+	UnitPrice     float64 `json:"unit_price"`
+	Amount        float64 `json:"amount"`
+	ServiceCharge float64 `json:"service_charge"`
+	Tax           float64 `json:"tax"`
+	Total         float64 `json:"total"`
+	Balance       float64 `json:"balance"`
+	PostedBy      *int    `json:"posted_by"`
+}
+
+type FolioResponse struct {
+	RegisterNumber string                 `json:"register_number"`
+	GuestName      string                 `json:"guest_name"`
+	RoomName       string                 `json:"room_name"`
+	CheckIn        string                 `json:"check_in"`
+	CheckOut       string                 `json:"check_out"`
+	Status         string                 `json:"status"`
+	Charges        []*FolioChargeResponse `json:"charges"`
+	Subtotal       float64                `json:"subtotal"`
+	ServiceCharge  float64                `json:"service_charge"`
+	Tax            float64                `json:"tax"`
+	Total          float64                `json:"total"`
+	Paid           float64                `json:"paid"`
+	Balance        float64                `json:"balance"`
+}
+
+type PostFolioChargeRequest struct {
+	ChargeType  int     `json:"charge_type" validate:"required"`
+	Description string  `json:"description" validate:"required"`
+	Quantity    int     `json:"quantity,omitempty" validate:"omitempty,gte=1"`
+	UnitPrice   float64 `json:"unit_price" validate:"required,gt=0"`
+	ChargeDate  string  `json:"charge_date,omitempty"`
+}
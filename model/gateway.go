@@ -0,0 +1,23 @@
+package model
+
+import "github.com/zakiyalmaya/hotel-management/constant"
+
+// GatewayTransaction is the outcome of an operation on the payment gateway. Refunds get
+// their own ID, every other operation keeps the ID of the authorization.
+type GatewayTransaction struct {
+	ID      string
+	OrderID string
+	Amount  float64
+	Status  constant.GatewayStatus
+}
+
+// GatewayEvent is the body of a webhook callback. OrderID is the register number the
+// authorization was made for and Reference the ID of the transaction that moved the money.
+type GatewayEvent struct {
+	ID            string                    `json:"id"`
+	Type          constant.GatewayEventType `json:"type"`
+	OrderID       string                    `json:"order_id"`
+	TransactionID string                    `json:"transaction_id"`
+	Reference     string                    `json:"reference"`
+	Amount        float64                   `json:"amount"`
+}
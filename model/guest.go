@@ -3,15 +3,17 @@ package model
 import "time"
 
 type GuestEntity struct {
-	ID          int        `db:"id"`
-	FirstName   string     `db:"first_name"`
-	LastName    string     `db:"last_name"`
-	Identity    string     `db:"identity_number"`
-	DateOfBirth time.Time  `db:"date_of_birth"`
-	PhoneNumber string     `db:"phone_number"`
-	Email       string     `db:"email"`
-	CreatedAt   *time.Time `db:"created_at"`
-	UpdatedAt   *time.Time `db:"updated_at"`
+	ID           int        `db:"id"`
+	FirstName    string     `db:"first_name"`
+	LastName     string     `db:"last_name"`
+	Identity     string     `db:"identity_number"`
+	DateOfBirth  time.Time  `db:"date_of_birth"`
+	PhoneNumber  string     `db:"phone_number"`
+	Email        string     `db:"email"`
+	CreatedAt    *time.Time `db:"created_at"`
+	UpdatedAt    *time.Time `db:"updated_at"`
+	AnonymizedAt *time.Time `db:"anonymized_at"`
+	AnonymizedBy *int       `db:"anonymized_by"`
 }
 
 type GuestResponse struct {
@@ -31,3 +33,42 @@ type CreateGuestRequest struct {
 	PhoneNumber string `json:"phone_number" validate:"required"`
 	Email       string `json:"email" validate:"required"`
 }
+
+// SearchGuestRequest filters guests, every term given must match. The name
+// matches guests whose first or last name starts with each word of it. The
+// other terms are stored encrypted, they match only in full, ignoring case.
+type SearchGuestRequest struct {
+	Name        *string `json:"name,omitempty"`
+	PhoneNumber *string `json:"phone_number,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Identity    *string `json:"identity_number,omitempty"`
+}
+
+type UpdateGuestRequest struct {
+	FirstName   *string `json:"first_name,omitempty" validate:"omitempty,min=1,max=255"`
+	LastName    *string `json:"last_name,omitempty" validate:"omitempty,min=1,max=255"`
+	PhoneNumber *string `json:"phone_number,omitempty" validate:"omitempty,min=6,max=20"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+// AnonymizeInactiveResponse sums up a run of the retention job. Processed guests are the ones
+// found without a stay in the retention period, Failed holds the ids of those that could not
+// be anonymized, they are tried again on the next run.
+type AnonymizeInactiveResponse struct {
+	Processed  int   `json:"processed"`
+	Anonymized int   `json:"anonymized"`
+	Failed     []int `json:"failed"`
+}
+
+// GuestSummaryResponse sums up the stays of a guest so regulars are recognised at check in.
+// Stays are bookings the guest arrived for, total spent is what they paid less refunds.
+type GuestSummaryResponse struct {
+	GuestID       int     `json:"guest_id"`
+	Name          string  `json:"name"`
+	TotalStays    int     `json:"total_stays"`
+	TotalNights   int     `json:"total_nights"`
+	TotalSpent    float64 `json:"total_spent"`
+	LastStay      *string `json:"last_stay"`
+	Cancellations int     `json:"cancellations"`
+	NoShows       int     `json:"no_shows"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+// GuestDuplicateEntity suggests that two guests are the same person. GuestID is the older
+// guest of the pair, Reasons lists the details that matched separated by commas.
+type GuestDuplicateEntity struct {
+	ID               int                           `db:"id"`
+	GuestID          int                           `db:"guest_id"`
+	DuplicateGuestID int                           `db:"duplicate_guest_id"`
+	Score            float64                       `db:"score"`
+	Reasons          string                        `db:"reasons"`
+	Status           constant.GuestDuplicateStatus `db:"status"`
+	ReviewedBy       *int                          `db:"reviewed_by"`
+	ReviewedAt       *time.Time                    `db:"reviewed_at"`
+	CreatedAt        *time.Time                    `db:"created_at"`
+	UpdatedAt        *time.Time                    `db:"updated_at"`
+}
+
+// GuestMergeEntity is the audit record of a merge. The merged guest is removed,
+// MergedGuest keeps it as JSON so the merge can be traced back.
+type GuestMergeEntity struct {
+	ID               int        `db:"id"`
+	DuplicateID      int        `db:"duplicate_id"`
+	SurvivingGuestID int        `db:"surviving_guest_id"`
+	MergedGuestID    int        `db:"merged_guest_id"`
+	MergedGuest      string     `db:"merged_guest"`
+	BookingsMoved    int        `db:"bookings_moved"`
+	MergedBy         int        `db:"merged_by"`
+	CreatedAt        *time.Time `db:"created_at"`
+}
+
+type GuestDuplicateResponse struct {
+	ID             int            `json:"id"`
+	Score          float64        `json:"score"`
+	Reasons        []string       `json:"reasons"`
+	Guest          *GuestResponse `json:"guest"`
+	DuplicateGuest *GuestResponse `json:"duplicate_guest"`
+	CreatedAt      string         `json:"created_at"`
+}
+
+type MergeGuestRequest struct {
+	SurvivingGuestID int `json:"surviving_guest_id" validate:"required"`
+}
+
+type GuestMergeResponse struct {
+	SurvivingGuestID int `json:"surviving_guest_id"`
+	MergedGuestID    int `json:"merged_guest_id"`
+	BookingsMoved    int `json:"bookings_moved"`
+}
@@ -0,0 +1,56 @@
+package model
+
+import "github.com/zakiyalmaya/hotel-management/constant"
+
+// GuestExportResponse is everything the hotel keeps about a guest, handed over when
+// the guest asks for their data.
+type GuestExportResponse struct {
+	ExportedAt string                `json:"exported_at"`
+	Guest      *GuestExportProfile   `json:"guest"`
+	Bookings   []*GuestExportBooking `json:"bookings"`
+}
+
+type GuestExportProfile struct {
+	ID           int     `json:"id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	Identity     string  `json:"identity_number"`
+	DateOfBirth  string  `json:"date_of_birth"`
+	PhoneNumber  string  `json:"phone_number"`
+	Email        string  `json:"email"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+	AnonymizedAt *string `json:"anonymized_at"`
+}
+
+type GuestExportBooking struct {
+	RegisterNumber    string               `json:"register_number"`
+	RoomName          string               `json:"room_name"`
+	CheckIn           string               `json:"check_in"`
+	CheckOut          string               `json:"check_out"`
+	PaidAmount        float64              `json:"paid_amount"`
+	PaymentMethod     string               `json:"payment_method"`
+	PaymentStatus     string               `json:"payment_status"`
+	Status            string               `json:"status"`
+	AdditionalRequest *string              `json:"additional_request"`
+	RefundAmount      float64              `json:"refund_amount"`
+	CreatedAt         string               `json:"created_at"`
+	Payments          []*PaymentResponse   `json:"payments"`
+	Charges           []*GuestExportCharge `json:"charges"`
+}
+
+type GuestExportCharge struct {
+	ChargeType  string  `json:"charge_type"`
+	Description string  `json:"description"`
+	ChargeDate  string  `json:"charge_date"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// GuestExportDocument is the export of a guest ready to be sent to the client.
+type GuestExportDocument struct {
+	GuestID int
+	Format  constant.GuestExportFormat
+	Content []byte
+}
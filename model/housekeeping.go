@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+type HousekeepingTaskEntity struct {
+	ID             int                             `db:"id"`
+	RoomName       string                          `db:"room_name"`
+	RegisterNumber *string                         `db:"register_number"`
+	Status         constant.HousekeepingTaskStatus `db:"status"`
+	AssignedTo     *int                            `db:"assigned_to"`
+	ClaimedAt      *time.Time                      `db:"claimed_at"`
+	CompletedAt    *time.Time                      `db:"completed_at"`
+	InspectedBy    *int                            `db:"inspected_by"`
+	InspectedAt    *time.Time                      `db:"inspected_at"`
+	Notes          *string                         `db:"notes"`
+	CreatedAt      *time.Time                      `db:"created_at"`
+	UpdatedAt      *time.Time                      `db:"updated_at"`
+}
+
+type HousekeepingTaskResponse struct {
+	ID             int     `json:"id"`
+	RoomName       string  `json:"room_name"`
+	RegisterNumber *string `json:"register_number"`
+	Status         string  `json:"status"`
+	AssignedTo     *int    `json:"assigned_to"`
+	ClaimedAt      *string `json:"claimed_at"`
+	CompletedAt    *string `json:"completed_at"`
+	InspectedBy    *int    `json:"inspected_by"`
+	InspectedAt    *string `json:"inspected_at"`
+	Notes          *string `json:"notes"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type GetAllHousekeepingTaskRequest struct {
+	RoomName *string `json:"room_name,omitempty"`
+	Status   *string `json:"status,omitempty"`
+}
+
+type CompleteHousekeepingTaskRequest struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
+type InspectHousekeepingTaskRequest struct {
+	Passed *bool   `json:"passed" validate:"required"`
+	Notes  *string `json:"notes,omitempty"`
+}
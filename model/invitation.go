@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+// InvitationEntity lets the person at Email register once with Role until ExpiresAt.
+// Only the hash of the token is stored, the token itself is handed out when it is created.
+type InvitationEntity struct {
+	ID         int           `db:"id"`
+	TokenHash  string        `db:"token_hash"`
+	Email      string        `db:"email"`
+	Role       constant.Role `db:"role"`
+	ExpiresAt  time.Time     `db:"expires_at"`
+	CreatedBy  int           `db:"created_by"`
+	AcceptedAt *time.Time    `db:"accepted_at"`
+	AcceptedBy *int          `db:"accepted_by"`
+	RevokedAt  *time.Time    `db:"revoked_at"`
+	RevokedBy  *int          `db:"revoked_by"`
+	CreatedAt  *time.Time    `db:"created_at"`
+}
+
+type CreateInvitationRequest struct {
+	Email          string `json:"email" validate:"required,email"`
+	Role           int    `json:"role" validate:"required"`
+	ExpiresInHours int    `json:"expires_in_hours,omitempty" validate:"omitempty,gte=1"`
+}
+
+type InvitationResponse struct {
+	ID        int    `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Token     string `json:"token,omitempty"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedBy int    `json:"created_by"`
+}
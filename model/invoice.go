@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+// InvoiceEntity holds the number given to the invoice of a booking. The number is issued
+// once per booking and stays the same every time the invoice is printed again.
+type InvoiceEntity struct {
+	ID             int        `db:"id"`
+	Sequence       int        `db:"sequence"`
+	InvoiceNumber  string     `db:"invoice_number"`
+	RegisterNumber string     `db:"register_number"`
+	IssuedBy       *int       `db:"issued_by"`
+	IssuedAt       time.Time  `db:"issued_at"`
+	CreatedAt      *time.Time `db:"created_at"`
+}
+
+type InvoiceResponse struct {
+	InvoiceNumber           string                 `json:"invoice_number"`
+	IssuedAt                string                 `json:"issued_at"`
+	HotelName               string                 `json:"hotel_name"`
+	HotelAddress            string                 `json:"hotel_address"`
+	HotelPhone              string                 `json:"hotel_phone"`
+	HotelEmail              string                 `json:"hotel_email"`
+	RegisterNumber          string                 `json:"register_number"`
+	GuestName               string                 `json:"guest_name"`
+	RoomName                string                 `json:"room_name"`
+	RoomType                string                 `json:"room_type"`
+	CheckIn                 string                 `json:"check_in"`
+	CheckOut                string                 `json:"check_out"`
+	Nights                  int                    `json:"nights"`
+	Charges                 []*FolioChargeResponse `json:"charges"`
+	Subtotal                float64                `json:"subtotal"`
+	ServiceChargePercentage float64                `json:"service_charge_percentage"`
+	ServiceCharge           float64                `json:"service_charge"`
+	TaxPercentage           float64                `json:"tax_percentage"`
+	Tax                     float64                `json:"tax"`
+	Total                   float64                `json:"total"`
+	Payments                []*PaymentResponse     `json:"payments"`
+	Paid                    float64                `json:"paid"`
+	Balance                 float64                `json:"balance"`
+}
+
+// InvoiceDocument is a rendered invoice ready to be sent to the client.
+type InvoiceDocument struct {
+	InvoiceNumber string
+	Format        constant.InvoiceFormat
+	Content       []byte
+}
@@ -0,0 +1,8 @@
+package model
+
+// MailMessage is a plain text email to a single recipient.
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
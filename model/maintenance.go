@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+type MaintenanceOrderEntity struct {
+	ID                 int                          `db:"id"`
+	RoomName           string                       `db:"room_name"`
+	Issue              string                       `db:"issue"`
+	Priority           constant.MaintenancePriority `db:"priority"`
+	Status             constant.MaintenanceStatus   `db:"status"`
+	ReportedBy         int                          `db:"reported_by"`
+	AssignedTo         *int                         `db:"assigned_to"`
+	StartDate          time.Time                    `db:"start_date"`
+	ExpectedCompletion time.Time                    `db:"expected_completion"`
+	Resolution         *string                      `db:"resolution"`
+	ClosedBy           *int                         `db:"closed_by"`
+	ClosedAt           *time.Time                   `db:"closed_at"`
+	CreatedAt          *time.Time                   `db:"created_at"`
+	UpdatedAt          *time.Time                   `db:"updated_at"`
+}
+
+type MaintenanceOrderResponse struct {
+	ID                 int     `json:"id"`
+	RoomName           string  `json:"room_name"`
+	Issue              string  `json:"issue"`
+	Priority           string  `json:"priority"`
+	Status             string  `json:"status"`
+	ReportedBy         int     `json:"reported_by"`
+	AssignedTo         *int    `json:"assigned_to"`
+	StartDate          string  `json:"start_date"`
+	ExpectedCompletion string  `json:"expected_completion"`
+	Resolution         *string `json:"resolution"`
+	ClosedBy           *int    `json:"closed_by"`
+	ClosedAt           *string `json:"closed_at"`
+	CreatedAt          string  `json:"created_at"`
+}
+
+type CreateMaintenanceOrderRequest struct {
+	RoomName           string `json:"room_name" validate:"required"`
+	Issue              string `json:"issue" validate:"required"`
+	Priority           int    `json:"priority" validate:"required"`
+	AssignedTo         *int   `json:"assigned_to,omitempty"`
+	StartDate          string `json:"start_date,omitempty"`
+	ExpectedCompletion string `json:"expected_completion" validate:"required"`
+}
+
+type UpdateMaintenanceOrderRequest struct {
+	Priority           *int    `json:"priority,omitempty"`
+	AssignedTo         *int    `json:"assigned_to,omitempty"`
+	ExpectedCompletion *string `json:"expected_completion,omitempty"`
+}
+
+type CloseMaintenanceOrderRequest struct {
+	Resolution string `json:"resolution" validate:"required"`
+}
+
+type GetAllMaintenanceOrderRequest struct {
+	RoomName *string `json:"room_name,omitempty"`
+	Status   *string `json:"status,omitempty"`
+}
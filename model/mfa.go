@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+// MfaPolicyEntity tells whether hoteliers with Role must use two-factor authentication.
+type MfaPolicyEntity struct {
+	Role      constant.Role `db:"role"`
+	Required  bool          `db:"required"`
+	UpdatedBy *int          `db:"updated_by"`
+	UpdatedAt *time.Time    `db:"updated_at"`
+}
+
+// MfaChallengeEntity is the second login step, kept in Redis as JSON after the password
+// was right. Enroll is set when the hotelier has to enrol an authenticator app first.
+type MfaChallengeEntity struct {
+	TokenHash string    `json:"token_hash"`
+	UserID    int       `json:"user_id"`
+	Device    string    `json:"device"`
+	Enroll    bool      `json:"enroll"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type MfaEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MfaCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// MfaLoginRequest finishes a login with a code of the authenticator app or, when the
+// app is lost, one of the recovery codes.
+type MfaLoginRequest struct {
+	MfaToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code"`
+	IP           string `json:"-"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MfaPolicyRequest struct {
+	Required *bool `json:"required" validate:"required"`
+}
+
+type MfaPolicyResponse struct {
+	Role     string `json:"role"`
+	Required bool   `json:"required"`
+}
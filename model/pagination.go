@@ -0,0 +1,30 @@
+package model
+
+// PaginationRequest is the page of a list to return, pages count from 1.
+type PaginationRequest struct {
+	Page  int `validate:"gte=1"`
+	Limit int `validate:"gte=1,lte=100"`
+}
+
+func (p *PaginationRequest) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// PaginatedResponse is a page of a list with what is needed to ask for the other pages.
+type PaginatedResponse struct {
+	Items      interface{} `json:"items"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	Total      int         `json:"total"`
+	TotalPages int         `json:"total_pages"`
+}
+
+func NewPaginatedResponse(items interface{}, pagination *PaginationRequest, total int) *PaginatedResponse {
+	return &PaginatedResponse{
+		Items:      items,
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		Total:      total,
+		TotalPages: (total + pagination.Limit - 1) / pagination.Limit,
+	}
+}
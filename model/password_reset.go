@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// PasswordResetEntity lets a hotelier who forgot their password choose a new one once
+// until ExpiresAt. Only the hash of the token is stored, the token itself is emailed.
+type PasswordResetEntity struct {
+	ID        int        `db:"id"`
+	UserID    int        `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt *time.Time `db:"created_at"`
+}
+
+type ForgotPasswordRequest struct {
+	Username string `json:"username" validate:"required_without=Email"`
+	Email    string `json:"email" validate:"required_without=Username,omitempty,email"`
+	IP       string `json:"-"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6,max=100"`
+}
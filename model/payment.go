@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+// PaymentEntity is one money movement on a booking, refunds are stored with a negative amount.
+type PaymentEntity struct {
+	ID               int                    `db:"id"`
+	RegisterNumber   string                 `db:"register_number"`
+	Amount           float64                `db:"amount"`
+	PaymentMethod    constant.PaymentMethod `db:"payment_method"`
+	Reference        *string                `db:"reference"`
+	PaidAt           time.Time              `db:"paid_at"`
+	ReceivedBy       *int                   `db:"received_by"`
+	GatewayReference *string                `db:"gateway_reference"`
+	CreatedAt        *time.Time             `db:"created_at"`
+}
+
+type PaymentResponse struct {
+	ID               int     `json:"id"`
+	Amount           float64 `json:"amount"`
+	PaymentMethod    string  `json:"payment_method"`
+	Reference        *string `json:"reference"`
+	PaidAt           string  `json:"paid_at"`
+	ReceivedBy       *int    `json:"received_by"`
+	GatewayReference *string `json:"gateway_reference"`
+}
+
+type PaymentSummaryResponse struct {
+	RegisterNumber string             `json:"register_number"`
+	Total          float64            `json:"total"`
+	Paid           float64            `json:"paid"`
+	Balance        float64            `json:"balance"`
+	PaymentStatus  string             `json:"payment_status"`
+	Payments       []*PaymentResponse `json:"payments"`
+}
+
+type RecordPaymentRequest struct {
+	Amount        float64 `json:"amount" validate:"required,gt=0"`
+	PaymentMethod int     `json:"payment_method" validate:"required"`
+	Reference     string  `json:"reference,omitempty"`
+}
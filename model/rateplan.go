@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+type RatePlanEntity struct {
+	ID               int        `db:"id"`
+	RoomType         string     `db:"room_type"`
+	BaseRate         float64    `db:"base_rate"`
+	WeekendSurcharge float64    `db:"weekend_surcharge"`
+	MinStay          int        `db:"min_stay"`
+	CreatedAt        *time.Time `db:"created_at"`
+	UpdatedAt        *time.Time `db:"updated_at"`
+}
+
+type SeasonalRateEntity struct {
+	ID        int        `db:"id"`
+	RoomType  string     `db:"room_type"`
+	Name      string     `db:"name"`
+	StartDate time.Time  `db:"start_date"`
+	EndDate   time.Time  `db:"end_date"`
+	Rate      float64    `db:"rate"`
+	MinStay   int        `db:"min_stay"`
+	CreatedAt *time.Time `db:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+type RatePlanResponse struct {
+	ID               int     `json:"id"`
+	RoomType         string  `json:"room_type"`
+	BaseRate         float64 `json:"base_rate"`
+	WeekendSurcharge float64 `json:"weekend_surcharge"`
+	MinStay          int     `json:"min_stay"`
+}
+
+type SeasonalRateResponse struct {
+	ID        int     `json:"id"`
+	RoomType  string  `json:"room_type"`
+	Name      string  `json:"name"`
+	StartDate string  `json:"start_date"`
+	EndDate   string  `json:"end_date"`
+	Rate      float64 `json:"rate"`
+	MinStay   int     `json:"min_stay"`
+}
+
+type CreateRatePlanRequest struct {
+	RoomType         string  `json:"room_type" validate:"required"`
+	BaseRate         float64 `json:"base_rate" validate:"required,gt=0"`
+	WeekendSurcharge float64 `json:"weekend_surcharge,omitempty" validate:"omitempty,gte=0"`
+	MinStay          int     `json:"min_stay,omitempty" validate:"omitempty,gte=1"`
+}
+
+type UpdateRatePlanRequest struct {
+	BaseRate         *float64 `json:"base_rate,omitempty" validate:"omitempty,gt=0"`
+	WeekendSurcharge *float64 `json:"weekend_surcharge,omitempty" validate:"omitempty,gte=0"`
+	MinStay          *int     `json:"min_stay,omitempty" validate:"omitempty,gte=1"`
+}
+
+type CreateSeasonalRateRequest struct {
+	RoomType  string  `json:"room_type" validate:"required"`
+	Name      string  `json:"name" validate:"required"`
+	StartDate string  `json:"start_date" validate:"required"`
+	EndDate   string  `json:"end_date" validate:"required"`
+	Rate      float64 `json:"rate" validate:"required,gt=0"`
+	MinStay   int     `json:"min_stay,omitempty" validate:"omitempty,gte=1"`
+}
+
+type PriceQuoteRequest struct {
+	RoomName string
+	CheckIn  time.Time
+	CheckOut time.Time
+}
+
+type NightlyRateResponse struct {
+	Date    string  `json:"date"`
+	Rate    float64 `json:"rate"`
+	Season  *string `json:"season"`
+	Weekend bool    `json:"weekend"`
+}
+
+type PriceQuoteResponse struct {
+	RoomName  string                 `json:"room_name"`
+	RoomType  string                 `json:"room_type"`
+	CheckIn   string                 `json:"check_in"`
+	CheckOut  string                 `json:"check_out"`
+	Nights    int                    `json:"nights"`
+	Total     float64                `json:"total"`
+	Breakdown []*NightlyRateResponse `json:"breakdown"`
+}
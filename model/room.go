@@ -13,6 +13,7 @@ type RoomEntity struct {
 	Type        string              `db:"type"`
 	Price       float64             `db:"price"`
 	Status      constant.RoomStatus `db:"status"`
+	Capacity    int                 `db:"capacity"`
 	Description *string             `db:"description"`
 	CreatedAt   *time.Time          `db:"created_at"`
 	UpdatedAt   *time.Time          `db:"updated_at"`
@@ -25,7 +26,10 @@ type RoomResponse struct {
 	Type        string  `json:"type"`
 	Price       float64 `json:"price"`
 	Status      string  `json:"status"`
+	Capacity    int     `json:"capacity"`
 	Description *string `json:"description"`
+	// StayPrice is the quoted price of the whole stay, only the room search fills it
+	StayPrice *float64 `json:"stay_price,omitempty"`
 }
 
 type RoomsResponse struct {
@@ -38,6 +42,7 @@ type CreateRoomRequest struct {
 	Type        string  `json:"type" validate:"required"`
 	Price       float64 `json:"price" validate:"required"`
 	Status      int     `json:"status" validate:"required"`
+	Capacity    int     `json:"capacity,omitempty" validate:"omitempty,gt=0"`
 	Description *string `json:"description,omitempty"`
 }
 
@@ -51,5 +56,16 @@ type UpdateRoomRequest struct {
 	Floor       *int     `json:"floor,omitempty"`
 	Status      *int     `json:"status,omitempty"`
 	Price       *float64 `json:"price,omitempty"`
+	Capacity    *int     `json:"capacity,omitempty" validate:"omitempty,gt=0"`
 	Description *string  `json:"description,omitempty"`
 }
+
+type SearchRoomRequest struct {
+	CheckIn  time.Time
+	CheckOut time.Time
+	Guests   int
+	Type     *string
+	Floor    *int
+	MinPrice *float64
+	MaxPrice *float64
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// SessionEntity is a login of a hotelier on one device, kept in Redis as JSON. Only the hash
+// of the current refresh token is stored, every refresh replaces it with the hash of a new one.
+type SessionEntity struct {
+	ID               string    `json:"id"`
+	UserID           int       `json:"user_id"`
+	Device           string    `json:"device"`
+	IP               string    `json:"ip"`
+	RefreshTokenHash string    `json:"refresh_token_hash"`
+	CreatedAt        time.Time `json:"created_at"`
+	LastSeenAt       time.Time `json:"last_seen_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	IP           string `json:"-"`
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	Device     string `json:"device"`
+	IP         string `json:"ip"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"`
+}
@@ -1,22 +1,44 @@
 package model
 
-import "time"
+import (
+	"time"
 
+	"github.com/zakiyalmaya/hotel-management/constant"
+)
+
+// UserEntity is a hotelier. TOTPSecret is set when two-factor authentication is enrolled and
+// only used at login once TOTPEnabled, TOTPLastStep is the step of the last code accepted.
+// A deactivated hotelier can not log in but is kept, so the records they made still point at them.
 type UserEntity struct {
-	ID        int        `db:"id"`
-	Name      string     `db:"name"`
-	Username  string     `db:"username"`
-	Password  string     `db:"password"`
-	Email     string     `db:"email"`
-	CreatedAt *time.Time `db:"created_at"`
-	UpdatedAt *time.Time `db:"updated_at"`
+	ID            int           `db:"id"`
+	Name          string        `db:"name"`
+	Username      string        `db:"username"`
+	Password      string        `db:"password"`
+	Email         string        `db:"email"`
+	Role          constant.Role `db:"role"`
+	TOTPSecret    *string       `db:"totp_secret"`
+	TOTPEnabled   bool          `db:"totp_enabled"`
+	TOTPLastStep  int64         `db:"totp_last_step"`
+	DeactivatedAt *time.Time    `db:"deactivated_at"`
+	DeactivatedBy *int          `db:"deactivated_by"`
+	CreatedAt     *time.Time    `db:"created_at"`
+	UpdatedAt     *time.Time    `db:"updated_at"`
 }
 
 type CreateUserRequest struct {
-	Name     string `json:"name" validate:"required,min=3,max=100"`
-	Username string `json:"username" validate:"required,min=3,max=100"`
-	Password string `json:"password" validate:"required,min=6,max=100"`
-	Email    string `json:"email" validate:"required,email"`
+	Name            string `json:"name" validate:"required,min=3,max=100"`
+	Username        string `json:"username" validate:"required,min=3,max=100"`
+	Password        string `json:"password" validate:"required,min=6,max=100"`
+	Email           string `json:"email" validate:"required,email"`
+	InvitationToken string `json:"invitation_token" validate:"required"`
+}
+
+// CreateAdminRequest bootstraps the first admin from the command line, where there is no invitation.
+type CreateAdminRequest struct {
+	Name     string `validate:"required,min=3,max=100"`
+	Username string `validate:"required,min=3,max=100"`
+	Password string `validate:"required,min=6,max=100"`
+	Email    string `validate:"required,email"`
 }
 
 type ChangePasswordRequest struct {
@@ -24,3 +46,26 @@ type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" validate:"required,min=6,max=100"`
 	NewPassword string `json:"new_password" validate:"required,min=6,max=100"`
 }
+
+type UpdateRoleRequest struct {
+	Role int `json:"role" validate:"required"`
+}
+
+// UpdateUserRequest changes the fields that are set and keeps the others.
+type UpdateUserRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=3,max=100"`
+	Email *string `json:"email" validate:"omitempty,email"`
+	Role  *int    `json:"role"`
+}
+
+type UserResponse struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	Role          string `json:"role"`
+	MfaEnabled    bool   `json:"mfa_enabled"`
+	Active        bool   `json:"active"`
+	DeactivatedAt string `json:"deactivated_at,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
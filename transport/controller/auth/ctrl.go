@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"math"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/zakiyalmaya/hotel-management/application/auth"
 	"github.com/zakiyalmaya/hotel-management/model"
@@ -28,17 +31,48 @@ func (a *AuthController) Login(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
 	}
 
+	authReq.IP = ctx.IP()
+	if authReq.Device == "" {
+		authReq.Device = ctx.Get(fiber.HeaderUserAgent)
+	}
+
 	authRes, err := a.authSvc.Login(&authReq)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+		if tooManyRequestsErr, ok := err.(*model.TooManyRequestsError); ok {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(tooManyRequestsErr.RetryAfter.Seconds()))))
+		}
+
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", authRes))
+}
+
+func (a *AuthController) VerifyMfa(ctx *fiber.Ctx) error {
+	mfaReq := model.MfaLoginRequest{}
+
+	if err := ctx.BodyParser(&mfaReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(mfaReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	mfaReq.IP = ctx.IP()
+	authRes, err := a.authSvc.VerifyMfa(&mfaReq)
+	if err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", authRes))
 }
 
 func (a *AuthController) Logout(ctx *fiber.Ctx) error {
-	username := ctx.Locals("username").(string)
-	if err := a.authSvc.Logout(username); err != nil {
+	sessionID := ctx.Locals("session_id").(string)
+	if err := a.authSvc.Logout(sessionID); err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
 	}
 
@@ -46,11 +80,49 @@ func (a *AuthController) Logout(ctx *fiber.Ctx) error {
 }
 
 func (a *AuthController) Refresh(ctx *fiber.Ctx) error {
-	username := ctx.Locals("username").(string)
-	refreshRes, err := a.authSvc.RefreshAuthToken(username)
+	refreshReq := model.RefreshRequest{}
+
+	if err := ctx.BodyParser(&refreshReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(refreshReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	refreshReq.IP = ctx.IP()
+	refreshRes, err := a.authSvc.RefreshAuthToken(&refreshReq)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", refreshRes))
 }
+
+func (a *AuthController) GetSessions(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(int)
+	sessionID := ctx.Locals("session_id").(string)
+	sessions, err := a.authSvc.GetSessions(userID, sessionID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", sessions))
+}
+
+func (a *AuthController) RevokeSession(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(int)
+	if err := a.authSvc.RevokeSession(userID, ctx.Params("id")); err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+// JWKS publishes the public keys of the access tokens as a plain JSON Web Key Set,
+// the format other services expect, instead of the usual response envelope.
+func (a *AuthController) JWKS(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(a.authSvc.JWKS())
+}
@@ -0,0 +1,54 @@
+package availability
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/availability"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+type AvailabilityController struct {
+	availabilitySvc availability.AvailabilityService
+}
+
+func NewAvailabilityController(availabilitySvc availability.AvailabilityService) *AvailabilityController {
+	return &AvailabilityController{availabilitySvc: availabilitySvc}
+}
+
+func (c *AvailabilityController) IsAvailable(ctx *fiber.Ctx) error {
+	roomName := ctx.Query("room_name")
+	if roomName == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "room name is required", nil))
+	}
+
+	parseCheckIn, err := time.Parse("02-01-2006", ctx.Query("check_in"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	parseCheckOut, err := time.Parse("02-01-2006", ctx.Query("check_out"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if !parseCheckOut.After(parseCheckIn) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "check out date must be greater than check in date", nil))
+	}
+
+	available, err := c.availabilitySvc.IsAvailable(&model.AvailabilityRequest{
+		RoomName: roomName,
+		CheckIn:  parseCheckIn,
+		CheckOut: parseCheckOut,
+	})
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", &model.AvailabilityResponse{
+		RoomName:  roomName,
+		CheckIn:   parseCheckIn.Format("02 January 2006"),
+		CheckOut:  parseCheckOut.Format("02 January 2006"),
+		Available: available,
+	}))
+}
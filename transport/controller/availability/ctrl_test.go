@@ -0,0 +1,72 @@
+package availability
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+)
+
+func Test_IsAvailable(t *testing.T) {
+	app := fiber.New()
+	mockAvailability := new(mocks.AvailabilityService)
+
+	testCases := []struct {
+		name       string
+		request    string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when check availability then return success response",
+			request: "room_name=101&check_in=01-12-2000&check_out=03-12-2000",
+			mockCall: func() {
+				mockAvailability.On("IsAvailable", mock.Anything).Return(true, nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:    "Given error when check availability then return error response",
+			request: "room_name=101&check_in=01-12-2000&check_out=03-12-2000",
+			mockCall: func() {
+				mockAvailability.On("IsAvailable", mock.Anything).Return(false, errors.New("error")).Once()
+			},
+			statusCode: fiber.StatusInternalServerError,
+		},
+		{
+			name:       "Given missing room name when check availability then return error response",
+			request:    "check_in=01-12-2000&check_out=03-12-2000",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid date when check availability then return error response",
+			request:    "room_name=101&check_in=2000-12-01&check_out=03-12-2000",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given check out before check in when check availability then return error response",
+			request:    "room_name=101&check_in=03-12-2000&check_out=01-12-2000",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewAvailabilityController(mockAvailability)
+			app.Get("/availability", ctrl.IsAvailable)
+
+			req, _ := http.NewRequest("GET", "/availability?"+tc.request, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package booking
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -56,9 +57,11 @@ func (c *BookingController) Books(ctx *fiber.Ctx) error {
 		CheckOut:          parseCheckOut,
 		PaymentMethod:     constant.PaymentMethod(bookingReq.PaymentMethod),
 		PaymentStatus:     constant.PaymentStatusPending,
+		Status:            constant.BookingStatusConfirmed,
 		AdditionalRequest: &bookingReq.AdditionRequest,
+		CardToken:         bookingReq.CardToken,
 	}); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", map[string]string{"register_number": registerNumber}))
@@ -78,28 +81,18 @@ func (c *BookingController) GetByRegisterNumber(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
 }
 
-func (c *BookingController) UpdatePayment(ctx *fiber.Ctx) error {
-	updatePaymentReq := model.UpdatePaymentRequest{}
-	if err := ctx.BodyParser(&updatePaymentReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
-	}
-
-	if err := utils.Validator(updatePaymentReq); err != nil {
+func (c *BookingController) GetByGuestID(ctx *fiber.Ctx) error {
+	guestID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
 	}
 
-	if isStatusValid := constant.PaymentMethod(updatePaymentReq.PaymentStatus).Validation(); !isStatusValid {
-		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid payment status", nil))
-	}
-
-	if err := c.bookingSvc.UpdatePayment(&model.BookingEntity{
-		RegisterNumber: updatePaymentReq.RegisterNumber,
-		PaymentStatus:  constant.PaymentStatus(updatePaymentReq.PaymentStatus),
-	}); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	resp, err := c.bookingSvc.GetByGuestID(guestID)
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
 	}
 
-	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
 }
 
 func (c *BookingController) Reschedule(ctx *fiber.Ctx) error {
@@ -127,7 +120,62 @@ func (c *BookingController) Reschedule(ctx *fiber.Ctx) error {
 		CheckIn:        parseCheckIn,
 		CheckOut:       parseCheckOut,
 	}); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *BookingController) Cancel(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	resp, err := c.bookingSvc.Cancel(registerNumber)
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *BookingController) CheckIn(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	userID := ctx.Locals("user_id").(int)
+	if err := c.bookingSvc.CheckIn(registerNumber, userID); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *BookingController) CheckOut(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	userID := ctx.Locals("user_id").(int)
+	if err := c.bookingSvc.CheckOut(registerNumber, userID); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *BookingController) NoShow(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	if err := c.bookingSvc.NoShow(registerNumber); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
@@ -12,7 +12,6 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/mock"
 	"github.com/zakiyalmaya/hotel-management/application/mocks"
-	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -60,6 +59,36 @@ func Test_Books(t *testing.T) {
 			},
 			statusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "Given stay shorter than minimum when booking room then return bad request",
+			request: &model.BookingRequest{
+				GuestID:         1,
+				RoomName:        "room_name",
+				CheckIn:         "01-12-2000",
+				CheckOut:        "02-12-2000",
+				PaymentMethod:   1,
+				AdditionRequest: "additional_request",
+			},
+			mockCall: func() {
+				mockBooing.On("Books", mock.Anything).Return(model.NewValidationError("minimum stay for deluxe is 2 nights")).Once()
+			},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given room already booked for the dates when booking room then return conflict",
+			request: &model.BookingRequest{
+				GuestID:         1,
+				RoomName:        "room_name",
+				CheckIn:         "01-12-2000",
+				CheckOut:        "02-12-2000",
+				PaymentMethod:   1,
+				AdditionRequest: "additional_request",
+			},
+			mockCall: func() {
+				mockBooing.On("Books", mock.Anything).Return(model.NewConflictError("room not available")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
 		{
 			name: "Given invalid check out date format when booking room then return error",
 			request: &model.BookingRequest{
@@ -199,7 +228,7 @@ func Test_GetByRegisterNumber(t *testing.T) {
 	}
 }
 
-func Test_UpdatePayment(t *testing.T) {
+func Test_Rescedule(t *testing.T) {
 	app := fiber.New()
 	mockBooing = new(mocks.BookingService)
 
@@ -210,52 +239,84 @@ func Test_UpdatePayment(t *testing.T) {
 		statusCode int
 	}{
 		{
-			name: "Given valid request when update payment then return success",
-			request: &model.UpdatePaymentRequest{
+			name: "Given valid request when reschedule reservation then return success",
+			request: &model.ResceduleRequest{
 				RegisterNumber: "register_number",
-				PaymentStatus:  2,
+				CheckIn:        "01-12-2024",
+				CheckOut:       "02-12-2024",
 			},
 			mockCall: func() {
-				mockBooing.On("UpdatePayment", &model.BookingEntity{
+				mockBooing.On("Reschedule", &model.BookingEntity{
 					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusCompleted,
+					CheckIn:        time.Date(2024, time.December, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut:       time.Date(2024, time.December, 02, 00, 00, 00, 00, time.UTC),
 				}).Return(nil).Once()
 			},
 			statusCode: http.StatusOK,
 		},
 		{
-			name: "Given valid request when update payment then return success",
-			request: &model.UpdatePaymentRequest{
+			name: "Given error when reschedule reservation then return error",
+			request: &model.ResceduleRequest{
 				RegisterNumber: "register_number",
-				PaymentStatus:  2,
+				CheckIn:        "01-12-2024",
+				CheckOut:       "02-12-2024",
 			},
 			mockCall: func() {
-				mockBooing.On("UpdatePayment", &model.BookingEntity{
+				mockBooing.On("Reschedule", &model.BookingEntity{
 					RegisterNumber: "register_number",
-					PaymentStatus:  constant.PaymentStatusCompleted,
+					CheckIn:        time.Date(2024, time.December, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut:       time.Date(2024, time.December, 02, 00, 00, 00, 00, time.UTC),
 				}).Return(errors.New("error")).Once()
 			},
 			statusCode: http.StatusInternalServerError,
 		},
 		{
-			name: "Given missing required request when update payment then return error",
-			request: &model.UpdatePaymentRequest{
+			name: "Given room not bookable when reschedule reservation then return conflict",
+			request: &model.ResceduleRequest{
+				RegisterNumber: "register_number",
+				CheckIn:        "01-12-2024",
+				CheckOut:       "02-12-2024",
+			},
+			mockCall: func() {
+				mockBooing.On("Reschedule", &model.BookingEntity{
+					RegisterNumber: "register_number",
+					CheckIn:        time.Date(2024, time.December, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut:       time.Date(2024, time.December, 02, 00, 00, 00, 00, time.UTC),
+				}).Return(model.NewConflictError("invalid room status")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name: "Given invalid check out date when reschedule reservation then return error",
+			request: &model.ResceduleRequest{
 				RegisterNumber: "register_number",
+				CheckIn:        "01-12-2024",
+				CheckOut:       "02/12/2024",
 			},
 			mockCall:   func() {},
 			statusCode: http.StatusBadRequest,
 		},
 		{
-			name: "Given invalid payment status when update payment then return error",
-			request: &model.UpdatePaymentRequest{
+			name: "Given invalid check in date when reschedule reservation then return error",
+			request: &model.ResceduleRequest{
 				RegisterNumber: "register_number",
-				PaymentStatus:  10,
+				CheckIn:        "01/12/2024",
+				CheckOut:       "02-12-2024",
 			},
 			mockCall:   func() {},
 			statusCode: http.StatusBadRequest,
 		},
 		{
-			name:       "Given invalid request when update payment then return error",
+			name: "Given missing required request when reschedule reservation then return error",
+			request: &model.ResceduleRequest{
+				CheckIn:  "01-12-2024",
+				CheckOut: "02-12-2024",
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid request when reschedule reservation then return error",
 			request:    "a",
 			mockCall:   func() {},
 			statusCode: http.StatusBadRequest,
@@ -265,10 +326,10 @@ func Test_UpdatePayment(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := NewBookingController(mockBooing)
-			app.Put("/payment", ctrl.UpdatePayment)
+			app.Put("/reschedule", ctrl.Reschedule)
 
 			body, _ := json.Marshal(tc.request)
-			req, _ := http.NewRequest("PUT", "/payment", bytes.NewBuffer(body))
+			req, _ := http.NewRequest("PUT", "/reschedule", bytes.NewBuffer(body))
 			req.Header.Set("Content-Type", "application/json")
 			tc.mockCall()
 
@@ -278,93 +339,268 @@ func Test_UpdatePayment(t *testing.T) {
 	}
 }
 
-func Test_Rescedule(t *testing.T) {
+func Test_Cancel(t *testing.T) {
 	app := fiber.New()
 	mockBooing = new(mocks.BookingService)
 
 	testCases := []struct {
 		name       string
-		request    interface{}
+		request    string
 		mockCall   func()
 		statusCode int
 	}{
 		{
-			name: "Given valid request when reschedule reservation then return success",
-			request: &model.ResceduleRequest{
-				RegisterNumber: "register_number",
-				CheckIn:        "01-12-2024",
-				CheckOut:       "02-12-2024",
+			name:    "Given valid request when cancel booking then return success",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("Cancel", "register_number").Return(&model.CancelBookingResponse{RegisterNumber: "register_number"}, nil).Once()
 			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Given error when cancel booking then return error",
+			request: "register_number",
 			mockCall: func() {
-				mockBooing.On("Reschedule", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					CheckIn:        time.Date(2024, time.December, 01, 00, 00, 00, 00, time.UTC),
-					CheckOut:       time.Date(2024, time.December, 02, 00, 00, 00, 00, time.UTC),
-				}).Return(nil).Once()
+				mockBooing.On("Cancel", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "Given check in date passed when cancel booking then return conflict",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("Cancel", "register_number").Return(nil, model.NewConflictError("booking can not be canceled after check in date")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewBookingController(mockBooing)
+			app.Post("/booking/:register_number/cancel", ctrl.Cancel)
+
+			req, _ := http.NewRequest("POST", "/booking/"+tc.request+"/cancel", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_CheckIn(t *testing.T) {
+	app := fiber.New()
+	mockBooing = new(mocks.BookingService)
+
+	testCases := []struct {
+		name       string
+		request    string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when check in booking then return success",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("CheckIn", "register_number", 1).Return(nil).Once()
 			},
 			statusCode: http.StatusOK,
 		},
 		{
-			name: "Given error when reschedule reservation then return error",
-			request: &model.ResceduleRequest{
-				RegisterNumber: "register_number",
-				CheckIn:        "01-12-2024",
-				CheckOut:       "02-12-2024",
+			name:    "Given error when check in booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("CheckIn", "register_number", 1).Return(errors.New("error")).Once()
 			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "Given unpaid booking when check in booking then return conflict",
+			request: "register_number",
 			mockCall: func() {
-				mockBooing.On("Reschedule", &model.BookingEntity{
-					RegisterNumber: "register_number",
-					CheckIn:        time.Date(2024, time.December, 01, 00, 00, 00, 00, time.UTC),
-					CheckOut:       time.Date(2024, time.December, 02, 00, 00, 00, 00, time.UTC),
-				}).Return(errors.New("error")).Once()
+				mockBooing.On("CheckIn", "register_number", 1).Return(model.NewConflictError("booking is not paid")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:    "Given date outside the stay when check in booking then return conflict",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("CheckIn", "register_number", 1).Return(model.NewConflictError("check in is only allowed during the booking dates")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:    "Given room not ready when check in booking then return conflict",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("CheckIn", "register_number", 1).Return(model.NewConflictError("room is not ready for check in")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewBookingController(mockBooing)
+			app.Post("/booking/:register_number/checkin", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.CheckIn(ctx)
+			})
+
+			req, _ := http.NewRequest("POST", "/booking/"+tc.request+"/checkin", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_CheckOut(t *testing.T) {
+	app := fiber.New()
+	mockBooing = new(mocks.BookingService)
+
+	testCases := []struct {
+		name       string
+		request    string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when check out booking then return success",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("CheckOut", "register_number", 1).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Given error when check out booking then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("CheckOut", "register_number", 1).Return(errors.New("error")).Once()
 			},
 			statusCode: http.StatusInternalServerError,
 		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewBookingController(mockBooing)
+			app.Post("/booking/:register_number/checkout", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.CheckOut(ctx)
+			})
+
+			req, _ := http.NewRequest("POST", "/booking/"+tc.request+"/checkout", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_NoShow(t *testing.T) {
+	app := fiber.New()
+	mockBooing = new(mocks.BookingService)
+
+	testCases := []struct {
+		name       string
+		request    string
+		mockCall   func()
+		statusCode int
+	}{
 		{
-			name: "Given invalid check out date when reschedule reservation then return error",
-			request: &model.ResceduleRequest{
-				RegisterNumber: "register_number",
-				CheckIn:        "01-12-2024",
-				CheckOut:       "02/12/2024",
+			name:    "Given valid request when record no show then return success",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("NoShow", "register_number").Return(nil).Once()
 			},
-			mockCall: func() {},
-			statusCode: http.StatusBadRequest,
+			statusCode: http.StatusOK,
 		},
 		{
-			name: "Given invalid check in date when reschedule reservation then return error",
-			request: &model.ResceduleRequest{
-				RegisterNumber: "register_number",
-				CheckIn:        "01/12/2024",
-				CheckOut:       "02-12-2024",
+			name:    "Given illegal status transition when record no show then return conflict",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("NoShow", "register_number").Return(model.NewConflictError("booking can not move from Checked In to No Show")).Once()
 			},
-			mockCall: func() {},
-			statusCode: http.StatusBadRequest,
+			statusCode: http.StatusConflict,
 		},
 		{
-			name: "Given missing required request when reschedule reservation then return error",
-			request: &model.ResceduleRequest{
-				CheckIn:        "01-12-2024",
-				CheckOut:       "02-12-2024",
+			name:    "Given check in date not reached when record no show then return conflict",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("NoShow", "register_number").Return(model.NewConflictError("no show can only be recorded from the check in date")).Once()
 			},
-			mockCall: func() {},
-			statusCode: http.StatusBadRequest,
+			statusCode: http.StatusConflict,
 		},
 		{
-			name: "Given invalid request when reschedule reservation then return error",
-			request: "a",
-			mockCall: func() {},
-			statusCode: http.StatusBadRequest,
+			name:    "Given error when record no show then return error",
+			request: "register_number",
+			mockCall: func() {
+				mockBooing.On("NoShow", "register_number").Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := NewBookingController(mockBooing)
-			app.Put("/reschedule", ctrl.Reschedule)
+			app.Post("/booking/:register_number/noshow", ctrl.NoShow)
 
-			body, _ := json.Marshal(tc.request)
-			req, _ := http.NewRequest("PUT", "/reschedule", bytes.NewBuffer(body))
-			req.Header.Set("Content-Type", "application/json")
+			req, _ := http.NewRequest("POST", "/booking/"+tc.request+"/noshow", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_GetByGuestID(t *testing.T) {
+	app := fiber.New()
+	mockBooing = new(mocks.BookingService)
+
+	testCases := []struct {
+		name       string
+		request    string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when get bookings by guest then return success",
+			request: "1",
+			mockCall: func() {
+				mockBooing.On("GetByGuestID", 1).Return([]*model.BookingResponse{{RegisterNumber: "register_number", GuestID: 1}}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given invalid id when get bookings by guest then return bad request",
+			request:    "a",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given unknown guest when get bookings by guest then return not found",
+			request: "2",
+			mockCall: func() {
+				mockBooing.On("GetByGuestID", 2).Return(nil, model.NewNotFoundError("guest not found")).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	ctrl := NewBookingController(mockBooing)
+	app.Get("/guest/:id/bookings", ctrl.GetByGuestID)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/guest/"+tc.request+"/bookings", nil)
 			tc.mockCall()
 
 			res, _ := app.Test(req)
@@ -3,26 +3,56 @@ package controller
 import (
 	"github.com/zakiyalmaya/hotel-management/application"
 	"github.com/zakiyalmaya/hotel-management/transport/controller/auth"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/availability"
 	"github.com/zakiyalmaya/hotel-management/transport/controller/booking"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/folio"
 	"github.com/zakiyalmaya/hotel-management/transport/controller/guest"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/housekeeping"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/invitation"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/invoice"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/maintenance"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/mfa"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/payment"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/pricing"
+	"github.com/zakiyalmaya/hotel-management/transport/controller/rateplan"
 	"github.com/zakiyalmaya/hotel-management/transport/controller/room"
 	"github.com/zakiyalmaya/hotel-management/transport/controller/user"
 )
 
 type Controller struct {
-	RoomCtrl    *room.RoomController
-	GuestCtrl   *guest.GuestController
-	BookingCtrl *booking.BookingController
-	UserCtrl    *user.UserController
-	AuthCtrl    *auth.AuthController
+	RoomCtrl         *room.RoomController
+	GuestCtrl        *guest.GuestController
+	BookingCtrl      *booking.BookingController
+	UserCtrl         *user.UserController
+	AuthCtrl         *auth.AuthController
+	AvailabilityCtrl *availability.AvailabilityController
+	HousekeepingCtrl *housekeeping.HousekeepingController
+	MaintenanceCtrl  *maintenance.MaintenanceController
+	RatePlanCtrl     *rateplan.RatePlanController
+	PricingCtrl      *pricing.PricingController
+	FolioCtrl        *folio.FolioController
+	PaymentCtrl      *payment.PaymentController
+	InvoiceCtrl      *invoice.InvoiceController
+	InvitationCtrl   *invitation.InvitationController
+	MfaCtrl          *mfa.MfaController
 }
 
 func NewController(application *application.Application) *Controller {
 	return &Controller{
-		RoomCtrl:    room.NewRoomController(application.RoomSvc),
-		GuestCtrl:   guest.NewGuestController(application.GuestSvc),
-		BookingCtrl: booking.NewBookingController(application.BookingSvc),
-		UserCtrl:    user.NewUserController(application.UserSvc),
-		AuthCtrl:    auth.NewAuthController(application.AuthSvc),
+		RoomCtrl:         room.NewRoomController(application.RoomSvc),
+		GuestCtrl:        guest.NewGuestController(application.GuestSvc),
+		BookingCtrl:      booking.NewBookingController(application.BookingSvc),
+		UserCtrl:         user.NewUserController(application.UserSvc),
+		AuthCtrl:         auth.NewAuthController(application.AuthSvc),
+		AvailabilityCtrl: availability.NewAvailabilityController(application.AvailabilitySvc),
+		HousekeepingCtrl: housekeeping.NewHousekeepingController(application.HousekeepingSvc),
+		MaintenanceCtrl:  maintenance.NewMaintenanceController(application.MaintenanceSvc),
+		RatePlanCtrl:     rateplan.NewRatePlanController(application.RatePlanSvc),
+		PricingCtrl:      pricing.NewPricingController(application.PricingSvc),
+		FolioCtrl:        folio.NewFolioController(application.FolioSvc),
+		PaymentCtrl:      payment.NewPaymentController(application.PaymentSvc),
+		InvoiceCtrl:      invoice.NewInvoiceController(application.InvoiceSvc),
+		InvitationCtrl:   invitation.NewInvitationController(application.InvitationSvc),
+		MfaCtrl:          mfa.NewMfaController(application.MfaSvc),
 	}
 }
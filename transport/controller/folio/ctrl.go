@@ -0,0 +1,83 @@
+package folio
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/folio"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type FolioController struct {
+	folioSvc folio.FolioService
+}
+
+func NewFolioController(folioSvc folio.FolioService) *FolioController {
+	return &FolioController{folioSvc: folioSvc}
+}
+
+func (c *FolioController) GetByRegisterNumber(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	resp, err := c.folioSvc.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *FolioController) PostCharge(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	chargeReq := model.PostFolioChargeRequest{}
+	if err := ctx.BodyParser(&chargeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(chargeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if isExtra := constant.FolioChargeType(chargeReq.ChargeType).IsExtra(); !isExtra {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid charge type", nil))
+	}
+
+	if chargeReq.Quantity == 0 {
+		chargeReq.Quantity = 1
+	}
+
+	// extras are charged today unless posted for an earlier day of the stay
+	now := time.Now()
+	parseChargeDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if chargeReq.ChargeDate != "" {
+		parsed, err := time.Parse("02-01-2006", chargeReq.ChargeDate)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+		parseChargeDate = parsed
+	}
+
+	postedBy := ctx.Locals("user_id").(int)
+	if err := c.folioSvc.PostCharge(&model.FolioChargeEntity{
+		RegisterNumber: registerNumber,
+		ChargeType:     constant.FolioChargeType(chargeReq.ChargeType),
+		Description:    chargeReq.Description,
+		ChargeDate:     parseChargeDate,
+		Quantity:       chargeReq.Quantity,
+		UnitPrice:      chargeReq.UnitPrice,
+		PostedBy:       &postedBy,
+	}); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", nil))
+}
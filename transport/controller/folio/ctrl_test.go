@@ -0,0 +1,129 @@
+package folio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockFolio *mocks.FolioService
+)
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	app := fiber.New()
+	mockFolio = new(mocks.FolioService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when get folio then return success",
+			mockCall: func() {
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(&model.FolioResponse{}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error when get folio then return error",
+			mockCall: func() {
+				mockFolio.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewFolioController(mockFolio)
+			app.Get("/booking/:register_number/folio", ctrl.GetByRegisterNumber)
+
+			req, _ := http.NewRequest("GET", "/booking/register_number/folio", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_PostCharge(t *testing.T) {
+	app := fiber.New()
+	mockFolio = new(mocks.FolioService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when post charge then return success",
+			request: &model.PostFolioChargeRequest{ChargeType: 2, Description: "Soft drink", UnitPrice: 25000, ChargeDate: "01-03-2000"},
+			mockCall: func() {
+				mockFolio.On("PostCharge", mock.MatchedBy(func(charge *model.FolioChargeEntity) bool {
+					return charge.RegisterNumber == "register_number" && charge.ChargeType == constant.FolioChargeTypeMinibar &&
+						charge.Quantity == 1 && *charge.PostedBy == 1 && charge.ChargeDate.Day() == 1
+				})).Return(nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Given room charge type when post charge then return bad request",
+			request:    &model.PostFolioChargeRequest{ChargeType: 1, Description: "Extra night", UnitPrice: 500000},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Given missing unit price when post charge then return bad request",
+			request:    &model.PostFolioChargeRequest{ChargeType: 3, Description: "Shirt"},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given checked out booking when post charge then return conflict",
+			request: &model.PostFolioChargeRequest{ChargeType: 3, Description: "Shirt", UnitPrice: 30000},
+			mockCall: func() {
+				mockFolio.On("PostCharge", mock.Anything).Return(model.NewConflictError("can not post charges to a Checked Out booking")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:    "Given error when post charge then return error",
+			request: &model.PostFolioChargeRequest{ChargeType: 3, Description: "Shirt", UnitPrice: 30000},
+			mockCall: func() {
+				mockFolio.On("PostCharge", mock.Anything).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewFolioController(mockFolio)
+			app.Post("/booking/:register_number/folio/charge", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.PostCharge(ctx)
+			})
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/booking/register_number/folio/charge", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package guest
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/zakiyalmaya/hotel-management/application/guest"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 	"github.com/zakiyalmaya/hotel-management/utils"
 )
@@ -61,3 +63,183 @@ func (c *GuestController) GetByID(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
 }
+
+func (c *GuestController) Search(ctx *fiber.Ctx) error {
+	request := &model.SearchGuestRequest{}
+	if name := ctx.Query("name"); name != "" {
+		request.Name = &name
+	}
+
+	if phoneNumber := ctx.Query("phone_number"); phoneNumber != "" {
+		request.PhoneNumber = &phoneNumber
+	}
+
+	if email := ctx.Query("email"); email != "" {
+		request.Email = &email
+	}
+
+	if identity := ctx.Query("identity_number"); identity != "" {
+		request.Identity = &identity
+	}
+
+	paginationReq := model.PaginationRequest{
+		Page:  ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 20),
+	}
+
+	if err := utils.Validator(paginationReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	resp, err := c.guestSvc.Search(request, &paginationReq)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *GuestController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	guestReq := model.UpdateGuestRequest{}
+	if err := ctx.BodyParser(&guestReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(guestReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.guestSvc.Update(id, &guestReq); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *GuestController) GetSummary(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	resp, err := c.guestSvc.GetSummary(id)
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+// DetectDuplicates runs the duplicate search now instead of waiting for the scheduled scan.
+func (c *GuestController) DetectDuplicates(ctx *fiber.Ctx) error {
+	found, err := c.guestSvc.DetectDuplicates()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", map[string]int{"suggestions": found}))
+}
+
+func (c *GuestController) GetDuplicates(ctx *fiber.Ctx) error {
+	paginationReq := model.PaginationRequest{
+		Page:  ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 20),
+	}
+
+	if err := utils.Validator(paginationReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	resp, err := c.guestSvc.GetDuplicates(&paginationReq)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *GuestController) MergeDuplicate(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	mergeReq := model.MergeGuestRequest{}
+	if err := ctx.BodyParser(&mergeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(mergeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	resp, err := c.guestSvc.MergeDuplicate(id, &mergeReq, ctx.Locals("user_id").(int))
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *GuestController) DismissDuplicate(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.guestSvc.DismissDuplicate(id, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+// Export downloads everything kept about a guest, as json unless the zip format is asked for.
+func (c *GuestController) Export(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	format := constant.GuestExportFormat(ctx.Query("format", string(constant.GuestExportFormatJSON)))
+	if isFormatValid := format.Validation(); !isFormatValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid export format", nil))
+	}
+
+	document, err := c.guestSvc.Export(id, format)
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	ctx.Set(fiber.HeaderContentType, document.Format.ContentType())
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("guest-%d.%s", document.GuestID, document.Format)))
+	return ctx.Status(fiber.StatusOK).Send(document.Content)
+}
+
+func (c *GuestController) Anonymize(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.guestSvc.Anonymize(id, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+// AnonymizeInactive runs the retention job now instead of waiting for the scheduled run.
+func (c *GuestController) AnonymizeInactive(ctx *fiber.Ctx) error {
+	resp, err := c.guestSvc.AnonymizeInactive()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
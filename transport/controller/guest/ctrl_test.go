@@ -11,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -166,3 +167,511 @@ func Test_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	name, phoneNumber := "jo do", "0812"
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given name and phone number when search guests then return the first page",
+			path: "/api/guests?name=jo%20do&phone_number=0812",
+			mockCall: func() {
+				mockGuest.On("Search", &model.SearchGuestRequest{Name: &name, PhoneNumber: &phoneNumber}, &model.PaginationRequest{Page: 1, Limit: 20}).Return(&model.PaginatedResponse{
+					Items:      []*model.GuestResponse{{ID: 1, Name: "John Doe", PhoneNumber: "08123456789"}},
+					Page:       1,
+					Limit:      20,
+					Total:      1,
+					TotalPages: 1,
+				}, nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given page zero when search guests then return bad request",
+			path:       "/api/guests?page=0",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "Given error from service when search guests then return internal server error",
+			path: "/api/guests?page=2&limit=10",
+			mockCall: func() {
+				mockGuest.On("Search", &model.SearchGuestRequest{}, &model.PaginationRequest{Page: 2, Limit: 10}).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Get("/api/guests", ctrl.Search)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	phoneNumber, email, invalidEmail := "08123456789", "john@mail.com", "john"
+
+	testCases := []struct {
+		name       string
+		path       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when update guest then return success response",
+			path:    "/api/guest/1",
+			request: &model.UpdateGuestRequest{PhoneNumber: &phoneNumber, Email: &email},
+			mockCall: func() {
+				mockGuest.On("Update", 1, &model.UpdateGuestRequest{PhoneNumber: &phoneNumber, Email: &email}).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given invalid email when update guest then return bad request",
+			path:       "/api/guest/1",
+			request:    &model.UpdateGuestRequest{Email: &invalidEmail},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid id when update guest then return bad request",
+			path:       "/api/guest/abc",
+			request:    &model.UpdateGuestRequest{Email: &email},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:    "Given unknown guest when update guest then return not found",
+			path:    "/api/guest/2",
+			request: &model.UpdateGuestRequest{Email: &email},
+			mockCall: func() {
+				mockGuest.On("Update", 2, &model.UpdateGuestRequest{Email: &email}).Return(model.NewNotFoundError("guest not found")).Once()
+			},
+			statusCode: fiber.StatusNotFound,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Put("/api/guest/:id", ctrl.Update)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("PUT", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_GetSummary(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		request    string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when get guest summary then return success response",
+			request: "1",
+			mockCall: func() {
+				mockGuest.On("GetSummary", 1).Return(&model.GuestSummaryResponse{GuestID: 1, Name: "John Doe", TotalStays: 3}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given invalid id when get guest summary then return bad request",
+			request:    "a",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given unknown guest when get guest summary then return not found",
+			request: "2",
+			mockCall: func() {
+				mockGuest.On("GetSummary", 2).Return(nil, model.NewNotFoundError("guest not found")).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Get("/guest/:id/summary", ctrl.GetSummary)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/guest/"+tc.request+"/summary", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_DetectDuplicates(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given scan when detect duplicates then return the number of suggestions",
+			mockCall: func() {
+				mockGuest.On("DetectDuplicates").Return(2, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error from service when detect duplicates then return internal server error",
+			mockCall: func() {
+				mockGuest.On("DetectDuplicates").Return(0, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Post("/guests/duplicates/scan", ctrl.DetectDuplicates)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/guests/duplicates/scan", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_GetDuplicates(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given no query when get duplicates then return the first page",
+			path: "/guests/duplicates",
+			mockCall: func() {
+				mockGuest.On("GetDuplicates", &model.PaginationRequest{Page: 1, Limit: 20}).Return(&model.PaginatedResponse{Page: 1, Limit: 20}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given limit above the maximum when get duplicates then return bad request",
+			path:       "/guests/duplicates?limit=101",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Get("/guests/duplicates", ctrl.GetDuplicates)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_MergeDuplicate(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when merge duplicate then return the merge",
+			path:    "/guests/duplicates/7/merge",
+			request: &model.MergeGuestRequest{SurvivingGuestID: 1},
+			mockCall: func() {
+				mockGuest.On("MergeDuplicate", 7, &model.MergeGuestRequest{SurvivingGuestID: 1}, 1).Return(&model.GuestMergeResponse{SurvivingGuestID: 1, MergedGuestID: 2, BookingsMoved: 3}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given missing surviving guest when merge duplicate then return bad request",
+			path:       "/guests/duplicates/7/merge",
+			request:    &model.MergeGuestRequest{},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given reviewed suggestion when merge duplicate then return conflict",
+			path:    "/guests/duplicates/8/merge",
+			request: &model.MergeGuestRequest{SurvivingGuestID: 1},
+			mockCall: func() {
+				mockGuest.On("MergeDuplicate", 8, &model.MergeGuestRequest{SurvivingGuestID: 1}, 1).Return(nil, model.NewConflictError("duplicate suggestion has already been reviewed")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Post("/guests/duplicates/:id/merge", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.MergeDuplicate(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_DismissDuplicate(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given pending suggestion when dismiss duplicate then return success",
+			path: "/guests/duplicates/7/dismiss",
+			mockCall: func() {
+				mockGuest.On("DismissDuplicate", 7, 1).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given invalid id when dismiss duplicate then return bad request",
+			path:       "/guests/duplicates/abc/dismiss",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given unknown suggestion when dismiss duplicate then return not found",
+			path: "/guests/duplicates/8/dismiss",
+			mockCall: func() {
+				mockGuest.On("DismissDuplicate", 8, 1).Return(model.NewNotFoundError("duplicate suggestion not found")).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Post("/guests/duplicates/:id/dismiss", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.DismissDuplicate(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tc.path, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Export(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name        string
+		path        string
+		mockCall    func()
+		statusCode  int
+		contentType string
+	}{
+		{
+			name: "Given no format when export guest then return json attachment",
+			path: "/guest/1/export",
+			mockCall: func() {
+				mockGuest.On("Export", 1, constant.GuestExportFormatJSON).Return(&model.GuestExportDocument{GuestID: 1, Format: constant.GuestExportFormatJSON, Content: []byte("{}")}, nil).Once()
+			},
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+		},
+		{
+			name: "Given zip format when export guest then return zip attachment",
+			path: "/guest/1/export?format=zip",
+			mockCall: func() {
+				mockGuest.On("Export", 1, constant.GuestExportFormatZIP).Return(&model.GuestExportDocument{GuestID: 1, Format: constant.GuestExportFormatZIP, Content: []byte("PK")}, nil).Once()
+			},
+			statusCode:  http.StatusOK,
+			contentType: "application/zip",
+		},
+		{
+			name:        "Given unknown format when export guest then return bad request",
+			path:        "/guest/1/export?format=xml",
+			mockCall:    func() {},
+			statusCode:  http.StatusBadRequest,
+			contentType: "application/json",
+		},
+		{
+			name: "Given unknown guest when export guest then return not found",
+			path: "/guest/2/export",
+			mockCall: func() {
+				mockGuest.On("Export", 2, constant.GuestExportFormatJSON).Return(nil, model.NewNotFoundError("guest not found")).Once()
+			},
+			statusCode:  http.StatusNotFound,
+			contentType: "application/json",
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Get("/guest/:id/export", ctrl.Export)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			assert.Equal(t, tc.contentType, res.Header.Get(fiber.HeaderContentType))
+		})
+	}
+}
+
+func Test_Anonymize(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given guest when anonymize then return success",
+			path: "/guest/1/anonymize",
+			mockCall: func() {
+				mockGuest.On("Anonymize", 1, 1).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given invalid id when anonymize then return bad request",
+			path:       "/guest/abc/anonymize",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given guest staying when anonymize then return conflict",
+			path: "/guest/2/anonymize",
+			mockCall: func() {
+				mockGuest.On("Anonymize", 2, 1).Return(model.NewConflictError("guest has a booking that is not finished yet")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Post("/guest/:id/anonymize", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Anonymize(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tc.path, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_AnonymizeInactive(t *testing.T) {
+	app := fiber.New()
+	mockGuest = new(mocks.GuestService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given run when anonymize inactive then return the number of guests anonymized",
+			mockCall: func() {
+				mockGuest.On("AnonymizeInactive").Return(&model.AnonymizeInactiveResponse{Processed: 4, Anonymized: 3, Failed: []int{7}}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error from service when anonymize inactive then return internal server error",
+			mockCall: func() {
+				mockGuest.On("AnonymizeInactive").Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewGuestController(mockGuest)
+	app.Post("/guests/retention/run", ctrl.AnonymizeInactive)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/guests/retention/run", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
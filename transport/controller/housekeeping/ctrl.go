@@ -0,0 +1,109 @@
+package housekeeping
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/housekeeping"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type HousekeepingController struct {
+	housekeepingSvc housekeeping.HousekeepingService
+}
+
+func NewHousekeepingController(housekeepingSvc housekeeping.HousekeepingService) *HousekeepingController {
+	return &HousekeepingController{housekeepingSvc: housekeepingSvc}
+}
+
+func (c *HousekeepingController) GetAll(ctx *fiber.Ctx) error {
+	request := &model.GetAllHousekeepingTaskRequest{}
+	if roomName := ctx.Query("room_name"); roomName != "" {
+		request.RoomName = &roomName
+	}
+
+	if status := ctx.Query("status"); status != "" {
+		request.Status = &status
+	}
+
+	resp, err := c.housekeepingSvc.GetAll(request)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *HousekeepingController) GetByID(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	resp, err := c.housekeepingSvc.GetByID(id)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *HousekeepingController) Claim(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	userID := ctx.Locals("user_id").(int)
+	if err := c.housekeepingSvc.Claim(id, userID); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *HousekeepingController) Complete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	// notes are optional, so an empty body is accepted
+	completeReq := model.CompleteHousekeepingTaskRequest{}
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&completeReq); err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+	}
+
+	userID := ctx.Locals("user_id").(int)
+	if err := c.housekeepingSvc.Complete(id, userID, &completeReq); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *HousekeepingController) Inspect(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	inspectReq := model.InspectHousekeepingTaskRequest{}
+	if err := ctx.BodyParser(&inspectReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(inspectReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	userID := ctx.Locals("user_id").(int)
+	if err := c.housekeepingSvc.Inspect(id, userID, &inspectReq); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
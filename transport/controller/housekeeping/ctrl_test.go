@@ -0,0 +1,228 @@
+package housekeeping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockHousekeeping *mocks.HousekeepingService
+)
+
+func Test_GetAll(t *testing.T) {
+	app := fiber.New()
+	mockHousekeeping = new(mocks.HousekeepingService)
+
+	status := "1"
+
+	testCases := []struct {
+		name       string
+		query      string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:  "Given valid request when get all housekeeping tasks then return success",
+			query: "?status=1",
+			mockCall: func() {
+				mockHousekeeping.On("GetAll", &model.GetAllHousekeepingTaskRequest{Status: &status}).Return([]*model.HousekeepingTaskResponse{}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:  "Given error when get all housekeeping tasks then return error",
+			query: "",
+			mockCall: func() {
+				mockHousekeeping.On("GetAll", &model.GetAllHousekeepingTaskRequest{}).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewHousekeepingController(mockHousekeeping)
+			app.Get("/housekeeping", ctrl.GetAll)
+
+			req, _ := http.NewRequest("GET", "/housekeeping"+tc.query, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Claim(t *testing.T) {
+	app := fiber.New()
+	mockHousekeeping = new(mocks.HousekeepingService)
+
+	testCases := []struct {
+		name       string
+		param      string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:  "Given valid request when claim housekeeping task then return success",
+			param: "1",
+			mockCall: func() {
+				mockHousekeeping.On("Claim", 1, 1).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:  "Given task already claimed when claim housekeeping task then return conflict",
+			param: "1",
+			mockCall: func() {
+				mockHousekeeping.On("Claim", 1, 1).Return(model.NewConflictError("housekeeping task already claimed")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Given invalid id when claim housekeeping task then return bad request",
+			param:      "abc",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewHousekeepingController(mockHousekeeping)
+			app.Post("/housekeeping/:id/claim", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.Claim(ctx)
+			})
+
+			req, _ := http.NewRequest("POST", "/housekeeping/"+tc.param+"/claim", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	app := fiber.New()
+	mockHousekeeping = new(mocks.HousekeepingService)
+
+	notes := "minibar restocked"
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when complete housekeeping task then return success",
+			request: &model.CompleteHousekeepingTaskRequest{Notes: &notes},
+			mockCall: func() {
+				mockHousekeeping.On("Complete", 1, 1, &model.CompleteHousekeepingTaskRequest{Notes: &notes}).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Given empty body when complete housekeeping task then return success",
+			request: nil,
+			mockCall: func() {
+				mockHousekeeping.On("Complete", 1, 1, &model.CompleteHousekeepingTaskRequest{}).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Given error when complete housekeeping task then return error",
+			request: &model.CompleteHousekeepingTaskRequest{Notes: &notes},
+			mockCall: func() {
+				mockHousekeeping.On("Complete", 1, 1, &model.CompleteHousekeepingTaskRequest{Notes: &notes}).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewHousekeepingController(mockHousekeeping)
+			app.Post("/housekeeping/:id/complete", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.Complete(ctx)
+			})
+
+			var body []byte
+			if tc.request != nil {
+				body, _ = json.Marshal(tc.request)
+			}
+			req, _ := http.NewRequest("POST", "/housekeeping/1/complete", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Inspect(t *testing.T) {
+	app := fiber.New()
+	mockHousekeeping = new(mocks.HousekeepingService)
+
+	passed := true
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when inspect housekeeping task then return success",
+			request: &model.InspectHousekeepingTaskRequest{Passed: &passed},
+			mockCall: func() {
+				mockHousekeeping.On("Inspect", 1, 1, &model.InspectHousekeepingTaskRequest{Passed: &passed}).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Given task not completed when inspect housekeeping task then return conflict",
+			request: &model.InspectHousekeepingTaskRequest{Passed: &passed},
+			mockCall: func() {
+				mockHousekeeping.On("Inspect", 1, 1, &model.InspectHousekeepingTaskRequest{Passed: &passed}).Return(model.NewConflictError("housekeeping task can not move from Pending to Inspected")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Given missing result when inspect housekeeping task then return bad request",
+			request:    &model.InspectHousekeepingTaskRequest{},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewHousekeepingController(mockHousekeeping)
+			app.Post("/housekeeping/:id/inspect", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.Inspect(ctx)
+			})
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/housekeeping/1/inspect", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
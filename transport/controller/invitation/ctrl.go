@@ -0,0 +1,63 @@
+package invitation
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/invitation"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type InvitationController struct {
+	invitationSvc invitation.InvitationService
+}
+
+func NewInvitationController(invitationSvc invitation.InvitationService) *InvitationController {
+	return &InvitationController{invitationSvc: invitationSvc}
+}
+
+func (c *InvitationController) Create(ctx *fiber.Ctx) error {
+	invitationReq := model.CreateInvitationRequest{}
+	if err := ctx.BodyParser(&invitationReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(invitationReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if isRoleValid := constant.Role(invitationReq.Role).Validation(); !isRoleValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid role", nil))
+	}
+
+	resp, err := c.invitationSvc.Create(&invitationReq, ctx.Locals("user_id").(int))
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", resp))
+}
+
+func (c *InvitationController) GetPending(ctx *fiber.Ctx) error {
+	resp, err := c.invitationSvc.GetPending()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *InvitationController) Revoke(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.invitationSvc.Revoke(id, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
@@ -0,0 +1,175 @@
+package invitation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockInvitation *mocks.InvitationService
+)
+
+func Test_Create(t *testing.T) {
+	app := fiber.New()
+	mockInvitation = new(mocks.InvitationService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when create invitation then return the token",
+			request: &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 2},
+			mockCall: func() {
+				mockInvitation.On("Create", &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 2}, 1).Return(&model.InvitationResponse{ID: 1, Token: "token"}, nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Given invalid role when create invitation then return bad request",
+			request:    &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 9},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid email when create invitation then return bad request",
+			request:    &model.CreateInvitationRequest{Email: "jane", Role: 2},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given error when create invitation then return error",
+			request: &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 2},
+			mockCall: func() {
+				mockInvitation.On("Create", &model.CreateInvitationRequest{Email: "jane@mail.com", Role: 2}, 1).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewInvitationController(mockInvitation)
+	app.Post("/invitations", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Create(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/invitations", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_GetPending(t *testing.T) {
+	app := fiber.New()
+	mockInvitation = new(mocks.InvitationService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when get pending invitations then return success",
+			mockCall: func() {
+				mockInvitation.On("GetPending").Return([]*model.InvitationResponse{}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error when get pending invitations then return error",
+			mockCall: func() {
+				mockInvitation.On("GetPending").Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewInvitationController(mockInvitation)
+	app.Get("/invitations", ctrl.GetPending)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/invitations", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Revoke(t *testing.T) {
+	app := fiber.New()
+	mockInvitation = new(mocks.InvitationService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given pending invitation when revoke then return success",
+			path: "/invitations/3",
+			mockCall: func() {
+				mockInvitation.On("Revoke", 3, 1).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given invalid id when revoke then return bad request",
+			path:       "/invitations/abc",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given accepted invitation when revoke then return conflict",
+			path: "/invitations/3",
+			mockCall: func() {
+				mockInvitation.On("Revoke", 3, 1).Return(model.NewConflictError("invitation is no longer pending")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name: "Given unknown invitation when revoke then return not found",
+			path: "/invitations/3",
+			mockCall: func() {
+				mockInvitation.On("Revoke", 3, 1).Return(model.NewNotFoundError("invitation not found")).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	ctrl := NewInvitationController(mockInvitation)
+	app.Delete("/invitations/:id", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Revoke(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", tc.path, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
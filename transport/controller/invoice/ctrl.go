@@ -0,0 +1,41 @@
+package invoice
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/invoice"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type InvoiceController struct {
+	invoiceSvc invoice.InvoiceService
+}
+
+func NewInvoiceController(invoiceSvc invoice.InvoiceService) *InvoiceController {
+	return &InvoiceController{invoiceSvc: invoiceSvc}
+}
+
+// GetByRegisterNumber renders the invoice of a booking, as a pdf unless the html format is asked for.
+func (c *InvoiceController) GetByRegisterNumber(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	format := constant.InvoiceFormat(ctx.Query("format", string(constant.InvoiceFormatPDF)))
+	if isFormatValid := format.Validation(); !isFormatValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid invoice format", nil))
+	}
+
+	document, err := c.invoiceSvc.Generate(registerNumber, format, ctx.Locals("user_id").(int))
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	ctx.Set(fiber.HeaderContentType, document.Format.ContentType())
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", document.InvoiceNumber+"."+string(document.Format)))
+	return ctx.Status(fiber.StatusOK).Send(document.Content)
+}
@@ -0,0 +1,86 @@
+package invoice
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockInvoice *mocks.InvoiceService
+)
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	app := fiber.New()
+	mockInvoice = new(mocks.InvoiceService)
+
+	testCases := []struct {
+		name        string
+		path        string
+		mockCall    func()
+		statusCode  int
+		contentType string
+	}{
+		{
+			name: "Given no format when get invoice then return pdf document",
+			path: "/booking/register_number/invoice",
+			mockCall: func() {
+				mockInvoice.On("Generate", "register_number", constant.InvoiceFormatPDF, 1).Return(&model.InvoiceDocument{
+					InvoiceNumber: "INV-000001", Format: constant.InvoiceFormatPDF, Content: []byte("%PDF-1.3"),
+				}, nil).Once()
+			},
+			statusCode:  http.StatusOK,
+			contentType: "application/pdf",
+		},
+		{
+			name: "Given html format when get invoice then return html document",
+			path: "/booking/register_number/invoice?format=html",
+			mockCall: func() {
+				mockInvoice.On("Generate", "register_number", constant.InvoiceFormatHTML, 1).Return(&model.InvoiceDocument{
+					InvoiceNumber: "INV-000001", Format: constant.InvoiceFormatHTML, Content: []byte("<html></html>"),
+				}, nil).Once()
+			},
+			statusCode:  http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+		},
+		{
+			name:        "Given unknown format when get invoice then return bad request",
+			path:        "/booking/register_number/invoice?format=docx",
+			mockCall:    func() {},
+			statusCode:  http.StatusBadRequest,
+			contentType: "application/json",
+		},
+		{
+			name: "Given error when get invoice then return error",
+			path: "/booking/register_number/invoice",
+			mockCall: func() {
+				mockInvoice.On("Generate", "register_number", constant.InvoiceFormatPDF, 1).Return(nil, errors.New("error")).Once()
+			},
+			statusCode:  http.StatusInternalServerError,
+			contentType: "application/json",
+		},
+	}
+
+	ctrl := NewInvoiceController(mockInvoice)
+	app.Get("/booking/:register_number/invoice", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.GetByRegisterNumber(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"))
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package maintenance
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/maintenance"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type MaintenanceController struct {
+	maintenanceSvc maintenance.MaintenanceService
+}
+
+func NewMaintenanceController(maintenanceSvc maintenance.MaintenanceService) *MaintenanceController {
+	return &MaintenanceController{maintenanceSvc: maintenanceSvc}
+}
+
+func (c *MaintenanceController) Create(ctx *fiber.Ctx) error {
+	orderReq := model.CreateMaintenanceOrderRequest{}
+	if err := ctx.BodyParser(&orderReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(orderReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if isPriorityValid := constant.MaintenancePriority(orderReq.Priority).Validation(); !isPriorityValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid priority", nil))
+	}
+
+	// work starts today unless it is scheduled ahead
+	now := time.Now()
+	parseStartDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if orderReq.StartDate != "" {
+		parsed, err := time.Parse("02-01-2006", orderReq.StartDate)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+		parseStartDate = parsed
+	}
+
+	parseExpectedCompletion, err := time.Parse("02-01-2006", orderReq.ExpectedCompletion)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if !parseExpectedCompletion.After(parseStartDate) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "expected completion date must be greater than start date", nil))
+	}
+
+	resp, err := c.maintenanceSvc.Create(&model.MaintenanceOrderEntity{
+		RoomName:           orderReq.RoomName,
+		Issue:              orderReq.Issue,
+		Priority:           constant.MaintenancePriority(orderReq.Priority),
+		ReportedBy:         ctx.Locals("user_id").(int),
+		AssignedTo:         orderReq.AssignedTo,
+		StartDate:          parseStartDate,
+		ExpectedCompletion: parseExpectedCompletion,
+	})
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", resp))
+}
+
+func (c *MaintenanceController) GetByID(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	resp, err := c.maintenanceSvc.GetByID(id)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *MaintenanceController) GetAll(ctx *fiber.Ctx) error {
+	request := &model.GetAllMaintenanceOrderRequest{}
+	if roomName := ctx.Query("room_name"); roomName != "" {
+		request.RoomName = &roomName
+	}
+
+	if status := ctx.Query("status"); status != "" {
+		request.Status = &status
+	}
+
+	resp, err := c.maintenanceSvc.GetAll(request)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+// GetByRoom returns the maintenance history of a room, newest first.
+func (c *MaintenanceController) GetByRoom(ctx *fiber.Ctx) error {
+	roomName := ctx.Params("name")
+	if roomName == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "room name is required", nil))
+	}
+
+	resp, err := c.maintenanceSvc.GetAll(&model.GetAllMaintenanceOrderRequest{RoomName: &roomName})
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *MaintenanceController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	orderReq := model.UpdateMaintenanceOrderRequest{}
+	if err := ctx.BodyParser(&orderReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	order := &model.MaintenanceOrderEntity{ID: id, AssignedTo: orderReq.AssignedTo}
+	if orderReq.Priority != nil {
+		if isPriorityValid := constant.MaintenancePriority(*orderReq.Priority).Validation(); !isPriorityValid {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid priority", nil))
+		}
+		order.Priority = constant.MaintenancePriority(*orderReq.Priority)
+	}
+
+	if orderReq.ExpectedCompletion != nil {
+		parseExpectedCompletion, err := time.Parse("02-01-2006", *orderReq.ExpectedCompletion)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+		order.ExpectedCompletion = parseExpectedCompletion
+	}
+
+	if err := c.maintenanceSvc.Update(order); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *MaintenanceController) Close(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	closeReq := model.CloseMaintenanceOrderRequest{}
+	if err := ctx.BodyParser(&closeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(closeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	userID := ctx.Locals("user_id").(int)
+	if err := c.maintenanceSvc.Close(id, userID, &closeReq); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
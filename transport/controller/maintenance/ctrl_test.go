@@ -0,0 +1,197 @@
+package maintenance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockMaintenance *mocks.MaintenanceService
+)
+
+func Test_Create(t *testing.T) {
+	app := fiber.New()
+	mockMaintenance = new(mocks.MaintenanceService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when create maintenance order then return success",
+			request: &model.CreateMaintenanceOrderRequest{
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           3,
+				StartDate:          "01-03-2000",
+				ExpectedCompletion: "03-03-2000",
+			},
+			mockCall: func() {
+				mockMaintenance.On("Create", mock.MatchedBy(func(order *model.MaintenanceOrderEntity) bool {
+					return order.RoomName == "101" && order.ReportedBy == 1 && order.ExpectedCompletion.Day() == 3
+				})).Return(&model.MaintenanceOrderResponse{}, nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "Given invalid priority when create maintenance order then return bad request",
+			request: &model.CreateMaintenanceOrderRequest{
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           9,
+				ExpectedCompletion: "03-03-2000",
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given expected completion before start date when create maintenance order then return bad request",
+			request: &model.CreateMaintenanceOrderRequest{
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           3,
+				StartDate:          "03-03-2000",
+				ExpectedCompletion: "01-03-2000",
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given error when create maintenance order then return error",
+			request: &model.CreateMaintenanceOrderRequest{
+				RoomName:           "101",
+				Issue:              "leaking shower",
+				Priority:           3,
+				StartDate:          "01-03-2000",
+				ExpectedCompletion: "03-03-2000",
+			},
+			mockCall: func() {
+				mockMaintenance.On("Create", mock.Anything).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewMaintenanceController(mockMaintenance)
+			app.Post("/maintenance", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.Create(ctx)
+			})
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/maintenance", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_GetByRoom(t *testing.T) {
+	app := fiber.New()
+	mockMaintenance = new(mocks.MaintenanceService)
+
+	roomName := "101"
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when get room maintenance history then return success",
+			mockCall: func() {
+				mockMaintenance.On("GetAll", &model.GetAllMaintenanceOrderRequest{RoomName: &roomName}).Return([]*model.MaintenanceOrderResponse{}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error when get room maintenance history then return error",
+			mockCall: func() {
+				mockMaintenance.On("GetAll", &model.GetAllMaintenanceOrderRequest{RoomName: &roomName}).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewMaintenanceController(mockMaintenance)
+			app.Get("/room/:name/maintenance", ctrl.GetByRoom)
+
+			req, _ := http.NewRequest("GET", "/room/"+roomName+"/maintenance", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Close(t *testing.T) {
+	app := fiber.New()
+	mockMaintenance = new(mocks.MaintenanceService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when close maintenance order then return success",
+			request: &model.CloseMaintenanceOrderRequest{Resolution: "replaced the valve"},
+			mockCall: func() {
+				mockMaintenance.On("Close", 1, 1, &model.CloseMaintenanceOrderRequest{Resolution: "replaced the valve"}).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Given closed order when close maintenance order then return conflict",
+			request: &model.CloseMaintenanceOrderRequest{Resolution: "replaced the valve"},
+			mockCall: func() {
+				mockMaintenance.On("Close", 1, 1, &model.CloseMaintenanceOrderRequest{Resolution: "replaced the valve"}).Return(model.NewConflictError("maintenance order is already Closed")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Given missing resolution when close maintenance order then return bad request",
+			request:    &model.CloseMaintenanceOrderRequest{},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewMaintenanceController(mockMaintenance)
+			app.Post("/maintenance/:id/close", func(ctx *fiber.Ctx) error {
+				ctx.Locals("user_id", 1)
+				return ctrl.Close(ctx)
+			})
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/maintenance/1/close", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package mfa
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/mfa"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type MfaController struct {
+	mfaSvc mfa.MfaService
+}
+
+func NewMfaController(mfaSvc mfa.MfaService) *MfaController {
+	return &MfaController{mfaSvc: mfaSvc}
+}
+
+func (m *MfaController) Enroll(ctx *fiber.Ctx) error {
+	enrollment, err := m.mfaSvc.Enroll(ctx.Locals("user_id").(int))
+	if err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", enrollment))
+}
+
+func (m *MfaController) Activate(ctx *fiber.Ctx) error {
+	codeReq := model.MfaCodeRequest{}
+	if err := ctx.BodyParser(&codeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(codeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	recoveryCodes, err := m.mfaSvc.Activate(ctx.Locals("user_id").(int), codeReq.Code)
+	if err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", recoveryCodes))
+}
+
+func (m *MfaController) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	codeReq := model.MfaCodeRequest{}
+	if err := ctx.BodyParser(&codeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(codeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	recoveryCodes, err := m.mfaSvc.RegenerateRecoveryCodes(ctx.Locals("user_id").(int), codeReq.Code)
+	if err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", recoveryCodes))
+}
+
+func (m *MfaController) Disable(ctx *fiber.Ctx) error {
+	codeReq := model.MfaCodeRequest{}
+	if err := ctx.BodyParser(&codeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(codeReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := m.mfaSvc.Disable(ctx.Locals("user_id").(int), codeReq.Code); err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (m *MfaController) Reset(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := m.mfaSvc.Reset(id); err != nil {
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (m *MfaController) GetPolicies(ctx *fiber.Ctx) error {
+	policies, err := m.mfaSvc.GetPolicies()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", policies))
+}
+
+func (m *MfaController) UpdatePolicy(ctx *fiber.Ctx) error {
+	role, err := strconv.Atoi(ctx.Params("role"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if isRoleValid := constant.Role(role).Validation(); !isRoleValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid role", nil))
+	}
+
+	policyReq := model.MfaPolicyRequest{}
+	if err := ctx.BodyParser(&policyReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(policyReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := m.mfaSvc.UpdatePolicy(constant.Role(role), *policyReq.Required, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
@@ -0,0 +1,188 @@
+package mfa
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+func Test_Activate(t *testing.T) {
+	app := fiber.New()
+	mockMfa := new(mocks.MfaService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid code when activate then return recovery codes",
+			request: &model.MfaCodeRequest{Code: "123456"},
+			mockCall: func() {
+				mockMfa.On("Activate", 1, "123456").Return(&model.RecoveryCodesResponse{RecoveryCodes: []string{"abcde-12345"}}, nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:    "Given wrong code when activate then return bad request",
+			request: &model.MfaCodeRequest{Code: "000000"},
+			mockCall: func() {
+				mockMfa.On("Activate", 1, "000000").Return(nil, model.NewValidationError("invalid code")).Once()
+			},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:    "Given no pending secret when activate then return conflict",
+			request: &model.MfaCodeRequest{Code: "123456"},
+			mockCall: func() {
+				mockMfa.On("Activate", 1, "123456").Return(nil, model.NewConflictError("enroll an authenticator app first")).Once()
+			},
+			statusCode: fiber.StatusConflict,
+		},
+		{
+			name:       "Given malformed code when activate then return bad request",
+			request:    &model.MfaCodeRequest{Code: "12ab"},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	ctrl := NewMfaController(mockMfa)
+	app.Post("/api/mfa/activate", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Activate(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/api/mfa/activate", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Disable(t *testing.T) {
+	app := fiber.New()
+	mockMfa := new(mocks.MfaService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid code when disable then return success response",
+			mockCall: func() {
+				mockMfa.On("Disable", 1, "123456").Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name: "Given role requiring two-factor authentication when disable then return conflict",
+			mockCall: func() {
+				mockMfa.On("Disable", 1, "123456").Return(model.NewConflictError("two-factor authentication is required for your role")).Once()
+			},
+			statusCode: fiber.StatusConflict,
+		},
+	}
+
+	ctrl := NewMfaController(mockMfa)
+	app.Post("/api/mfa/disable", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Disable(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(&model.MfaCodeRequest{Code: "123456"})
+			req, _ := http.NewRequest("POST", "/api/mfa/disable", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_UpdatePolicy(t *testing.T) {
+	app := fiber.New()
+	mockMfa := new(mocks.MfaService)
+	required := true
+
+	testCases := []struct {
+		name       string
+		path       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when update policy then return success response",
+			path:    "/api/mfa/policies/4",
+			request: &model.MfaPolicyRequest{Required: &required},
+			mockCall: func() {
+				mockMfa.On("UpdatePolicy", constant.RoleAccountant, true, 1).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given invalid role when update policy then return bad request",
+			path:       "/api/mfa/policies/9",
+			request:    &model.MfaPolicyRequest{Required: &required},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given missing required when update policy then return bad request",
+			path:       "/api/mfa/policies/4",
+			request:    &model.MfaPolicyRequest{},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:    "Given error when update policy then return error response",
+			path:    "/api/mfa/policies/4",
+			request: &model.MfaPolicyRequest{Required: &required},
+			mockCall: func() {
+				mockMfa.On("UpdatePolicy", constant.RoleAccountant, true, 1).Return(errors.New("error")).Once()
+			},
+			statusCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewMfaController(mockMfa)
+	app.Put("/api/mfa/policies/:role", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.UpdatePolicy(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("PUT", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
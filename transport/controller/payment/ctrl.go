@@ -0,0 +1,92 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/payment"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/gateway"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type PaymentController struct {
+	paymentSvc payment.PaymentService
+}
+
+func NewPaymentController(paymentSvc payment.PaymentService) *PaymentController {
+	return &PaymentController{paymentSvc: paymentSvc}
+}
+
+func (c *PaymentController) Record(ctx *fiber.Ctx) error {
+	return c.record(ctx, 1)
+}
+
+// Refund takes the same request as a payment, the amount is stored negated.
+func (c *PaymentController) Refund(ctx *fiber.Ctx) error {
+	return c.record(ctx, -1)
+}
+
+func (c *PaymentController) GetByRegisterNumber(ctx *fiber.Ctx) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	resp, err := c.paymentSvc.GetByRegisterNumber(registerNumber)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+// Webhook receives gateway callbacks. It is not behind the login, the signature of the
+// body proves the callback comes from the gateway.
+func (c *PaymentController) Webhook(ctx *fiber.Ctx) error {
+	if err := c.paymentSvc.HandleWebhook(ctx.Body(), ctx.Get(gateway.SignatureHeader)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *PaymentController) record(ctx *fiber.Ctx, sign float64) error {
+	registerNumber := ctx.Params("register_number")
+	if registerNumber == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "register number is required", nil))
+	}
+
+	paymentReq := model.RecordPaymentRequest{}
+	if err := ctx.BodyParser(&paymentReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(paymentReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if isMethodValid := constant.PaymentMethod(paymentReq.PaymentMethod).Validation(); !isMethodValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid payment method", nil))
+	}
+
+	var reference *string
+	if paymentReq.Reference != "" {
+		reference = &paymentReq.Reference
+	}
+
+	receivedBy := ctx.Locals("user_id").(int)
+	if err := c.paymentSvc.Record(&model.PaymentEntity{
+		RegisterNumber: registerNumber,
+		Amount:         sign * paymentReq.Amount,
+		PaymentMethod:  constant.PaymentMethod(paymentReq.PaymentMethod),
+		Reference:      reference,
+		PaidAt:         time.Now(),
+		ReceivedBy:     &receivedBy,
+	}); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", nil))
+}
@@ -0,0 +1,210 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
+	"github.com/zakiyalmaya/hotel-management/infrastructure/gateway"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockPayment *mocks.PaymentService
+)
+
+func Test_Record(t *testing.T) {
+	app := fiber.New()
+	mockPayment = new(mocks.PaymentService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when record payment then return success",
+			path:    "/booking/register_number/payment",
+			request: &model.RecordPaymentRequest{Amount: 300000, PaymentMethod: 3, Reference: "receipt-001"},
+			mockCall: func() {
+				mockPayment.On("Record", mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+					return payment.RegisterNumber == "register_number" && payment.Amount == 300000 &&
+						payment.PaymentMethod == constant.PaymentMethodCash && *payment.Reference == "receipt-001" && *payment.ReceivedBy == 1
+				})).Return(nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:    "Given valid request when record refund then return success with negative amount",
+			path:    "/booking/register_number/refund",
+			request: &model.RecordPaymentRequest{Amount: 100000, PaymentMethod: 2},
+			mockCall: func() {
+				mockPayment.On("Record", mock.MatchedBy(func(payment *model.PaymentEntity) bool {
+					return payment.Amount == -100000 && payment.Reference == nil
+				})).Return(nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Given invalid payment method when record payment then return bad request",
+			path:       "/booking/register_number/payment",
+			request:    &model.RecordPaymentRequest{Amount: 300000, PaymentMethod: 9},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Given missing amount when record payment then return bad request",
+			path:       "/booking/register_number/payment",
+			request:    &model.RecordPaymentRequest{PaymentMethod: 3},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given refund larger than paid when record refund then return bad request",
+			path:    "/booking/register_number/refund",
+			request: &model.RecordPaymentRequest{Amount: 900000, PaymentMethod: 3},
+			mockCall: func() {
+				mockPayment.On("Record", mock.Anything).Return(model.NewValidationError("refund amount exceeds the amount paid")).Once()
+			},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given cancelled booking when record payment then return conflict",
+			path:    "/booking/register_number/payment",
+			request: &model.RecordPaymentRequest{Amount: 300000, PaymentMethod: 3},
+			mockCall: func() {
+				mockPayment.On("Record", mock.Anything).Return(model.NewConflictError("can not take payments for a Cancelled booking")).Once()
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:    "Given error when record payment then return error",
+			path:    "/booking/register_number/payment",
+			request: &model.RecordPaymentRequest{Amount: 300000, PaymentMethod: 3},
+			mockCall: func() {
+				mockPayment.On("Record", mock.Anything).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewPaymentController(mockPayment)
+	app.Post("/booking/:register_number/payment", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Record(ctx)
+	})
+	app.Post("/booking/:register_number/refund", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Refund(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_GetByRegisterNumber(t *testing.T) {
+	app := fiber.New()
+	mockPayment = new(mocks.PaymentService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when get payments then return success",
+			mockCall: func() {
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(&model.PaymentSummaryResponse{}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error when get payments then return error",
+			mockCall: func() {
+				mockPayment.On("GetByRegisterNumber", "register_number").Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewPaymentController(mockPayment)
+			app.Get("/booking/:register_number/payments", ctrl.GetByRegisterNumber)
+
+			req, _ := http.NewRequest("GET", "/booking/register_number/payments", nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Webhook(t *testing.T) {
+	app := fiber.New()
+	mockPayment = new(mocks.PaymentService)
+
+	payload := []byte(`{"id":"sim_evt_1","type":"payment.captured","order_id":"register_number","reference":"sim_auth_1","amount":1000}`)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given signed callback when receive webhook then return success",
+			mockCall: func() {
+				mockPayment.On("HandleWebhook", payload, "signature").Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given invalid signature when receive webhook then return unauthorized",
+			mockCall: func() {
+				mockPayment.On("HandleWebhook", payload, "signature").Return(model.NewUnauthorizedError("invalid signature")).Once()
+			},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Given error when receive webhook then return error",
+			mockCall: func() {
+				mockPayment.On("HandleWebhook", payload, "signature").Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewPaymentController(mockPayment)
+			app.Post("/payment/webhook", ctrl.Webhook)
+
+			req, _ := http.NewRequest("POST", "/payment/webhook", bytes.NewBuffer(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(gateway.SignatureHeader, "signature")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/pricing"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type PricingController struct {
+	pricingSvc pricing.PricingService
+}
+
+func NewPricingController(pricingSvc pricing.PricingService) *PricingController {
+	return &PricingController{pricingSvc: pricingSvc}
+}
+
+func (c *PricingController) Quote(ctx *fiber.Ctx) error {
+	roomName := ctx.Query("room_name")
+	if roomName == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "room name is required", nil))
+	}
+
+	parseCheckIn, err := time.Parse("02-01-2006", ctx.Query("check_in"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	parseCheckOut, err := time.Parse("02-01-2006", ctx.Query("check_out"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if !parseCheckOut.After(parseCheckIn) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "check out date must be greater than check in date", nil))
+	}
+
+	resp, err := c.pricingSvc.Quote(&model.PriceQuoteRequest{
+		RoomName: roomName,
+		CheckIn:  parseCheckIn,
+		CheckOut: parseCheckOut,
+	})
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
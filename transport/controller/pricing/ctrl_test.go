@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockPricing *mocks.PricingService
+)
+
+func Test_Quote(t *testing.T) {
+	app := fiber.New()
+	mockPricing = new(mocks.PricingService)
+
+	testCases := []struct {
+		name       string
+		query      string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:  "Given valid request when quote price then return success",
+			query: "?room_name=101&check_in=02-03-2000&check_out=05-03-2000",
+			mockCall: func() {
+				mockPricing.On("Quote", mock.MatchedBy(func(request *model.PriceQuoteRequest) bool {
+					return request.RoomName == "101" && request.CheckIn.Day() == 2 && request.CheckOut.Day() == 5
+				})).Return(&model.PriceQuoteResponse{Nights: 3, Total: 1820}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given missing room name when quote price then return bad request",
+			query:      "?check_in=02-03-2000&check_out=05-03-2000",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Given check out before check in when quote price then return bad request",
+			query:      "?room_name=101&check_in=05-03-2000&check_out=02-03-2000",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Given stay shorter than minimum when quote price then return bad request",
+			query: "?room_name=101&check_in=02-03-2000&check_out=03-03-2000",
+			mockCall: func() {
+				mockPricing.On("Quote", mock.Anything).Return(nil, model.NewValidationError("minimum stay for deluxe is 2 nights")).Once()
+			},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Given error when quote price then return error",
+			query: "?room_name=101&check_in=02-03-2000&check_out=05-03-2000",
+			mockCall: func() {
+				mockPricing.On("Quote", mock.Anything).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewPricingController(mockPricing)
+			app.Get("/pricing", ctrl.Quote)
+
+			req, _ := http.NewRequest("GET", "/pricing"+tc.query, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
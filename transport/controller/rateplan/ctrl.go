@@ -0,0 +1,141 @@
+package rateplan
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zakiyalmaya/hotel-management/application/rateplan"
+	"github.com/zakiyalmaya/hotel-management/model"
+	"github.com/zakiyalmaya/hotel-management/utils"
+)
+
+type RatePlanController struct {
+	ratePlanSvc rateplan.RatePlanService
+}
+
+func NewRatePlanController(ratePlanSvc rateplan.RatePlanService) *RatePlanController {
+	return &RatePlanController{ratePlanSvc: ratePlanSvc}
+}
+
+func (c *RatePlanController) Create(ctx *fiber.Ctx) error {
+	planReq := model.CreateRatePlanRequest{}
+	if err := ctx.BodyParser(&planReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(planReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if planReq.MinStay == 0 {
+		planReq.MinStay = 1
+	}
+
+	if err := c.ratePlanSvc.Create(&model.RatePlanEntity{
+		RoomType:         planReq.RoomType,
+		BaseRate:         planReq.BaseRate,
+		WeekendSurcharge: planReq.WeekendSurcharge,
+		MinStay:          planReq.MinStay,
+	}); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", nil))
+}
+
+func (c *RatePlanController) GetAll(ctx *fiber.Ctx) error {
+	resp, err := c.ratePlanSvc.GetAll()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *RatePlanController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	planReq := model.UpdateRatePlanRequest{}
+	if err := ctx.BodyParser(&planReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(planReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.ratePlanSvc.Update(id, &planReq); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *RatePlanController) CreateSeasonalRate(ctx *fiber.Ctx) error {
+	seasonReq := model.CreateSeasonalRateRequest{}
+	if err := ctx.BodyParser(&seasonReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(seasonReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	parseStartDate, err := time.Parse("02-01-2006", seasonReq.StartDate)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	parseEndDate, err := time.Parse("02-01-2006", seasonReq.EndDate)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if parseStartDate.After(parseEndDate) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "end date must not be before start date", nil))
+	}
+
+	if err := c.ratePlanSvc.CreateSeasonalRate(&model.SeasonalRateEntity{
+		RoomType:  seasonReq.RoomType,
+		Name:      seasonReq.Name,
+		StartDate: parseStartDate,
+		EndDate:   parseEndDate,
+		Rate:      seasonReq.Rate,
+		MinStay:   seasonReq.MinStay,
+	}); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", nil))
+}
+
+func (c *RatePlanController) GetSeasonalRates(ctx *fiber.Ctx) error {
+	var roomType *string
+	if roomTypeQuery := ctx.Query("room_type"); roomTypeQuery != "" {
+		roomType = &roomTypeQuery
+	}
+
+	resp, err := c.ratePlanSvc.GetSeasonalRates(roomType)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
+
+func (c *RatePlanController) DeleteSeasonalRate(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.ratePlanSvc.DeleteSeasonalRate(id); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
@@ -0,0 +1,269 @@
+package rateplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+var (
+	mockRatePlan *mocks.RatePlanService
+)
+
+func Test_Create(t *testing.T) {
+	app := fiber.New()
+	mockRatePlan = new(mocks.RatePlanService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given request without min stay when create rate plan then default min stay to one night",
+			request: &model.CreateRatePlanRequest{RoomType: "deluxe", BaseRate: 500, WeekendSurcharge: 20},
+			mockCall: func() {
+				mockRatePlan.On("Create", &model.RatePlanEntity{RoomType: "deluxe", BaseRate: 500, WeekendSurcharge: 20, MinStay: 1}).Return(nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Given missing base rate when create rate plan then return bad request",
+			request:    &model.CreateRatePlanRequest{RoomType: "deluxe"},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given error when create rate plan then return error",
+			request: &model.CreateRatePlanRequest{RoomType: "deluxe", BaseRate: 500, MinStay: 2},
+			mockCall: func() {
+				mockRatePlan.On("Create", mock.Anything).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewRatePlanController(mockRatePlan)
+			app.Post("/rate-plan", ctrl.Create)
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/rate-plan", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	app := fiber.New()
+	mockRatePlan = new(mocks.RatePlanService)
+
+	baseRate := 650.0
+	minStay := 0
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when update rate plan then return success",
+			request: &model.UpdateRatePlanRequest{BaseRate: &baseRate},
+			mockCall: func() {
+				mockRatePlan.On("Update", 1, &model.UpdateRatePlanRequest{BaseRate: &baseRate}).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given min stay below one night when update rate plan then return bad request",
+			request:    &model.UpdateRatePlanRequest{MinStay: &minStay},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Given error when update rate plan then return error",
+			request: &model.UpdateRatePlanRequest{BaseRate: &baseRate},
+			mockCall: func() {
+				mockRatePlan.On("Update", 1, mock.Anything).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:    "Given unknown rate plan when update rate plan then return not found",
+			request: &model.UpdateRatePlanRequest{BaseRate: &baseRate},
+			mockCall: func() {
+				mockRatePlan.On("Update", 1, mock.Anything).Return(model.NewNotFoundError("rate plan not found")).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewRatePlanController(mockRatePlan)
+			app.Put("/rate-plan/:id", ctrl.Update)
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("PUT", "/rate-plan/1", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_CreateSeasonalRate(t *testing.T) {
+	app := fiber.New()
+	mockRatePlan = new(mocks.RatePlanService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when create seasonal rate then return success",
+			request: &model.CreateSeasonalRateRequest{
+				RoomType:  "deluxe",
+				Name:      "holiday",
+				StartDate: "20-12-2000",
+				EndDate:   "31-12-2000",
+				Rate:      800,
+				MinStay:   3,
+			},
+			mockCall: func() {
+				mockRatePlan.On("CreateSeasonalRate", mock.MatchedBy(func(season *model.SeasonalRateEntity) bool {
+					return season.Name == "holiday" && season.StartDate.Day() == 20 && season.EndDate.Day() == 31
+				})).Return(nil).Once()
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "Given end date before start date when create seasonal rate then return bad request",
+			request: &model.CreateSeasonalRateRequest{
+				RoomType:  "deluxe",
+				Name:      "holiday",
+				StartDate: "31-12-2000",
+				EndDate:   "20-12-2000",
+				Rate:      800,
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given invalid start date format when create seasonal rate then return bad request",
+			request: &model.CreateSeasonalRateRequest{
+				RoomType:  "deluxe",
+				Name:      "holiday",
+				StartDate: "20/12/2000",
+				EndDate:   "31-12-2000",
+				Rate:      800,
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given error when create seasonal rate then return error",
+			request: &model.CreateSeasonalRateRequest{
+				RoomType:  "deluxe",
+				Name:      "holiday",
+				StartDate: "20-12-2000",
+				EndDate:   "31-12-2000",
+				Rate:      800,
+			},
+			mockCall: func() {
+				mockRatePlan.On("CreateSeasonalRate", mock.Anything).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewRatePlanController(mockRatePlan)
+			app.Post("/rate-plan/season", ctrl.CreateSeasonalRate)
+
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/rate-plan/season", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_DeleteSeasonalRate(t *testing.T) {
+	app := fiber.New()
+	mockRatePlan = new(mocks.RatePlanService)
+
+	testCases := []struct {
+		name       string
+		id         string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid id when delete seasonal rate then return success",
+			id:   "1",
+			mockCall: func() {
+				mockRatePlan.On("DeleteSeasonalRate", 1).Return(nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Given invalid id when delete seasonal rate then return bad request",
+			id:         "abc",
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given unknown seasonal rate when delete seasonal rate then return not found",
+			id:   "99",
+			mockCall: func() {
+				mockRatePlan.On("DeleteSeasonalRate", 99).Return(model.NewNotFoundError("seasonal rate not found")).Once()
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name: "Given error when delete seasonal rate then return error",
+			id:   "1",
+			mockCall: func() {
+				mockRatePlan.On("DeleteSeasonalRate", 1).Return(errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewRatePlanController(mockRatePlan)
+			app.Delete("/rate-plan/season/:id", ctrl.DeleteSeasonalRate)
+
+			req, _ := http.NewRequest("DELETE", "/rate-plan/season/"+tc.id, nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package room
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/zakiyalmaya/hotel-management/application/room"
 	"github.com/zakiyalmaya/hotel-management/constant"
@@ -26,12 +29,17 @@ func (c *RoomController) Create(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
 	}
 
+	if roomReq.Capacity == 0 {
+		roomReq.Capacity = constant.DefaultRoomCapacity
+	}
+
 	if err := c.roomSvc.Create(&model.RoomEntity{
 		Name:        roomReq.Name,
 		Type:        roomReq.Type,
 		Price:       roomReq.Price,
 		Floor:       roomReq.Floor,
 		Status:      constant.RoomStatus(roomReq.Status),
+		Capacity:    roomReq.Capacity,
 		Description: roomReq.Description,
 	}); err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
@@ -89,4 +97,65 @@ func (c *RoomController) Update(ctx *fiber.Ctx) error {
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
-}
\ No newline at end of file
+}
+
+func (c *RoomController) Search(ctx *fiber.Ctx) error {
+	parseCheckIn, err := time.Parse("02-01-2006", ctx.Query("check_in"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	parseCheckOut, err := time.Parse("02-01-2006", ctx.Query("check_out"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if !parseCheckOut.After(parseCheckIn) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "check out date must be greater than check in date", nil))
+	}
+
+	request := model.SearchRoomRequest{
+		CheckIn:  parseCheckIn,
+		CheckOut: parseCheckOut,
+		Guests:   ctx.QueryInt("guests", 1),
+	}
+
+	if request.Guests < 1 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "guests must be greater than 0", nil))
+	}
+
+	if roomType := ctx.Query("type"); roomType != "" {
+		request.Type = &roomType
+	}
+
+	if floor := ctx.Query("floor"); floor != "" {
+		floorInt, err := strconv.Atoi(floor)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+		request.Floor = &floorInt
+	}
+
+	if minPrice := ctx.Query("min_price"); minPrice != "" {
+		minPriceFloat, err := strconv.ParseFloat(minPrice, 64)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+		request.MinPrice = &minPriceFloat
+	}
+
+	if maxPrice := ctx.Query("max_price"); maxPrice != "" {
+		maxPriceFloat, err := strconv.ParseFloat(maxPrice, 64)
+		if err != nil {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+		}
+		request.MaxPrice = &maxPriceFloat
+	}
+
+	resp, err := c.roomSvc.Search(&request)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", resp))
+}
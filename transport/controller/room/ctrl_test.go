@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-playground/assert/v2"
 	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
 	"github.com/zakiyalmaya/hotel-management/application/mocks"
 	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
@@ -36,11 +38,12 @@ func Test_Create(t *testing.T) {
 			},
 			mockCall: func() {
 				mockRoom.On("Create", &model.RoomEntity{
-					Name:   "room name",
-					Floor:  1,
-					Type:   "type",
-					Price:  100.00,
-					Status: constant.RoomStatus(1),
+					Name:     "room name",
+					Floor:    1,
+					Type:     "type",
+					Price:    100.00,
+					Status:   constant.RoomStatus(1),
+					Capacity: constant.DefaultRoomCapacity,
 				}).Return(nil).Once()
 			},
 			statusCode: http.StatusCreated,
@@ -56,11 +59,12 @@ func Test_Create(t *testing.T) {
 			},
 			mockCall: func() {
 				mockRoom.On("Create", &model.RoomEntity{
-					Name:   "room name",
-					Floor:  1,
-					Type:   "type",
-					Price:  100.00,
-					Status: constant.RoomStatus(1),
+					Name:     "room name",
+					Floor:    1,
+					Type:     "type",
+					Price:    100.00,
+					Status:   constant.RoomStatus(1),
+					Capacity: constant.DefaultRoomCapacity,
 				}).Return(errors.New("error")).Once()
 			},
 			statusCode: http.StatusInternalServerError,
@@ -265,10 +269,10 @@ func Test_Update(t *testing.T) {
 			statusCode: http.StatusInternalServerError,
 		},
 		{
-			name:  "Given invalid request when update room data then return error",
-			param: "roomname",
-			request: "test",
-			mockCall: func() {},
+			name:       "Given invalid request when update room data then return error",
+			param:      "roomname",
+			request:    "test",
+			mockCall:   func() {},
 			statusCode: http.StatusBadRequest,
 		},
 	}
@@ -288,3 +292,97 @@ func Test_Update(t *testing.T) {
 		})
 	}
 }
+
+func Test_Search(t *testing.T) {
+	app := fiber.New()
+	mockRoom := new(mocks.RoomService)
+
+	roomType := "deluxe"
+	floor := 2
+	maxPrice := 500.0
+
+	testCases := []struct {
+		name       string
+		request    map[string]string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when search available rooms then return success response",
+			request: map[string]string{
+				"check_in":  "01-12-2000",
+				"check_out": "03-12-2000",
+				"guests":    "2",
+				"type":      roomType,
+				"floor":     "2",
+				"max_price": "500",
+			},
+			mockCall: func() {
+				mockRoom.On("Search", &model.SearchRoomRequest{
+					CheckIn:  time.Date(2000, time.December, 01, 00, 00, 00, 00, time.UTC),
+					CheckOut: time.Date(2000, time.December, 03, 00, 00, 00, 00, time.UTC),
+					Guests:   2,
+					Type:     &roomType,
+					Floor:    &floor,
+					MaxPrice: &maxPrice,
+				}).Return([]*model.RoomResponse{}, nil).Once()
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Given error when search available rooms then return error response",
+			request: map[string]string{
+				"check_in":  "01-12-2000",
+				"check_out": "03-12-2000",
+			},
+			mockCall: func() {
+				mockRoom.On("Search", mock.Anything).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "Given missing check in date when search available rooms then return bad request response",
+			request: map[string]string{
+				"check_out": "03-12-2000",
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given check out before check in when search available rooms then return bad request response",
+			request: map[string]string{
+				"check_in":  "03-12-2000",
+				"check_out": "01-12-2000",
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Given invalid price when search available rooms then return bad request response",
+			request: map[string]string{
+				"check_in":  "01-12-2000",
+				"check_out": "03-12-2000",
+				"min_price": "cheap",
+			},
+			mockCall:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := NewRoomController(mockRoom)
+			app.Get("/rooms/available", ctrl.Search)
+
+			query := url.Values{}
+			for k, v := range tc.request {
+				query.Set(k, v)
+			}
+			req, _ := http.NewRequest("GET", "/rooms/available?"+query.Encode(), nil)
+			tc.mockCall()
+
+			res, _ := app.Test(req)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
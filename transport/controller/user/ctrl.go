@@ -1,8 +1,12 @@
 package user
 
 import (
+	"math"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/zakiyalmaya/hotel-management/application/user"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 	"github.com/zakiyalmaya/hotel-management/utils"
 )
@@ -27,7 +31,7 @@ func (c *UserController) Create(ctx *fiber.Ctx) error {
 	}
 
 	if err := c.userSvc.Create(&createUserReq); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(model.NewHttpResponse(fiber.StatusInternalServerError, err.Error(), nil))
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(model.NewHttpResponse(fiber.StatusCreated, "success", nil))
@@ -51,3 +55,150 @@ func (c *UserController) ChangePassword(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
 }
+
+// ForgotPassword answers the same whether or not an account was found.
+func (c *UserController) ForgotPassword(ctx *fiber.Ctx) error {
+	forgotPasswordReq := model.ForgotPasswordRequest{}
+	if err := ctx.BodyParser(&forgotPasswordReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(forgotPasswordReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	forgotPasswordReq.IP = ctx.IP()
+	if err := c.userSvc.ForgotPassword(&forgotPasswordReq); err != nil {
+		if tooManyRequestsErr, ok := err.(*model.TooManyRequestsError); ok {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(tooManyRequestsErr.RetryAfter.Seconds()))))
+		}
+
+		statusCode := utils.ErrorStatusCode(err)
+		return ctx.Status(statusCode).JSON(model.NewHttpResponse(statusCode, err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "if the account exists, a password reset link has been emailed", nil))
+}
+
+func (c *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	resetPasswordReq := model.ResetPasswordRequest{}
+	if err := ctx.BodyParser(&resetPasswordReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(resetPasswordReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.userSvc.ResetPassword(&resetPasswordReq); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *UserController) AssignRole(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	roleReq := model.UpdateRoleRequest{}
+	if err := ctx.BodyParser(&roleReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(roleReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if isRoleValid := constant.Role(roleReq.Role).Validation(); !isRoleValid {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid role", nil))
+	}
+
+	if err := c.userSvc.AssignRole(&model.UserEntity{ID: id, Role: constant.Role(roleReq.Role)}, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *UserController) Unlock(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.userSvc.Unlock(id); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+func (c *UserController) GetAll(ctx *fiber.Ctx) error {
+	paginationReq := model.PaginationRequest{
+		Page:  ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 20),
+	}
+
+	if err := utils.Validator(paginationReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	users, err := c.userSvc.GetAll(&paginationReq)
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", users))
+}
+
+func (c *UserController) Me(ctx *fiber.Ctx) error {
+	user, err := c.userSvc.GetByID(ctx.Locals("user_id").(int))
+	if err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", user))
+}
+
+func (c *UserController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	updateUserReq := model.UpdateUserRequest{}
+	if err := ctx.BodyParser(&updateUserReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := utils.Validator(updateUserReq); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if updateUserReq.Role != nil && !constant.Role(*updateUserReq.Role).Validation() {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, "invalid role", nil))
+	}
+
+	if err := c.userSvc.Update(id, &updateUserReq, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
+
+// Deactivate keeps the account and its history but ends its sessions and blocks new logins.
+func (c *UserController) Deactivate(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.NewHttpResponse(fiber.StatusBadRequest, err.Error(), nil))
+	}
+
+	if err := c.userSvc.Deactivate(id, ctx.Locals("user_id").(int)); err != nil {
+		return ctx.Status(utils.ErrorStatusCode(err)).JSON(model.NewHttpResponse(utils.ErrorStatusCode(err), err.Error(), nil))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.NewHttpResponse(fiber.StatusOK, "success", nil))
+}
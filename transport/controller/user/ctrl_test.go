@@ -6,10 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/zakiyalmaya/hotel-management/application/mocks"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/model"
 )
 
@@ -26,17 +29,19 @@ func Test_Create(t *testing.T) {
 		{
 			name: "Given valid request when create a new user then return success response",
 			request: &model.CreateUserRequest{
-				Name:     "John Doe",
-				Username: "username",
-				Email:    "example@mail.com",
-				Password: "password",
+				Name:            "John Doe",
+				Username:        "username",
+				Email:           "example@mail.com",
+				Password:        "password",
+				InvitationToken: "token",
 			},
 			mockCall: func() {
 				mockUser.On("Create", &model.CreateUserRequest{
-					Name:     "John Doe",
-					Username: "username",
-					Email:    "example@mail.com",
-					Password: "password",
+					Name:            "John Doe",
+					Username:        "username",
+					Email:           "example@mail.com",
+					Password:        "password",
+					InvitationToken: "token",
 				}).Return(nil).Once()
 			},
 			statusCode: fiber.StatusCreated,
@@ -44,21 +49,48 @@ func Test_Create(t *testing.T) {
 		{
 			name: "Given error when create a new user then return error response",
 			request: &model.CreateUserRequest{
-				Name:     "John Doe",
-				Username: "username",
-				Email:    "example@mail.com",
-				Password: "password",
+				Name:            "John Doe",
+				Username:        "username",
+				Email:           "example@mail.com",
+				Password:        "password",
+				InvitationToken: "token",
 			},
 			mockCall: func() {
 				mockUser.On("Create", &model.CreateUserRequest{
-					Name:     "John Doe",
-					Username: "username",
-					Email:    "example@mail.com",
-					Password: "password",
+					Name:            "John Doe",
+					Username:        "username",
+					Email:           "example@mail.com",
+					Password:        "password",
+					InvitationToken: "token",
 				}).Return(errors.New("error")).Once()
 			},
 			statusCode: fiber.StatusInternalServerError,
 		},
+		{
+			name: "Given invalid invitation when create a new user then return bad request",
+			request: &model.CreateUserRequest{
+				Name:            "John Doe",
+				Username:        "username",
+				Email:           "example@mail.com",
+				Password:        "password",
+				InvitationToken: "expired",
+			},
+			mockCall: func() {
+				mockUser.On("Create", mock.Anything).Return(model.NewValidationError("invitation is no longer valid")).Once()
+			},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "Given missing invitation token when create a new user then return bad request",
+			request: &model.CreateUserRequest{
+				Name:     "John Doe",
+				Username: "username",
+				Email:    "example@mail.com",
+				Password: "password",
+			},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
 		{
 			name:       "Given missing request when create a new user then return error response",
 			request:    &model.CreateUserRequest{},
@@ -102,7 +134,7 @@ func Test_ChangePassword(t *testing.T) {
 		statusCode int
 	}{
 		{
-			name: "Given valid request when change password then return success response",
+			name:     "Given valid request when change password then return success response",
 			username: "testuser",
 			request: &model.ChangePasswordRequest{
 				OldPassword: "oldpassword",
@@ -118,7 +150,7 @@ func Test_ChangePassword(t *testing.T) {
 			statusCode: fiber.StatusOK,
 		},
 		{
-			name: "Given error when change password then return error response",
+			name:     "Given error when change password then return error response",
 			username: "testuser",
 			request: &model.ChangePasswordRequest{
 				OldPassword: "oldpassword",
@@ -167,3 +199,477 @@ func Test_ChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func Test_ForgotPassword(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given username when forgot password then return success response",
+			request: &model.ForgotPasswordRequest{Username: "janedoe"},
+			mockCall: func() {
+				mockUser.On("ForgotPassword", mock.MatchedBy(func(request *model.ForgotPasswordRequest) bool {
+					return request.Username == "janedoe" && request.IP != ""
+				})).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given neither username nor email when forgot password then return bad request",
+			request:    &model.ForgotPasswordRequest{},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid email when forgot password then return bad request",
+			request:    &model.ForgotPasswordRequest{Email: "jane"},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:    "Given error when forgot password then return error response",
+			request: &model.ForgotPasswordRequest{Email: "jane@mail.com"},
+			mockCall: func() {
+				mockUser.On("ForgotPassword", mock.Anything).Return(errors.New("error")).Once()
+			},
+			statusCode: fiber.StatusInternalServerError,
+		},
+		{
+			name:    "Given too many requests when forgot password then return too many requests",
+			request: &model.ForgotPasswordRequest{Email: "jane@mail.com"},
+			mockCall: func() {
+				mockUser.On("ForgotPassword", mock.Anything).Return(model.NewTooManyRequestsError(90*time.Second, "too many password reset requests, try again in %d seconds", 90)).Once()
+			},
+			statusCode: fiber.StatusTooManyRequests,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Post("/api/password/forgot", ctrl.ForgotPassword)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/api/password/forgot", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_ResetPassword(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid token when reset password then return success response",
+			request: &model.ResetPasswordRequest{Token: "token", NewPassword: "new-password"},
+			mockCall: func() {
+				mockUser.On("ResetPassword", &model.ResetPasswordRequest{Token: "token", NewPassword: "new-password"}).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:    "Given expired token when reset password then return bad request",
+			request: &model.ResetPasswordRequest{Token: "token", NewPassword: "new-password"},
+			mockCall: func() {
+				mockUser.On("ResetPassword", mock.Anything).Return(model.NewValidationError("invalid or expired reset token")).Once()
+			},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given short password when reset password then return bad request",
+			request:    &model.ResetPasswordRequest{Token: "token", NewPassword: "new"},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Post("/api/password/reset", ctrl.ResetPassword)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("POST", "/api/password/reset", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_AssignRole(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when assign role then return success response",
+			path:    "/api/users/2/role",
+			request: &model.UpdateRoleRequest{Role: 4},
+			mockCall: func() {
+				mockUser.On("AssignRole", &model.UserEntity{ID: 2, Role: constant.RoleAccountant}, 1).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given invalid role when assign role then return bad request",
+			path:       "/api/users/2/role",
+			request:    &model.UpdateRoleRequest{Role: 9},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid id when assign role then return bad request",
+			path:       "/api/users/abc/role",
+			request:    &model.UpdateRoleRequest{Role: 4},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:    "Given unknown user when assign role then return not found",
+			path:    "/api/users/2/role",
+			request: &model.UpdateRoleRequest{Role: 4},
+			mockCall: func() {
+				mockUser.On("AssignRole", mock.Anything, 1).Return(model.NewNotFoundError("user not found")).Once()
+			},
+			statusCode: fiber.StatusNotFound,
+		},
+		{
+			name:    "Given own account when assign role then return conflict",
+			path:    "/api/users/1/role",
+			request: &model.UpdateRoleRequest{Role: 5},
+			mockCall: func() {
+				mockUser.On("AssignRole", mock.Anything, 1).Return(model.NewConflictError("can not change your own role")).Once()
+			},
+			statusCode: fiber.StatusConflict,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Put("/api/users/:id/role", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.AssignRole(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("PUT", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Unlock(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when unlock then return success response",
+			path: "/api/users/2/unlock",
+			mockCall: func() {
+				mockUser.On("Unlock", 2).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given invalid id when unlock then return bad request",
+			path:       "/api/users/abc/unlock",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "Given unknown user when unlock then return not found",
+			path: "/api/users/3/unlock",
+			mockCall: func() {
+				mockUser.On("Unlock", 3).Return(model.NewNotFoundError("user not found")).Once()
+			},
+			statusCode: fiber.StatusNotFound,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Post("/api/users/:id/unlock", ctrl.Unlock)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tc.path, nil)
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_GetAll(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given no query when get all users then use the first page",
+			path: "/api/users",
+			mockCall: func() {
+				mockUser.On("GetAll", &model.PaginationRequest{Page: 1, Limit: 20}).Return(&model.PaginatedResponse{
+					Items:      []*model.UserResponse{{ID: 1, Username: "janedoe", Role: "Admin", Active: true}},
+					Page:       1,
+					Limit:      20,
+					Total:      1,
+					TotalPages: 1,
+				}, nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name: "Given page and limit when get all users then return the page",
+			path: "/api/users?page=2&limit=5",
+			mockCall: func() {
+				mockUser.On("GetAll", &model.PaginationRequest{Page: 2, Limit: 5}).Return(&model.PaginatedResponse{Page: 2, Limit: 5, Total: 6, TotalPages: 2}, nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given limit above the maximum when get all users then return bad request",
+			path:       "/api/users?limit=500",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "Given error from service when get all users then return internal server error",
+			path: "/api/users",
+			mockCall: func() {
+				mockUser.On("GetAll", mock.Anything).Return(nil, errors.New("error")).Once()
+			},
+			statusCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Get("/api/users", ctrl.GetAll)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Me(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given logged in hotelier when get me then return the profile",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(&model.UserResponse{ID: 1, Username: "janedoe", Role: "Admin", Active: true}, nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name: "Given unknown user when get me then return not found",
+			mockCall: func() {
+				mockUser.On("GetByID", 1).Return(nil, model.NewNotFoundError("user not found")).Once()
+			},
+			statusCode: fiber.StatusNotFound,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Get("/api/users/me", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Me(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/api/users/me", nil)
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	name, email, invalidEmail := "Jane Doe", "jane@mail.com", "jane"
+	accountant, invalidRole := 4, 9
+
+	testCases := []struct {
+		name       string
+		path       string
+		request    interface{}
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name:    "Given valid request when update user then return success response",
+			path:    "/api/users/2",
+			request: &model.UpdateUserRequest{Name: &name, Email: &email, Role: &accountant},
+			mockCall: func() {
+				mockUser.On("Update", 2, &model.UpdateUserRequest{Name: &name, Email: &email, Role: &accountant}, 1).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given invalid email when update user then return bad request",
+			path:       "/api/users/2",
+			request:    &model.UpdateUserRequest{Email: &invalidEmail},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid role when update user then return bad request",
+			path:       "/api/users/2",
+			request:    &model.UpdateUserRequest{Role: &invalidRole},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "Given invalid id when update user then return bad request",
+			path:       "/api/users/abc",
+			request:    &model.UpdateUserRequest{Name: &name},
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:    "Given own role when update user then return conflict",
+			path:    "/api/users/1",
+			request: &model.UpdateUserRequest{Role: &accountant},
+			mockCall: func() {
+				mockUser.On("Update", 1, mock.Anything, 1).Return(model.NewConflictError("can not change your own role")).Once()
+			},
+			statusCode: fiber.StatusConflict,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Put("/api/users/:id", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Update(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(tc.request)
+			req, _ := http.NewRequest("PUT", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Deactivate(t *testing.T) {
+	app := fiber.New()
+	mockUser := new(mocks.UserService)
+
+	testCases := []struct {
+		name       string
+		path       string
+		mockCall   func()
+		statusCode int
+	}{
+		{
+			name: "Given valid request when deactivate then return success response",
+			path: "/api/users/2/deactivate",
+			mockCall: func() {
+				mockUser.On("Deactivate", 2, 1).Return(nil).Once()
+			},
+			statusCode: fiber.StatusOK,
+		},
+		{
+			name:       "Given invalid id when deactivate then return bad request",
+			path:       "/api/users/abc/deactivate",
+			mockCall:   func() {},
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name: "Given deactivated user when deactivate then return conflict",
+			path: "/api/users/3/deactivate",
+			mockCall: func() {
+				mockUser.On("Deactivate", 3, 1).Return(model.NewConflictError("user is already deactivated")).Once()
+			},
+			statusCode: fiber.StatusConflict,
+		},
+	}
+
+	ctrl := NewUserController(mockUser)
+	app.Post("/api/users/:id/deactivate", func(ctx *fiber.Ctx) error {
+		ctx.Locals("user_id", 1)
+		return ctrl.Deactivate(ctx)
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tc.path, nil)
+			tc.mockCall()
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, resp.StatusCode)
+		})
+	}
+}
@@ -1,33 +1,108 @@
 package transport
 
 import (
-	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
 	"github.com/zakiyalmaya/hotel-management/application"
+	"github.com/zakiyalmaya/hotel-management/constant"
 	"github.com/zakiyalmaya/hotel-management/middleware"
 	"github.com/zakiyalmaya/hotel-management/transport/controller"
 )
 
-func Handler(application *application.Application, redcl *redis.Client, r *fiber.App) {
+func Handler(application *application.Application, r *fiber.App) {
 	ctrl := controller.NewController(application)
+	auth := middleware.AuthMiddleware(application.Repos.SessionRepo, application.KeySet)
 
-	r.Post("/api/room", middleware.AuthMiddleware(redcl), ctrl.RoomCtrl.Create)
-	r.Get("/api/room", middleware.AuthMiddleware(redcl), ctrl.RoomCtrl.GetByName)
-	r.Get("/api/rooms", middleware.AuthMiddleware(redcl), ctrl.RoomCtrl.GetAll)
-	r.Put("/api/room/:name", middleware.AuthMiddleware(redcl), ctrl.RoomCtrl.Update)
+	// admins pass every role check, read only users can only reach the routes without one
+	admin := middleware.RequireRole(constant.RoleAdmin)
+	frontDesk := middleware.RequireRole(constant.RoleFrontDesk)
+	cashier := middleware.RequireRole(constant.RoleFrontDesk, constant.RoleAccountant)
+	housekeeping := middleware.RequireRole(constant.RoleHousekeeping)
+	facilities := middleware.RequireRole(constant.RoleFrontDesk, constant.RoleHousekeeping)
 
-	r.Post("/api/guest", middleware.AuthMiddleware(redcl), ctrl.GuestCtrl.Create)
-	r.Get("/api/guest", middleware.AuthMiddleware(redcl), ctrl.GuestCtrl.GetByID)
+	r.Post("/api/room", auth, admin, ctrl.RoomCtrl.Create)
+	r.Get("/api/room", auth, ctrl.RoomCtrl.GetByName)
+	r.Get("/api/rooms", auth, ctrl.RoomCtrl.GetAll)
+	r.Get("/api/rooms/available", auth, ctrl.RoomCtrl.Search)
+	r.Put("/api/room/:name", auth, admin, ctrl.RoomCtrl.Update)
+	r.Get("/api/availability", auth, ctrl.AvailabilityCtrl.IsAvailable)
 
-	r.Post("/api/booking", middleware.AuthMiddleware(redcl), ctrl.BookingCtrl.Books)
-	r.Get("/api/booking", middleware.AuthMiddleware(redcl), ctrl.BookingCtrl.GetByRegisterNumber)
-	r.Put("/api/payment", middleware.AuthMiddleware(redcl), ctrl.BookingCtrl.UpdatePayment)
-	r.Put("/api/reschedule", middleware.AuthMiddleware(redcl), ctrl.BookingCtrl.Reschedule)
+	r.Post("/api/guest", auth, frontDesk, ctrl.GuestCtrl.Create)
+	r.Get("/api/guest", auth, ctrl.GuestCtrl.GetByID)
+	r.Put("/api/guest/:id", auth, frontDesk, ctrl.GuestCtrl.Update)
+	r.Get("/api/guests", auth, ctrl.GuestCtrl.Search)
+	r.Get("/api/guest/:id/bookings", auth, ctrl.BookingCtrl.GetByGuestID)
+	r.Get("/api/guest/:id/summary", auth, ctrl.GuestCtrl.GetSummary)
+	r.Get("/api/guests/duplicates", auth, frontDesk, ctrl.GuestCtrl.GetDuplicates)
+	r.Post("/api/guests/duplicates/scan", auth, admin, ctrl.GuestCtrl.DetectDuplicates)
+	r.Post("/api/guests/duplicates/:id/merge", auth, frontDesk, ctrl.GuestCtrl.MergeDuplicate)
+	r.Post("/api/guests/duplicates/:id/dismiss", auth, frontDesk, ctrl.GuestCtrl.DismissDuplicate)
+	r.Get("/api/guest/:id/export", auth, admin, ctrl.GuestCtrl.Export)
+	r.Post("/api/guest/:id/anonymize", auth, admin, ctrl.GuestCtrl.Anonymize)
+	r.Post("/api/guests/retention/run", auth, admin, ctrl.GuestCtrl.AnonymizeInactive)
+
+	r.Post("/api/booking", auth, frontDesk, ctrl.BookingCtrl.Books)
+	r.Get("/api/booking", auth, ctrl.BookingCtrl.GetByRegisterNumber)
+	r.Post("/api/payment/webhook", ctrl.PaymentCtrl.Webhook)
+	r.Put("/api/reschedule", auth, frontDesk, ctrl.BookingCtrl.Reschedule)
+	r.Post("/api/booking/:register_number/cancel", auth, frontDesk, ctrl.BookingCtrl.Cancel)
+	r.Post("/api/booking/:register_number/checkin", auth, frontDesk, ctrl.BookingCtrl.CheckIn)
+	r.Post("/api/booking/:register_number/checkout", auth, frontDesk, ctrl.BookingCtrl.CheckOut)
+	r.Post("/api/booking/:register_number/noshow", auth, frontDesk, ctrl.BookingCtrl.NoShow)
+	r.Get("/api/booking/:register_number/folio", auth, ctrl.FolioCtrl.GetByRegisterNumber)
+	r.Post("/api/booking/:register_number/folio/charge", auth, cashier, ctrl.FolioCtrl.PostCharge)
+	r.Post("/api/booking/:register_number/payment", auth, cashier, ctrl.PaymentCtrl.Record)
+	r.Post("/api/booking/:register_number/refund", auth, cashier, ctrl.PaymentCtrl.Refund)
+	r.Get("/api/booking/:register_number/payments", auth, ctrl.PaymentCtrl.GetByRegisterNumber)
+	r.Get("/api/booking/:register_number/invoice", auth, cashier, ctrl.InvoiceCtrl.GetByRegisterNumber)
+
+	r.Get("/api/housekeeping", auth, ctrl.HousekeepingCtrl.GetAll)
+	r.Get("/api/housekeeping/:id", auth, ctrl.HousekeepingCtrl.GetByID)
+	r.Post("/api/housekeeping/:id/claim", auth, housekeeping, ctrl.HousekeepingCtrl.Claim)
+	r.Post("/api/housekeeping/:id/complete", auth, housekeeping, ctrl.HousekeepingCtrl.Complete)
+	r.Post("/api/housekeeping/:id/inspect", auth, housekeeping, ctrl.HousekeepingCtrl.Inspect)
+
+	r.Post("/api/maintenance", auth, facilities, ctrl.MaintenanceCtrl.Create)
+	r.Get("/api/maintenance", auth, ctrl.MaintenanceCtrl.GetAll)
+	r.Get("/api/maintenance/:id", auth, ctrl.MaintenanceCtrl.GetByID)
+	r.Put("/api/maintenance/:id", auth, facilities, ctrl.MaintenanceCtrl.Update)
+	r.Post("/api/maintenance/:id/close", auth, facilities, ctrl.MaintenanceCtrl.Close)
+	r.Get("/api/room/:name/maintenance", auth, ctrl.MaintenanceCtrl.GetByRoom)
+
+	r.Post("/api/rate-plan", auth, admin, ctrl.RatePlanCtrl.Create)
+	r.Get("/api/rate-plans", auth, ctrl.RatePlanCtrl.GetAll)
+	r.Post("/api/rate-plan/season", auth, admin, ctrl.RatePlanCtrl.CreateSeasonalRate)
+	r.Get("/api/rate-plan/seasons", auth, ctrl.RatePlanCtrl.GetSeasonalRates)
+	r.Delete("/api/rate-plan/season/:id", auth, admin, ctrl.RatePlanCtrl.DeleteSeasonalRate)
+	r.Put("/api/rate-plan/:id", auth, admin, ctrl.RatePlanCtrl.Update)
+	r.Get("/api/pricing", auth, ctrl.PricingCtrl.Quote)
 
 	r.Post("/api/register", ctrl.UserCtrl.Create)
-	r.Put("/api/password", middleware.AuthMiddleware(redcl), ctrl.UserCtrl.ChangePassword)
+	r.Put("/api/password", auth, ctrl.UserCtrl.ChangePassword)
+	r.Post("/api/password/forgot", ctrl.UserCtrl.ForgotPassword)
+	r.Post("/api/password/reset", ctrl.UserCtrl.ResetPassword)
+	r.Get("/api/users", auth, admin, ctrl.UserCtrl.GetAll)
+	r.Get("/api/users/me", auth, ctrl.UserCtrl.Me)
+	r.Put("/api/users/:id", auth, admin, ctrl.UserCtrl.Update)
+	r.Post("/api/users/:id/deactivate", auth, admin, ctrl.UserCtrl.Deactivate)
+	r.Put("/api/users/:id/role", auth, admin, ctrl.UserCtrl.AssignRole)
+	r.Post("/api/users/:id/unlock", auth, admin, ctrl.UserCtrl.Unlock)
+	r.Delete("/api/users/:id/mfa", auth, admin, ctrl.MfaCtrl.Reset)
+	r.Post("/api/invitations", auth, admin, ctrl.InvitationCtrl.Create)
+	r.Get("/api/invitations", auth, admin, ctrl.InvitationCtrl.GetPending)
+	r.Delete("/api/invitations/:id", auth, admin, ctrl.InvitationCtrl.Revoke)
 
 	r.Post("/auth/login", ctrl.AuthCtrl.Login)
-	r.Post("/auth/logout", middleware.AuthMiddleware(redcl), ctrl.AuthCtrl.Logout)
-	r.Post("/auth/refresh", middleware.AuthMiddleware(redcl), ctrl.AuthCtrl.Refresh)
+	r.Post("/auth/logout", auth, ctrl.AuthCtrl.Logout)
+	r.Post("/auth/refresh", ctrl.AuthCtrl.Refresh)
+	r.Post("/auth/mfa", ctrl.AuthCtrl.VerifyMfa)
+	r.Get("/api/sessions", auth, ctrl.AuthCtrl.GetSessions)
+	r.Delete("/api/sessions/:id", auth, ctrl.AuthCtrl.RevokeSession)
+	r.Get("/.well-known/jwks.json", ctrl.AuthCtrl.JWKS)
+
+	r.Post("/api/mfa/enroll", auth, ctrl.MfaCtrl.Enroll)
+	r.Post("/api/mfa/activate", auth, ctrl.MfaCtrl.Activate)
+	r.Post("/api/mfa/recovery-codes", auth, ctrl.MfaCtrl.RegenerateRecoveryCodes)
+	r.Post("/api/mfa/disable", auth, ctrl.MfaCtrl.Disable)
+	r.Get("/api/mfa/policies", auth, admin, ctrl.MfaCtrl.GetPolicies)
+	r.Put("/api/mfa/policies/:role", auth, admin, ctrl.MfaCtrl.UpdatePolicy)
 }
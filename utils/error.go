@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zakiyalmaya/hotel-management/model"
+)
+
+// ErrorStatusCode maps a service error to the http status returned to the client.
+func ErrorStatusCode(err error) int {
+	var conflictErr *model.ConflictError
+	if errors.As(err, &conflictErr) {
+		return http.StatusConflict
+	}
+
+	var validationErr *model.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+
+	var unauthorizedErr *model.UnauthorizedError
+	if errors.As(err, &unauthorizedErr) {
+		return http.StatusUnauthorized
+	}
+
+	var notFoundErr *model.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound
+	}
+
+	var tooManyRequestsErr *model.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) {
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
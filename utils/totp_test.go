@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the SHA1 test vectors of RFC 6238 appendix B, truncated to 6 digits
+func Test_TOTPCode(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+
+	testCases := []struct {
+		at   int64
+		code string
+	}{
+		{at: 59, code: "287082"},
+		{at: 1111111109, code: "081804"},
+		{at: 1111111111, code: "050471"},
+		{at: 1234567890, code: "005924"},
+		{at: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := TOTPCode(secret, time.Unix(tc.at, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.code, code)
+	}
+}
+
+func Test_ValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, _ := TOTPCode(secret, now)
+
+	step, ok := ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30, step)
+
+	// a code from the previous step is still accepted, two steps back is not
+	_, ok = ValidateTOTP(secret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+	_, ok = ValidateTOTP(secret, code, now.Add(60*time.Second))
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "000000", now)
+	assert.Equal(t, code == "000000", ok)
+}
+
+func Test_TOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Hotel Management", "janedoe", "JBSWY3DPEHPK3PXP")
+	assert.Equal(t, "otpauth://totp/Hotel%20Management:janedoe?algorithm=SHA1&digits=6&issuer=Hotel+Management&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}